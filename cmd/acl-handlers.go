@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/xml"
 	"io"
 	"net/http"
@@ -25,39 +26,54 @@ import (
 	"github.com/gorilla/mux"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/acl"
 	"github.com/minio/minio/pkg/bucket/policy"
 )
 
-// Data types used for returning dummy access control
-// policy XML, these variables shouldn't be used elsewhere
-// they are only defined to be used in this file alone.
-type grantee struct {
-	XMLNS       string `xml:"xmlns:xsi,attr"`
-	XMLXSI      string `xml:"xsi:type,attr"`
-	Type        string `xml:"Type"`
-	ID          string `xml:"ID,omitempty"`
-	DisplayName string `xml:"DisplayName,omitempty"`
-	URI         string `xml:"URI,omitempty"`
+// parseACLRequest - returns the ACL set by the x-amz-acl or x-amz-grant-*
+// request headers, or by the access control policy in the request body.
+func parseACLRequest(r *http.Request) (*acl.AccessControlPolicy, error) {
+	if acl.IsRequested(r.Header) {
+		return acl.ParseHeaders(r.Header, aclOwner(), aclOwner())
+	}
+	config := &acl.AccessControlPolicy{}
+	if err := xmlDecoder(r.Body, config, r.ContentLength); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
 }
 
-type grant struct {
-	Grantee    grantee `xml:"Grantee"`
-	Permission string  `xml:"Permission"`
+// writeACLErrorResponse - writes the error of parsing an ACL request.
+func writeACLErrorResponse(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	if err == io.EOF {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingSecurityHeader), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 }
 
-type accessControlPolicy struct {
-	XMLName           xml.Name `xml:"AccessControlPolicy"`
-	Owner             Owner    `xml:"Owner"`
-	AccessControlList struct {
-		Grants []grant `xml:"Grant"`
-	} `xml:"AccessControlList"`
+// writeACLResponse - writes the access control policy of a bucket or
+// an object.
+func writeACLResponse(ctx context.Context, w http.ResponseWriter, r *http.Request, config *acl.AccessControlPolicy) {
+	response := *config
+	response.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+	configData, err := xml.Marshal(response)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	writeSuccessResponseXML(w, configData)
 }
 
 // PutBucketACLHandler - PUT Bucket ACL
 // -----------------
 // This operation uses the ACL subresource
-// to set ACL for a bucket, this is a dummy call
-// only responds success if the ACL is private.
+// to set the ACL of a bucket, either with a canned ACL,
+// explicit grant headers or an access control policy.
 func (api objectAPIHandlers) PutBucketACLHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "PutBucketACL")
 
@@ -72,9 +88,7 @@ func (api objectAPIHandlers) PutBucketACLHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	// Allow putBucketACL if policy action is set, since this is a dummy call
-	// we are simply re-purposing the bucketPolicyAction.
-	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketAclAction, bucket, ""); s3Error != ErrNone {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
 		return
 	}
@@ -86,36 +100,34 @@ func (api objectAPIHandlers) PutBucketACLHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	aclHeader := r.Header.Get(xhttp.AmzACL)
-	if aclHeader == "" {
-		acl := &accessControlPolicy{}
-		if err = xmlDecoder(r.Body, acl, r.ContentLength); err != nil {
-			if err == io.EOF {
-				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingSecurityHeader),
-					r.URL, guessIsBrowserReq(r))
-				return
-			}
-			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
-			return
-		}
+	config, err := parseACLRequest(r)
+	if err != nil {
+		writeACLErrorResponse(ctx, w, r, err)
+		return
+	}
 
-		if len(acl.AccessControlList.Grants) == 0 {
-			writeErrorResponse(ctx, w, toAPIError(ctx, NotImplemented{}), r.URL, guessIsBrowserReq(r))
-			return
-		}
+	// The owner of a bucket can not be changed.
+	if config.Owner.ID != aclOwner().ID {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+		return
+	}
 
-		if acl.AccessControlList.Grants[0].Permission != "FULL_CONTROL" {
-			writeErrorResponse(ctx, w, toAPIError(ctx, NotImplemented{}), r.URL, guessIsBrowserReq(r))
-			return
-		}
+	if err = checkACLSupported(bucket, config); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
 	}
 
-	if aclHeader != "" && aclHeader != "private" {
-		writeErrorResponse(ctx, w, toAPIError(ctx, NotImplemented{}), r.URL, guessIsBrowserReq(r))
+	if isPublicACLBlocked(bucket, config) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = updateBucketACL(bucket, config); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
-	w.(http.Flusher).Flush()
+	writeSuccessResponseHeadersOnly(w)
 }
 
 // GetBucketACLHandler - GET Bucket ACL
@@ -136,9 +148,7 @@ func (api objectAPIHandlers) GetBucketACLHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	// Allow getBucketACL if policy action is set, since this is a dummy call
-	// we are simply re-purposing the bucketPolicyAction.
-	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketAclAction, bucket, ""); s3Error != ErrNone {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
 		return
 	}
@@ -150,29 +160,20 @@ func (api objectAPIHandlers) GetBucketACLHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	acl := &accessControlPolicy{}
-	acl.AccessControlList.Grants = append(acl.AccessControlList.Grants, grant{
-		Grantee: grantee{
-			XMLNS:  "http://www.w3.org/2001/XMLSchema-instance",
-			XMLXSI: "CanonicalUser",
-			Type:   "CanonicalUser",
-		},
-		Permission: "FULL_CONTROL",
-	})
-
-	if err := xml.NewEncoder(w).Encode(acl); err != nil {
+	config, err := getBucketACL(bucket)
+	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
-	w.(http.Flusher).Flush()
+	writeACLResponse(ctx, w, r, config)
 }
 
 // PutObjectACLHandler - PUT Object ACL
 // -----------------
 // This operation uses the ACL subresource
-// to set ACL for a bucket, this is a dummy call
-// only responds success if the ACL is private.
+// to set the ACL of an object, either with a canned ACL,
+// explicit grant headers or an access control policy.
 func (api objectAPIHandlers) PutObjectACLHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "PutObjectACL")
 
@@ -192,45 +193,86 @@ func (api objectAPIHandlers) PutObjectACLHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	// Allow putObjectACL if policy action is set, since this is a dummy call
-	// we are simply re-purposing the bucketPolicyAction.
-	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+	s3Error := checkRequestAuthType(ctx, r, policy.PutObjectAclAction, bucket, object)
+	if s3Error != ErrNone && s3Error != ErrCheckObjectACL {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
+	opts, err := getOpts(ctx, r, bucket, object)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// Before proceeding validate if object exists.
-	_, err = objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, opts)
+	if s3Error == ErrCheckObjectACL && (err != nil || !isAllowedByObjectACL(r, policy.PutObjectAclAction, objInfo)) {
+		// Do not reveal whether the object exists.
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+		return
+	}
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
-	aclHeader := r.Header.Get(xhttp.AmzACL)
-	if aclHeader == "" {
-		acl := &accessControlPolicy{}
-		if err = xmlDecoder(r.Body, acl, r.ContentLength); err != nil {
-			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
-			return
-		}
+	config, err := parseACLRequest(r)
+	if err != nil {
+		writeACLErrorResponse(ctx, w, r, err)
+		return
+	}
 
-		if len(acl.AccessControlList.Grants) == 0 {
-			writeErrorResponse(ctx, w, toAPIError(ctx, NotImplemented{}), r.URL, guessIsBrowserReq(r))
-			return
-		}
+	// The owner of an object can not be changed, ACLs set by the
+	// request headers have no owner of their own.
+	if !acl.IsRequested(r.Header) && config.Owner.ID != getObjectOwner(objInfo, getObjectOwnership(bucket)).ID {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+		return
+	}
 
-		if acl.AccessControlList.Grants[0].Permission != "FULL_CONTROL" {
-			writeErrorResponse(ctx, w, toAPIError(ctx, NotImplemented{}), r.URL, guessIsBrowserReq(r))
+	if err = checkACLSupported(bucket, config); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if isPublicACLBlocked(bucket, config) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if globalIsGateway {
+		if !config.IsPrivate() {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
 			return
 		}
+		writeSuccessResponseHeadersOnly(w)
+		return
 	}
 
-	if aclHeader != "" && aclHeader != "private" {
-		writeErrorResponse(ctx, w, toAPIError(ctx, NotImplemented{}), r.URL, guessIsBrowserReq(r))
+	if err = setObjectACLMetadata(objInfo.UserDefined, config); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if objInfo.UserTags != "" {
+		objInfo.UserDefined[xhttp.AmzObjectTagging] = objInfo.UserTags
+	}
+
+	objInfo.metadataOnly = true // Perform only metadata updates.
+	if _, err = objAPI.CopyObject(ctx, bucket, object, bucket, object, objInfo, ObjectOptions{
+		VersionID: opts.VersionID,
+	}, ObjectOptions{
+		VersionID: opts.VersionID,
+		MTime:     opts.MTime,
+	}); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
-	w.(http.Flusher).Flush()
+	if opts.VersionID != "" {
+		w.Header()[xhttp.AmzVersionID] = []string{opts.VersionID}
+	}
+
+	writeSuccessResponseHeadersOnly(w)
 }
 
 // GetObjectACLHandler - GET Object ACL
@@ -256,33 +298,40 @@ func (api objectAPIHandlers) GetObjectACLHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	// Allow getObjectACL if policy action is set, since this is a dummy call
-	// we are simply re-purposing the bucketPolicyAction.
-	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+	s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAclAction, bucket, object)
+	if s3Error != ErrNone && s3Error != ErrCheckObjectACL {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
+	opts, err := getOpts(ctx, r, bucket, object)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// Before proceeding validate if object exists.
-	_, err = objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, opts)
+	if s3Error == ErrCheckObjectACL && (err != nil || !isAllowedByObjectACL(r, policy.GetObjectAclAction, objInfo)) {
+		// Do not reveal whether the object exists.
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+		return
+	}
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
-	acl := &accessControlPolicy{}
-	acl.AccessControlList.Grants = append(acl.AccessControlList.Grants, grant{
-		Grantee: grantee{
-			XMLNS:  "http://www.w3.org/2001/XMLSchema-instance",
-			XMLXSI: "CanonicalUser",
-			Type:   "CanonicalUser",
-		},
-		Permission: "FULL_CONTROL",
-	})
-	if err := xml.NewEncoder(w).Encode(acl); err != nil {
+	config, err := getObjectACL(objInfo)
+	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	config.Owner = getObjectOwner(objInfo, getObjectOwnership(bucket))
+
+	if opts.VersionID != "" {
+		w.Header()[xhttp.AmzVersionID] = []string{opts.VersionID}
+	}
 
-	w.(http.Flusher).Flush()
+	writeACLResponse(ctx, w, r, config)
 }
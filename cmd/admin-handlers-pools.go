@@ -0,0 +1,478 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// StartDecommission - POST /minio/admin/v3/pools/decommission?pool=http://server{1...4}/disk{1...4}
+// ----------
+// Starts moving all objects of the pool to the remaining pools, no new
+// objects are placed on the pool from now on.
+func (a adminAPIHandlers) StartDecommission(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StartDecommission")
+
+	defer logger.AuditLog(w, r, "StartDecommission", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DecommissionAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	idx, err := pools.PoolIndex(mux.Vars(r)["pool"])
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err = pools.Decommission(ctx, idx); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Notify all other MinIO peers to reload the pool status.
+	globalNotificationSys.ReloadPoolMeta(ctx)
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// CancelDecommission - POST /minio/admin/v3/pools/cancel?pool=http://server{1...4}/disk{1...4}
+// ----------
+// Cancels an ongoing decommission, the objects already moved stay on
+// the remaining pools and new objects are placed on the pool again.
+func (a adminAPIHandlers) CancelDecommission(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelDecommission")
+
+	defer logger.AuditLog(w, r, "CancelDecommission", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DecommissionAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	idx, err := pools.PoolIndex(mux.Vars(r)["pool"])
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err = pools.DecommissionCancel(ctx, idx); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Notify all other MinIO peers to reload the pool status.
+	globalNotificationSys.ReloadPoolMeta(ctx)
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// StatusPool - GET /minio/admin/v3/pools/status?pool=http://server{1...4}/disk{1...4}
+// ----------
+// Returns the status of the pool, including the progress of its decommission.
+func (a adminAPIHandlers) StatusPool(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StatusPool")
+
+	defer logger.AuditLog(w, r, "StatusPool", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	idx, err := pools.PoolIndex(mux.Vars(r)["pool"])
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	status, err := pools.Status(ctx, idx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// ListPools - GET /minio/admin/v3/pools/list
+// ----------
+// Returns the status of all pools.
+func (a adminAPIHandlers) ListPools(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListPools")
+
+	defer logger.AuditLog(w, r, "ListPools", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	statuses := make([]madmin.PoolStatus, len(pools.serverPools))
+	for idx := range pools.serverPools {
+		status, err := pools.Status(ctx, idx)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+		statuses[idx] = status
+	}
+
+	data, err := json.Marshal(statuses)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// RebalanceStart - POST /minio/admin/v3/rebalance/start
+// ----------
+// Starts moving objects from the pools which are fuller than the rest,
+// responds with the id of the rebalance.
+func (a adminAPIHandlers) RebalanceStart(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RebalanceStart")
+
+	defer logger.AuditLog(w, r, "RebalanceStart", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.RebalanceAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	id, err := pools.RebalanceStart(ctx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Notify all other MinIO peers to reload the pool status.
+	globalNotificationSys.ReloadPoolMeta(ctx)
+
+	data, err := json.Marshal(struct {
+		ID string `json:"id"`
+	}{ID: id})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// RebalanceStop - POST /minio/admin/v3/rebalance/stop
+// ----------
+// Stops an ongoing rebalance, the objects already moved stay on their
+// new pools.
+func (a adminAPIHandlers) RebalanceStop(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RebalanceStop")
+
+	defer logger.AuditLog(w, r, "RebalanceStop", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.RebalanceAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	if err := pools.RebalanceStop(ctx); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Notify all other MinIO peers to reload the pool status.
+	globalNotificationSys.ReloadPoolMeta(ctx)
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// RebalanceStatus - GET /minio/admin/v3/rebalance/status
+// ----------
+// Returns the status of the last rebalance, including the progress on
+// each pool.
+func (a adminAPIHandlers) RebalanceStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RebalanceStatus")
+
+	defer logger.AuditLog(w, r, "RebalanceStatus", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	status, err := pools.RebalanceStatus(ctx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// RestripeStart - POST /minio/admin/v3/restripe/start?bucket={bucket}&prefix={prefix}
+// ----------
+// Starts rewriting the existing object versions of the bucket under the
+// prefix, or of all buckets, with the current parity and block size,
+// responds with the id of the re-stripe.
+func (a adminAPIHandlers) RestripeStart(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RestripeStart")
+
+	defer logger.AuditLog(w, r, "RestripeStart", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.RestripeAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	prefix := r.URL.Query().Get("prefix")
+	id, err := pools.RestripeStart(ctx, bucket, prefix)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Notify all other MinIO peers to reload the pool status.
+	globalNotificationSys.ReloadPoolMeta(ctx)
+
+	data, err := json.Marshal(struct {
+		ID string `json:"id"`
+	}{ID: id})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// RestripeStop - POST /minio/admin/v3/restripe/stop
+// ----------
+// Stops an ongoing re-stripe, the versions already rewritten keep
+// their new layout.
+func (a adminAPIHandlers) RestripeStop(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RestripeStop")
+
+	defer logger.AuditLog(w, r, "RestripeStop", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.RestripeAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	if err := pools.RestripeStop(ctx); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Notify all other MinIO peers to reload the pool status.
+	globalNotificationSys.ReloadPoolMeta(ctx)
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// RestripeStatus - GET /minio/admin/v3/restripe/status
+// ----------
+// Returns the status of the last re-stripe.
+func (a adminAPIHandlers) RestripeStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RestripeStatus")
+
+	defer logger.AuditLog(w, r, "RestripeStatus", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	status, err := pools.RestripeStatus(ctx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// DrivesStatus - GET /minio/admin/v3/drives/status
+// ----------
+// Returns the lifecycle state of all drives, along with the heal
+// progress of the drives being healed.
+func (a adminAPIHandlers) DrivesStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DrivesStatus")
+
+	defer logger.AuditLog(w, r, "DrivesStatus", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(pools.DrivesStatus(ctx))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// driveHandler - handles the drive lifecycle operations which act on the
+// drive passed with the "drive" query parameter.
+func (a adminAPIHandlers) driveHandler(w http.ResponseWriter, r *http.Request, name string, fn func(z *erasureServerPools, ctx context.Context, endpoint string) error) {
+	ctx := newContext(r, w, name)
+
+	defer logger.AuditLog(w, r, name, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	if err := fn(pools, ctx, mux.Vars(r)["drive"]); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// ReplaceDrive - POST /minio/admin/v3/drives/replace?drive=http://server1/disk1
+// ----------
+// Marks an online drive as being replaced, it does not serve reads
+// anymore and is healed once it is swapped for a new drive.
+func (a adminAPIHandlers) ReplaceDrive(w http.ResponseWriter, r *http.Request) {
+	a.driveHandler(w, r, "ReplaceDrive", (*erasureServerPools).ReplaceDrive)
+}
+
+// CancelReplaceDrive - POST /minio/admin/v3/drives/cancel-replace?drive=http://server1/disk1
+// ----------
+// Makes a drive marked as being replaced active again.
+func (a adminAPIHandlers) CancelReplaceDrive(w http.ResponseWriter, r *http.Request) {
+	a.driveHandler(w, r, "CancelReplaceDrive", (*erasureServerPools).CancelReplaceDrive)
+}
+
+// PauseDriveHeal - POST /minio/admin/v3/drives/pause-heal?drive=http://server1/disk1
+// ----------
+// Pauses the heal of a new drive.
+func (a adminAPIHandlers) PauseDriveHeal(w http.ResponseWriter, r *http.Request) {
+	a.driveHandler(w, r, "PauseDriveHeal", (*erasureServerPools).PauseDriveHeal)
+}
+
+// ResumeDriveHeal - POST /minio/admin/v3/drives/resume-heal?drive=http://server1/disk1
+// ----------
+// Resumes a paused heal of a new drive.
+func (a adminAPIHandlers) ResumeDriveHeal(w http.ResponseWriter, r *http.Request) {
+	a.driveHandler(w, r, "ResumeDriveHeal", (*erasureServerPools).ResumeDriveHeal)
+}
@@ -25,8 +25,10 @@ import (
 	"path"
 
 	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/config"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/env"
 	iampolicy "github.com/minio/minio/pkg/iam/policy"
 	"github.com/minio/minio/pkg/madmin"
 )
@@ -317,6 +319,149 @@ func (a adminAPIHandlers) SetGroupStatus(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// SetUserQuota - PUT /minio/admin/v3/set-user-quota?accessKey=<access_key>
+func (a adminAPIHandlers) SetUserQuota(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetUserQuota")
+
+	defer logger.AuditLog(w, r, "SetUserQuota", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.SetUserQuotaAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	accessKey := mux.Vars(r)["accessKey"]
+
+	// The root user is not subject to quotas.
+	if accessKey == globalActiveCred.AccessKey {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	quota, ok := readIAMQuota(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	// Quotas of deleted users may still be removed.
+	if !quota.IsEmpty() && globalIAMSys.usersSysType == MinIOUsersSysType {
+		if _, ok := globalIAMSys.GetUser(accessKey); !ok {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errNoSuchUser), r.URL)
+			return
+		}
+	}
+
+	if err := globalIAMQuotaSys.SetUserQuota(ctx, objectAPI, accessKey, quota); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// GetUserQuota - GET /minio/admin/v3/get-user-quota?accessKey=<access_key>
+func (a adminAPIHandlers) GetUserQuota(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetUserQuota")
+
+	defer logger.AuditLog(w, r, "GetUserQuota", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.GetUserQuotaAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	info, err := globalIAMQuotaSys.GetUserQuota(objectAPI, mux.Vars(r)["accessKey"])
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// SetGroupQuota - PUT /minio/admin/v3/set-group-quota?group=mygroup1
+func (a adminAPIHandlers) SetGroupQuota(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetGroupQuota")
+
+	defer logger.AuditLog(w, r, "SetGroupQuota", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.SetGroupQuotaAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	group := mux.Vars(r)["group"]
+
+	quota, ok := readIAMQuota(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	// Quotas of deleted groups may still be removed.
+	if !quota.IsEmpty() {
+		if _, err := globalIAMSys.GetGroupDescription(group); err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+	}
+
+	if err := globalIAMQuotaSys.SetGroupQuota(ctx, objectAPI, group, quota); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// GetGroupQuota - GET /minio/admin/v3/get-group-quota?group=mygroup1
+func (a adminAPIHandlers) GetGroupQuota(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetGroupQuota")
+
+	defer logger.AuditLog(w, r, "GetGroupQuota", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminUsersReq(ctx, w, r, iampolicy.GetGroupQuotaAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	info, err := globalIAMQuotaSys.GetGroupQuota(objectAPI, mux.Vars(r)["group"])
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// readIAMQuota - reads the quota from the request body, quotas are
+// unavailable when the data usage crawler is disabled.
+func readIAMQuota(ctx context.Context, w http.ResponseWriter, r *http.Request) (quota madmin.IAMQuota, ok bool) {
+	if env.Get(envDataUsageCrawlConf, config.EnableOn) == config.EnableOff {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminBucketQuotaDisabled), r.URL)
+		return quota, false
+	}
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return quota, false
+	}
+
+	if err = json.Unmarshal(data, &quota); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return quota, false
+	}
+	return quota, true
+}
+
 // SetUserStatus - PUT /minio/admin/v3/set-user-status?accessKey=<access_key>&status=[enabled|disabled]
 func (a adminAPIHandlers) SetUserStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "SetUserStatus")
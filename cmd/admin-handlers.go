@@ -349,6 +349,41 @@ func (a adminAPIHandlers) DataUsageInfoHandler(w http.ResponseWriter, r *http.Re
 	writeSuccessResponseJSON(w, dataUsageInfoJSON)
 }
 
+// CapacityForecastHandler - GET /minio/admin/v3/capacity/forecast
+// ----------
+// Get the growth rates of the pools and buckets along with the projected
+// dates when the pools are full and when the bucket quotas are reached.
+func (a adminAPIHandlers) CapacityForecastHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CapacityForecast")
+
+	defer logger.AuditLog(w, r, "CapacityForecast", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DataUsageInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	forecast, err := pools.CapacityForecast(ctx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	forecastJSON, err := json.Marshal(forecast)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, forecastJSON)
+}
+
 func lriToLockEntry(l lockRequesterInfo, resource, server string) *madmin.LockEntry {
 	entry := &madmin.LockEntry{
 		Timestamp:  l.Timestamp,
@@ -903,6 +938,41 @@ func (a adminAPIHandlers) BackgroundHealStatusHandler(w http.ResponseWriter, r *
 	w.(http.Flusher).Flush()
 }
 
+// ScrubStatusHandler - GET /minio/admin/v3/scrub/status
+// ----------
+// Returns the scrub report of all drives, along with the corrupted
+// shards found on each drive.
+func (a adminAPIHandlers) ScrubStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ScrubStatus")
+
+	defer logger.AuditLog(w, r, "ScrubStatus", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	pools, ok := objectAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrHealNotImplemented), r.URL)
+		return
+	}
+
+	reports, err := pools.ScrubStatus(ctx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(reports)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
 func validateAdminReq(ctx context.Context, w http.ResponseWriter, r *http.Request, action iampolicy.AdminAction) (ObjectLayer, auth.Credentials) {
 	var cred auth.Credentials
 	var adminAPIErr APIErrorCode
@@ -947,6 +1017,20 @@ func toAdminAPIErrCode(ctx context.Context, err error) APIErrorCode {
 	switch err {
 	case errErasureWriteQuorum:
 		return ErrAdminConfigNoQuorum
+	case errNoSuchPool:
+		return ErrAdminNoSuchPool
+	case errDecommissionSinglePool, errDecommissionNoTargetPool, errDecommissionAlreadyRunning,
+		errDecommissionComplete, errDecommissionNotStarted:
+		return ErrAdminDecommissionNotAllowed
+	case errRebalanceSinglePool, errRebalanceAlreadyRunning, errRebalanceNotStarted:
+		return ErrAdminRebalanceNotAllowed
+	case errRestripeAlreadyRunning, errRestripeNotStarted, errRestripePrefixNoBucket:
+		return ErrAdminRestripeNotAllowed
+	case errNoSuchDrive:
+		return ErrAdminNoSuchDrive
+	case errDriveOffline, errDriveHealing, errDriveNotReplacing, errDriveNotHealing,
+		errDriveAlreadyPaused, errDriveNotPaused:
+		return ErrAdminDriveStateNotAllowed
 	default:
 		return toAPIErrorCode(ctx, err)
 	}
@@ -1436,6 +1520,31 @@ func (a adminAPIHandlers) HealthInfoHandler(w http.ResponseWriter, r *http.Reque
 
 }
 
+// ReplicationQueueInfoHandler - GET /minio/admin/v3/replication/queue
+// ----------
+// Get the replication tasks queued on all servers and not attempted yet.
+func (a adminAPIHandlers) ReplicationQueueInfoHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReplicationQueueInfo")
+
+	defer logger.AuditLog(w, r, "ReplicationQueueInfo", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ReplicationInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	queues := []madmin.ReplicationQueueInfo{getLocalReplicationQueueInfo(r)}
+	queues = append(queues, globalNotificationSys.ReplicationQueueInfo()...)
+
+	queuesJSON, err := json.Marshal(queues)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, queuesJSON)
+}
+
 // BandwidthMonitorHandler - GET /minio/admin/v3/bandwidth
 // ----------
 // Get bandwidth consumption information
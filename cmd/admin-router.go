@@ -63,6 +63,7 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/storageinfo").HandlerFunc(httpTraceAll(adminAPI.StorageInfoHandler))
 		// DataUsageInfo operations
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/datausageinfo").HandlerFunc(httpTraceAll(adminAPI.DataUsageInfoHandler))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/capacity/forecast").HandlerFunc(httpTraceAll(adminAPI.CapacityForecastHandler))
 
 		if globalIsDistErasure || globalIsErasure {
 			/// Heal operations
@@ -73,6 +74,30 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/heal/{bucket}/{prefix:.*}").HandlerFunc(httpTraceAll(adminAPI.HealHandler))
 
 			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/background-heal/status").HandlerFunc(httpTraceAll(adminAPI.BackgroundHealStatusHandler))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/scrub/status").HandlerFunc(httpTraceAll(adminAPI.ScrubStatusHandler))
+
+			/// Pool operations
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/pools/list").HandlerFunc(httpTraceAll(adminAPI.ListPools))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/pools/status").HandlerFunc(httpTraceAll(adminAPI.StatusPool)).Queries("pool", "{pool:.*}")
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/pools/decommission").HandlerFunc(httpTraceAll(adminAPI.StartDecommission)).Queries("pool", "{pool:.*}")
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/pools/cancel").HandlerFunc(httpTraceAll(adminAPI.CancelDecommission)).Queries("pool", "{pool:.*}")
+
+			/// Rebalance operations
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/rebalance/start").HandlerFunc(httpTraceAll(adminAPI.RebalanceStart))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/rebalance/stop").HandlerFunc(httpTraceAll(adminAPI.RebalanceStop))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/rebalance/status").HandlerFunc(httpTraceAll(adminAPI.RebalanceStatus))
+
+			/// Re-stripe operations
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/restripe/start").HandlerFunc(httpTraceAll(adminAPI.RestripeStart))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/restripe/stop").HandlerFunc(httpTraceAll(adminAPI.RestripeStop))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/restripe/status").HandlerFunc(httpTraceAll(adminAPI.RestripeStatus))
+
+			/// Drive lifecycle operations
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/drives/status").HandlerFunc(httpTraceAll(adminAPI.DrivesStatus))
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/drives/replace").HandlerFunc(httpTraceAll(adminAPI.ReplaceDrive)).Queries("drive", "{drive:.*}")
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/drives/cancel-replace").HandlerFunc(httpTraceAll(adminAPI.CancelReplaceDrive)).Queries("drive", "{drive:.*}")
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/drives/pause-heal").HandlerFunc(httpTraceAll(adminAPI.PauseDriveHeal)).Queries("drive", "{drive:.*}")
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/drives/resume-heal").HandlerFunc(httpTraceAll(adminAPI.ResumeDriveHeal)).Queries("drive", "{drive:.*}")
 
 			/// Health operations
 
@@ -169,9 +194,23 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 
 			// Set Group Status
 			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-group-status").HandlerFunc(httpTraceHdrs(adminAPI.SetGroupStatus)).Queries("group", "{group:.*}").Queries("status", "{status:.*}")
+
+			// User and group quotas
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-user-quota").HandlerFunc(httpTraceHdrs(adminAPI.SetUserQuota)).Queries("accessKey", "{accessKey:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-user-quota").HandlerFunc(httpTraceHdrs(adminAPI.GetUserQuota)).Queries("accessKey", "{accessKey:.*}")
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-group-quota").HandlerFunc(httpTraceHdrs(adminAPI.SetGroupQuota)).Queries("group", "{group:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-group-quota").HandlerFunc(httpTraceHdrs(adminAPI.GetGroupQuota)).Queries("group", "{group:.*}")
 		}
 
 		if globalIsDistErasure || globalIsErasure {
+			// Bucket storage class operations
+			// GetBucketStorageClassConfig
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-storage-class").HandlerFunc(
+				httpTraceHdrs(adminAPI.GetBucketStorageClassConfigHandler)).Queries("bucket", "{bucket:.*}")
+			// PutBucketStorageClassConfig
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-storage-class").HandlerFunc(
+				httpTraceHdrs(adminAPI.PutBucketStorageClassConfigHandler)).Queries("bucket", "{bucket:.*}")
+
 			// Quota operations
 			if env.Get(envDataUsageCrawlConf, config.EnableOn) == config.EnableOn {
 				// GetBucketQuotaConfig
@@ -191,6 +230,15 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 				// RemoveRemoteTargetHandler
 				adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/remove-remote-target").HandlerFunc(
 					httpTraceHdrs(adminAPI.RemoveRemoteTargetHandler)).Queries("bucket", "{bucket:.*}", "arn", "{arn:.*}")
+				// ResyncReplicationTargetHandler
+				adminRouter.Methods(http.MethodPost).Path(adminVersion+"/replication/resync").HandlerFunc(
+					httpTraceHdrs(adminAPI.ResyncReplicationTargetHandler)).Queries("bucket", "{bucket:.*}", "arn", "{arn:.*}")
+				// ReplicationResyncStatusHandler
+				adminRouter.Methods(http.MethodGet).Path(adminVersion+"/replication/resync").HandlerFunc(
+					httpTraceHdrs(adminAPI.ReplicationResyncStatusHandler)).Queries("bucket", "{bucket:.*}")
+				// BucketReplicationStatsHandler
+				adminRouter.Methods(http.MethodGet).Path(adminVersion+"/replication/stats").HandlerFunc(
+					httpTraceHdrs(adminAPI.BucketReplicationStatsHandler)).Queries("bucket", "{bucket:.*}")
 			}
 		}
 		// -- Top APIs --
@@ -219,6 +267,8 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 				HandlerFunc(httpTraceHdrs(adminAPI.HealthInfoHandler))
 			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/bandwidth").
 				HandlerFunc(httpTraceHdrs(adminAPI.BandwidthMonitorHandler))
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/replication/queue").
+				HandlerFunc(httpTraceAll(adminAPI.ReplicationQueueInfoHandler))
 		}
 	}
 
@@ -33,12 +33,19 @@ import (
 	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/acl"
+	"github.com/minio/minio/pkg/bucket/cors"
+	"github.com/minio/minio/pkg/bucket/inventory"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/logging"
 	"github.com/minio/minio/pkg/bucket/replication"
 
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
+	"github.com/minio/minio/pkg/bucket/ownership"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/publicaccess"
 	"github.com/minio/minio/pkg/bucket/versioning"
+	"github.com/minio/minio/pkg/bucket/website"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/hash"
 )
@@ -104,7 +111,21 @@ const (
 	ErrNoSuchLifecycleConfiguration
 	ErrNoSuchBucketSSEConfig
 	ErrNoSuchCORSConfiguration
+	ErrCORSForbidden
 	ErrNoSuchWebsiteConfiguration
+	ErrInvalidTargetBucketForLogging
+	ErrNoSuchConfiguration
+	ErrInvalidInventoryDestination
+	ErrInventoryIDMismatch
+	ErrNoSuchPublicAccessBlockConfiguration
+	ErrOwnershipControlsNotFoundError
+	ErrAccessControlListNotSupported
+	ErrInvalidBucketACLWithObjectOwnership
+	// Returned by the auth checks of object actions matched by no
+	// policy, the handler grants access if the object ACL allows it.
+	ErrCheckObjectACL
+	ErrInvalidChecksum
+	ErrInvalidAttributeName
 	ErrReplicationConfigurationNotFoundError
 	ErrRemoteDestinationNotFoundError
 	ErrReplicationDestinationMissingLock
@@ -220,6 +241,7 @@ const (
 
 	// S3 extended errors.
 	ErrContentSHA256Mismatch
+	ErrContentChecksumMismatch
 
 	// Add new extended error codes here.
 
@@ -262,8 +284,15 @@ const (
 	ErrObjectTampered
 	// Bucket Quota error codes
 	ErrAdminBucketQuotaExceeded
+	ErrAdminIAMQuotaExceeded
 	ErrAdminNoSuchQuotaConfiguration
 	ErrAdminBucketQuotaDisabled
+	ErrAdminNoSuchPool
+	ErrAdminDecommissionNotAllowed
+	ErrAdminRebalanceNotAllowed
+	ErrAdminRestripeNotAllowed
+	ErrAdminNoSuchDrive
+	ErrAdminDriveStateNotAllowed
 
 	ErrHealNotImplemented
 	ErrHealNoSuchProcess
@@ -809,11 +838,71 @@ var errorCodes = errorCodeMap{
 		Description:    "The CORS configuration does not exist",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrCORSForbidden: {
+		Code:           "AccessForbidden",
+		Description:    "CORSResponse: This CORS request is not allowed. This is usually because the evaluation of Origin, request method / Access-Control-Request-Method or Access-Control-Request-Headers are not whitelisted by the resource's CORS spec.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
 	ErrNoSuchWebsiteConfiguration: {
 		Code:           "NoSuchWebsiteConfiguration",
 		Description:    "The specified bucket does not have a website configuration",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrInvalidTargetBucketForLogging: {
+		Code:           "InvalidTargetBucketForLogging",
+		Description:    "The target bucket for logging does not exist",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrNoSuchConfiguration: {
+		Code:           "NoSuchConfiguration",
+		Description:    "The specified configuration does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrInvalidInventoryDestination: {
+		Code:           "InvalidArgument",
+		Description:    "The destination bucket of the inventory configuration does not exist",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInventoryIDMismatch: {
+		Code:           "InvalidArgument",
+		Description:    "The Id of the inventory configuration does not match the id query parameter",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrNoSuchPublicAccessBlockConfiguration: {
+		Code:           "NoSuchPublicAccessBlockConfiguration",
+		Description:    "The public access block configuration was not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrOwnershipControlsNotFoundError: {
+		Code:           "OwnershipControlsNotFoundError",
+		Description:    "The bucket ownership controls were not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAccessControlListNotSupported: {
+		Code:           "AccessControlListNotSupported",
+		Description:    "The bucket does not allow ACLs",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidBucketACLWithObjectOwnership: {
+		Code:           "InvalidBucketAclWithObjectOwnership",
+		Description:    "Bucket cannot have ACLs set with ObjectOwnership's BucketOwnerEnforced setting",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrCheckObjectACL: {
+		Code:           "AccessDenied",
+		Description:    "Access Denied.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrInvalidChecksum: {
+		Code:           "InvalidArgument",
+		Description:    "Invalid checksum provided.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidAttributeName: {
+		Code:           "InvalidArgument",
+		Description:    "Invalid attribute name specified.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrReplicationConfigurationNotFoundError: {
 		Code:           "ReplicationConfigurationNotFoundError",
 		Description:    "The replication configuration was not found",
@@ -1087,6 +1176,11 @@ var errorCodes = errorCodeMap{
 		Description:    "The provided 'x-amz-content-sha256' header does not match what was computed.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrContentChecksumMismatch: {
+		Code:           "XAmzContentChecksumMismatch",
+		Description:    "The provided 'x-amz-checksum' header does not match what was computed.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 
 	/// MinIO extensions.
 	ErrStorageFull: {
@@ -1210,6 +1304,11 @@ var errorCodes = errorCodeMap{
 		Description:    "Bucket quota exceeded",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrAdminIAMQuotaExceeded: {
+		Code:           "XMinioAdminIAMQuotaExceeded",
+		Description:    "User or group quota exceeded",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrAdminNoSuchQuotaConfiguration: {
 		Code:           "XMinioAdminNoSuchQuotaConfiguration",
 		Description:    "The quota configuration does not exist",
@@ -1220,6 +1319,36 @@ var errorCodes = errorCodeMap{
 		Description:    "Quota specified but disk usage crawl is disabled on MinIO server",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrAdminNoSuchPool: {
+		Code:           "XMinioAdminNoSuchPool",
+		Description:    "The specified pool does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminDecommissionNotAllowed: {
+		Code:           "XMinioAdminDecommissionNotAllowed",
+		Description:    "The decommission operation is not allowed on this pool",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminRebalanceNotAllowed: {
+		Code:           "XMinioAdminRebalanceNotAllowed",
+		Description:    "The rebalance operation is not allowed",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminRestripeNotAllowed: {
+		Code:           "XMinioAdminRestripeNotAllowed",
+		Description:    "The re-stripe operation is not allowed",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminNoSuchDrive: {
+		Code:           "XMinioAdminNoSuchDrive",
+		Description:    "The specified drive does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminDriveStateNotAllowed: {
+		Code:           "XMinioAdminDriveStateNotAllowed",
+		Description:    "The operation is not allowed in the current state of the drive",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrInsecureClientRequest: {
 		Code:           "XMinioInsecureClientRequest",
 		Description:    "Cannot respond to plain-text request from TLS-encrypted server",
@@ -1842,6 +1971,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrObjectLockInvalidHeaders
 	case objectlock.ErrMalformedXML:
 		apiErr = ErrMalformedXML
+	case hash.ErrInvalidChecksum:
+		apiErr = ErrInvalidChecksum
 	}
 
 	// Compression errors
@@ -1904,6 +2035,10 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrInvalidObjectNamePrefixSlash
 	case InvalidUploadID:
 		apiErr = ErrNoSuchUpload
+	case PreConditionFailed:
+		apiErr = ErrPreconditionFailed
+	case ACLNotSupported:
+		apiErr = ErrAccessControlListNotSupported
 	case InvalidPart:
 		apiErr = ErrInvalidPart
 	case InsufficientWriteQuorum:
@@ -1924,6 +2059,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrSignatureDoesNotMatch
 	case hash.SHA256Mismatch:
 		apiErr = ErrContentSHA256Mismatch
+	case hash.ChecksumMismatch:
+		apiErr = ErrContentChecksumMismatch
 	case ObjectTooLarge:
 		apiErr = ErrEntityTooLarge
 	case ObjectTooSmall:
@@ -1942,6 +2079,16 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchBucketSSEConfig
 	case BucketTaggingNotFound:
 		apiErr = ErrBucketTaggingNotFound
+	case BucketCorsConfigNotFound:
+		apiErr = ErrNoSuchCORSConfiguration
+	case BucketWebsiteConfigNotFound:
+		apiErr = ErrNoSuchWebsiteConfiguration
+	case BucketInventoryConfigNotFound:
+		apiErr = ErrNoSuchConfiguration
+	case BucketPublicAccessBlockConfigNotFound:
+		apiErr = ErrNoSuchPublicAccessBlockConfiguration
+	case BucketOwnershipControlsConfigNotFound:
+		apiErr = ErrOwnershipControlsNotFoundError
 	case BucketObjectLockConfigNotFound:
 		apiErr = ErrObjectLockConfigurationNotFound
 	case BucketQuotaConfigNotFound:
@@ -1972,6 +2119,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrReplicationSourceNotVersionedError
 	case BucketQuotaExceeded:
 		apiErr = ErrAdminBucketQuotaExceeded
+	case IAMQuotaExceeded:
+		apiErr = ErrAdminIAMQuotaExceeded
 	case *event.ErrInvalidEventName:
 		apiErr = ErrEventNotification
 	case *event.ErrInvalidARN:
@@ -2088,6 +2237,48 @@ func toAPIError(ctx context.Context, err error) APIError {
 				Description:    e.Error(),
 				HTTPStatusCode: http.StatusBadRequest,
 			}
+		case cors.Error:
+			apiErr = APIError{
+				Code:           "MalformedXML",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case website.Error:
+			apiErr = APIError{
+				Code:           "MalformedXML",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case logging.Error:
+			apiErr = APIError{
+				Code:           "MalformedXML",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case publicaccess.Error:
+			apiErr = APIError{
+				Code:           "MalformedXML",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case ownership.Error:
+			apiErr = APIError{
+				Code:           "MalformedXML",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case inventory.Error:
+			apiErr = APIError{
+				Code:           "MalformedXML",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case acl.Error:
+			apiErr = APIError{
+				Code:           "MalformedACLError",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
 		case tags.Error:
 			apiErr = APIError{
 				Code:           e.Code(),
@@ -28,8 +28,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/minio/minio-go/v7/pkg/set"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/ownership"
 	"github.com/minio/minio/pkg/handlers"
 )
 
@@ -154,6 +156,7 @@ type Part struct {
 	LastModified string
 	ETag         string
 	Size         int64
+	ObjectChecksums
 }
 
 // ListPartsResponse - format for list parts response.
@@ -343,6 +346,36 @@ type CompleteMultipartUploadResponse struct {
 	Bucket   string
 	Key      string
 	ETag     string
+	ObjectChecksums
+}
+
+// ObjectAttributesPart - part of an object in GetObjectAttributes response.
+type ObjectAttributesPart struct {
+	PartNumber int
+	Size       int64
+	ObjectChecksums
+}
+
+// ObjectAttributesParts - parts of an object in GetObjectAttributes response.
+type ObjectAttributesParts struct {
+	IsTruncated          bool
+	MaxParts             int
+	NextPartNumberMarker int
+	PartNumberMarker     int
+	PartsCount           int
+	Parts                []ObjectAttributesPart `xml:"Part"`
+}
+
+// GetObjectAttributesResponse - format for GetObjectAttributes response,
+// only the requested attributes are set.
+type GetObjectAttributesResponse struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ GetObjectAttributesOutput" json:"-"`
+
+	ETag         string                 `xml:",omitempty"`
+	Checksum     *ObjectChecksums       `xml:",omitempty"`
+	ObjectParts  *ObjectAttributesParts `xml:",omitempty"`
+	StorageClass string                 `xml:",omitempty"`
+	ObjectSize   *int64                 `xml:",omitempty"`
 }
 
 // DeleteError structure.
@@ -426,13 +459,18 @@ func generateListBucketsResponse(buckets []BucketInfo) ListBucketsResponse {
 	return data
 }
 
+// newObjectOwner - returns the owner of the object in listings.
+func newObjectOwner(objInfo ObjectInfo, objectOwnership ownership.ObjectOwnership) Owner {
+	owner := getObjectOwner(objInfo, objectOwnership)
+	return Owner{ID: owner.ID, DisplayName: owner.DisplayName}
+}
+
 // generates an ListBucketVersions response for the said bucket with other enumerated options.
 func generateListVersionsResponse(bucket, prefix, marker, versionIDMarker, delimiter, encodingType string, maxKeys int, resp ListObjectVersionsInfo) ListVersionsResponse {
 	versions := make([]ObjectVersion, 0, len(resp.Objects))
-	var owner = Owner{}
 	var data = ListVersionsResponse{}
 
-	owner.ID = globalMinioDefaultOwnerID
+	objectOwnership := getObjectOwnership(bucket)
 	for _, object := range resp.Objects {
 		var content = ObjectVersion{}
 		if object.Name == "" {
@@ -449,7 +487,7 @@ func generateListVersionsResponse(bucket, prefix, marker, versionIDMarker, delim
 		} else {
 			content.StorageClass = globalMinioDefaultStorageClass
 		}
-		content.Owner = owner
+		content.Owner = newObjectOwner(object, objectOwnership)
 		content.VersionID = object.VersionID
 		if content.VersionID == "" {
 			content.VersionID = nullVersionID
@@ -485,10 +523,9 @@ func generateListVersionsResponse(bucket, prefix, marker, versionIDMarker, delim
 // generates an ListObjectsV1 response for the said bucket with other enumerated options.
 func generateListObjectsV1Response(bucket, prefix, marker, delimiter, encodingType string, maxKeys int, resp ListObjectsInfo) ListObjectsResponse {
 	contents := make([]Object, 0, len(resp.Objects))
-	var owner = Owner{}
 	var data = ListObjectsResponse{}
 
-	owner.ID = globalMinioDefaultOwnerID
+	objectOwnership := getObjectOwnership(bucket)
 	for _, object := range resp.Objects {
 		var content = Object{}
 		if object.Name == "" {
@@ -505,7 +542,7 @@ func generateListObjectsV1Response(bucket, prefix, marker, delimiter, encodingTy
 		} else {
 			content.StorageClass = globalMinioDefaultStorageClass
 		}
-		content.Owner = owner
+		content.Owner = newObjectOwner(object, objectOwnership)
 		contents = append(contents, content)
 	}
 	data.Name = bucket
@@ -532,11 +569,11 @@ func generateListObjectsV1Response(bucket, prefix, marker, delimiter, encodingTy
 // generates an ListObjectsV2 response for the said bucket with other enumerated options.
 func generateListObjectsV2Response(bucket, prefix, token, nextToken, startAfter, delimiter, encodingType string, fetchOwner, isTruncated bool, maxKeys int, objects []ObjectInfo, prefixes []string, metadata bool) ListObjectsV2Response {
 	contents := make([]Object, 0, len(objects))
-	var owner = Owner{}
 	var data = ListObjectsV2Response{}
 
+	var objectOwnership ownership.ObjectOwnership
 	if fetchOwner {
-		owner.ID = globalMinioDefaultOwnerID
+		objectOwnership = getObjectOwnership(bucket)
 	}
 
 	for _, object := range objects {
@@ -555,7 +592,9 @@ func generateListObjectsV2Response(bucket, prefix, token, nextToken, startAfter,
 		} else {
 			content.StorageClass = globalMinioDefaultStorageClass
 		}
-		content.Owner = owner
+		if fetchOwner {
+			content.Owner = newObjectOwner(object, objectOwnership)
+		}
 		if metadata {
 			content.UserMetadata = make(StringMap)
 			for k, v := range CleanMinioInternalMetadataKeys(object.UserDefined) {
@@ -654,11 +693,69 @@ func generateListPartsResponse(partsInfo ListPartsInfo, encodingType string) Lis
 		newPart.ETag = "\"" + part.ETag + "\""
 		newPart.Size = part.Size
 		newPart.LastModified = part.LastModified.UTC().Format(iso8601TimeFormat)
+		newPart.ObjectChecksums = newObjectChecksums(part.Checksum)
 		listPartsResponse.Parts[index] = newPart
 	}
 	return listPartsResponse
 }
 
+// generates GetObjectAttributesResponse with the requested attributes of
+// the object, parts with higher part numbers than partNumberMarker are
+// listed up to maxParts.
+func generateGetObjectAttributesResponse(objInfo ObjectInfo, attributes set.StringSet, partNumberMarker, maxParts int) GetObjectAttributesResponse {
+	var response GetObjectAttributesResponse
+	if attributes.Contains(objectAttributeETag) {
+		response.ETag = objInfo.ETag
+	}
+	if attributes.Contains(objectAttributeChecksum) {
+		if checksum, ok := getObjectChecksum(objInfo.UserDefined, 0); ok {
+			checksums := newObjectChecksums(checksum)
+			response.Checksum = &checksums
+		}
+	}
+	if attributes.Contains(objectAttributeObjectParts) && len(objInfo.Parts) > 0 {
+		parts := &ObjectAttributesParts{
+			MaxParts:         maxParts,
+			PartNumberMarker: partNumberMarker,
+			PartsCount:       len(objInfo.Parts),
+		}
+		for i, part := range objInfo.Parts {
+			if part.Number <= partNumberMarker {
+				continue
+			}
+			if len(parts.Parts) == maxParts {
+				parts.IsTruncated = true
+				break
+			}
+			size := part.ActualSize
+			if size <= 0 {
+				size = part.Size
+			}
+			attributesPart := ObjectAttributesPart{PartNumber: part.Number, Size: size}
+			if checksum, ok := getObjectChecksum(objInfo.UserDefined, i+1); ok {
+				attributesPart.ObjectChecksums = newObjectChecksums(checksum)
+			}
+			parts.Parts = append(parts.Parts, attributesPart)
+			parts.NextPartNumberMarker = part.Number
+		}
+		response.ObjectParts = parts
+	}
+	if attributes.Contains(objectAttributeStorageClass) {
+		response.StorageClass = objInfo.StorageClass
+		if response.StorageClass == "" {
+			response.StorageClass = globalMinioDefaultStorageClass
+		}
+	}
+	if attributes.Contains(objectAttributeObjectSize) {
+		size, err := objInfo.GetActualSize()
+		if err != nil {
+			size = objInfo.Size
+		}
+		response.ObjectSize = &size
+	}
+	return response
+}
+
 // generates ListMultipartUploadsResponse for given bucket and ListMultipartsInfo.
 func generateListMultipartUploadsResponse(bucket string, multipartsInfo ListMultipartsInfo, encodingType string) ListMultipartUploadsResponse {
 	listMultipartUploadsResponse := ListMultipartUploadsResponse{}
@@ -725,6 +822,8 @@ const (
 	mimeJSON mimeType = "application/json"
 	// Means response type is XML.
 	mimeXML mimeType = "application/xml"
+	// Means response type is HTML.
+	mimeHTML mimeType = "text/html"
 )
 
 // writeSuccessResponseJSON writes success headers and response if any,
@@ -76,7 +76,15 @@ func isRequestPostPolicySignatureV4(r *http.Request) bool {
 
 // Verify if the request has AWS Streaming Signature Version '4'. This is only valid for 'PUT' operation.
 func isRequestSignStreamingV4(r *http.Request) bool {
-	return r.Header.Get(xhttp.AmzContentSha256) == streamingContentSHA256 &&
+	payload := r.Header.Get(xhttp.AmzContentSha256)
+	return (payload == streamingContentSHA256 || payload == streamingContentSHA256Trailer) &&
+		r.Method == http.MethodPut
+}
+
+// Verify if the request has an unsigned streaming payload with a trailing
+// checksum, the request itself is signed with AWS Signature Version '4'.
+func isRequestUnsignedTrailerV4(r *http.Request) bool {
+	return r.Header.Get(xhttp.AmzContentSha256) == streamingUnsignedTrailer &&
 		r.Method == http.MethodPut
 }
 
@@ -269,6 +277,8 @@ func checkClaimsFromToken(r *http.Request, cred auth.Credentials) (map[string]in
 // - validates the request signature
 // - validates the policy action if anonymous tests bucket policies if any,
 //   for authenticated requests validates IAM policies.
+// - checks the ACLs if no policy statement applies to the request,
+//   ErrCheckObjectACL is returned if the object ACL is to be checked.
 // returns APIErrorCode if any to be replied to the client.
 func checkRequestAuthType(ctx context.Context, r *http.Request, action policy.Action, bucketName, objectName string) (s3Err APIErrorCode) {
 	_, _, s3Err = checkRequestAuthTypeToAccessKey(ctx, r, action, bucketName, objectName)
@@ -279,6 +289,8 @@ func checkRequestAuthType(ctx context.Context, r *http.Request, action policy.Ac
 // - validates the request signature
 // - validates the policy action if anonymous tests bucket policies if any,
 //   for authenticated requests validates IAM policies.
+// - checks the ACLs if no policy statement applies to the request,
+//   ErrCheckObjectACL is returned if the object ACL is to be checked.
 // returns APIErrorCode if any to be replied to the client.
 // Additionally returns the accessKey used in the request, and if this request is by an admin.
 func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, action policy.Action, bucketName, objectName string) (accessKey string, owner bool, s3Err APIErrorCode) {
@@ -339,15 +351,21 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 	}
 
 	if action != policy.ListAllMyBucketsAction && cred.AccessKey == "" {
+		// Anonymous requests are denied if the bucket restricts public access.
+		if getPublicAccessBlock(bucketName).RestrictPublicBuckets {
+			return cred.AccessKey, owner, ErrAccessDenied
+		}
+
 		// Anonymous checks are not meant for ListBuckets action
-		if globalPolicySys.IsAllowed(policy.Args{
+		decision := globalPolicySys.Evaluate(policy.Args{
 			AccountName:     cred.AccessKey,
 			Action:          action,
 			BucketName:      bucketName,
 			ConditionValues: getConditionValues(r, locationConstraint, "", nil),
 			IsOwner:         false,
 			ObjectName:      objectName,
-		}) {
+		})
+		if decision == policy.Allowed {
 			// Request is allowed return the appropriate access key.
 			return cred.AccessKey, owner, ErrNone
 		}
@@ -355,7 +373,7 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 		if action == policy.ListBucketVersionsAction {
 			// In AWS S3 s3:ListBucket permission is same as s3:ListBucketVersions permission
 			// verify as a fallback.
-			if globalPolicySys.IsAllowed(policy.Args{
+			switch globalPolicySys.Evaluate(policy.Args{
 				AccountName:     cred.AccessKey,
 				Action:          policy.ListBucketAction,
 				BucketName:      bucketName,
@@ -363,15 +381,23 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 				IsOwner:         false,
 				ObjectName:      objectName,
 			}) {
+			case policy.Allowed:
 				// Request is allowed return the appropriate access key.
 				return cred.AccessKey, owner, ErrNone
+			case policy.Denied:
+				decision = policy.Denied
 			}
 		}
 
-		return cred.AccessKey, owner, ErrAccessDenied
+		if decision == policy.Denied {
+			return cred.AccessKey, owner, ErrAccessDenied
+		}
+
+		// No policy applies, check the ACLs.
+		return cred.AccessKey, owner, checkACL(cred, owner, action, bucketName, objectName)
 	}
 
-	if globalIAMSys.IsAllowed(iampolicy.Args{
+	decision := globalIAMSys.Evaluate(iampolicy.Args{
 		AccountName:     cred.AccessKey,
 		Action:          iampolicy.Action(action),
 		BucketName:      bucketName,
@@ -379,7 +405,8 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 		ObjectName:      objectName,
 		IsOwner:         owner,
 		Claims:          claims,
-	}) {
+	})
+	if decision == policy.Allowed {
 		// Request is allowed return the appropriate access key.
 		return cred.AccessKey, owner, ErrNone
 	}
@@ -387,7 +414,7 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 	if action == policy.ListBucketVersionsAction {
 		// In AWS S3 s3:ListBucket permission is same as s3:ListBucketVersions permission
 		// verify as a fallback.
-		if globalIAMSys.IsAllowed(iampolicy.Args{
+		switch globalIAMSys.Evaluate(iampolicy.Args{
 			AccountName:     cred.AccessKey,
 			Action:          iampolicy.ListBucketAction,
 			BucketName:      bucketName,
@@ -396,12 +423,20 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 			IsOwner:         owner,
 			Claims:          claims,
 		}) {
+		case policy.Allowed:
 			// Request is allowed return the appropriate access key.
 			return cred.AccessKey, owner, ErrNone
+		case policy.Denied:
+			decision = policy.Denied
 		}
 	}
 
-	return cred.AccessKey, owner, ErrAccessDenied
+	if decision == policy.Denied {
+		return cred.AccessKey, owner, ErrAccessDenied
+	}
+
+	// No policy applies, check the ACLs.
+	return cred.AccessKey, owner, checkACL(cred, owner, action, bucketName, objectName)
 }
 
 // Verify if request has valid AWS Signature Version '2'.
@@ -655,7 +690,11 @@ func isPutActionAllowed(ctx context.Context, atype authType, bucketName, objectN
 	}
 
 	if cred.AccessKey == "" {
-		if globalPolicySys.IsAllowed(policy.Args{
+		// Anonymous requests are denied if the bucket restricts public access.
+		if getPublicAccessBlock(bucketName).RestrictPublicBuckets {
+			return ErrAccessDenied
+		}
+		switch globalPolicySys.Evaluate(policy.Args{
 			AccountName:     cred.AccessKey,
 			Action:          policy.Action(action),
 			BucketName:      bucketName,
@@ -663,12 +702,16 @@ func isPutActionAllowed(ctx context.Context, atype authType, bucketName, objectN
 			IsOwner:         false,
 			ObjectName:      objectName,
 		}) {
+		case policy.Allowed:
 			return ErrNone
+		case policy.Denied:
+			return ErrAccessDenied
 		}
-		return ErrAccessDenied
+		// The object is being written, only the bucket ACL applies.
+		return checkACL(cred, owner, policy.Action(action), bucketName, "")
 	}
 
-	if globalIAMSys.IsAllowed(iampolicy.Args{
+	switch globalIAMSys.Evaluate(iampolicy.Args{
 		AccountName:     cred.AccessKey,
 		Action:          action,
 		BucketName:      bucketName,
@@ -677,7 +720,11 @@ func isPutActionAllowed(ctx context.Context, atype authType, bucketName, objectN
 		IsOwner:         owner,
 		Claims:          claims,
 	}) {
+	case policy.Allowed:
 		return ErrNone
+	case policy.Denied:
+		return ErrAccessDenied
 	}
-	return ErrAccessDenied
+	// The object is being written, only the bucket ACL applies.
+	return checkACL(cred, owner, policy.Action(action), bucketName, "")
 }
@@ -26,18 +26,169 @@ import (
 	"github.com/dustin/go-humanize"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/console"
+	"github.com/minio/minio/pkg/madmin"
 )
 
 const (
 	defaultMonitorNewDiskInterval = time.Second * 10
 	healingTrackerFilename        = ".healing.bin"
+	healingPausedFilename         = ".healing-paused"
+	healingTrackerUpdateInterval  = time.Second * 10
 )
 
 //go:generate msgp -file $GOFILE -unexported
 type healingTracker struct {
 	ID string
 
-	// future add more tracking capabilities
+	// Lifecycle state of the drive, a tracker without
+	// a state is written by older servers for healing.
+	State string
+
+	Started    time.Time
+	LastUpdate time.Time
+
+	// Set by the admin API in its own file on the drive, saving
+	// the progress of the heal never overwrites it.
+	Paused bool `msg:"-"`
+
+	// Bucket being healed, buckets fully healed are
+	// skipped when the heal is resumed.
+	Bucket        string
+	HealedBuckets []string
+
+	ObjectsHealed uint64
+	ObjectsFailed uint64
+	BytesHealed   uint64
+
+	// Estimated from the data usage when the heal started.
+	ObjectsTotal uint64
+	BytesTotal   uint64
+
+	// Drive the tracker is saved on.
+	disk StorageAPI `msg:"-"`
+}
+
+// loadHealingTracker - reads the healing tracker of the drive.
+func loadHealingTracker(ctx context.Context, disk StorageAPI) (*healingTracker, error) {
+	b, err := disk.ReadAll(ctx, minioMetaBucket,
+		pathJoin(bucketMetaPrefix, slashSeparator, healingTrackerFilename))
+	if err != nil {
+		return nil, err
+	}
+	var h healingTracker
+	if _, err = h.UnmarshalMsg(b); err != nil {
+		return nil, err
+	}
+	if h.State == "" {
+		h.State = string(madmin.DriveHealing)
+	}
+	h.disk = disk
+	h.Paused = h.isPaused(ctx)
+	return &h, nil
+}
+
+// isPaused - returns true if the heal of the drive is paused.
+func (h *healingTracker) isPaused(ctx context.Context) bool {
+	_, err := h.disk.ReadAll(ctx, minioMetaBucket,
+		pathJoin(bucketMetaPrefix, slashSeparator, healingPausedFilename))
+	return err == nil
+}
+
+// setPaused - pauses or resumes the heal of the drive.
+func (h *healingTracker) setPaused(ctx context.Context, paused bool) error {
+	if paused {
+		return h.disk.WriteAll(ctx, minioMetaBucket,
+			pathJoin(bucketMetaPrefix, slashSeparator, healingPausedFilename),
+			[]byte(UTCNow().Format(time.RFC3339)))
+	}
+	err := h.disk.Delete(ctx, pathJoin(minioMetaBucket, bucketMetaPrefix),
+		healingPausedFilename, false)
+	if errors.Is(err, errFileNotFound) {
+		return nil
+	}
+	return err
+}
+
+// save - writes the healing tracker to its drive.
+func (h *healingTracker) save(ctx context.Context) error {
+	htrackerBytes, err := h.MarshalMsg(nil)
+	if err != nil {
+		return err
+	}
+	return h.disk.WriteAll(ctx, minioMetaBucket,
+		pathJoin(bucketMetaPrefix, slashSeparator, healingTrackerFilename),
+		htrackerBytes)
+}
+
+// delete - removes the healing tracker from its drive.
+func (h *healingTracker) delete(ctx context.Context) error {
+	if err := h.setPaused(ctx, false); err != nil {
+		return err
+	}
+	err := h.disk.Delete(ctx, pathJoin(minioMetaBucket, bucketMetaPrefix),
+		healingTrackerFilename, false)
+	if errors.Is(err, errFileNotFound) {
+		return nil
+	}
+	return err
+}
+
+// isHealed - returns true if the bucket was fully healed.
+func (h *healingTracker) isHealed(bucket string) bool {
+	for _, b := range h.HealedBuckets {
+		if b == bucket {
+			return true
+		}
+	}
+	return false
+}
+
+// update - saves the progress of the heal, at most once per interval
+// unless forced, and waits while the heal is paused. The paused state
+// is changed by the admin API on any server, so it is read back from
+// the drive. Failing to save the progress does not stop the heal, an
+// error is only returned once ctx is canceled.
+func (h *healingTracker) update(ctx context.Context, force bool) error {
+	if !force && time.Since(h.LastUpdate) < healingTrackerUpdateInterval {
+		return nil
+	}
+	for {
+		h.Paused = h.isPaused(ctx)
+		h.LastUpdate = UTCNow()
+		if err := h.save(ctx); err != nil {
+			logger.LogIf(ctx, err)
+		}
+		if !h.Paused {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(healingTrackerUpdateInterval):
+		}
+	}
+}
+
+// toHealProgress - returns the heal progress, the ETA is extrapolated
+// from the bytes healed so far.
+func (h *healingTracker) toHealProgress() *madmin.DriveHealProgress {
+	p := &madmin.DriveHealProgress{
+		Started:       h.Started,
+		LastUpdate:    h.LastUpdate,
+		Paused:        h.Paused,
+		Bucket:        h.Bucket,
+		ObjectsHealed: h.ObjectsHealed,
+		ObjectsFailed: h.ObjectsFailed,
+		BytesHealed:   h.BytesHealed,
+		ObjectsTotal:  h.ObjectsTotal,
+		BytesTotal:    h.BytesTotal,
+	}
+	if h.BytesHealed > 0 && h.BytesTotal > h.BytesHealed && h.LastUpdate.After(h.Started) {
+		elapsed := h.LastUpdate.Sub(h.Started)
+		remaining := float64(h.BytesTotal-h.BytesHealed) / float64(h.BytesHealed)
+		p.ETA = h.LastUpdate.Add(time.Duration(float64(elapsed) * remaining))
+	}
+	return p
 }
 
 func initAutoHeal(ctx context.Context, objAPI ObjectLayer) {
@@ -203,16 +354,40 @@ wait:
 							}
 						}
 
+						tracker, err := loadHealingTracker(ctx, disk)
+						if err != nil {
+							logger.LogIf(ctx, err)
+							goto wait
+						}
+
+						// Drives marked for replacement are healed
+						// once they are swapped for a new drive.
+						if tracker.State == string(madmin.DriveReplacing) {
+							globalBackgroundHealState.popHealLocalDisks(disk.Endpoint())
+							continue
+						}
+
+						if tracker.Started.IsZero() {
+							tracker.Started = UTCNow()
+							if usage, err := loadDataUsageFromBackend(ctx, z); err == nil {
+								var sets uint64
+								for _, pool := range z.serverPools {
+									sets += uint64(len(pool.sets))
+								}
+								tracker.ObjectsTotal = usage.ObjectsTotalCount / sets
+								tracker.BytesTotal = usage.ObjectsTotalSize / sets
+							}
+						}
+
 						lbDisks := z.serverPools[i].sets[setIndex].getOnlineDisks()
-						if err := healErasureSet(ctx, setIndex, buckets, lbDisks); err != nil {
+						if err := healErasureSet(ctx, setIndex, buckets, lbDisks, tracker); err != nil {
 							logger.LogIf(ctx, err)
 							continue
 						}
 
 						logger.Info("Healing disk '%s' on %s zone complete", disk, humanize.Ordinal(i+1))
 
-						if err := disk.Delete(ctx, pathJoin(minioMetaBucket, bucketMetaPrefix),
-							healingTrackerFilename, false); err != nil && !errors.Is(err, errFileNotFound) {
+						if err := tracker.delete(ctx); err != nil {
 							logger.LogIf(ctx, err)
 							continue
 						}
@@ -23,8 +23,10 @@ import (
 	"fmt"
 	"hash"
 	"io"
+	"io/ioutil"
 
 	"github.com/minio/minio/cmd/logger"
+	xioutil "github.com/minio/minio/pkg/ioutil"
 )
 
 type errHashMismatch struct {
@@ -37,7 +39,7 @@ func (err *errHashMismatch) Error() string {
 
 // Calculates bitrot in chunks and writes the hash into the stream.
 type streamingBitrotWriter struct {
-	iow       *io.PipeWriter
+	iow       io.WriteCloser
 	h         hash.Hash
 	shardSize int64
 	canClose  chan struct{} // Needed to avoid race explained in Close() call.
@@ -88,9 +90,18 @@ func newStreamingBitrotWriter(disk StorageAPI, volume, filePath string, length i
 	return bw
 }
 
+// Returns streaming bitrot writer implementation which writes the
+// hashes and the data to w, used for data stored inline in xl.meta.
+func newStreamingBitrotWriterBuffer(w io.Writer, algo BitrotAlgorithm, shardSize int64) io.WriteCloser {
+	canClose := make(chan struct{})
+	close(canClose)
+	return &streamingBitrotWriter{xioutil.NopCloser(w), algo.New(), shardSize, canClose}
+}
+
 // ReadAt() implementation which verifies the bitrot hash available as part of the stream.
 type streamingBitrotReader struct {
 	disk       StorageAPI
+	data       []byte
 	rc         io.ReadCloser
 	volume     string
 	filePath   string
@@ -119,9 +130,14 @@ func (b *streamingBitrotReader) ReadAt(buf []byte, offset int64) (int, error) {
 		// For the first ReadAt() call we need to open the stream for reading.
 		b.currOffset = offset
 		streamOffset := (offset/b.shardSize)*int64(b.h.Size()) + offset
-		b.rc, err = b.disk.ReadFileStream(context.TODO(), b.volume, b.filePath, streamOffset, b.tillOffset-streamOffset)
-		if err != nil {
-			return 0, err
+		if b.data != nil {
+			// Inline data is read from memory.
+			b.rc = ioutil.NopCloser(io.NewSectionReader(bytes.NewReader(b.data), streamOffset, b.tillOffset-streamOffset))
+		} else {
+			b.rc, err = b.disk.ReadFileStream(context.TODO(), b.volume, b.filePath, streamOffset, b.tillOffset-streamOffset)
+			if err != nil {
+				return 0, err
+			}
 		}
 	}
 	if offset != b.currOffset {
@@ -149,11 +165,13 @@ func (b *streamingBitrotReader) ReadAt(buf []byte, offset int64) (int, error) {
 	return len(buf), nil
 }
 
-// Returns streaming bitrot reader implementation.
-func newStreamingBitrotReader(disk StorageAPI, volume, filePath string, tillOffset int64, algo BitrotAlgorithm, shardSize int64) *streamingBitrotReader {
+// Returns streaming bitrot reader implementation, reads from data
+// instead of the disk if it is not nil.
+func newStreamingBitrotReader(disk StorageAPI, data []byte, volume, filePath string, tillOffset int64, algo BitrotAlgorithm, shardSize int64) *streamingBitrotReader {
 	h := algo.New()
 	return &streamingBitrotReader{
 		disk,
+		data,
 		nil,
 		volume,
 		filePath,
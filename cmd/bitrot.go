@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"bytes"
 	"errors"
 	"hash"
 	"io"
@@ -103,9 +104,9 @@ func newBitrotWriter(disk StorageAPI, volume, filePath string, length int64, alg
 	return newWholeBitrotWriter(disk, volume, filePath, algo, shardSize)
 }
 
-func newBitrotReader(disk StorageAPI, bucket string, filePath string, tillOffset int64, algo BitrotAlgorithm, sum []byte, shardSize int64) io.ReaderAt {
+func newBitrotReader(disk StorageAPI, data []byte, bucket string, filePath string, tillOffset int64, algo BitrotAlgorithm, sum []byte, shardSize int64) io.ReaderAt {
 	if algo == HighwayHash256S {
-		return newStreamingBitrotReader(disk, bucket, filePath, tillOffset, algo, shardSize)
+		return newStreamingBitrotReader(disk, data, bucket, filePath, tillOffset, algo, shardSize)
 	}
 	return newWholeBitrotReader(disk, bucket, filePath, algo, tillOffset, sum)
 }
@@ -136,6 +137,47 @@ func bitrotWriterSum(w io.Writer) []byte {
 	return nil
 }
 
+// bitrotVerify verifies the streaming bitrot hashes of the shard read
+// from r, wantSize is the size of the shard including its hashes and
+// partSize the size of the shard data alone.
+func bitrotVerify(r io.Reader, wantSize, partSize int64, algo BitrotAlgorithm, shardSize int64) error {
+	// Calculate the size of the bitrot file and compare
+	// it with the actual file size.
+	if wantSize != bitrotShardFileSize(partSize, shardSize, algo) {
+		return errFileCorrupt
+	}
+
+	buf := make([]byte, shardSize)
+	h := algo.New()
+	hashBuf := make([]byte, h.Size())
+	size := wantSize
+	for {
+		if size == 0 {
+			return nil
+		}
+		h.Reset()
+		n, err := io.ReadFull(r, hashBuf)
+		if err != nil {
+			// Read's failed for object with right size, file is corrupt.
+			return err
+		}
+		size -= int64(n)
+		if size < int64(len(buf)) {
+			buf = buf[:size]
+		}
+		n, err = io.ReadFull(r, buf)
+		if err != nil {
+			// Read's failed for object with right size, at different offsets.
+			return err
+		}
+		size -= int64(n)
+		h.Write(buf)
+		if !bytes.Equal(h.Sum(nil), hashBuf) {
+			return errFileCorrupt
+		}
+	}
+}
+
 // Returns the size of the file with bitrot protection
 func bitrotShardFileSize(size int64, shardSize int64, algo BitrotAlgorithm) int64 {
 	if algo != HighwayHash256S {
@@ -0,0 +1,212 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/acl"
+	"github.com/minio/minio/pkg/bucket/policy"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+const (
+	// ACL configuration file.
+	bucketACLConfig = "acl.xml"
+
+	// Object ACLs are stored in the internal metadata of the object.
+	objectACLMetadataKey = ReservedMetadataPrefixLower + "acl"
+)
+
+// Permissions required in the bucket ACL for bucket actions.
+var bucketACLPermissions = map[policy.Action]acl.Permission{
+	policy.ListBucketAction:                 acl.PermissionRead,
+	policy.ListBucketVersionsAction:         acl.PermissionRead,
+	policy.ListBucketMultipartUploadsAction: acl.PermissionRead,
+	policy.PutObjectAction:                  acl.PermissionWrite,
+	policy.DeleteObjectAction:               acl.PermissionWrite,
+	policy.AbortMultipartUploadAction:       acl.PermissionWrite,
+	policy.GetBucketAclAction:               acl.PermissionReadACP,
+	policy.PutBucketAclAction:               acl.PermissionWriteACP,
+}
+
+// Permissions required in the object ACL for object actions.
+var objectACLPermissions = map[policy.Action]acl.Permission{
+	policy.GetObjectAction:           acl.PermissionRead,
+	policy.GetObjectAttributesAction: acl.PermissionRead,
+	policy.GetObjectAclAction:        acl.PermissionReadACP,
+	policy.PutObjectAclAction:        acl.PermissionWriteACP,
+}
+
+// aclOwner - owner of all buckets and objects.
+func aclOwner() acl.Owner {
+	return acl.Owner{ID: globalMinioDefaultOwnerID}
+}
+
+// getACLRequesterID - returns the canonical user id of the requester as
+// used in ACL grants. The root user is identified by the owner id, other
+// users by their access key and temporary or service account credentials
+// by the access key of their parent user. An empty id is returned for
+// anonymous requests.
+func getACLRequesterID(cred auth.Credentials, owner bool) string {
+	if cred.AccessKey == "" {
+		return ""
+	}
+	if owner || cred.ParentUser == globalActiveCred.AccessKey {
+		return globalMinioDefaultOwnerID
+	}
+	if cred.ParentUser != "" {
+		return cred.ParentUser
+	}
+	return cred.AccessKey
+}
+
+// getBucketACL - returns the ACL of the bucket, buckets without an ACL
+// or with ACLs disabled are private.
+func getBucketACL(bucket string) (*acl.AccessControlPolicy, error) {
+	if isACLDisabled(bucket) {
+		return acl.Canned(acl.CannedPrivate, aclOwner(), aclOwner())
+	}
+	config, err := globalBucketMetadataSys.GetACLConfig(bucket)
+	if err != nil {
+		var notFound BucketACLConfigNotFound
+		if errors.As(err, &notFound) {
+			return acl.Canned(acl.CannedPrivate, aclOwner(), aclOwner())
+		}
+		return nil, err
+	}
+	return config, nil
+}
+
+// getObjectACL - returns the ACL of the object, objects without an ACL
+// or in a bucket with ACLs disabled are private.
+func getObjectACL(objInfo ObjectInfo) (*acl.AccessControlPolicy, error) {
+	data, ok := objInfo.UserDefined[objectACLMetadataKey]
+	if !ok || isACLDisabled(objInfo.Bucket) {
+		return acl.Canned(acl.CannedPrivate, aclOwner(), aclOwner())
+	}
+	return acl.ParseAccessControlPolicy(strings.NewReader(data))
+}
+
+// updateBucketACL - stores the ACL of the bucket, private ACLs are not
+// stored.
+func updateBucketACL(bucket string, config *acl.AccessControlPolicy) error {
+	if config.IsPrivate() {
+		if globalIsGateway {
+			return nil
+		}
+		return globalBucketMetadataSys.Update(bucket, bucketACLConfig, nil)
+	}
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return globalBucketMetadataSys.Update(bucket, bucketACLConfig, configData)
+}
+
+// setObjectACLMetadata - stores the ACL in the object metadata, private
+// ACLs are not stored.
+func setObjectACLMetadata(metadata map[string]string, config *acl.AccessControlPolicy) error {
+	delete(metadata, objectACLMetadataKey)
+	if config == nil || config.IsPrivate() {
+		return nil
+	}
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	metadata[objectACLMetadataKey] = string(data)
+	return nil
+}
+
+// setObjectACLFromRequest - stores the ACL set by the x-amz-acl or
+// x-amz-grant-* request headers in the object metadata. Setting any ACL
+// but the default private ACL requires the s3:PutObjectAcl permission.
+func setObjectACLFromRequest(ctx context.Context, r *http.Request, bucket, object string, metadata map[string]string) error {
+	config, err := acl.ParseHeaders(r.Header, aclOwner(), aclOwner())
+	if err != nil {
+		return err
+	}
+	if err = checkACLSupported(bucket, config); err != nil {
+		return err
+	}
+	if config != nil && !config.IsPrivate() {
+		if s3Err := isPutActionAllowed(ctx, getRequestAuthType(r), bucket, object, r, iampolicy.PutObjectAclAction); s3Err != ErrNone {
+			return PrefixAccessDenied{Bucket: bucket, Object: object}
+		}
+	}
+	if isPublicACLBlocked(bucket, config) {
+		return PrefixAccessDenied{Bucket: bucket, Object: object}
+	}
+	return setObjectACLMetadata(metadata, config)
+}
+
+// checkACL - returns the auth error of a request matched by no bucket or
+// IAM policy, ACLs can not override an explicit deny. ErrNone is returned
+// if the bucket ACL grants the requester the permission required by the
+// action, ErrCheckObjectACL if the object ACL may grant it, the handler
+// checks it once the object is read, ErrAccessDenied otherwise.
+func checkACL(cred auth.Credentials, owner bool, action policy.Action, bucket, object string) APIErrorCode {
+	if globalIsGateway || bucket == "" {
+		return ErrAccessDenied
+	}
+
+	id := getACLRequesterID(cred, owner)
+	if permission, ok := bucketACLPermissions[action]; ok {
+		config, err := getBucketACL(bucket)
+		if err == nil && withoutIgnoredGrants(bucket, config).IsAllowed(id, permission) {
+			return ErrNone
+		}
+	}
+
+	if _, ok := objectACLPermissions[action]; ok && object != "" && !isACLDisabled(bucket) {
+		return ErrCheckObjectACL
+	}
+	return ErrAccessDenied
+}
+
+// isAllowedByObjectACL - returns true if the object ACL grants the
+// requester the permission required by the action, it is checked by
+// the handlers of requests for which the auth check returned
+// ErrCheckObjectACL.
+func isAllowedByObjectACL(r *http.Request, action policy.Action, objInfo ObjectInfo) bool {
+	permission, ok := objectACLPermissions[action]
+	if !ok {
+		return false
+	}
+	config, err := getObjectACL(objInfo)
+	if err != nil {
+		return false
+	}
+	cred := getReqAccessCred(r, globalServerRegion)
+	return withoutIgnoredGrants(objInfo.Bucket, config).IsAllowed(getACLRequesterID(cred, false), permission)
+}
+
+// withoutIgnoredGrants - returns the ACL without its grants to public
+// groups if the bucket ignores public ACLs.
+func withoutIgnoredGrants(bucket string, config *acl.AccessControlPolicy) *acl.AccessControlPolicy {
+	if !getPublicAccessBlock(bucket).IgnorePublicAcls {
+		return config
+	}
+	withoutPublic := config.WithoutPublicGrants()
+	return &withoutPublic
+}
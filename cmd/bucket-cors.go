@@ -0,0 +1,125 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/bucket/cors"
+)
+
+// bucketCorsHandler evaluates cross-origin requests against the CORS
+// configuration of the bucket being accessed. Requests on buckets
+// without a CORS configuration are served by the server wide handler
+// configured with `api cors_allow_origin`.
+type bucketCorsHandler struct {
+	handler       http.Handler
+	globalHandler http.Handler
+}
+
+// getBucketCorsConfigForRequest returns the CORS configuration of the
+// bucket addressed by the incoming request, nil if there is none.
+func getBucketCorsConfigForRequest(r *http.Request) *cors.Config {
+	bucket := getWebsiteBucket(r)
+	if bucket == "" {
+		bucket, _ = request2BucketObjectName(r)
+	}
+	if bucket == "" || isMinioMetaBucketName(bucket) || isMinioReservedBucket(bucket) {
+		return nil
+	}
+	if s3utils.CheckValidBucketName(bucket) != nil {
+		return nil
+	}
+	config, err := globalBucketMetadataSys.GetCorsConfig(bucket)
+	if err != nil {
+		return nil
+	}
+	return config
+}
+
+// setCorsAllowOrigin sets the allowed origin, S3 responds with a wildcard
+// without credentials when the rule allows all origins.
+func setCorsAllowOrigin(header http.Header, rule cors.Rule, origin string) {
+	if rule.AllowsAnyOrigin() {
+		header.Set(xhttp.AccessControlAllowOrigin, "*")
+		return
+	}
+	header.Set(xhttp.AccessControlAllowOrigin, origin)
+	header.Set(xhttp.AccessControlAllowCredentials, "true")
+}
+
+func (h bucketCorsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get(xhttp.Origin)
+	if origin == "" {
+		h.globalHandler.ServeHTTP(w, r)
+		return
+	}
+
+	config := getBucketCorsConfigForRequest(r)
+	if config == nil {
+		h.globalHandler.ServeHTTP(w, r)
+		return
+	}
+
+	header := w.Header()
+
+	// Preflight request, respond right away without calling the handlers.
+	if r.Method == http.MethodOptions && r.Header.Get(xhttp.AccessControlRequestMethod) != "" {
+		header.Add(xhttp.Vary, xhttp.Origin)
+		header.Add(xhttp.Vary, xhttp.AccessControlRequestMethod)
+		header.Add(xhttp.Vary, xhttp.AccessControlRequestHeaders)
+
+		var reqHeaders []string
+		if v := r.Header.Get(xhttp.AccessControlRequestHeaders); v != "" {
+			reqHeaders = strings.Split(v, ",")
+		}
+		rule, ok := config.Match(origin, r.Header.Get(xhttp.AccessControlRequestMethod), reqHeaders)
+		if !ok {
+			writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrCORSForbidden), r.URL, guessIsBrowserReq(r))
+			return
+		}
+
+		setCorsAllowOrigin(header, rule, origin)
+		header.Set(xhttp.AccessControlAllowMethods, strings.Join(rule.AllowedMethod, ", "))
+		if v := r.Header.Get(xhttp.AccessControlRequestHeaders); v != "" {
+			header.Set(xhttp.AccessControlAllowHeaders, v)
+		}
+		if len(rule.ExposeHeader) > 0 {
+			header.Set(xhttp.AccessControlExposeHeaders, strings.Join(rule.ExposeHeader, ", "))
+		}
+		if rule.MaxAgeSeconds > 0 {
+			header.Set(xhttp.AccessControlMaxAge, strconv.Itoa(rule.MaxAgeSeconds))
+		}
+		writeSuccessResponseHeadersOnly(w)
+		return
+	}
+
+	// Actual request, CORS headers are only set for allowed requests,
+	// the request itself is always served.
+	header.Add(xhttp.Vary, xhttp.Origin)
+	if rule, ok := config.Match(origin, r.Method, nil); ok {
+		setCorsAllowOrigin(header, rule, origin)
+		if len(rule.ExposeHeader) > 0 {
+			header.Set(xhttp.AccessControlExposeHeaders, strings.Join(rule.ExposeHeader, ", "))
+		}
+	}
+	h.handler.ServeHTTP(w, r)
+}
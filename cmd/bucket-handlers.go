@@ -38,6 +38,7 @@ import (
 	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/acl"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
 	"github.com/minio/minio/pkg/bucket/policy"
@@ -630,6 +631,28 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Parse the ACL set by the request headers, buckets are private by default.
+	bucketACL, err := acl.ParseHeaders(r.Header, aclOwner(), aclOwner())
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if bucketACL != nil && bucketACL.IsPrivate() {
+		bucketACL = nil
+	}
+	if bucketACL != nil {
+		if s3Error := isPutActionAllowed(ctx, getRequestAuthType(r), bucket, "", r, iampolicy.PutBucketAclAction); s3Error != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+	// New buckets have no public access block configuration yet,
+	// only the server wide settings apply.
+	if isPublicACLBlocked("", bucketACL) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// Parse incoming location constraint.
 	location, s3Error := parseLocationConstraint(r)
 	if s3Error != ErrNone {
@@ -667,6 +690,15 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 					return
 				}
 
+				if bucketACL != nil {
+					if err = updateBucketACL(bucket, bucketACL); err != nil {
+						globalDNSConfig.Delete(bucket)
+						objectAPI.DeleteBucket(ctx, bucket, false)
+						writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+						return
+					}
+				}
+
 				// Load updated bucket metadata into memory.
 				globalNotificationSys.LoadBucketMetadata(GlobalContext, bucket)
 
@@ -704,12 +736,20 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	// Proceed to creating a bucket.
-	err := objectAPI.MakeBucketWithLocation(ctx, bucket, opts)
+	err = objectAPI.MakeBucketWithLocation(ctx, bucket, opts)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
+	if bucketACL != nil {
+		if err = updateBucketACL(bucket, bucketACL); err != nil {
+			objectAPI.DeleteBucket(ctx, bucket, false)
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
 	// Load updated bucket metadata into memory.
 	globalNotificationSys.LoadBucketMetadata(GlobalContext, bucket)
 
@@ -880,6 +920,12 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	cred := getPostPolicyCred(formValues)
+	if err = enforceIAMQuotaFor(cred, fileSize); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	setObjectWriterMetadataFor(cred, formValues.Get("Acl"), bucket, metadata)
 
 	hashReader, err := hash.NewReader(fileBody, fileSize, "", "", fileSize, globalCLIContext.StrictS3Compat)
 	if err != nil {
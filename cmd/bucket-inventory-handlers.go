@@ -0,0 +1,312 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/inventory"
+	"github.com/minio/minio/pkg/bucket/policy"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+// Maximum number of inventory configurations returned in a list response.
+const maxInventoryConfigsList = 100
+
+// ListInventoryConfigurationsResult - response of the list inventory
+// configurations API.
+type ListInventoryConfigurationsResult struct {
+	XMLName                 xml.Name           `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListInventoryConfigurationsResult"`
+	InventoryConfigurations []inventory.Config `xml:"InventoryConfiguration"`
+	IsTruncated             bool               `xml:"IsTruncated"`
+	ContinuationToken       string             `xml:"ContinuationToken,omitempty"`
+	NextContinuationToken   string             `xml:"NextContinuationToken,omitempty"`
+}
+
+// getBucketInventoryConfigs - returns a copy of the inventory
+// configurations of the bucket.
+func getBucketInventoryConfigs(bucket string) (*inventory.Configs, error) {
+	config, err := globalBucketMetadataSys.GetInventoryConfig(bucket)
+	if err != nil {
+		if _, ok := err.(BucketInventoryConfigNotFound); ok {
+			return &inventory.Configs{}, nil
+		}
+		return nil, err
+	}
+	return &inventory.Configs{
+		Configs: append([]inventory.Config{}, config.Configs...),
+	}, nil
+}
+
+// updateBucketInventoryConfigs - stores the inventory configurations of
+// the bucket, the configuration file is removed once the last inventory
+// configuration is deleted.
+func updateBucketInventoryConfigs(bucket string, configs *inventory.Configs) error {
+	if len(configs.Configs) == 0 {
+		return globalBucketMetadataSys.Update(bucket, bucketInventoryConfig, nil)
+	}
+	configData, err := xml.Marshal(configs)
+	if err != nil {
+		return err
+	}
+	return globalBucketMetadataSys.Update(bucket, bucketInventoryConfig, configData)
+}
+
+// PutBucketInventoryConfigurationHandler - This HTTP handler adds or
+// replaces an inventory configuration of a bucket as per
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketInventoryConfiguration.html
+func (api objectAPIHandlers) PutBucketInventoryConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketInventoryConfiguration")
+
+	defer logger.AuditLog(w, r, "PutBucketInventoryConfiguration", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	id := vars["id"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutInventoryConfigurationAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	inventoryConfig, err := inventory.ParseConfig(io.LimitReader(r.Body, maxBucketInventoryConfigSize))
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if inventoryConfig.ID != id {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInventoryIDMismatch), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	dstBucket := inventoryConfig.DestinationBucket()
+	if isMinioReservedBucket(dstBucket) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidInventoryDestination), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if _, err = objAPI.GetBucketInfo(ctx, dstBucket); err != nil {
+		if _, ok := err.(BucketNotFound); ok {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidInventoryDestination), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Inventory reports are written on behalf of the requester, who
+	// must be allowed to write to the destination bucket.
+	if s3Error := isPutActionAllowed(ctx, getRequestAuthType(r), dstBucket,
+		inventoryConfig.Destination.S3BucketDestination.Prefix, r, iampolicy.PutObjectAction); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	configs, err := getBucketInventoryConfigs(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = configs.Set(*inventoryConfig); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = updateBucketInventoryConfigs(bucket, configs); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Success.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketInventoryConfigurationHandler - This HTTP handler returns an
+// inventory configuration of a bucket.
+func (api objectAPIHandlers) GetBucketInventoryConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketInventoryConfiguration")
+
+	defer logger.AuditLog(w, r, "GetBucketInventoryConfiguration", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetInventoryConfigurationAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	var err error
+	if _, err = objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	configs, err := getBucketInventoryConfigs(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	config, ok := configs.Get(vars["id"])
+	if !ok {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchConfiguration), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	config.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Write bucket inventory configuration to client
+	writeSuccessResponseXML(w, configData)
+}
+
+// ListBucketInventoryConfigurationsHandler - This HTTP handler returns
+// the inventory configurations of a bucket, sorted by id and at most
+// 100 per response.
+func (api objectAPIHandlers) ListBucketInventoryConfigurationsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListBucketInventoryConfigurations")
+
+	defer logger.AuditLog(w, r, "ListBucketInventoryConfigurations", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetInventoryConfigurationAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	var err error
+	if _, err = objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	configs, err := getBucketInventoryConfigs(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// The continuation token is the id of the first configuration
+	// of the next page.
+	token := r.URL.Query().Get("continuation-token")
+	response := ListInventoryConfigurationsResult{
+		ContinuationToken: token,
+	}
+	for _, config := range configs.Configs {
+		if config.ID < token {
+			continue
+		}
+		if len(response.InventoryConfigurations) == maxInventoryConfigsList {
+			response.IsTruncated = true
+			response.NextContinuationToken = config.ID
+			break
+		}
+		response.InventoryConfigurations = append(response.InventoryConfigurations, config)
+	}
+
+	// Write bucket inventory configurations to client
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
+
+// DeleteBucketInventoryConfigurationHandler - This HTTP handler removes
+// an inventory configuration of a bucket.
+func (api objectAPIHandlers) DeleteBucketInventoryConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteBucketInventoryConfiguration")
+
+	defer logger.AuditLog(w, r, "DeleteBucketInventoryConfiguration", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	id := vars["id"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutInventoryConfigurationAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	var err error
+	if _, err = objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	configs, err := getBucketInventoryConfigs(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if !configs.Delete(id) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchConfiguration), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = updateBucketInventoryConfigs(bucket, configs); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	deleteBucketInventoryState(ctx, objAPI, bucket, id)
+
+	// Success.
+	writeSuccessNoContent(w)
+}
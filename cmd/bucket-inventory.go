@@ -0,0 +1,418 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	gohash "hash"
+	"io"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/cmd/config/storageclass"
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/inventory"
+	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
+	"github.com/minio/minio/pkg/hash"
+)
+
+const (
+	// Inventory configuration file.
+	bucketInventoryConfig = "inventory.xml"
+
+	// Maximum number of records of an inventory file, larger
+	// inventory reports are split into several files.
+	inventoryMaxRecordsPerFile = 1000000
+)
+
+// Set while inventory reports are being generated.
+var bucketInventoryRunning int32
+
+// bucketInventoryState - tracks when the last inventory report of an
+// inventory configuration was generated.
+type bucketInventoryState struct {
+	LastReport time.Time `json:"lastReport"`
+}
+
+func bucketInventoryStateObject(bucket, id string) string {
+	return pathJoin(bucket, ".inventory", id+".json")
+}
+
+func loadBucketInventoryState(ctx context.Context, objAPI ObjectLayer, bucket, id string) (state bucketInventoryState, err error) {
+	var buf bytes.Buffer
+	err = objAPI.GetObject(ctx, dataUsageBucket, bucketInventoryStateObject(bucket, id), 0, -1, &buf, "", ObjectOptions{})
+	if err != nil {
+		if isErrObjectNotFound(err) {
+			return state, nil
+		}
+		return state, err
+	}
+	err = json.Unmarshal(buf.Bytes(), &state)
+	return state, err
+}
+
+func saveBucketInventoryState(ctx context.Context, objAPI ObjectLayer, bucket, id string, state bucketInventoryState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	r, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", "", int64(len(data)), false)
+	if err != nil {
+		return err
+	}
+	_, err = objAPI.PutObject(ctx, dataUsageBucket, bucketInventoryStateObject(bucket, id), NewPutObjReader(r, nil, nil), ObjectOptions{})
+	return err
+}
+
+// startBucketInventory - generates the inventory reports which are due
+// in the background. It is called by the data crawler after every
+// crawl cycle, reports of a previous cycle which are still being
+// generated are not interrupted.
+func startBucketInventory(ctx context.Context, objAPI ObjectLayer) {
+	if !atomic.CompareAndSwapInt32(&bucketInventoryRunning, 0, 1) {
+		return
+	}
+	go func() {
+		defer atomic.StoreInt32(&bucketInventoryRunning, 0)
+		runBucketInventory(ctx, objAPI)
+	}()
+}
+
+func runBucketInventory(ctx context.Context, objAPI ObjectLayer) {
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	for _, bucket := range buckets {
+		configs, err := globalBucketMetadataSys.GetInventoryConfig(bucket.Name)
+		if err != nil {
+			continue
+		}
+
+		// Only the configurations whose report is due on their
+		// schedule are generated, all of them from a single listing
+		// of the bucket.
+		now := UTCNow()
+		var due []inventory.Config
+		var states []bucketInventoryState
+		for _, config := range configs.Configs {
+			if !config.IsEnabled {
+				continue
+			}
+			state, err := loadBucketInventoryState(ctx, objAPI, bucket.Name, config.ID)
+			if err != nil {
+				logger.LogIf(ctx, err)
+				continue
+			}
+			if now.Sub(state.LastReport) < config.Schedule.Frequency.Interval() {
+				continue
+			}
+			due = append(due, config)
+			states = append(states, state)
+		}
+		if len(due) == 0 {
+			continue
+		}
+
+		for i, err := range generateInventoryReports(ctx, objAPI, bucket.Name, due, now) {
+			if err != nil {
+				logger.LogIf(ctx, err)
+				continue
+			}
+			states[i].LastReport = now
+			logger.LogIf(ctx, saveBucketInventoryState(ctx, objAPI, bucket.Name, due[i].ID, states[i]))
+		}
+	}
+}
+
+// inventoryFile - an inventory file which is streamed into the
+// destination bucket while records are written.
+type inventoryFile struct {
+	name    string
+	pw      *io.PipeWriter
+	md5     gohash.Hash
+	size    int64
+	records int
+	writer  inventory.Writer
+	putErr  chan error
+}
+
+func (f *inventoryFile) Write(p []byte) (int, error) {
+	n, err := f.pw.Write(p)
+	f.md5.Write(p[:n])
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *inventoryFile) Close() error {
+	return f.pw.Close()
+}
+
+func newInventoryFile(ctx context.Context, objAPI ObjectLayer, bucket string, config inventory.Config) (*inventoryFile, error) {
+	dstBucket := config.DestinationBucket()
+	pr, pw := io.Pipe()
+	f := &inventoryFile{
+		name:   inventory.DataObjectName(config, bucket, mustGetUUID()),
+		pw:     pw,
+		md5:    md5.New(),
+		putErr: make(chan error, 1),
+	}
+
+	writer, err := inventory.NewWriter(f, config)
+	if err != nil {
+		return nil, err
+	}
+	f.writer = writer
+
+	go func() {
+		defer pr.Close()
+		r, err := hash.NewReader(pr, -1, "", "", -1, false)
+		if err != nil {
+			pr.CloseWithError(err)
+			f.putErr <- err
+			return
+		}
+		_, err = objAPI.PutObject(ctx, dstBucket, f.name, NewPutObjReader(r, nil, nil), ObjectOptions{
+			Versioned: globalBucketVersioningSys.Enabled(dstBucket),
+		})
+		pr.CloseWithError(err)
+		f.putErr <- err
+	}()
+	return f, nil
+}
+
+// finish - completes the inventory file and returns its manifest entry.
+func (f *inventoryFile) finish() (inventory.ManifestFile, error) {
+	if err := f.writer.Close(); err != nil {
+		f.pw.CloseWithError(err)
+		<-f.putErr
+		return inventory.ManifestFile{}, err
+	}
+	if err := <-f.putErr; err != nil {
+		return inventory.ManifestFile{}, err
+	}
+	return inventory.ManifestFile{
+		Key:         f.name,
+		Size:        f.size,
+		MD5Checksum: hex.EncodeToString(f.md5.Sum(nil)),
+	}, nil
+}
+
+// abort - discards the inventory file.
+func (f *inventoryFile) abort(err error) {
+	f.pw.CloseWithError(err)
+	<-f.putErr
+}
+
+// inventoryRecord - returns the inventory record of an object version.
+func inventoryRecord(bucket string, obj ObjectInfo) inventory.Record {
+	record := inventory.Record{
+		Bucket:              bucket,
+		Key:                 obj.Name,
+		VersionID:           obj.VersionID,
+		IsLatest:            obj.IsLatest,
+		IsDeleteMarker:      obj.DeleteMarker,
+		Size:                obj.Size,
+		LastModifiedDate:    obj.ModTime,
+		ETag:                obj.ETag,
+		StorageClass:        obj.StorageClass,
+		IsMultipartUploaded: strings.Contains(obj.ETag, "-"),
+		ReplicationStatus:   string(obj.ReplicationStatus),
+		EncryptionStatus:    "NOT-SSE",
+	}
+	if size, err := obj.GetActualSize(); err == nil {
+		record.Size = size
+	}
+	if record.StorageClass == "" {
+		record.StorageClass = storageclass.STANDARD
+	}
+	switch {
+	case crypto.SSEC.IsEncrypted(obj.UserDefined):
+		record.EncryptionStatus = "SSE-C"
+	case crypto.S3KMS.IsEncrypted(obj.UserDefined):
+		record.EncryptionStatus = "SSE-KMS"
+	case crypto.S3.IsEncrypted(obj.UserDefined):
+		record.EncryptionStatus = "SSE-S3"
+	}
+	retention := objectlock.GetObjectRetentionMeta(obj.UserDefined)
+	record.ObjectLockMode = string(retention.Mode)
+	record.ObjectLockRetainUntilDate = retention.RetainUntilDate.Time
+	record.ObjectLockLegalHoldStatus = string(objectlock.GetObjectLegalHoldMeta(obj.UserDefined).Status)
+	return record
+}
+
+// inventoryReport - an inventory report being generated for an
+// inventory configuration.
+type inventoryReport struct {
+	config   inventory.Config
+	manifest inventory.Manifest
+	file     *inventoryFile
+	err      error
+}
+
+// add - writes the record of an object version to the report, a new
+// inventory file is started when the current one is full.
+func (report *inventoryReport) add(ctx context.Context, objAPI ObjectLayer, bucket string, obj ObjectInfo) {
+	if report.err != nil {
+		return
+	}
+	if report.file == nil {
+		if report.file, report.err = newInventoryFile(ctx, objAPI, bucket, report.config); report.err != nil {
+			return
+		}
+	}
+	if report.err = report.file.writer.Write(inventoryRecord(bucket, obj)); report.err != nil {
+		report.file.abort(report.err)
+		report.file = nil
+		return
+	}
+	report.file.records++
+	if report.file.records < inventoryMaxRecordsPerFile {
+		return
+	}
+	report.err = report.finishFile()
+}
+
+// finishFile - completes the current inventory file of the report.
+func (report *inventoryReport) finishFile() error {
+	manifestFile, err := report.file.finish()
+	report.file = nil
+	if err != nil {
+		return err
+	}
+	report.manifest.Files = append(report.manifest.Files, manifestFile)
+	return nil
+}
+
+// publish - completes the report and publishes its manifest.
+func (report *inventoryReport) publish(ctx context.Context, objAPI ObjectLayer, bucket string, created time.Time) error {
+	if report.err != nil {
+		if report.file != nil {
+			report.file.abort(report.err)
+		}
+		return report.err
+	}
+	if report.file != nil {
+		if err := report.finishFile(); err != nil {
+			return err
+		}
+	}
+
+	manifestData, err := json.Marshal(report.manifest)
+	if err != nil {
+		return err
+	}
+	dstBucket := report.config.DestinationBucket()
+	// The checksum is published after the manifest, once the manifest
+	// exists the report is complete.
+	for _, object := range []struct {
+		name string
+		data []byte
+	}{
+		{inventory.ManifestName, manifestData},
+		{inventory.ManifestChecksumName, []byte(getMD5Hash(manifestData))},
+	} {
+		r, err := hash.NewReader(bytes.NewReader(object.data), int64(len(object.data)), getMD5Hash(object.data), getSHA256Hash(object.data), int64(len(object.data)), false)
+		if err != nil {
+			return err
+		}
+		_, err = objAPI.PutObject(ctx, dstBucket, inventory.ManifestObjectName(report.config, bucket, created, object.name), NewPutObjReader(r, nil, nil), ObjectOptions{
+			Versioned: globalBucketVersioningSys.Enabled(dstBucket),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateInventoryReports - lists the objects of the bucket once and
+// writes the objects matching each of the inventory configurations
+// into inventory files in its destination bucket, then publishes the
+// manifest of each report. The returned errors are in the order of
+// the configurations.
+func generateInventoryReports(ctx context.Context, objAPI ObjectLayer, bucket string, configs []inventory.Config, created time.Time) []error {
+	errs := make([]error, len(configs))
+	var reports []*inventoryReport
+	var reportIdx []int
+	for i, config := range configs {
+		if _, err := objAPI.GetBucketInfo(ctx, config.DestinationBucket()); err != nil {
+			errs[i] = err
+			continue
+		}
+		reports = append(reports, &inventoryReport{
+			config:   config,
+			manifest: inventory.NewManifest(config, bucket, created),
+		})
+		reportIdx = append(reportIdx, i)
+	}
+	if len(reports) == 0 {
+		return errs
+	}
+
+	// The listing covers the common prefix of all the reports and
+	// includes all versions if any of the reports needs them.
+	prefix := reports[0].config.Prefix()
+	var walkVersions bool
+	for _, report := range reports {
+		for !strings.HasPrefix(report.config.Prefix(), prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+		walkVersions = walkVersions || report.config.AllVersions()
+	}
+
+	objInfoCh := make(chan ObjectInfo)
+	if err := objAPI.Walk(ctx, bucket, prefix, objInfoCh, ObjectOptions{WalkVersions: walkVersions}); err != nil {
+		for _, i := range reportIdx {
+			errs[i] = err
+		}
+		return errs
+	}
+	for obj := range objInfoCh {
+		for _, report := range reports {
+			if !strings.HasPrefix(obj.Name, report.config.Prefix()) {
+				continue
+			}
+			if walkVersions && !report.config.AllVersions() && (!obj.IsLatest || obj.DeleteMarker) {
+				continue
+			}
+			report.add(ctx, objAPI, bucket, obj)
+		}
+	}
+
+	for i, report := range reports {
+		errs[reportIdx[i]] = report.publish(ctx, objAPI, bucket, created)
+	}
+	return errs
+}
+
+// deleteBucketInventoryState - removes the state of an inventory
+// configuration which is deleted.
+func deleteBucketInventoryState(ctx context.Context, objAPI ObjectLayer, bucket, id string) {
+	_, err := objAPI.DeleteObject(ctx, dataUsageBucket, bucketInventoryStateObject(bucket, id), ObjectOptions{})
+	if err != nil && !isErrObjectNotFound(err) {
+		logger.LogIf(ctx, err)
+	}
+}
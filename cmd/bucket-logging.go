@@ -0,0 +1,308 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/gorilla/mux"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/cmd/logger/message/audit"
+	"github.com/minio/minio/pkg/bucket/logging"
+	"github.com/minio/minio/pkg/hash"
+)
+
+const (
+	// Interval at which buffered access logs are delivered.
+	bucketLoggingFlushInterval = 5 * time.Minute
+
+	// Access logs are delivered right away once the buffered
+	// records of a target reach this size.
+	bucketLoggingMaxBufferSize = 4 * humanize.MiByte
+)
+
+// Operation names of sub-resources, as used in S3 server access logs.
+var bucketLoggingSubResources = map[string]string{
+	"acl":          "ACL",
+	"cors":         "CORS",
+	"delete":       "MULTI_OBJECT_DELETE",
+	"encryption":   "ENCRYPTION",
+	"legal-hold":   "LEGAL_HOLD",
+	"lifecycle":    "LIFECYCLE",
+	"location":     "LOCATION",
+	"logging":      "LOGGING_STATUS",
+	"notification": "NOTIFICATION",
+	"object-lock":  "OBJECT_LOCK_CONFIGURATION",
+	"policy":       "BUCKETPOLICY",
+	"replication":  "REPLICATION",
+	"retention":    "RETENTION",
+	"select":       "SELECT",
+	"tagging":      "TAGGING",
+	"uploadId":     "UPLOAD",
+	"uploads":      "UPLOADS",
+	"versioning":   "VERSIONING",
+	"versions":     "BUCKETVERSIONS",
+	"website":      "WEBSITE",
+}
+
+// bucketAccessLogTarget - identifies where access logs are delivered.
+type bucketAccessLogTarget struct {
+	bucket string
+	prefix string
+}
+
+// BucketLoggingSys - batches server access log records of buckets with
+// access logging enabled and delivers them as log objects into the
+// configured target bucket.
+type BucketLoggingSys struct {
+	sync.Mutex
+	buffers map[bucketAccessLogTarget]*bytes.Buffer
+}
+
+// NewBucketLoggingSys - creates new bucket logging system.
+func NewBucketLoggingSys() *BucketLoggingSys {
+	return &BucketLoggingSys{
+		buffers: make(map[bucketAccessLogTarget]*bytes.Buffer),
+	}
+}
+
+// Init - starts delivering buffered access logs periodically.
+func (sys *BucketLoggingSys) Init(ctx context.Context, objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	// In gateway mode, bucket logging is not supported.
+	if globalIsGateway {
+		return nil
+	}
+
+	go func() {
+		ticker := time.NewTicker(bucketLoggingFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				sys.flush(ctx, objAPI)
+			}
+		}
+	}()
+	return nil
+}
+
+// Enabled - returns the logging status of the bucket if access logging
+// is enabled for the bucket, nil otherwise.
+func (sys *BucketLoggingSys) Enabled(bucket string) *logging.Config {
+	if sys == nil || globalIsGateway || bucket == "" {
+		return nil
+	}
+	config, err := globalBucketMetadataSys.GetLoggingConfig(bucket)
+	if err != nil || !config.Enabled() {
+		return nil
+	}
+	return config
+}
+
+// Log - records the access log of a request served by an S3 API handler,
+// the record is built from the same request data as the audit log entry.
+func (sys *BucketLoggingSys) Log(config *logging.Config, r *http.Request, w *logger.ResponseWriter) {
+	if config == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	object, err := url.PathUnescape(vars["object"])
+	if err != nil {
+		object = vars["object"]
+	}
+
+	auditEntry := audit.ToEntry(w, r, nil, globalDeploymentID)
+	entry := logging.Entry{
+		BucketOwner:      globalMinioDefaultOwnerID,
+		Bucket:           vars["bucket"],
+		Time:             w.StartTime,
+		RemoteIP:         auditEntry.RemoteHost,
+		Requester:        getReqAccessCred(r, globalServerRegion).AccessKey,
+		RequestID:        auditEntry.RequestID,
+		Operation:        bucketLoggingOperation(r, object),
+		Key:              object,
+		RequestURI:       r.Method + " " + r.URL.RequestURI() + " " + r.Proto,
+		HTTPStatus:       w.StatusCode,
+		ErrorCode:        bucketLoggingErrorCode(w),
+		TotalTime:        time.Since(w.StartTime),
+		TurnAroundTime:   w.TimeToFirstByte,
+		Referer:          r.Referer(),
+		UserAgent:        auditEntry.UserAgent,
+		VersionID:        auditEntry.RespHeader[xhttp.AmzVersionID],
+		SignatureVersion: bucketLoggingSignatureVersion(r),
+		AuthType:         bucketLoggingAuthType(r),
+		HostHeader:       r.Host,
+	}
+	entry.BytesSent, _ = strconv.ParseInt(auditEntry.RespHeader[xhttp.ContentLength], 10, 64)
+	if object != "" {
+		switch r.Method {
+		case http.MethodPut, http.MethodPost:
+			entry.ObjectSize = r.ContentLength
+			if size, err := strconv.ParseInt(r.Header.Get(xhttp.AmzDecodedContentLength), 10, 64); err == nil {
+				entry.ObjectSize = size
+			}
+		default:
+			entry.ObjectSize = entry.BytesSent
+		}
+	}
+	if r.TLS != nil {
+		entry.CipherSuite = tls.CipherSuiteName(r.TLS.CipherSuite)
+		entry.TLSVersion = bucketLoggingTLSVersion(r.TLS.Version)
+	}
+
+	target := bucketAccessLogTarget{
+		bucket: config.LoggingEnabled.TargetBucket,
+		prefix: config.LoggingEnabled.TargetPrefix,
+	}
+
+	sys.Lock()
+	buf, ok := sys.buffers[target]
+	if !ok {
+		buf = &bytes.Buffer{}
+		sys.buffers[target] = buf
+	}
+	buf.WriteString(entry.String())
+	var data []byte
+	if buf.Len() >= bucketLoggingMaxBufferSize {
+		data = buf.Bytes()
+		delete(sys.buffers, target)
+	}
+	sys.Unlock()
+
+	if data != nil {
+		go sys.deliver(GlobalContext, newObjectLayerFn(), target, data)
+	}
+}
+
+// flush - delivers all buffered access logs.
+func (sys *BucketLoggingSys) flush(ctx context.Context, objAPI ObjectLayer) {
+	sys.Lock()
+	buffers := sys.buffers
+	sys.buffers = make(map[bucketAccessLogTarget]*bytes.Buffer)
+	sys.Unlock()
+
+	for target, buf := range buffers {
+		sys.deliver(ctx, objAPI, target, buf.Bytes())
+	}
+}
+
+// deliver - writes the access log records as a new object into the
+// target bucket, records are dropped if the target is unavailable.
+func (sys *BucketLoggingSys) deliver(ctx context.Context, objAPI ObjectLayer, target bucketAccessLogTarget, data []byte) {
+	if objAPI == nil || len(data) == 0 {
+		return
+	}
+
+	uniqueID := strings.ToUpper(strings.Replace(mustGetUUID(), "-", "", -1))[:16]
+	object := logging.ObjectName(target.prefix, UTCNow(), uniqueID)
+
+	hashReader, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), getMD5Hash(data), getSHA256Hash(data), int64(len(data)), globalCLIContext.StrictS3Compat)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	opts := ObjectOptions{
+		UserDefined: map[string]string{
+			xhttp.ContentType: "text/plain",
+		},
+		Versioned: globalBucketVersioningSys.Enabled(target.bucket),
+	}
+	if _, err = objAPI.PutObject(ctx, target.bucket, object, NewPutObjReader(hashReader, nil, nil), opts); err != nil {
+		logger.LogIf(ctx, err)
+	}
+}
+
+// bucketLoggingOperation - returns the S3 operation name of the request,
+// such as REST.GET.OBJECT.
+func bucketLoggingOperation(r *http.Request, object string) string {
+	resource := "BUCKET"
+	if object != "" {
+		resource = "OBJECT"
+	}
+	for name := range r.URL.Query() {
+		if subResource, ok := bucketLoggingSubResources[name]; ok {
+			resource = subResource
+			break
+		}
+	}
+	return "REST." + r.Method + "." + resource
+}
+
+// bucketLoggingErrorCode - returns the S3 error code of failed requests.
+func bucketLoggingErrorCode(w *logger.ResponseWriter) string {
+	if w.StatusCode < http.StatusBadRequest {
+		return ""
+	}
+	var errResp APIErrorResponse
+	if err := xml.Unmarshal(w.Body(), &errResp); err != nil {
+		return ""
+	}
+	return errResp.Code
+}
+
+func bucketLoggingSignatureVersion(r *http.Request) string {
+	switch getRequestAuthType(r) {
+	case authTypeSigned, authTypePresigned, authTypeStreamingSigned, authTypePostPolicy:
+		return "SigV4"
+	case authTypeSignedV2, authTypePresignedV2:
+		return "SigV2"
+	}
+	return ""
+}
+
+func bucketLoggingAuthType(r *http.Request) string {
+	switch getRequestAuthType(r) {
+	case authTypeSigned, authTypeSignedV2, authTypeStreamingSigned:
+		return "AuthHeader"
+	case authTypePresigned, authTypePresignedV2:
+		return "QueryString"
+	}
+	return ""
+}
+
+func bucketLoggingTLSVersion(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "TLSv1"
+	case tls.VersionTLS11:
+		return "TLSv1.1"
+	case tls.VersionTLS12:
+		return "TLSv1.2"
+	case tls.VersionTLS13:
+		return "TLSv1.3"
+	}
+	return ""
+}
@@ -26,12 +26,19 @@ import (
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/acl"
+	"github.com/minio/minio/pkg/bucket/cors"
 	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
+	"github.com/minio/minio/pkg/bucket/inventory"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/logging"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
+	"github.com/minio/minio/pkg/bucket/ownership"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/publicaccess"
 	"github.com/minio/minio/pkg/bucket/replication"
 	"github.com/minio/minio/pkg/bucket/versioning"
+	"github.com/minio/minio/pkg/bucket/website"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/madmin"
 	"github.com/minio/minio/pkg/sync/errgroup"
@@ -173,6 +180,22 @@ func (sys *BucketMetadataSys) Update(bucket string, configFile string, configDat
 		if err != nil {
 			return fmt.Errorf("Error encrypting bucket target metadata %w", err)
 		}
+	case bucketCorsConfig:
+		meta.CorsConfigXML = configData
+	case bucketWebsiteConfig:
+		meta.WebsiteConfigXML = configData
+	case bucketLoggingConfig:
+		meta.LoggingConfigXML = configData
+	case bucketACLConfig:
+		meta.ACLConfigXML = configData
+	case bucketInventoryConfig:
+		meta.InventoryConfigXML = configData
+	case bucketPublicAccessBlockConfig:
+		meta.PublicAccessBlockConfigXML = configData
+	case bucketOwnershipControlsConfig:
+		meta.OwnershipControlsConfigXML = configData
+	case bucketStorageClassConfigFile:
+		meta.StorageClassConfigJSON = configData
 	default:
 		return fmt.Errorf("Unknown bucket %s metadata update requested %s", bucket, configFile)
 	}
@@ -361,6 +384,134 @@ func (sys *BucketMetadataSys) GetReplicationConfig(ctx context.Context, bucket s
 	return meta.replicationConfig, nil
 }
 
+// GetCorsConfig returns configured bucket CORS config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetCorsConfig(bucket string) (*cors.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketCorsConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.corsConfig == nil {
+		return nil, BucketCorsConfigNotFound{Bucket: bucket}
+	}
+	return meta.corsConfig, nil
+}
+
+// GetWebsiteConfig returns configured bucket website config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetWebsiteConfig(bucket string) (*website.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketWebsiteConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.websiteConfig == nil {
+		return nil, BucketWebsiteConfigNotFound{Bucket: bucket}
+	}
+	return meta.websiteConfig, nil
+}
+
+// GetLoggingConfig returns configured bucket logging config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetLoggingConfig(bucket string) (*logging.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketLoggingConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.loggingConfig == nil {
+		return nil, BucketLoggingConfigNotFound{Bucket: bucket}
+	}
+	return meta.loggingConfig, nil
+}
+
+// GetACLConfig returns configured bucket ACL config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetACLConfig(bucket string) (*acl.AccessControlPolicy, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketACLConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.aclConfig == nil {
+		return nil, BucketACLConfigNotFound{Bucket: bucket}
+	}
+	return meta.aclConfig, nil
+}
+
+// GetInventoryConfig returns configured bucket inventory config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetInventoryConfig(bucket string) (*inventory.Configs, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketInventoryConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.inventoryConfig == nil {
+		return nil, BucketInventoryConfigNotFound{Bucket: bucket}
+	}
+	return meta.inventoryConfig, nil
+}
+
+// GetPublicAccessBlockConfig returns configured bucket public access block config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetPublicAccessBlockConfig(bucket string) (*publicaccess.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketPublicAccessBlockConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.publicAccessBlockConfig == nil {
+		return nil, BucketPublicAccessBlockConfigNotFound{Bucket: bucket}
+	}
+	return meta.publicAccessBlockConfig, nil
+}
+
+// GetOwnershipControlsConfig returns configured bucket ownership controls config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetOwnershipControlsConfig(bucket string) (*ownership.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketOwnershipControlsConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.ownershipControlsConfig == nil {
+		return nil, BucketOwnershipControlsConfigNotFound{Bucket: bucket}
+	}
+	return meta.ownershipControlsConfig, nil
+}
+
+// GetStorageClassConfig returns configured bucket storage class config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetStorageClassConfig(bucket string) (*madmin.BucketStorageClass, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketStorageClassConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.storageClassConfig == nil {
+		return nil, BucketStorageClassConfigNotFound{Bucket: bucket}
+	}
+	return meta.storageClassConfig, nil
+}
+
 // GetBucketTargetsConfig returns configured bucket targets for this bucket
 // The returned object may not be modified.
 func (sys *BucketMetadataSys) GetBucketTargetsConfig(bucket string) (*madmin.BucketTargets, error) {
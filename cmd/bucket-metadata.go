@@ -31,12 +31,19 @@ import (
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/acl"
+	"github.com/minio/minio/pkg/bucket/cors"
 	bucketsse "github.com/minio/minio/pkg/bucket/encryption"
+	"github.com/minio/minio/pkg/bucket/inventory"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/logging"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
+	"github.com/minio/minio/pkg/bucket/ownership"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/publicaccess"
 	"github.com/minio/minio/pkg/bucket/replication"
 	"github.com/minio/minio/pkg/bucket/versioning"
+	"github.com/minio/minio/pkg/bucket/website"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/madmin"
 	"github.com/minio/sio"
@@ -78,19 +85,35 @@ type BucketMetadata struct {
 	ReplicationConfigXML        []byte
 	BucketTargetsConfigJSON     []byte
 	BucketTargetsConfigMetaJSON []byte
+	CorsConfigXML               []byte
+	WebsiteConfigXML            []byte
+	LoggingConfigXML            []byte
+	ACLConfigXML                []byte
+	InventoryConfigXML          []byte
+	PublicAccessBlockConfigXML  []byte
+	OwnershipControlsConfigXML  []byte
+	StorageClassConfigJSON      []byte
 
 	// Unexported fields. Must be updated atomically.
-	policyConfig           *policy.Policy
-	notificationConfig     *event.Config
-	lifecycleConfig        *lifecycle.Lifecycle
-	objectLockConfig       *objectlock.Config
-	versioningConfig       *versioning.Versioning
-	sseConfig              *bucketsse.BucketSSEConfig
-	taggingConfig          *tags.Tags
-	quotaConfig            *madmin.BucketQuota
-	replicationConfig      *replication.Config
-	bucketTargetConfig     *madmin.BucketTargets
-	bucketTargetConfigMeta map[string]string
+	policyConfig            *policy.Policy
+	notificationConfig      *event.Config
+	lifecycleConfig         *lifecycle.Lifecycle
+	objectLockConfig        *objectlock.Config
+	versioningConfig        *versioning.Versioning
+	sseConfig               *bucketsse.BucketSSEConfig
+	taggingConfig           *tags.Tags
+	quotaConfig             *madmin.BucketQuota
+	replicationConfig       *replication.Config
+	bucketTargetConfig      *madmin.BucketTargets
+	bucketTargetConfigMeta  map[string]string
+	corsConfig              *cors.Config
+	websiteConfig           *website.Config
+	loggingConfig           *logging.Config
+	aclConfig               *acl.AccessControlPolicy
+	inventoryConfig         *inventory.Configs
+	publicAccessBlockConfig *publicaccess.Config
+	ownershipControlsConfig *ownership.Config
+	storageClassConfig      *madmin.BucketStorageClass
 }
 
 // newBucketMetadata creates BucketMetadata with the supplied name and Created to Now.
@@ -247,6 +270,78 @@ func (b *BucketMetadata) parseAllConfigs(ctx context.Context, objectAPI ObjectLa
 	} else {
 		b.bucketTargetConfig = &madmin.BucketTargets{}
 	}
+
+	if len(b.CorsConfigXML) != 0 {
+		b.corsConfig, err = cors.ParseConfig(bytes.NewReader(b.CorsConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.corsConfig = nil
+	}
+
+	if len(b.WebsiteConfigXML) != 0 {
+		b.websiteConfig, err = website.ParseConfig(bytes.NewReader(b.WebsiteConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.websiteConfig = nil
+	}
+
+	if len(b.LoggingConfigXML) != 0 {
+		b.loggingConfig, err = logging.ParseConfig(bytes.NewReader(b.LoggingConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.loggingConfig = nil
+	}
+
+	if len(b.ACLConfigXML) != 0 {
+		b.aclConfig, err = acl.ParseAccessControlPolicy(bytes.NewReader(b.ACLConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.aclConfig = nil
+	}
+
+	if len(b.InventoryConfigXML) != 0 {
+		b.inventoryConfig, err = inventory.ParseConfigs(bytes.NewReader(b.InventoryConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.inventoryConfig = nil
+	}
+
+	if len(b.PublicAccessBlockConfigXML) != 0 {
+		b.publicAccessBlockConfig, err = publicaccess.ParseConfig(bytes.NewReader(b.PublicAccessBlockConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.publicAccessBlockConfig = nil
+	}
+
+	if len(b.OwnershipControlsConfigXML) != 0 {
+		b.ownershipControlsConfig, err = ownership.ParseConfig(bytes.NewReader(b.OwnershipControlsConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.ownershipControlsConfig = nil
+	}
+
+	if len(b.StorageClassConfigJSON) != 0 {
+		b.storageClassConfig, err = parseBucketStorageClass(b.Name, b.StorageClassConfigJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.storageClassConfig = nil
+	}
 	return nil
 }
 
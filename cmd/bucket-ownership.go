@@ -0,0 +1,122 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/acl"
+	"github.com/minio/minio/pkg/bucket/ownership"
+)
+
+const (
+	// Ownership controls configuration file.
+	bucketOwnershipControlsConfig = "ownership-controls.xml"
+
+	// The identity which wrote an object is stored in the internal
+	// metadata of the object, it owns the object unless the bucket owner
+	// does and its usage counts towards its IAM quotas. Objects without a
+	// writer were written by the root user.
+	objectWriterMetadataKey = ReservedMetadataPrefixLower + "writer"
+
+	// The service account which wrote an object, the object is written
+	// on behalf of the parent user of the service account.
+	objectServiceAccountMetadataKey = ReservedMetadataPrefixLower + "service-account"
+
+	// Set for objects owned by the bucket owner because of the ownership
+	// controls of the bucket at the time the object was written.
+	objectBucketOwnedMetadataKey = ReservedMetadataPrefixLower + "bucket-owned"
+)
+
+// getObjectOwnership - returns the object ownership of the bucket,
+// buckets without ownership controls use ObjectWriter.
+func getObjectOwnership(bucket string) ownership.ObjectOwnership {
+	config, err := globalBucketMetadataSys.GetOwnershipControlsConfig(bucket)
+	if err != nil {
+		return ownership.ObjectWriter
+	}
+	return config.ObjectOwnership()
+}
+
+// isACLDisabled - returns true if the bucket owner owns all objects of
+// the bucket, ACLs no longer affect access permissions then.
+func isACLDisabled(bucket string) bool {
+	return getObjectOwnership(bucket) == ownership.BucketOwnerEnforced
+}
+
+// checkACLSupported - returns ACLNotSupported if a request sets an ACL
+// while ACLs are disabled for the bucket, ACLs which only grant the owner
+// full control such as bucket-owner-full-control are still accepted.
+func checkACLSupported(bucket string, config *acl.AccessControlPolicy) error {
+	if config == nil || config.IsPrivate() || !isACLDisabled(bucket) {
+		return nil
+	}
+	return ACLNotSupported{Bucket: bucket}
+}
+
+// setObjectWriterMetadata - records the identity writing an object with
+// the request in the object metadata. Objects written with temporary or
+// service account credentials are written on behalf of their parent user,
+// objects written by the root user are not recorded.
+func setObjectWriterMetadata(r *http.Request, bucket string, metadata map[string]string) {
+	setObjectWriterMetadataFor(getReqAccessCred(r, globalServerRegion), r.Header.Get(acl.AmzACL), bucket, metadata)
+}
+
+// setObjectWriterMetadataFor - records the identity writing an object with
+// the credentials cred and the canned ACL in the object metadata, as
+// setObjectWriterMetadata.
+func setObjectWriterMetadataFor(cred auth.Credentials, cannedACL, bucket string, metadata map[string]string) {
+	delete(metadata, objectWriterMetadataKey)
+	delete(metadata, objectServiceAccountMetadataKey)
+	delete(metadata, objectBucketOwnedMetadataKey)
+	if globalIsGateway {
+		return
+	}
+	id := getACLRequesterID(cred, cred.AccessKey == globalActiveCred.AccessKey)
+	if id == "" || id == aclOwner().ID {
+		return
+	}
+	metadata[objectWriterMetadataKey] = id
+	if cred.IsServiceAccount() {
+		metadata[objectServiceAccountMetadataKey] = cred.AccessKey
+	}
+
+	// The writer owns the object unless the ownership controls of the
+	// bucket make the bucket owner own it.
+	switch getObjectOwnership(bucket) {
+	case ownership.BucketOwnerEnforced:
+		metadata[objectBucketOwnedMetadataKey] = "true"
+	case ownership.BucketOwnerPreferred:
+		if cannedACL == acl.CannedBucketOwnerFullControl {
+			metadata[objectBucketOwnedMetadataKey] = "true"
+		}
+	}
+}
+
+// getObjectOwner - returns the owner of the object, the bucket owner owns
+// all objects of buckets with the BucketOwnerEnforced object ownership.
+func getObjectOwner(objInfo ObjectInfo, objectOwnership ownership.ObjectOwnership) acl.Owner {
+	id, ok := objInfo.UserDefined[objectWriterMetadataKey]
+	if !ok || objectOwnership == ownership.BucketOwnerEnforced {
+		return aclOwner()
+	}
+	if _, ok = objInfo.UserDefined[objectBucketOwnedMetadataKey]; ok {
+		return aclOwner()
+	}
+	return acl.Owner{ID: id, DisplayName: id}
+}
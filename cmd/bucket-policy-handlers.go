@@ -87,6 +87,13 @@ func (api objectAPIHandlers) PutBucketPolicyHandler(w http.ResponseWriter, r *ht
 		return
 	}
 
+	// Reject policies granting access to everyone if public policies
+	// are blocked for the bucket.
+	if bucketPolicy.IsPublic() && getPublicAccessBlock(bucket).BlockPublicPolicy {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	configData, err := json.Marshal(bucketPolicy)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
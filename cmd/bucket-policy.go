@@ -40,21 +40,40 @@ func (sys *PolicySys) Get(bucket string) (*policy.Policy, error) {
 	return globalBucketMetadataSys.GetPolicyConfig(bucket)
 }
 
-// IsAllowed - checks given policy args is allowed to continue the Rest API.
-func (sys *PolicySys) IsAllowed(args policy.Args) bool {
+// Evaluate - returns whether given policy args are explicitly denied,
+// allowed or not matched by the bucket policy.
+func (sys *PolicySys) Evaluate(args policy.Args) policy.Decision {
+	// Buckets restricting public access can not be accessed by
+	// anyone but the owner through the bucket policy.
+	if !args.IsOwner && getPublicAccessBlock(args.BucketName).RestrictPublicBuckets {
+		return policy.Denied
+	}
+
 	p, err := sys.Get(args.BucketName)
 	if err == nil {
-		return p.IsAllowed(args)
+		return p.Evaluate(args)
 	}
 
-	// Log unhandled errors.
+	// Log unhandled errors, the request is denied as the
+	// policy may deny it.
 	if _, ok := err.(BucketPolicyNotFound); !ok {
 		logger.LogIf(GlobalContext, err)
+		if !args.IsOwner {
+			return policy.Denied
+		}
 	}
 
-	// As policy is not available for given bucket name, returns IsOwner i.e.
-	// operation is allowed only for owner.
-	return args.IsOwner
+	// As policy is not available for given bucket name, operation
+	// is allowed only for owner.
+	if args.IsOwner {
+		return policy.Allowed
+	}
+	return policy.NoMatch
+}
+
+// IsAllowed - checks given policy args is allowed to continue the Rest API.
+func (sys *PolicySys) IsAllowed(args policy.Args) bool {
+	return sys.Evaluate(args) == policy.Allowed
 }
 
 // NewPolicySys - creates new policy system.
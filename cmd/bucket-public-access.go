@@ -0,0 +1,49 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/minio/minio/pkg/bucket/acl"
+	"github.com/minio/minio/pkg/bucket/publicaccess"
+)
+
+const (
+	// Public access block configuration file.
+	bucketPublicAccessBlockConfig = "public-access-block.xml"
+)
+
+// getPublicAccessBlock - returns the public access block settings in
+// effect for the bucket, which combine the settings of the bucket with
+// the server wide settings. Only the server wide settings apply to
+// buckets without a configuration.
+func getPublicAccessBlock(bucket string) publicaccess.Config {
+	serverConfig := globalAPIConfig.getPublicAccessBlock()
+	if bucket == "" {
+		return serverConfig
+	}
+	config, err := globalBucketMetadataSys.GetPublicAccessBlockConfig(bucket)
+	if err != nil {
+		return serverConfig
+	}
+	return config.Merge(serverConfig)
+}
+
+// isPublicACLBlocked - returns true if the ACL grants public access
+// while public ACLs are blocked for the bucket.
+func isPublicACLBlocked(bucket string, config *acl.AccessControlPolicy) bool {
+	return config != nil && config.IsPublic() && getPublicAccessBlock(bucket).BlockPublicAcls
+}
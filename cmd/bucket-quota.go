@@ -20,6 +20,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/minio/minio/cmd/logger"
@@ -30,6 +32,10 @@ import (
 // BucketQuotaSys - map of bucket and quota configuration.
 type BucketQuotaSys struct {
 	bucketStorageCache timedValue
+
+	// Usage of the folders of each bucket with prefix quotas.
+	prefixUsageMu    sync.Mutex
+	prefixUsageCache map[string]*timedValue
 }
 
 // Get - Get quota configuration.
@@ -62,7 +68,7 @@ func parseBucketQuota(bucket string, data []byte) (quotaCfg *madmin.BucketQuota,
 	return
 }
 
-func (sys *BucketQuotaSys) check(ctx context.Context, bucket string, size int64) error {
+func (sys *BucketQuotaSys) check(ctx context.Context, bucket, object string, size int64) error {
 	objAPI := newObjectLayerFn()
 	if objAPI == nil {
 		return errServerNotInitialized
@@ -81,8 +87,12 @@ func (sys *BucketQuotaSys) check(ctx context.Context, bucket string, size int64)
 	if err != nil {
 		return err
 	}
+	if q == nil {
+		return nil
+	}
 
-	if q != nil && q.Type == madmin.HardQuota && q.Quota > 0 {
+	hardQuota := q.Type == madmin.HardQuota && q.Quota > 0
+	if hardQuota || q.ObjectsQuota > 0 {
 		v, err := sys.bucketStorageCache.Get()
 		if err != nil {
 			return err
@@ -90,27 +100,106 @@ func (sys *BucketQuotaSys) check(ctx context.Context, bucket string, size int64)
 
 		dui := v.(DataUsageInfo)
 
-		bui, ok := dui.BucketsUsage[bucket]
-		if !ok {
-			// bucket not found, cannot enforce quota
-			// call will fail anyways later.
-			return nil
+		// The bucket quota can not be enforced for buckets not
+		// crawled yet, their prefix quotas are still checked.
+		if bui, ok := dui.BucketsUsage[bucket]; ok {
+			if hardQuota && (bui.Size+uint64(size)) >= q.Quota {
+				return BucketQuotaExceeded{Bucket: bucket}
+			}
+			if q.ObjectsQuota > 0 && bui.ObjectsCount >= q.ObjectsQuota {
+				return BucketQuotaExceeded{Bucket: bucket}
+			}
 		}
+	}
 
-		if (bui.Size + uint64(size)) >= q.Quota {
-			return BucketQuotaExceeded{Bucket: bucket}
+	var prefixQuotas []madmin.PrefixQuota
+	for _, pq := range q.PrefixQuotas {
+		if strings.HasPrefix(object, pq.Prefix) {
+			prefixQuotas = append(prefixQuotas, pq)
 		}
 	}
+	if len(prefixQuotas) == 0 {
+		return nil
+	}
 
+	v, err := sys.prefixUsage(objAPI, bucket).Get()
+	if err != nil {
+		return err
+	}
+	caches := v.([]dataUsageCache)
+	for _, pq := range prefixQuotas {
+		var usage dataUsageEntry
+		for i := range caches {
+			if e := caches[i].sizeRecursive(pathJoin(bucket, pq.Prefix)); e != nil {
+				usage.merge(*e)
+			}
+		}
+		if pq.Quota > 0 && uint64(usage.Size)+uint64(size) >= pq.Quota {
+			return BucketQuotaExceeded{Bucket: bucket}
+		}
+		if pq.ObjectsQuota > 0 && usage.Objects >= pq.ObjectsQuota {
+			return BucketQuotaExceeded{Bucket: bucket}
+		}
+	}
 	return nil
 }
 
-func enforceBucketQuota(ctx context.Context, bucket string, size int64) error {
+// prefixUsage - returns the cached crawler results of the bucket, which hold
+// the usage of each folder of the bucket.
+func (sys *BucketQuotaSys) prefixUsage(objAPI ObjectLayer, bucket string) *timedValue {
+	sys.prefixUsageMu.Lock()
+	defer sys.prefixUsageMu.Unlock()
+	if sys.prefixUsageCache == nil {
+		sys.prefixUsageCache = make(map[string]*timedValue)
+	}
+	tv, ok := sys.prefixUsageCache[bucket]
+	if !ok {
+		tv = &timedValue{
+			// The caches are only updated by the crawler.
+			TTL: 10 * time.Second,
+			Update: func() (interface{}, error) {
+				ctx, done := context.WithTimeout(context.Background(), 5*time.Second)
+				defer done()
+				return loadBucketUsageCaches(ctx, objAPI, bucket)
+			},
+		}
+		sys.prefixUsageCache[bucket] = tv
+	}
+	return tv
+}
+
+// loadBucketUsageCaches - loads the crawler caches of the bucket, one per
+// erasure set, which hold the usage of each folder of the bucket.
+func loadBucketUsageCaches(ctx context.Context, objAPI ObjectLayer, bucket string) ([]dataUsageCache, error) {
+	var stores []objectIO
+	switch obj := objAPI.(type) {
+	case *erasureServerPools:
+		for _, pool := range obj.serverPools {
+			for _, set := range pool.sets {
+				stores = append(stores, set)
+			}
+		}
+	case *FSObjects:
+		stores = append(stores, obj)
+	}
+
+	caches := make([]dataUsageCache, len(stores))
+	for i, store := range stores {
+		if err := caches[i].load(ctx, store, pathJoin(bucket, dataUsageCacheName)); err != nil {
+			return nil, err
+		}
+	}
+	return caches, nil
+}
+
+// enforceBucketQuota - returns an error if adding size bytes to the object
+// exceeds the quotas of the bucket or of a prefix of the object.
+func enforceBucketQuota(ctx context.Context, bucket, object string, size int64) error {
 	if size < 0 {
 		return nil
 	}
 
-	return globalBucketQuotaSys.check(ctx, bucket, size)
+	return globalBucketQuotaSys.check(ctx, bucket, object, size)
 }
 
 // enforceFIFOQuota deletes objects in FIFO order until sufficient objects
@@ -0,0 +1,141 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestParseBucketQuota(t *testing.T) {
+	testCases := []struct {
+		config  string
+		success bool
+	}{
+		{`{"quota": 0}`, true},
+		{`{"quota": 100, "quotatype": "hard"}`, true},
+		{`{"quota": 100}`, false},
+		{`{"objectsQuota": 10}`, true},
+		{`{"prefixQuotas": [{"prefix": "tenant1/", "quota": 100}, {"prefix": "tenant2/", "objectsQuota": 10}]}`, true},
+		// Prefixes are folders.
+		{`{"prefixQuotas": [{"prefix": "tenant1", "quota": 100}]}`, false},
+		{`{"prefixQuotas": [{"prefix": "/tenant1/", "quota": 100}]}`, false},
+		// Prefixes need a limit.
+		{`{"prefixQuotas": [{"prefix": "tenant1/"}]}`, false},
+		{`{"prefixQuotas": [{"prefix": "tenant1/", "quota": 100}, {"prefix": "tenant1/", "quota": 200}]}`, false},
+	}
+	for i, tc := range testCases {
+		if _, err := parseBucketQuota("bucket", []byte(tc.config)); (err == nil) != tc.success {
+			t.Errorf("test %d: expected success %v, got %v", i+1, tc.success, err)
+		}
+	}
+}
+
+func TestBucketQuotaObjectsAndPrefixes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z := prepareErasurePools(ctx, t, 1)
+	defer setObjectLayer(newObjectLayerFn())
+	setObjectLayer(z)
+
+	bucket := "bucket"
+	if err := z.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Usage as found by the crawler: 3 objects of 100 bytes under
+	// tenant1/ and 1 object of 1000 bytes under tenant2/.
+	cache := dataUsageCache{Info: dataUsageCacheInfo{Name: bucket, LastUpdate: UTCNow()}}
+	cache.replace(bucket, "", dataUsageEntry{})
+	cache.replace(pathJoin(bucket, "tenant1"), bucket, dataUsageEntry{Size: 100, Objects: 1})
+	cache.replace(pathJoin(bucket, "tenant1/sub"), pathJoin(bucket, "tenant1"), dataUsageEntry{Size: 200, Objects: 2})
+	cache.replace(pathJoin(bucket, "tenant2"), bucket, dataUsageEntry{Size: 1000, Objects: 1})
+	if err := cache.save(ctx, z.serverPools[0].sets[0], pathJoin(bucket, dataUsageCacheName)); err != nil {
+		t.Fatal(err)
+	}
+	updates := make(chan DataUsageInfo, 1)
+	updates <- DataUsageInfo{BucketsUsage: map[string]BucketUsageInfo{
+		bucket: {Size: 1300, ObjectsCount: 4},
+	}}
+	close(updates)
+	storeDataUsageInBackend(ctx, z, updates)
+
+	setQuota := func(q madmin.BucketQuota) {
+		t.Helper()
+		data, err := json.Marshal(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = globalBucketMetadataSys.Update(bucket, bucketQuotaConfigFile, data); err != nil {
+			t.Fatal(err)
+		}
+		// Drop the cached usage of the previous test case.
+		globalBucketQuotaSys = NewBucketQuotaSys()
+	}
+	restoreBucketQuotaSys := globalBucketQuotaSys
+	defer func() {
+		globalBucketQuotaSys = restoreBucketQuotaSys
+	}()
+
+	testCases := []struct {
+		quota    madmin.BucketQuota
+		object   string
+		size     int64
+		exceeded bool
+	}{
+		{madmin.BucketQuota{ObjectsQuota: 5}, "object", 10, false},
+		{madmin.BucketQuota{ObjectsQuota: 4}, "object", 10, true},
+		// FIFO quotas are not enforced on uploads.
+		{madmin.BucketQuota{Quota: 1000, Type: madmin.FIFOQuota}, "object", 10, false},
+		{madmin.BucketQuota{Quota: 1000, Type: madmin.HardQuota}, "object", 10, true},
+		{madmin.BucketQuota{PrefixQuotas: []madmin.PrefixQuota{{Prefix: "tenant1/", Quota: 400}}}, "tenant1/sub/object", 50, false},
+		{madmin.BucketQuota{PrefixQuotas: []madmin.PrefixQuota{{Prefix: "tenant1/", Quota: 400}}}, "tenant1/object", 100, true},
+		{madmin.BucketQuota{PrefixQuotas: []madmin.PrefixQuota{{Prefix: "tenant1/", ObjectsQuota: 3}}}, "tenant1/object", 1, true},
+		{madmin.BucketQuota{PrefixQuotas: []madmin.PrefixQuota{{Prefix: "tenant1/sub/", ObjectsQuota: 3}}}, "tenant1/sub/object", 1, false},
+		// Quotas of other prefixes do not apply.
+		{madmin.BucketQuota{PrefixQuotas: []madmin.PrefixQuota{{Prefix: "tenant2/", Quota: 500}}}, "tenant1/object", 1, false},
+		{madmin.BucketQuota{PrefixQuotas: []madmin.PrefixQuota{{Prefix: "tenant2/", Quota: 500}}}, "tenant2/object", 1, true},
+		// Prefixes without usage are empty.
+		{madmin.BucketQuota{PrefixQuotas: []madmin.PrefixQuota{{Prefix: "tenant3/", ObjectsQuota: 1}}}, "tenant3/object", 1, false},
+	}
+	for i, tc := range testCases {
+		setQuota(tc.quota)
+		err := enforceBucketQuota(ctx, bucket, tc.object, tc.size)
+		if _, ok := err.(BucketQuotaExceeded); ok != tc.exceeded {
+			t.Errorf("test %d: expected exceeded %v, got %v", i+1, tc.exceeded, err)
+		}
+	}
+
+	// Prefix quotas are enforced for buckets missing from the usage
+	// stored by the crawler.
+	updates = make(chan DataUsageInfo, 1)
+	updates <- DataUsageInfo{BucketsUsage: map[string]BucketUsageInfo{}}
+	close(updates)
+	storeDataUsageInBackend(ctx, z, updates)
+	setQuota(madmin.BucketQuota{Quota: 1000, Type: madmin.HardQuota,
+		PrefixQuotas: []madmin.PrefixQuota{{Prefix: "tenant2/", Quota: 500}}})
+	if err := enforceBucketQuota(ctx, bucket, "tenant2/object", 1); err == nil {
+		t.Error("expected the prefix quota to be exceeded")
+	}
+	if err := enforceBucketQuota(ctx, bucket, "tenant1/object", 1); err != nil {
+		t.Errorf("expected the bucket quota not to be enforced, got %v", err)
+	}
+}
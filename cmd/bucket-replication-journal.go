@@ -0,0 +1,551 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/tinylib/msgp/msgp"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+
+const (
+	// replicationJournalFilename is the journal of the replication tasks
+	// queued on this server, it is kept on every local drive.
+	replicationJournalFilename = dataUsageBucket + SlashSeparator + ".replication-journal.bin"
+	replicationJournalVersion  = 2
+
+	// Version 1 journals do not record the resync target of the tasks.
+	replicationJournalVersionV1 = 1
+
+	// Queued tasks are written to the journal in batches.
+	replicationJournalFlushInterval = 100 * time.Millisecond
+
+	// Tasks which did not fit the in-memory queue are queued again at this interval.
+	replicationJournalRequeueInterval = 10 * time.Second
+
+	// The journal is rewritten with the pending tasks only once it grows beyond this size.
+	replicationJournalCompactSize = 64 << 20
+)
+
+//msgp:tuple replicationTask
+
+// replicationTask is the replication of an object version
+// or of a delete queued on this server.
+type replicationTask struct {
+	Bucket    string
+	Object    string
+	VersionID string
+	Queued    time.Time
+
+	// Set for deletes.
+	Delete                        bool
+	DeleteMarker                  bool
+	DeleteMarkerVersionID         string
+	DeleteMarkerMTime             time.Time
+	DeleteMarkerReplicationStatus string
+	VersionPurgeStatus            string
+	PurgeTransitioned             string
+
+	// Set when a target is resynced, the object version is
+	// replicated again to this target only.
+	ResyncTarget string
+}
+
+//msgp:tuple replicationTaskV1
+
+// replicationTaskV1 is a task of a version 1 journal.
+type replicationTaskV1 struct {
+	Bucket    string
+	Object    string
+	VersionID string
+	Queued    time.Time
+
+	Delete                        bool
+	DeleteMarker                  bool
+	DeleteMarkerVersionID         string
+	DeleteMarkerMTime             time.Time
+	DeleteMarkerReplicationStatus string
+	VersionPurgeStatus            string
+	PurgeTransitioned             string
+}
+
+func newObjectReplicationTask(oi ObjectInfo) replicationTask {
+	return replicationTask{
+		Bucket:    oi.Bucket,
+		Object:    oi.Name,
+		VersionID: oi.VersionID,
+		Queued:    UTCNow(),
+	}
+}
+
+func newDeleteReplicationTask(doi DeletedObjectVersionInfo) replicationTask {
+	return replicationTask{
+		Bucket:                        doi.Bucket,
+		Object:                        doi.ObjectName,
+		VersionID:                     doi.VersionID,
+		Queued:                        UTCNow(),
+		Delete:                        true,
+		DeleteMarker:                  doi.DeleteMarker,
+		DeleteMarkerVersionID:         doi.DeleteMarkerVersionID,
+		DeleteMarkerMTime:             doi.DeleteMarkerMTime.Time,
+		DeleteMarkerReplicationStatus: doi.DeleteMarkerReplicationStatus,
+		VersionPurgeStatus:            string(doi.VersionPurgeStatus),
+		PurgeTransitioned:             doi.PurgeTransitioned,
+		ResyncTarget:                  doi.ResyncTarget,
+	}
+}
+
+// key identifies the task, a task is queued at most once.
+func (t replicationTask) key() string {
+	if t.Delete {
+		return fmt.Sprintf("delete:%s:%s:%s:%s", pathJoin(t.Bucket, t.Object), t.VersionID, t.DeleteMarkerVersionID, t.ResyncTarget)
+	}
+	return fmt.Sprintf("object:%s:%s:%s", pathJoin(t.Bucket, t.Object), t.VersionID, t.ResyncTarget)
+}
+
+func (t replicationTask) objectInfo() ObjectInfo {
+	return ObjectInfo{
+		Bucket:    t.Bucket,
+		Name:      t.Object,
+		VersionID: t.VersionID,
+	}
+}
+
+func (t replicationTask) deletedObject() DeletedObjectVersionInfo {
+	return DeletedObjectVersionInfo{
+		DeletedObject: DeletedObject{
+			DeleteMarker:                  t.DeleteMarker,
+			DeleteMarkerVersionID:         t.DeleteMarkerVersionID,
+			ObjectName:                    t.Object,
+			VersionID:                     t.VersionID,
+			DeleteMarkerReplicationStatus: t.DeleteMarkerReplicationStatus,
+			DeleteMarkerMTime:             DeleteMarkerMTime{t.DeleteMarkerMTime},
+			VersionPurgeStatus:            VersionPurgeStatusType(t.VersionPurgeStatus),
+			PurgeTransitioned:             t.PurgeTransitioned,
+		},
+		Bucket:       t.Bucket,
+		ResyncTarget: t.ResyncTarget,
+	}
+}
+
+//msgp:tuple replicationJournalEntry
+
+// replicationJournalEntry records that a task was queued or is done.
+type replicationJournalEntry struct {
+	Done bool
+	Task replicationTask
+}
+
+//msgp:tuple replicationJournalEntryV1
+
+// replicationJournalEntryV1 is an entry of a version 1 journal.
+type replicationJournalEntryV1 struct {
+	Done bool
+	Task replicationTaskV1
+}
+
+func (e replicationJournalEntryV1) toV2() replicationJournalEntry {
+	return replicationJournalEntry{
+		Done: e.Done,
+		Task: replicationTask{
+			Bucket:                        e.Task.Bucket,
+			Object:                        e.Task.Object,
+			VersionID:                     e.Task.VersionID,
+			Queued:                        e.Task.Queued,
+			Delete:                        e.Task.Delete,
+			DeleteMarker:                  e.Task.DeleteMarker,
+			DeleteMarkerVersionID:         e.Task.DeleteMarkerVersionID,
+			DeleteMarkerMTime:             e.Task.DeleteMarkerMTime,
+			DeleteMarkerReplicationStatus: e.Task.DeleteMarkerReplicationStatus,
+			VersionPurgeStatus:            e.Task.VersionPurgeStatus,
+			PurgeTransitioned:             e.Task.PurgeTransitioned,
+		},
+	}
+}
+
+// journaledTask is a task which was queued and is not done yet.
+type journaledTask struct {
+	replicationTask
+
+	// inQueue is set while the task is in the in-memory queue,
+	// it is queued again by the journal otherwise.
+	inQueue bool
+}
+
+//msgp:ignore replicationJournal journaledTask
+
+// replicationJournal records the replication tasks queued on this server
+// until they are done, tasks pending when the server stops are queued
+// again when it starts.
+type replicationJournal struct {
+	drives []string
+
+	mu      sync.Mutex
+	pending map[string]*journaledTask
+	buf     bytes.Buffer // entries not written yet.
+	size    int          // size of the journal on the drives.
+
+	// Serializes writes to the drives.
+	flushMu sync.Mutex
+}
+
+func newReplicationJournal(drives []string) *replicationJournal {
+	return &replicationJournal{
+		drives:  drives,
+		pending: make(map[string]*journaledTask),
+	}
+}
+
+// load reads the pending tasks from the journals on all drives and
+// rewrites the journals with them.
+func (j *replicationJournal) load(ctx context.Context) {
+	for _, drive := range j.drives {
+		buf, err := ioutil.ReadFile(pathJoin(drive, replicationJournalFilename))
+		if err != nil {
+			if !osIsNotExist(err) {
+				logger.LogIf(ctx, err)
+			}
+			continue
+		}
+		pending, err := readReplicationJournal(buf)
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("replication journal %s: %w", drive, err))
+		}
+		// A drive may have missed writes, all tasks pending
+		// on any drive are queued again.
+		j.mu.Lock()
+		for key, t := range pending {
+			if _, ok := j.pending[key]; !ok {
+				j.pending[key] = &journaledTask{replicationTask: t}
+			}
+		}
+		j.mu.Unlock()
+	}
+	j.flush(ctx, true)
+}
+
+// readReplicationJournal returns the tasks of the journal which are not done,
+// the entries up to a partially written entry are read. Version 1 journals
+// are read too, they are rewritten with the current version on load.
+func readReplicationJournal(buf []byte) (map[string]replicationTask, error) {
+	pending := make(map[string]replicationTask)
+	if len(buf) == 0 {
+		return pending, nil
+	}
+	version := buf[0]
+	switch version {
+	case replicationJournalVersionV1, replicationJournalVersion:
+	default:
+		return pending, fmt.Errorf("unknown version: %d", int(version))
+	}
+	buf = buf[1:]
+	for len(buf) > 0 {
+		var e replicationJournalEntry
+		var err error
+		if version == replicationJournalVersionV1 {
+			var v1 replicationJournalEntryV1
+			buf, err = v1.UnmarshalMsg(buf)
+			e = v1.toV2()
+		} else {
+			buf, err = e.UnmarshalMsg(buf)
+		}
+		if err != nil {
+			if errors.Is(err, msgp.ErrShortBytes) {
+				// Partially written entry.
+				break
+			}
+			return pending, err
+		}
+		if e.Done {
+			delete(pending, e.Task.key())
+		} else {
+			pending[e.Task.key()] = e.Task
+		}
+	}
+	return pending, nil
+}
+
+// add records the task as queued, false is returned if
+// the task is already in the in-memory queue.
+func (j *replicationJournal) add(t replicationTask) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	key := t.key()
+	if jt, ok := j.pending[key]; ok {
+		if jt.inQueue {
+			return false
+		}
+		jt.inQueue = true
+		return true
+	}
+	j.pending[key] = &journaledTask{replicationTask: t, inQueue: true}
+	j.append(replicationJournalEntry{Task: t})
+	return true
+}
+
+// notQueued records that the task did not fit the in-memory
+// queue, it is queued again later.
+func (j *replicationJournal) notQueued(t replicationTask) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if jt, ok := j.pending[t.key()]; ok {
+		jt.inQueue = false
+	}
+}
+
+// done records that the task was attempted.
+func (j *replicationJournal) done(t replicationTask) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	key := t.key()
+	if _, ok := j.pending[key]; !ok {
+		return
+	}
+	delete(j.pending, key)
+	j.append(replicationJournalEntry{Done: true, Task: t})
+}
+
+// append an entry to the entries not written yet, j.mu must be held.
+func (j *replicationJournal) append(e replicationJournalEntry) {
+	b, err := e.MarshalMsg(nil)
+	if err != nil {
+		logger.LogIf(GlobalContext, err)
+		return
+	}
+	j.buf.Write(b)
+}
+
+// waiting returns the tasks which are not in the in-memory queue
+// and marks them as queued.
+func (j *replicationJournal) waiting() []replicationTask {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var tasks []replicationTask
+	for _, jt := range j.pending {
+		if !jt.inQueue {
+			jt.inQueue = true
+			tasks = append(tasks, jt.replicationTask)
+		}
+	}
+	return tasks
+}
+
+// flush writes the recorded entries to the journal on all drives, the
+// journal is rewritten with the pending tasks when it grew too large.
+func (j *replicationJournal) flush(ctx context.Context, compact bool) {
+	j.flushMu.Lock()
+	defer j.flushMu.Unlock()
+
+	j.mu.Lock()
+	if !compact && j.buf.Len() == 0 {
+		j.mu.Unlock()
+		return
+	}
+	compact = compact || j.size+j.buf.Len() > replicationJournalCompactSize
+	var data []byte
+	if compact {
+		data = append(data, replicationJournalVersion)
+		for _, jt := range j.pending {
+			e := replicationJournalEntry{Task: jt.replicationTask}
+			b, err := e.MarshalMsg(nil)
+			if err != nil {
+				logger.LogIf(ctx, err)
+				continue
+			}
+			data = append(data, b...)
+		}
+		j.size = len(data)
+	} else {
+		data = append(data, j.buf.Bytes()...)
+		j.size += len(data)
+	}
+	j.buf.Reset()
+	j.mu.Unlock()
+
+	for _, drive := range j.drives {
+		filePath := pathJoin(drive, replicationJournalFilename)
+		var err error
+		if compact {
+			err = writeReplicationJournal(filePath, data)
+		} else {
+			err = appendReplicationJournal(filePath, data)
+		}
+		if err != nil && !osIsNotExist(err) {
+			logger.LogIf(ctx, err)
+		}
+	}
+}
+
+// writeReplicationJournal replaces the journal at filePath with data.
+func writeReplicationJournal(filePath string, data []byte) error {
+	tmpPath := filePath + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filePath)
+}
+
+// appendReplicationJournal appends data to the journal at filePath.
+func appendReplicationJournal(filePath string, data []byte) error {
+	f, err := os.OpenFile(filePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if fi.Size() == 0 {
+		if _, err = f.Write([]byte{replicationJournalVersion}); err != nil {
+			return err
+		}
+	}
+	if _, err = f.Write(data); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// info returns the number and age of the pending tasks.
+func (j *replicationJournal) info() (info madmin.ReplicationQueueInfo) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for _, jt := range j.pending {
+		if jt.Delete {
+			info.PendingDeletes++
+		} else {
+			info.PendingObjects++
+		}
+		if !jt.inQueue {
+			info.Waiting++
+		}
+		if info.OldestQueued.IsZero() || jt.Queued.Before(info.OldestQueued) {
+			info.OldestQueued = jt.Queued
+		}
+	}
+	return info
+}
+
+// startJournal loads the journal of the replication tasks
+// on the local drives and keeps writing it.
+func (r *replicationState) startJournal(ctx context.Context, drives []string) {
+	j := newReplicationJournal(drives)
+	j.load(ctx)
+	r.journal = j
+
+	go func() {
+		t := time.NewTicker(replicationJournalFlushInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				j.flush(context.Background(), false)
+				return
+			case <-t.C:
+				j.flush(ctx, false)
+			}
+		}
+	}()
+}
+
+// resumeJournal queues the journaled tasks again, the tasks pending when
+// the server stopped first. It is called once the replication
+// configuration and targets of the buckets are loaded.
+func (r *replicationState) resumeJournal(ctx context.Context) {
+	if r == nil || r.journal == nil {
+		return
+	}
+	r.resumeOnce.Do(func() {
+		go func() {
+			t := time.NewTimer(0)
+			defer t.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-t.C:
+					r.requeue()
+					t.Reset(replicationJournalRequeueInterval)
+				}
+			}
+		}()
+	})
+}
+
+// requeue queues the journaled tasks which did not fit the in-memory queue.
+func (r *replicationState) requeue() {
+	for _, t := range r.journal.waiting() {
+		var queued bool
+		if t.Delete {
+			queued = r.sendDelete(t.deletedObject())
+		} else {
+			queued = r.sendObject(t)
+		}
+		if !queued {
+			r.journal.notQueued(t)
+		}
+	}
+}
+
+// getLocalReplicationQueueInfo returns the replication tasks queued on this server.
+func getLocalReplicationQueueInfo(r *http.Request) madmin.ReplicationQueueInfo {
+	var info madmin.ReplicationQueueInfo
+	if globalReplicationState != nil && globalReplicationState.journal != nil {
+		info = globalReplicationState.journal.info()
+	}
+	info.Node = r.Host
+	if globalIsDistErasure {
+		info.Node = GetLocalPeer(globalEndpoints)
+	}
+	return info
+}
+
+// localDrivePaths returns the paths of the drives of this server.
+func localDrivePaths(endpointServerPools EndpointServerPools) []string {
+	var drives []string
+	for _, ep := range endpointServerPools {
+		for _, endpoint := range ep.Endpoints {
+			if endpoint.IsLocal {
+				drives = append(drives, endpoint.Path)
+			}
+		}
+	}
+	return drives
+}
@@ -0,0 +1,667 @@
+package cmd
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// DecodeMsg implements msgp.Decodable
+func (z *replicationJournalEntry) DecodeMsg(dc *msgp.Reader) (err error) {
+	var zb0001 uint32
+	zb0001, err = dc.ReadArrayHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if zb0001 != 2 {
+		err = msgp.ArrayError{Wanted: 2, Got: zb0001}
+		return
+	}
+	z.Done, err = dc.ReadBool()
+	if err != nil {
+		err = msgp.WrapError(err, "Done")
+		return
+	}
+	err = z.Task.DecodeMsg(dc)
+	if err != nil {
+		err = msgp.WrapError(err, "Task")
+		return
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *replicationJournalEntry) EncodeMsg(en *msgp.Writer) (err error) {
+	// array header, size 2
+	err = en.Append(0x92)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.Done)
+	if err != nil {
+		err = msgp.WrapError(err, "Done")
+		return
+	}
+	err = z.Task.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Task")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *replicationJournalEntry) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// array header, size 2
+	o = append(o, 0x92)
+	o = msgp.AppendBool(o, z.Done)
+	o, err = z.Task.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Task")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *replicationJournalEntry) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadArrayHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if zb0001 != 2 {
+		err = msgp.ArrayError{Wanted: 2, Got: zb0001}
+		return
+	}
+	z.Done, bts, err = msgp.ReadBoolBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Done")
+		return
+	}
+	bts, err = z.Task.UnmarshalMsg(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Task")
+		return
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *replicationJournalEntry) Msgsize() (s int) {
+	s = 1 + msgp.BoolSize + z.Task.Msgsize()
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *replicationJournalEntryV1) DecodeMsg(dc *msgp.Reader) (err error) {
+	var zb0001 uint32
+	zb0001, err = dc.ReadArrayHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if zb0001 != 2 {
+		err = msgp.ArrayError{Wanted: 2, Got: zb0001}
+		return
+	}
+	z.Done, err = dc.ReadBool()
+	if err != nil {
+		err = msgp.WrapError(err, "Done")
+		return
+	}
+	err = z.Task.DecodeMsg(dc)
+	if err != nil {
+		err = msgp.WrapError(err, "Task")
+		return
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *replicationJournalEntryV1) EncodeMsg(en *msgp.Writer) (err error) {
+	// array header, size 2
+	err = en.Append(0x92)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.Done)
+	if err != nil {
+		err = msgp.WrapError(err, "Done")
+		return
+	}
+	err = z.Task.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Task")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *replicationJournalEntryV1) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// array header, size 2
+	o = append(o, 0x92)
+	o = msgp.AppendBool(o, z.Done)
+	o, err = z.Task.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Task")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *replicationJournalEntryV1) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadArrayHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if zb0001 != 2 {
+		err = msgp.ArrayError{Wanted: 2, Got: zb0001}
+		return
+	}
+	z.Done, bts, err = msgp.ReadBoolBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Done")
+		return
+	}
+	bts, err = z.Task.UnmarshalMsg(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Task")
+		return
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *replicationJournalEntryV1) Msgsize() (s int) {
+	s = 1 + msgp.BoolSize + z.Task.Msgsize()
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *replicationTask) DecodeMsg(dc *msgp.Reader) (err error) {
+	var zb0001 uint32
+	zb0001, err = dc.ReadArrayHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if zb0001 != 12 {
+		err = msgp.ArrayError{Wanted: 12, Got: zb0001}
+		return
+	}
+	z.Bucket, err = dc.ReadString()
+	if err != nil {
+		err = msgp.WrapError(err, "Bucket")
+		return
+	}
+	z.Object, err = dc.ReadString()
+	if err != nil {
+		err = msgp.WrapError(err, "Object")
+		return
+	}
+	z.VersionID, err = dc.ReadString()
+	if err != nil {
+		err = msgp.WrapError(err, "VersionID")
+		return
+	}
+	z.Queued, err = dc.ReadTime()
+	if err != nil {
+		err = msgp.WrapError(err, "Queued")
+		return
+	}
+	z.Delete, err = dc.ReadBool()
+	if err != nil {
+		err = msgp.WrapError(err, "Delete")
+		return
+	}
+	z.DeleteMarker, err = dc.ReadBool()
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarker")
+		return
+	}
+	z.DeleteMarkerVersionID, err = dc.ReadString()
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerVersionID")
+		return
+	}
+	z.DeleteMarkerMTime, err = dc.ReadTime()
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerMTime")
+		return
+	}
+	z.DeleteMarkerReplicationStatus, err = dc.ReadString()
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerReplicationStatus")
+		return
+	}
+	z.VersionPurgeStatus, err = dc.ReadString()
+	if err != nil {
+		err = msgp.WrapError(err, "VersionPurgeStatus")
+		return
+	}
+	z.PurgeTransitioned, err = dc.ReadString()
+	if err != nil {
+		err = msgp.WrapError(err, "PurgeTransitioned")
+		return
+	}
+	z.ResyncTarget, err = dc.ReadString()
+	if err != nil {
+		err = msgp.WrapError(err, "ResyncTarget")
+		return
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *replicationTask) EncodeMsg(en *msgp.Writer) (err error) {
+	// array header, size 12
+	err = en.Append(0x9c)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Bucket)
+	if err != nil {
+		err = msgp.WrapError(err, "Bucket")
+		return
+	}
+	err = en.WriteString(z.Object)
+	if err != nil {
+		err = msgp.WrapError(err, "Object")
+		return
+	}
+	err = en.WriteString(z.VersionID)
+	if err != nil {
+		err = msgp.WrapError(err, "VersionID")
+		return
+	}
+	err = en.WriteTime(z.Queued)
+	if err != nil {
+		err = msgp.WrapError(err, "Queued")
+		return
+	}
+	err = en.WriteBool(z.Delete)
+	if err != nil {
+		err = msgp.WrapError(err, "Delete")
+		return
+	}
+	err = en.WriteBool(z.DeleteMarker)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarker")
+		return
+	}
+	err = en.WriteString(z.DeleteMarkerVersionID)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerVersionID")
+		return
+	}
+	err = en.WriteTime(z.DeleteMarkerMTime)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerMTime")
+		return
+	}
+	err = en.WriteString(z.DeleteMarkerReplicationStatus)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerReplicationStatus")
+		return
+	}
+	err = en.WriteString(z.VersionPurgeStatus)
+	if err != nil {
+		err = msgp.WrapError(err, "VersionPurgeStatus")
+		return
+	}
+	err = en.WriteString(z.PurgeTransitioned)
+	if err != nil {
+		err = msgp.WrapError(err, "PurgeTransitioned")
+		return
+	}
+	err = en.WriteString(z.ResyncTarget)
+	if err != nil {
+		err = msgp.WrapError(err, "ResyncTarget")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *replicationTask) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// array header, size 12
+	o = append(o, 0x9c)
+	o = msgp.AppendString(o, z.Bucket)
+	o = msgp.AppendString(o, z.Object)
+	o = msgp.AppendString(o, z.VersionID)
+	o = msgp.AppendTime(o, z.Queued)
+	o = msgp.AppendBool(o, z.Delete)
+	o = msgp.AppendBool(o, z.DeleteMarker)
+	o = msgp.AppendString(o, z.DeleteMarkerVersionID)
+	o = msgp.AppendTime(o, z.DeleteMarkerMTime)
+	o = msgp.AppendString(o, z.DeleteMarkerReplicationStatus)
+	o = msgp.AppendString(o, z.VersionPurgeStatus)
+	o = msgp.AppendString(o, z.PurgeTransitioned)
+	o = msgp.AppendString(o, z.ResyncTarget)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *replicationTask) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadArrayHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if zb0001 != 12 {
+		err = msgp.ArrayError{Wanted: 12, Got: zb0001}
+		return
+	}
+	z.Bucket, bts, err = msgp.ReadStringBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Bucket")
+		return
+	}
+	z.Object, bts, err = msgp.ReadStringBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Object")
+		return
+	}
+	z.VersionID, bts, err = msgp.ReadStringBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "VersionID")
+		return
+	}
+	z.Queued, bts, err = msgp.ReadTimeBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Queued")
+		return
+	}
+	z.Delete, bts, err = msgp.ReadBoolBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Delete")
+		return
+	}
+	z.DeleteMarker, bts, err = msgp.ReadBoolBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarker")
+		return
+	}
+	z.DeleteMarkerVersionID, bts, err = msgp.ReadStringBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerVersionID")
+		return
+	}
+	z.DeleteMarkerMTime, bts, err = msgp.ReadTimeBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerMTime")
+		return
+	}
+	z.DeleteMarkerReplicationStatus, bts, err = msgp.ReadStringBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerReplicationStatus")
+		return
+	}
+	z.VersionPurgeStatus, bts, err = msgp.ReadStringBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "VersionPurgeStatus")
+		return
+	}
+	z.PurgeTransitioned, bts, err = msgp.ReadStringBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "PurgeTransitioned")
+		return
+	}
+	z.ResyncTarget, bts, err = msgp.ReadStringBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "ResyncTarget")
+		return
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *replicationTask) Msgsize() (s int) {
+	s = 1 + msgp.StringPrefixSize + len(z.Bucket) + msgp.StringPrefixSize + len(z.Object) + msgp.StringPrefixSize + len(z.VersionID) + msgp.TimeSize + msgp.BoolSize + msgp.BoolSize + msgp.StringPrefixSize + len(z.DeleteMarkerVersionID) + msgp.TimeSize + msgp.StringPrefixSize + len(z.DeleteMarkerReplicationStatus) + msgp.StringPrefixSize + len(z.VersionPurgeStatus) + msgp.StringPrefixSize + len(z.PurgeTransitioned) + msgp.StringPrefixSize + len(z.ResyncTarget)
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *replicationTaskV1) DecodeMsg(dc *msgp.Reader) (err error) {
+	var zb0001 uint32
+	zb0001, err = dc.ReadArrayHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if zb0001 != 11 {
+		err = msgp.ArrayError{Wanted: 11, Got: zb0001}
+		return
+	}
+	z.Bucket, err = dc.ReadString()
+	if err != nil {
+		err = msgp.WrapError(err, "Bucket")
+		return
+	}
+	z.Object, err = dc.ReadString()
+	if err != nil {
+		err = msgp.WrapError(err, "Object")
+		return
+	}
+	z.VersionID, err = dc.ReadString()
+	if err != nil {
+		err = msgp.WrapError(err, "VersionID")
+		return
+	}
+	z.Queued, err = dc.ReadTime()
+	if err != nil {
+		err = msgp.WrapError(err, "Queued")
+		return
+	}
+	z.Delete, err = dc.ReadBool()
+	if err != nil {
+		err = msgp.WrapError(err, "Delete")
+		return
+	}
+	z.DeleteMarker, err = dc.ReadBool()
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarker")
+		return
+	}
+	z.DeleteMarkerVersionID, err = dc.ReadString()
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerVersionID")
+		return
+	}
+	z.DeleteMarkerMTime, err = dc.ReadTime()
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerMTime")
+		return
+	}
+	z.DeleteMarkerReplicationStatus, err = dc.ReadString()
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerReplicationStatus")
+		return
+	}
+	z.VersionPurgeStatus, err = dc.ReadString()
+	if err != nil {
+		err = msgp.WrapError(err, "VersionPurgeStatus")
+		return
+	}
+	z.PurgeTransitioned, err = dc.ReadString()
+	if err != nil {
+		err = msgp.WrapError(err, "PurgeTransitioned")
+		return
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *replicationTaskV1) EncodeMsg(en *msgp.Writer) (err error) {
+	// array header, size 11
+	err = en.Append(0x9b)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Bucket)
+	if err != nil {
+		err = msgp.WrapError(err, "Bucket")
+		return
+	}
+	err = en.WriteString(z.Object)
+	if err != nil {
+		err = msgp.WrapError(err, "Object")
+		return
+	}
+	err = en.WriteString(z.VersionID)
+	if err != nil {
+		err = msgp.WrapError(err, "VersionID")
+		return
+	}
+	err = en.WriteTime(z.Queued)
+	if err != nil {
+		err = msgp.WrapError(err, "Queued")
+		return
+	}
+	err = en.WriteBool(z.Delete)
+	if err != nil {
+		err = msgp.WrapError(err, "Delete")
+		return
+	}
+	err = en.WriteBool(z.DeleteMarker)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarker")
+		return
+	}
+	err = en.WriteString(z.DeleteMarkerVersionID)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerVersionID")
+		return
+	}
+	err = en.WriteTime(z.DeleteMarkerMTime)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerMTime")
+		return
+	}
+	err = en.WriteString(z.DeleteMarkerReplicationStatus)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerReplicationStatus")
+		return
+	}
+	err = en.WriteString(z.VersionPurgeStatus)
+	if err != nil {
+		err = msgp.WrapError(err, "VersionPurgeStatus")
+		return
+	}
+	err = en.WriteString(z.PurgeTransitioned)
+	if err != nil {
+		err = msgp.WrapError(err, "PurgeTransitioned")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *replicationTaskV1) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// array header, size 11
+	o = append(o, 0x9b)
+	o = msgp.AppendString(o, z.Bucket)
+	o = msgp.AppendString(o, z.Object)
+	o = msgp.AppendString(o, z.VersionID)
+	o = msgp.AppendTime(o, z.Queued)
+	o = msgp.AppendBool(o, z.Delete)
+	o = msgp.AppendBool(o, z.DeleteMarker)
+	o = msgp.AppendString(o, z.DeleteMarkerVersionID)
+	o = msgp.AppendTime(o, z.DeleteMarkerMTime)
+	o = msgp.AppendString(o, z.DeleteMarkerReplicationStatus)
+	o = msgp.AppendString(o, z.VersionPurgeStatus)
+	o = msgp.AppendString(o, z.PurgeTransitioned)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *replicationTaskV1) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadArrayHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	if zb0001 != 11 {
+		err = msgp.ArrayError{Wanted: 11, Got: zb0001}
+		return
+	}
+	z.Bucket, bts, err = msgp.ReadStringBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Bucket")
+		return
+	}
+	z.Object, bts, err = msgp.ReadStringBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Object")
+		return
+	}
+	z.VersionID, bts, err = msgp.ReadStringBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "VersionID")
+		return
+	}
+	z.Queued, bts, err = msgp.ReadTimeBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Queued")
+		return
+	}
+	z.Delete, bts, err = msgp.ReadBoolBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "Delete")
+		return
+	}
+	z.DeleteMarker, bts, err = msgp.ReadBoolBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarker")
+		return
+	}
+	z.DeleteMarkerVersionID, bts, err = msgp.ReadStringBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerVersionID")
+		return
+	}
+	z.DeleteMarkerMTime, bts, err = msgp.ReadTimeBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerMTime")
+		return
+	}
+	z.DeleteMarkerReplicationStatus, bts, err = msgp.ReadStringBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerReplicationStatus")
+		return
+	}
+	z.VersionPurgeStatus, bts, err = msgp.ReadStringBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "VersionPurgeStatus")
+		return
+	}
+	z.PurgeTransitioned, bts, err = msgp.ReadStringBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err, "PurgeTransitioned")
+		return
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *replicationTaskV1) Msgsize() (s int) {
+	s = 1 + msgp.StringPrefixSize + len(z.Bucket) + msgp.StringPrefixSize + len(z.Object) + msgp.StringPrefixSize + len(z.VersionID) + msgp.TimeSize + msgp.BoolSize + msgp.BoolSize + msgp.StringPrefixSize + len(z.DeleteMarkerVersionID) + msgp.TimeSize + msgp.StringPrefixSize + len(z.DeleteMarkerReplicationStatus) + msgp.StringPrefixSize + len(z.VersionPurgeStatus) + msgp.StringPrefixSize + len(z.PurgeTransitioned)
+	return
+}
@@ -0,0 +1,173 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func newTestReplicationJournal(t *testing.T) (*replicationJournal, []string) {
+	t.Helper()
+	var drives []string
+	for i := 0; i < 2; i++ {
+		drive, err := ioutil.TempDir("", "minio-")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { os.RemoveAll(drive) })
+		if err = os.MkdirAll(pathJoin(drive, dataUsageBucket), 0777); err != nil {
+			t.Fatal(err)
+		}
+		drives = append(drives, drive)
+	}
+	return newReplicationJournal(drives), drives
+}
+
+func TestReplicationJournal(t *testing.T) {
+	ctx := context.Background()
+	j, drives := newTestReplicationJournal(t)
+
+	obj := newObjectReplicationTask(ObjectInfo{Bucket: "bucket", Name: "object", VersionID: "v1"})
+	del := newDeleteReplicationTask(DeletedObjectVersionInfo{
+		DeletedObject: DeletedObject{ObjectName: "object", DeleteMarker: true, DeleteMarkerVersionID: "v2"},
+		Bucket:        "bucket",
+	})
+	done := newObjectReplicationTask(ObjectInfo{Bucket: "bucket", Name: "done"})
+
+	for _, task := range []replicationTask{obj, del, done} {
+		if !j.add(task) {
+			t.Fatalf("task %s not queued", task.key())
+		}
+	}
+	if j.add(obj) {
+		t.Fatal("task queued twice")
+	}
+	j.done(done)
+	j.notQueued(del)
+	j.flush(ctx, false)
+
+	if info := j.info(); info.PendingObjects != 1 || info.PendingDeletes != 1 || info.Waiting != 1 {
+		t.Fatalf("unexpected queue info %+v", info)
+	}
+	if tasks := j.waiting(); len(tasks) != 1 || tasks[0].key() != del.key() {
+		t.Fatalf("unexpected waiting tasks %v", tasks)
+	}
+	if got := j.waiting(); len(got) != 0 {
+		t.Fatalf("tasks waiting twice %v", got)
+	}
+
+	// A write missed by a drive, torn at the end.
+	late := newObjectReplicationTask(ObjectInfo{Bucket: "bucket", Name: "late"})
+	e := replicationJournalEntry{Task: late}
+	b, err := e.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = appendReplicationJournal(pathJoin(drives[1], replicationJournalFilename), b); err != nil {
+		t.Fatal(err)
+	}
+	if err = appendReplicationJournal(pathJoin(drives[1], replicationJournalFilename), b[:len(b)/2]); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tasks pending on any drive are loaded after a restart.
+	j = newReplicationJournal(drives)
+	j.load(ctx)
+	waiting := make(map[string]replicationTask)
+	for _, task := range j.waiting() {
+		waiting[task.key()] = task
+	}
+	if len(waiting) != 3 {
+		t.Fatalf("expected 3 pending tasks, got %v", waiting)
+	}
+	for _, task := range []replicationTask{obj, del, late} {
+		got, ok := waiting[task.key()]
+		if !ok {
+			t.Fatalf("task %s not loaded", task.key())
+		}
+		if !got.Queued.Equal(task.Queued) {
+			t.Fatalf("task %s queued at %v, want %v", task.key(), got.Queued, task.Queued)
+		}
+	}
+	if doi := waiting[del.key()].deletedObject(); doi.DeleteMarkerVersionID != "v2" || !doi.DeleteMarker {
+		t.Fatalf("unexpected deleted object %+v", doi)
+	}
+
+	// The journals are rewritten with the pending tasks on load.
+	for _, drive := range drives {
+		buf, err := ioutil.ReadFile(pathJoin(drive, replicationJournalFilename))
+		if err != nil {
+			t.Fatal(err)
+		}
+		pending, err := readReplicationJournal(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pending) != 3 {
+			t.Fatalf("drive %s: expected 3 pending tasks, got %v", drive, pending)
+		}
+	}
+
+	for _, task := range []replicationTask{obj, del, late} {
+		j.done(task)
+	}
+	j.flush(ctx, false)
+	j = newReplicationJournal(drives)
+	j.load(ctx)
+	if info := j.info(); info.PendingObjects != 0 || info.PendingDeletes != 0 || !info.OldestQueued.IsZero() {
+		t.Fatalf("unexpected queue info after done %+v", info)
+	}
+}
+
+func TestReadReplicationJournalV1(t *testing.T) {
+	queued := UTCNow()
+	buf := []byte{replicationJournalVersionV1}
+	for _, e := range []replicationJournalEntryV1{
+		{Task: replicationTaskV1{Bucket: "bucket", Object: "object", VersionID: "v1", Queued: queued}},
+		{Task: replicationTaskV1{Bucket: "bucket", Object: "object", Queued: queued,
+			Delete: true, DeleteMarker: true, DeleteMarkerVersionID: "v2"}},
+		{Task: replicationTaskV1{Bucket: "bucket", Object: "done", Queued: queued}},
+		{Done: true, Task: replicationTaskV1{Bucket: "bucket", Object: "done", Queued: queued}},
+	} {
+		var err error
+		if buf, err = e.MarshalMsg(buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pending, err := readReplicationJournal(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("expected 2 pending tasks, got %v", pending)
+	}
+	obj := newObjectReplicationTask(ObjectInfo{Bucket: "bucket", Name: "object", VersionID: "v1"})
+	if got, ok := pending[obj.key()]; !ok || !got.Queued.Equal(queued) || got.ResyncTarget != "" {
+		t.Fatalf("unexpected object task %+v", got)
+	}
+	del := newDeleteReplicationTask(DeletedObjectVersionInfo{
+		DeletedObject: DeletedObject{ObjectName: "object", DeleteMarker: true, DeleteMarkerVersionID: "v2"},
+		Bucket:        "bucket",
+	})
+	if got, ok := pending[del.key()]; !ok || !got.DeleteMarker {
+		t.Fatalf("unexpected delete task %+v", got)
+	}
+}
@@ -0,0 +1,300 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// The progress of the resyncs of the targets of a bucket is kept
+	// in this directory of the bucket metadata, one file per target.
+	replicationResyncDir = "replication-resync"
+
+	// The progress of a resync is saved at this interval.
+	replicationResyncSaveInterval = 10 * time.Second
+)
+
+var errReplicationResyncSuperseded = errors.New("replication resync superseded by a newer resync of the target")
+
+// replicationResyncPath returns the path of the progress of the
+// resync of the target within the bucket metadata.
+func replicationResyncPath(bucket, arn string) (string, error) {
+	tgtArn, err := madmin.ParseARN(arn)
+	if err != nil {
+		return "", err
+	}
+	return path.Join(bucketConfigPrefix, bucket, replicationResyncDir, tgtArn.ID+".json"), nil
+}
+
+func loadReplicationResyncInfo(ctx context.Context, objAPI ObjectLayer, bucket, arn string) (info madmin.ReplicationResyncInfo, err error) {
+	configFile, err := replicationResyncPath(bucket, arn)
+	if err != nil {
+		return info, err
+	}
+	data, err := readConfig(ctx, objAPI, configFile)
+	if err != nil {
+		return info, err
+	}
+	err = json.Unmarshal(data, &info)
+	return info, err
+}
+
+func saveReplicationResyncInfo(ctx context.Context, objAPI ObjectLayer, info madmin.ReplicationResyncInfo) error {
+	configFile, err := replicationResyncPath(info.Bucket, info.TargetArn)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// mustResync returns true if the object version is
+// replicated to the target when the target is resynced.
+// Versions written before replication was configured are
+// resynced only if the rule replicates existing objects.
+func mustResync(cfg *replication.Config, oi ObjectInfo, arn string) bool {
+	if oi.ReplicationStatus == replication.Replica {
+		return false
+	}
+	if oi.DeleteMarker {
+		return cfg.Replicate(replication.ObjectOpts{
+			Name:           oi.Name,
+			DeleteMarker:   true,
+			TargetArn:      arn,
+			ExistingObject: oi.ReplicationStatus.Empty(),
+		})
+	}
+	return cfg.Replicate(replication.ObjectOpts{
+		Name:           oi.Name,
+		SSEC:           crypto.SSEC.IsEncrypted(oi.UserDefined),
+		UserTags:       oi.UserTags,
+		TargetArn:      arn,
+		ExistingObject: oi.ReplicationStatus.Empty(),
+	})
+}
+
+// startResync starts queueing again all versions of the bucket for
+// replication to the target, a resync of the target in progress is stopped.
+func (r *replicationState) startResync(ctx context.Context, objAPI ObjectLayer, bucket, arn string) (madmin.ReplicationResyncInfo, error) {
+	cfg, err := getReplicationConfig(ctx, bucket)
+	if err != nil {
+		return madmin.ReplicationResyncInfo{}, err
+	}
+	if !contains(cfg.TargetArns(), arn) {
+		return madmin.ReplicationResyncInfo{}, BucketRemoteTargetNotFound{Bucket: bucket}
+	}
+	now := UTCNow()
+	info := madmin.ReplicationResyncInfo{
+		Bucket:     bucket,
+		TargetArn:  arn,
+		ID:         mustGetUUID(),
+		Node:       GetLocalPeer(globalEndpoints),
+		Status:     madmin.ReplicationResyncStarted,
+		StartTime:  now,
+		LastUpdate: now,
+	}
+	if err = saveReplicationResyncInfo(ctx, objAPI, info); err != nil {
+		return madmin.ReplicationResyncInfo{}, err
+	}
+	r.runResync(objAPI, info)
+	return info, nil
+}
+
+// resumeResyncs resumes the resyncs this server was running when it stopped.
+func (r *replicationState) resumeResyncs(ctx context.Context, objAPI ObjectLayer, buckets []BucketInfo) {
+	if r == nil {
+		return
+	}
+	localNode := GetLocalPeer(globalEndpoints)
+	for _, bucket := range buckets {
+		cfg, err := getReplicationConfig(ctx, bucket.Name)
+		if err != nil {
+			continue
+		}
+		for _, arn := range cfg.TargetArns() {
+			info, err := loadReplicationResyncInfo(ctx, objAPI, bucket.Name, arn)
+			if err != nil {
+				if err != errConfigNotFound {
+					logger.LogIf(ctx, err)
+				}
+				continue
+			}
+			if info.Status == madmin.ReplicationResyncStarted && info.Node == localNode {
+				r.runResync(objAPI, info)
+			}
+		}
+	}
+}
+
+// replicationResyncJob is a resync running on this server.
+type replicationResyncJob struct {
+	id     string
+	cancel context.CancelFunc
+}
+
+// runResync walks the bucket in the background, a resync
+// of the same target in progress is stopped.
+func (r *replicationState) runResync(objAPI ObjectLayer, info madmin.ReplicationResyncInfo) {
+	ctx, cancel := context.WithCancel(GlobalContext)
+	key := pathJoin(info.Bucket, info.TargetArn)
+
+	r.resyncMu.Lock()
+	if job, ok := r.resyncs[key]; ok {
+		job.cancel()
+	}
+	r.resyncs[key] = replicationResyncJob{id: info.ID, cancel: cancel}
+	r.resyncMu.Unlock()
+
+	go func() {
+		defer cancel()
+		err := r.resync(ctx, objAPI, info)
+		if err != nil && !errors.Is(err, context.Canceled) && err != errReplicationResyncSuperseded {
+			logger.LogIf(GlobalContext, fmt.Errorf("replication resync of %s to %s: %w", info.Bucket, info.TargetArn, err))
+		}
+		r.resyncMu.Lock()
+		if job, ok := r.resyncs[key]; ok && job.id == info.ID {
+			delete(r.resyncs, key)
+		}
+		r.resyncMu.Unlock()
+	}()
+}
+
+// resync queues the versions of the bucket for replication to the target,
+// starting after the last object scanned when the resync was interrupted.
+func (r *replicationState) resync(ctx context.Context, objAPI ObjectLayer, info madmin.ReplicationResyncInfo) error {
+	save := func() error {
+		// A newer resync of the target, started on
+		// this or another server, stops this one.
+		current, err := loadReplicationResyncInfo(ctx, objAPI, info.Bucket, info.TargetArn)
+		if err == nil && current.ID != info.ID {
+			return errReplicationResyncSuperseded
+		}
+		info.LastUpdate = UTCNow()
+		return saveReplicationResyncInfo(ctx, objAPI, info)
+	}
+	fail := func(err error) error {
+		info.Status = madmin.ReplicationResyncFailed
+		info.Error = err.Error()
+		if serr := save(); serr != nil {
+			return serr
+		}
+		return err
+	}
+
+	cfg, err := getReplicationConfig(ctx, info.Bucket)
+	if err != nil {
+		return fail(err)
+	}
+	walkCtx, cancel := context.WithCancel(ctx)
+	objInfoCh := make(chan ObjectInfo)
+	if err = objAPI.Walk(walkCtx, info.Bucket, "", objInfoCh, ObjectOptions{WalkVersions: true}); err != nil {
+		cancel()
+		return fail(err)
+	}
+	defer func() {
+		// Stop the walk.
+		cancel()
+		for range objInfoCh {
+		}
+	}()
+
+	resumeAfter := info.LastObject
+	lastSave := time.Now()
+	var object string
+	for oi := range objInfoCh {
+		if resumeAfter != "" && oi.Name <= resumeAfter {
+			continue
+		}
+		if oi.Name != object {
+			// All versions of the previous object were scanned.
+			if object != "" {
+				info.LastObject = object
+			}
+			object = oi.Name
+			if time.Since(lastSave) > replicationResyncSaveInterval {
+				if err = save(); err != nil {
+					return err
+				}
+				lastSave = time.Now()
+			}
+		}
+		info.Scanned++
+		if !mustResync(cfg, oi, info.TargetArn) {
+			continue
+		}
+		if oi.DeleteMarker {
+			r.queueReplicaDeleteTask(DeletedObjectVersionInfo{
+				DeletedObject: DeletedObject{
+					ObjectName:                    oi.Name,
+					DeleteMarkerVersionID:         oi.VersionID,
+					DeleteMarkerReplicationStatus: string(oi.ReplicationStatus),
+					DeleteMarkerMTime:             DeleteMarkerMTime{oi.ModTime},
+					DeleteMarker:                  true,
+				},
+				Bucket:       oi.Bucket,
+				ResyncTarget: info.TargetArn,
+			})
+		} else if err = r.queueReplicaResyncTask(ctx, oi, info.TargetArn); err != nil {
+			return err
+		}
+		info.Queued++
+	}
+	if err = ctx.Err(); err != nil {
+		// Interrupted, the resync resumes when the server restarts.
+		return err
+	}
+	if object != "" {
+		info.LastObject = object
+	}
+	info.Status = madmin.ReplicationResyncCompleted
+	return save()
+}
+
+// resyncStatus returns the progress of the last
+// resync of each replication target of the bucket.
+func (r *replicationState) resyncStatus(ctx context.Context, objAPI ObjectLayer, bucket string) ([]madmin.ReplicationResyncInfo, error) {
+	cfg, err := getReplicationConfig(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+	resyncs := []madmin.ReplicationResyncInfo{}
+	for _, arn := range cfg.TargetArns() {
+		info, err := loadReplicationResyncInfo(ctx, objAPI, bucket, arn)
+		if err != nil {
+			if err == errConfigNotFound {
+				continue
+			}
+			return nil, err
+		}
+		resyncs = append(resyncs, info)
+	}
+	return resyncs, nil
+}
@@ -0,0 +1,166 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestReplicationResync(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj := prepareErasurePools(ctx, t, 1)
+	defer setObjectLayer(newObjectLayerFn())
+	setObjectLayer(obj)
+
+	// Replication is only supported in erasure mode.
+	globalIsErasure = true
+	defer func() { globalIsErasure = false }()
+
+	const arn = "arn:minio:replication::id1:dest"
+	bucket := "bucket"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	config := `<ReplicationConfiguration><Rule><Status>Enabled</Status><Priority>1</Priority>` +
+		`<DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication>` +
+		`<DeleteReplication><Status>Disabled</Status></DeleteReplication>` +
+		`<Filter><Prefix>a/</Prefix></Filter>` +
+		`<Destination><Bucket>` + arn + `</Bucket></Destination>` +
+		`<ExistingObjectReplication><Status>Enabled</Status></ExistingObjectReplication></Rule></ReplicationConfiguration>`
+	if err := globalBucketMetadataSys.Update(bucket, bucketReplicationConfig, []byte(config)); err != nil {
+		t.Fatal(err)
+	}
+
+	objects := map[string]map[string]string{
+		"a/1":       nil,
+		"a/2":       nil,
+		"a/replica": {xhttp.AmzBucketReplicationStatus: "REPLICA"},
+		"b/1":       nil,
+	}
+	for object, metadata := range objects {
+		data := []byte(object)
+		if _, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""),
+			ObjectOptions{UserDefined: metadata}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Objects written before replication was configured.
+	for object := range objects {
+		oi, err := obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := object == "a/1" || object == "a/2"; mustReplicateExisting(ctx, oi) != want {
+			t.Errorf("%s: expected existing object replication %v", object, want)
+		}
+	}
+
+	rs := newReplicationState()
+	resync := func(info madmin.ReplicationResyncInfo) ([]string, madmin.ReplicationResyncInfo, error) {
+		t.Helper()
+		if err := saveReplicationResyncInfo(ctx, obj, info); err != nil {
+			t.Fatal(err)
+		}
+		err := rs.resync(ctx, obj, info)
+		var queued []string
+		for len(rs.replicaCh) > 0 {
+			task := <-rs.replicaCh
+			if task.ResyncTarget != arn {
+				t.Fatalf("unexpected resync target %s", task.ResyncTarget)
+			}
+			queued = append(queued, task.Object)
+		}
+		sort.Strings(queued)
+		info, lerr := loadReplicationResyncInfo(ctx, obj, bucket, arn)
+		if lerr != nil {
+			t.Fatal(lerr)
+		}
+		return queued, info, err
+	}
+
+	info := madmin.ReplicationResyncInfo{Bucket: bucket, TargetArn: arn, ID: "1", Status: madmin.ReplicationResyncStarted}
+	queued, info, err := resync(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a/1", "a/2"}; !reflect.DeepEqual(queued, want) {
+		t.Fatalf("expected %v queued, got %v", want, queued)
+	}
+	if info.Status != madmin.ReplicationResyncCompleted || info.Scanned != 4 || info.Queued != 2 || info.LastObject != "b/1" {
+		t.Fatalf("unexpected resync progress %+v", info)
+	}
+
+	// An interrupted resync resumes after the last object scanned.
+	info = madmin.ReplicationResyncInfo{Bucket: bucket, TargetArn: arn, ID: "2", Status: madmin.ReplicationResyncStarted,
+		Scanned: 1, Queued: 1, LastObject: "a/1"}
+	queued, info, err = resync(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a/2"}; !reflect.DeepEqual(queued, want) {
+		t.Fatalf("expected %v queued, got %v", want, queued)
+	}
+	if info.Status != madmin.ReplicationResyncCompleted || info.Scanned != 4 || info.Queued != 2 {
+		t.Fatalf("unexpected resync progress %+v", info)
+	}
+
+	// A newer resync of the target stops the previous one.
+	if err = saveReplicationResyncInfo(ctx, obj, madmin.ReplicationResyncInfo{Bucket: bucket, TargetArn: arn, ID: "4"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = rs.resync(ctx, obj, madmin.ReplicationResyncInfo{Bucket: bucket, TargetArn: arn, ID: "3"}); err != errReplicationResyncSuperseded {
+		t.Fatalf("expected %v, got %v", errReplicationResyncSuperseded, err)
+	}
+
+	resyncs, err := rs.resyncStatus(ctx, obj, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resyncs) != 1 || resyncs[0].ID != "4" {
+		t.Fatalf("unexpected resync status %+v", resyncs)
+	}
+
+	// Versions written before replication was configured are only
+	// resynced if the rule replicates existing objects.
+	config = strings.Replace(config, "<ExistingObjectReplication><Status>Enabled", "<ExistingObjectReplication><Status>Disabled", 1)
+	if err = globalBucketMetadataSys.Update(bucket, bucketReplicationConfig, []byte(config)); err != nil {
+		t.Fatal(err)
+	}
+	for len(rs.replicaCh) > 0 {
+		// Versions queued before the resync was superseded.
+		<-rs.replicaCh
+	}
+	info = madmin.ReplicationResyncInfo{Bucket: bucket, TargetArn: arn, ID: "5", Status: madmin.ReplicationResyncStarted}
+	queued, info, err = resync(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queued) != 0 || info.Scanned != 4 || info.Queued != 0 {
+		t.Fatalf("expected no versions queued, got %v, %+v", queued, info)
+	}
+}
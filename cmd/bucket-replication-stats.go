@@ -0,0 +1,263 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// globalReplicationStats are the replications performed by this server.
+var globalReplicationStats = newReplicationStats()
+
+// lastMinuteLatency is the latency of the operations
+// of the last minute, kept in one slot per second.
+type lastMinuteLatency struct {
+	totals [60]time.Duration
+	maxes  [60]time.Duration
+	counts [60]uint64
+	last   int64 // Unix time of the last update.
+}
+
+// forward clears the slots of the seconds elapsed since the last update.
+func (l *lastMinuteLatency) forward(sec int64) {
+	if sec <= l.last {
+		return
+	}
+	if sec-l.last >= 60 {
+		*l = lastMinuteLatency{}
+	} else {
+		for t := l.last + 1; t <= sec; t++ {
+			i := t % 60
+			l.totals[i], l.maxes[i], l.counts[i] = 0, 0, 0
+		}
+	}
+	l.last = sec
+}
+
+func (l *lastMinuteLatency) add(d time.Duration, now time.Time) {
+	sec := now.Unix()
+	l.forward(sec)
+	i := sec % 60
+	l.totals[i] += d
+	l.counts[i]++
+	if d > l.maxes[i] {
+		l.maxes[i] = d
+	}
+}
+
+func (l *lastMinuteLatency) get(now time.Time) (lat madmin.ReplicationLatency) {
+	l.forward(now.Unix())
+	var total time.Duration
+	for i := range l.counts {
+		total += l.totals[i]
+		lat.Count += l.counts[i]
+		if l.maxes[i] > lat.Max {
+			lat.Max = l.maxes[i]
+		}
+	}
+	if lat.Count > 0 {
+		lat.Avg = total / time.Duration(lat.Count)
+	}
+	return lat
+}
+
+// mergeReplicationLatency returns the latency of the replications of a and b.
+func mergeReplicationLatency(a, b madmin.ReplicationLatency) madmin.ReplicationLatency {
+	count := a.Count + b.Count
+	if count == 0 {
+		return madmin.ReplicationLatency{}
+	}
+	lat := madmin.ReplicationLatency{
+		Avg:   (a.Avg*time.Duration(a.Count) + b.Avg*time.Duration(b.Count)) / time.Duration(count),
+		Max:   a.Max,
+		Count: count,
+	}
+	if b.Max > lat.Max {
+		lat.Max = b.Max
+	}
+	return lat
+}
+
+// targetReplicationStats are the replications to a
+// remote target performed by this server.
+type targetReplicationStats struct {
+	activeCount    uint64
+	activeSize     uint64
+	completedCount uint64
+	completedSize  uint64
+	failuresCount  uint64
+	failuresSize   uint64
+	latency        lastMinuteLatency
+}
+
+// replicationStats are the replications performed by this server since
+// it started, keyed by bucket and target ARN.
+type replicationStats struct {
+	mu      sync.Mutex
+	buckets map[string]map[string]*targetReplicationStats
+}
+
+func newReplicationStats() *replicationStats {
+	return &replicationStats{
+		buckets: make(map[string]map[string]*targetReplicationStats),
+	}
+}
+
+// target returns the stats of the target, s.mu must be held.
+func (s *replicationStats) target(bucket, arn string) *targetReplicationStats {
+	targets, ok := s.buckets[bucket]
+	if !ok {
+		targets = make(map[string]*targetReplicationStats)
+		s.buckets[bucket] = targets
+	}
+	t, ok := targets[arn]
+	if !ok {
+		t = &targetReplicationStats{}
+		targets[arn] = t
+	}
+	return t
+}
+
+// start records the start of a replication to the target.
+func (s *replicationStats) start(bucket, arn string, size int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.target(bucket, arn)
+	t.activeCount++
+	t.activeSize += uint64(size)
+}
+
+// done records the end of a replication to the target.
+func (s *replicationStats) done(bucket, arn string, size int64, status replication.StatusType, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.target(bucket, arn)
+	t.activeCount--
+	t.activeSize -= uint64(size)
+	if status == replication.Complete {
+		t.completedCount++
+		t.completedSize += uint64(size)
+	} else {
+		t.failuresCount++
+		t.failuresSize += uint64(size)
+	}
+	t.latency.add(d, time.Now())
+}
+
+// failed records a replication to the target which failed before
+// any data was sent.
+func (s *replicationStats) failed(bucket, arn string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.target(bucket, arn).failuresCount++
+}
+
+// bucketStats returns the replications to the targets of the bucket.
+func (s *replicationStats) bucketStats(bucket string) map[string]madmin.ReplicationTargetStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	stats := make(map[string]madmin.ReplicationTargetStats, len(s.buckets[bucket]))
+	for arn, t := range s.buckets[bucket] {
+		stats[arn] = madmin.ReplicationTargetStats{
+			Arn:            arn,
+			ActiveCount:    t.activeCount,
+			ActiveSize:     t.activeSize,
+			CompletedCount: t.completedCount,
+			CompletedSize:  t.completedSize,
+			FailuresCount:  t.failuresCount,
+			FailuresSize:   t.failuresSize,
+			Latency:        t.latency.get(now),
+		}
+	}
+	return stats
+}
+
+// bucketNames returns the buckets replicated by this server.
+func (s *replicationStats) bucketNames() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	buckets := make([]string, 0, len(s.buckets))
+	for bucket := range s.buckets {
+		buckets = append(buckets, bucket)
+	}
+	sort.Strings(buckets)
+	return buckets
+}
+
+// getBucketReplicationStats returns the replication backlog of the targets of the
+// bucket found by the last crawl, along with the replications of all servers.
+func getBucketReplicationStats(ctx context.Context, objAPI ObjectLayer, bucket string) (madmin.BucketReplicationStats, error) {
+	cfg, err := getReplicationConfig(ctx, bucket)
+	if err != nil {
+		return madmin.BucketReplicationStats{}, err
+	}
+	targets := make(map[string]madmin.ReplicationTargetStats)
+	for _, arn := range cfg.TargetArns() {
+		targets[arn] = madmin.ReplicationTargetStats{Arn: arn}
+	}
+
+	stats := madmin.BucketReplicationStats{Bucket: bucket}
+	dataUsageInfo, err := loadDataUsageFromBackend(ctx, objAPI)
+	if err != nil {
+		return madmin.BucketReplicationStats{}, err
+	}
+	if usage, ok := dataUsageInfo.BucketsUsage[bucket]; ok {
+		stats.LastCrawl = dataUsageInfo.LastUpdate
+		stats.ReplicaSize = usage.ReplicaSize
+		for arn, u := range usage.ReplicationTargets {
+			t := targets[arn]
+			t.Arn = arn
+			t.PendingSize = u.PendingSize
+			t.PendingCount = u.PendingCount
+			t.FailedSize = u.FailedSize
+			t.FailedCount = u.FailedCount
+			t.ReplicatedSize = u.ReplicatedSize
+			targets[arn] = t
+		}
+	}
+
+	servers := append(globalNotificationSys.GetReplicationStats(ctx, bucket), globalReplicationStats.bucketStats(bucket))
+	for _, server := range servers {
+		for arn, s := range server {
+			t := targets[arn]
+			t.Arn = arn
+			t.ActiveCount += s.ActiveCount
+			t.ActiveSize += s.ActiveSize
+			t.CompletedCount += s.CompletedCount
+			t.CompletedSize += s.CompletedSize
+			t.FailuresCount += s.FailuresCount
+			t.FailuresSize += s.FailuresSize
+			t.Latency = mergeReplicationLatency(t.Latency, s.Latency)
+			targets[arn] = t
+		}
+	}
+
+	for _, t := range targets {
+		stats.Targets = append(stats.Targets, t)
+	}
+	sort.Slice(stats.Targets, func(i, j int) bool {
+		return stats.Targets[i].Arn < stats.Targets[j].Arn
+	})
+	return stats, nil
+}
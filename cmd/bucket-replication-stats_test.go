@@ -0,0 +1,95 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestLastMinuteLatency(t *testing.T) {
+	var l lastMinuteLatency
+	now := time.Unix(1000, 0)
+	l.add(10*time.Millisecond, now)
+	l.add(30*time.Millisecond, now.Add(time.Second))
+	l.add(50*time.Millisecond, now.Add(59*time.Second))
+
+	lat := l.get(now.Add(59 * time.Second))
+	if lat.Count != 3 || lat.Avg != 30*time.Millisecond || lat.Max != 50*time.Millisecond {
+		t.Fatalf("unexpected latency %+v", lat)
+	}
+	// The first operation is out of the window.
+	lat = l.get(now.Add(60 * time.Second))
+	if lat.Count != 2 || lat.Avg != 40*time.Millisecond || lat.Max != 50*time.Millisecond {
+		t.Fatalf("unexpected latency %+v", lat)
+	}
+	// All operations are out of the window.
+	lat = l.get(now.Add(5 * time.Minute))
+	if lat != (madmin.ReplicationLatency{}) {
+		t.Fatalf("unexpected latency %+v", lat)
+	}
+}
+
+func TestReplicationStats(t *testing.T) {
+	s := newReplicationStats()
+	s.start("bucket", "arn1", 100)
+	s.start("bucket", "arn1", 200)
+	s.start("bucket", "arn2", 100)
+
+	stats := s.bucketStats("bucket")
+	if st := stats["arn1"]; st.ActiveCount != 2 || st.ActiveSize != 300 {
+		t.Fatalf("unexpected stats %+v", st)
+	}
+
+	s.done("bucket", "arn1", 100, replication.Complete, 10*time.Millisecond)
+	s.done("bucket", "arn1", 200, replication.Failed, 30*time.Millisecond)
+	s.done("bucket", "arn2", 100, replication.Complete, time.Millisecond)
+
+	stats = s.bucketStats("bucket")
+	st := stats["arn1"]
+	if st.ActiveCount != 0 || st.ActiveSize != 0 {
+		t.Fatalf("unexpected active replications %+v", st)
+	}
+	if st.CompletedCount != 1 || st.CompletedSize != 100 || st.FailuresCount != 1 || st.FailuresSize != 200 {
+		t.Fatalf("unexpected replications %+v", st)
+	}
+	if st.Latency.Count != 2 || st.Latency.Avg != 20*time.Millisecond || st.Latency.Max != 30*time.Millisecond {
+		t.Fatalf("unexpected latency %+v", st.Latency)
+	}
+	if st := stats["arn2"]; st.CompletedCount != 1 || st.CompletedSize != 100 {
+		t.Fatalf("unexpected stats %+v", st)
+	}
+	s.failed("bucket", "arn2")
+	if st := s.bucketStats("bucket")["arn2"]; st.FailuresCount != 1 || st.FailuresSize != 0 || st.ActiveCount != 0 {
+		t.Fatalf("unexpected stats %+v", st)
+	}
+	if len(s.bucketStats("other")) != 0 {
+		t.Fatal("expected no stats for bucket without replications")
+	}
+
+	lat := mergeReplicationLatency(st.Latency, madmin.ReplicationLatency{
+		Avg:   50 * time.Millisecond,
+		Max:   50 * time.Millisecond,
+		Count: 2,
+	})
+	if lat.Count != 4 || lat.Avg != 35*time.Millisecond || lat.Max != 50*time.Millisecond {
+		t.Fatalf("unexpected merged latency %+v", lat)
+	}
+}
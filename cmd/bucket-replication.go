@@ -19,9 +19,12 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	minio "github.com/minio/minio-go/v7"
@@ -53,32 +56,54 @@ func getReplicationConfig(ctx context.Context, bucketName string) (rc *replicati
 }
 
 // validateReplicationDestination returns error if replication destination bucket missing or not configured
-// It also returns true if replication destination is same as this server.
+// It also returns true if the destination of a rule is the source bucket on this server.
 func validateReplicationDestination(ctx context.Context, bucket string, rCfg *replication.Config) (bool, error) {
-	arn, err := madmin.ParseARN(rCfg.RoleArn)
+	var sameTarget bool
+	validated := make(map[string]struct{})
+	for _, rule := range rCfg.Rules {
+		arnStr := rCfg.RuleTargetArn(rule)
+		if _, ok := validated[arnStr]; ok {
+			continue
+		}
+		validated[arnStr] = struct{}{}
+		same, err := validateReplicationTarget(ctx, bucket, arnStr, rule.Destination.Bucket)
+		if err != nil {
+			return false, err
+		}
+		if same && rule.Destination.Bucket == bucket {
+			sameTarget = true
+		}
+	}
+	return sameTarget, nil
+}
+
+// validateReplicationTarget returns error if the remote target or its destination bucket is
+// missing or not configured, it also returns true if the target is this server.
+func validateReplicationTarget(ctx context.Context, bucket, arnStr, destBucket string) (bool, error) {
+	arn, err := madmin.ParseARN(arnStr)
 	if err != nil {
 		return false, BucketRemoteArnInvalid{}
 	}
 	if arn.Type != madmin.ReplicationService {
 		return false, BucketRemoteArnTypeInvalid{}
 	}
-	clnt := globalBucketTargetSys.GetRemoteTargetClient(ctx, rCfg.RoleArn)
+	clnt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arnStr)
 	if clnt == nil {
 		return false, BucketRemoteTargetNotFound{Bucket: bucket}
 	}
-	if found, _ := clnt.BucketExists(ctx, rCfg.GetDestination().Bucket); !found {
-		return false, BucketRemoteDestinationNotFound{Bucket: rCfg.GetDestination().Bucket}
+	if found, _ := clnt.BucketExists(ctx, destBucket); !found {
+		return false, BucketRemoteDestinationNotFound{Bucket: destBucket}
 	}
 	if ret, err := globalBucketObjectLockSys.Get(bucket); err == nil {
 		if ret.LockEnabled {
-			lock, _, _, _, err := clnt.GetObjectLockConfig(ctx, rCfg.GetDestination().Bucket)
+			lock, _, _, _, err := clnt.GetObjectLockConfig(ctx, destBucket)
 			if err != nil || lock != "Enabled" {
-				return false, BucketReplicationDestinationMissingLock{Bucket: rCfg.GetDestination().Bucket}
+				return false, BucketReplicationDestinationMissingLock{Bucket: destBucket}
 			}
 		}
 	}
 	// validate replication ARN against target endpoint
-	c, ok := globalBucketTargetSys.arnRemotesMap[rCfg.RoleArn]
+	c, ok := globalBucketTargetSys.arnRemotesMap[arnStr]
 	if ok {
 		if c.EndpointURL().String() == clnt.EndpointURL().String() {
 			sameTarget, _ := isLocalHost(clnt.EndpointURL().Hostname(), clnt.EndpointURL().Port(), globalMinioPort)
@@ -188,38 +213,58 @@ func replicateDelete(ctx context.Context, dobj DeletedObjectVersionInfo, objectA
 	if err != nil || rcfg == nil {
 		return
 	}
-	tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, rcfg.RoleArn)
-	if tgt == nil {
+	opts := replication.ObjectOpts{
+		Name:         dobj.ObjectName,
+		DeleteMarker: true,
+		VersionID:    dobj.VersionID,
+	}
+	arns := rcfg.FilterTargetArns(opts)
+	if len(arns) == 0 {
+		return
+	}
+	if dobj.ResyncTarget != "" && !contains(arns, dobj.ResyncTarget) {
 		return
 	}
 	versionID := dobj.DeleteMarkerVersionID
 	if versionID == "" {
 		versionID = dobj.VersionID
 	}
-	rmErr := tgt.RemoveObject(ctx, rcfg.GetDestination().Bucket, dobj.ObjectName, miniogo.RemoveObjectOptions{
-		VersionID: versionID,
-		Internal: miniogo.AdvancedRemoveOptions{
-			ReplicationDeleteMarker: dobj.DeleteMarkerVersionID != "",
-			ReplicationMTime:        dobj.DeleteMarkerMTime.Time,
-			ReplicationStatus:       miniogo.ReplicationStatusReplica,
-		},
-	})
 
-	replicationStatus := dobj.DeleteMarkerReplicationStatus
-	versionPurgeStatus := dobj.VersionPurgeStatus
+	// The status of the delete on each target is kept with the delete
+	// marker or the version being purged, the delete is retried on the
+	// targets it failed on only.
+	status := replication.StatusType(dobj.DeleteMarkerReplicationStatus)
+	if dobj.VersionID != "" {
+		status = replication.StatusType(dobj.VersionPurgeStatus)
+	}
+	statuses := getDeleteReplicationStatus(ctx, objectAPI, bucket, dobj.ObjectName, versionID)
+	if len(statuses) == 0 && (status == replication.Complete || status == replication.Failed) {
+		// Replicated before the status of each target was tracked.
+		for _, arn := range arns {
+			statuses[arn] = status
+		}
+	}
 
-	if rmErr != nil {
-		if dobj.VersionID == "" {
-			replicationStatus = string(replication.Failed)
-		} else {
-			versionPurgeStatus = Failed
+	for _, arn := range arns {
+		if dobj.ResyncTarget != "" {
+			// Resynced deletes are replicated again to the target
+			// being resynced only, whatever their status on it.
+			if arn != dobj.ResyncTarget {
+				continue
+			}
+		} else if statuses[arn] == replication.Complete {
+			continue
 		}
+		statuses[arn] = replicateDeleteToTarget(ctx, dobj, rcfg, opts, arn, versionID)
+	}
+
+	replicationStatus := dobj.DeleteMarkerReplicationStatus
+	versionPurgeStatus := dobj.VersionPurgeStatus
+	status = aggregateReplicationStatus(statuses, arns)
+	if dobj.VersionID == "" {
+		replicationStatus = string(status)
 	} else {
-		if dobj.VersionID == "" {
-			replicationStatus = string(replication.Complete)
-		} else {
-			versionPurgeStatus = Complete
-		}
+		versionPurgeStatus = VersionPurgeStatusType(status)
 	}
 	var eventName = event.ObjectReplicationComplete
 	if replicationStatus == string(replication.Failed) || versionPurgeStatus == Failed {
@@ -249,11 +294,56 @@ func replicateDelete(ctx context.Context, dobj DeletedObjectVersionInfo, objectA
 		Versioned:                     globalBucketVersioningSys.Enabled(bucket),
 		VersionPurgeStatus:            versionPurgeStatus,
 		VersionSuspended:              globalBucketVersioningSys.Suspended(bucket),
+		TargetReplicationStatus:       targetReplicationStatusString(statuses),
 	}); err != nil {
 		logger.LogIf(ctx, fmt.Errorf("Unable to update replication metadata for %s/%s %s: %w", bucket, dobj.ObjectName, dobj.VersionID, err))
 	}
 }
 
+// getDeleteReplicationStatus returns the replication status on each target
+// of the delete marker or of the version being purged.
+func getDeleteReplicationStatus(ctx context.Context, objectAPI ObjectLayer, bucket, object, versionID string) map[string]replication.StatusType {
+	// Delete markers and versions being purged are returned
+	// along with a MethodNotAllowed error.
+	oi, _ := objectAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: versionID})
+	return parseTargetReplicationStatus(oi.UserDefined[targetReplicationStatusKey])
+}
+
+// replicateDeleteToTarget replicates the delete to the destination bucket
+// of a remote target and returns the replication status on the target.
+func replicateDeleteToTarget(ctx context.Context, dobj DeletedObjectVersionInfo, rcfg *replication.Config, opts replication.ObjectOpts, arn, versionID string) replication.StatusType {
+	bucket := dobj.Bucket
+	globalReplicationStats.start(bucket, arn, 0)
+	start := time.Now()
+	tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn)
+	if tgt == nil {
+		logger.LogIf(ctx, fmt.Errorf("failed to get target for bucket:%s arn:%s", bucket, arn))
+		globalReplicationStats.done(bucket, arn, 0, replication.Failed, time.Since(start))
+		return replication.Failed
+	}
+	opts.TargetArn = arn
+	rules := rcfg.FilterActionableRules(opts)
+	if len(rules) == 0 {
+		logger.LogIf(ctx, fmt.Errorf("no replication rule found for bucket:%s arn:%s", bucket, arn))
+		globalReplicationStats.done(bucket, arn, 0, replication.Failed, time.Since(start))
+		return replication.Failed
+	}
+	dest := rules[0].Destination
+	status := replication.Complete
+	if err := tgt.RemoveObject(ctx, dest.Bucket, dobj.ObjectName, miniogo.RemoveObjectOptions{
+		VersionID: versionID,
+		Internal: miniogo.AdvancedRemoveOptions{
+			ReplicationDeleteMarker: dobj.DeleteMarkerVersionID != "",
+			ReplicationMTime:        dobj.DeleteMarkerMTime.Time,
+			ReplicationStatus:       miniogo.ReplicationStatusReplica,
+		},
+	}); err != nil {
+		status = replication.Failed
+	}
+	globalReplicationStats.done(bucket, arn, 0, status, time.Since(start))
+	return status
+}
+
 func getCopyObjMetadata(oi ObjectInfo, dest replication.Destination) map[string]string {
 	meta := make(map[string]string, len(oi.UserDefined))
 	for k, v := range oi.UserDefined {
@@ -393,9 +483,58 @@ func getReplicationAction(oi1 ObjectInfo, oi2 minio.ObjectInfo) replicationActio
 	return replicateNone
 }
 
-// replicateObject replicates the specified version of the object to destination bucket
-// The source object is then updated to reflect the replication status.
-func replicateObject(ctx context.Context, objInfo ObjectInfo, objectAPI ObjectLayer) {
+// targetReplicationStatusKey holds the replication status of an object on
+// each of its remote targets as "arn1=COMPLETE;arn2=FAILED;", the status on
+// all targets is kept in X-Amz-Replication-Status.
+const targetReplicationStatusKey = ReservedMetadataPrefixLower + "replication-status"
+
+// parseTargetReplicationStatus returns the replication status of each target.
+func parseTargetReplicationStatus(s string) map[string]replication.StatusType {
+	statuses := make(map[string]replication.StatusType)
+	for _, kv := range strings.Split(s, ";") {
+		i := strings.LastIndex(kv, "=")
+		if i < 0 {
+			continue
+		}
+		statuses[kv[:i]] = replication.StatusType(kv[i+1:])
+	}
+	return statuses
+}
+
+// targetReplicationStatusString encodes the replication status of each target.
+func targetReplicationStatusString(statuses map[string]replication.StatusType) string {
+	arns := make([]string, 0, len(statuses))
+	for arn := range statuses {
+		arns = append(arns, arn)
+	}
+	sort.Strings(arns)
+	var sb strings.Builder
+	for _, arn := range arns {
+		sb.WriteString(arn + "=" + statuses[arn].String() + ";")
+	}
+	return sb.String()
+}
+
+// aggregateReplicationStatus returns the replication status of an object on
+// all the given targets, replication failed if it failed on any target.
+func aggregateReplicationStatus(statuses map[string]replication.StatusType, arns []string) replication.StatusType {
+	status := replication.Complete
+	for _, arn := range arns {
+		switch statuses[arn] {
+		case replication.Failed:
+			return replication.Failed
+		case replication.Complete:
+		default:
+			status = replication.Pending
+		}
+	}
+	return status
+}
+
+// replicateObject replicates the specified version of the object to the destination bucket
+// of each of its remote targets, targets the version was already replicated to are skipped.
+// The source object is then updated to reflect the replication status on each target.
+func replicateObject(ctx context.Context, objInfo ObjectInfo, objectAPI ObjectLayer, resyncTarget string) {
 	bucket := objInfo.Bucket
 	object := objInfo.Name
 
@@ -404,29 +543,125 @@ func replicateObject(ctx context.Context, objInfo ObjectInfo, objectAPI ObjectLa
 		logger.LogIf(ctx, err)
 		return
 	}
-	tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, cfg.RoleArn)
-	if tgt == nil {
-		logger.LogIf(ctx, fmt.Errorf("failed to get target for bucket:%s arn:%s", bucket, cfg.RoleArn))
-		return
-	}
-	gr, err := objectAPI.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, readLock, ObjectOptions{
+	objInfo, err = objectAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{
 		VersionID: objInfo.VersionID,
 	})
 	if err != nil {
 		return
 	}
-	objInfo = gr.ObjInfo
-	size, err := objInfo.GetActualSize()
-	if err != nil {
-		logger.LogIf(ctx, err)
-		gr.Close()
+	if objInfo.ReplicationStatus == replication.Replica {
+		return
+	}
+	opts := replication.ObjectOpts{
+		Name:     object,
+		SSEC:     crypto.SSEC.IsEncrypted(objInfo.UserDefined),
+		UserTags: objInfo.UserTags,
+		// Versions without a status were written before replication was configured.
+		ExistingObject: objInfo.ReplicationStatus.Empty(),
+	}
+	arns := cfg.FilterTargetArns(opts)
+	if len(arns) == 0 {
 		return
 	}
+	if resyncTarget != "" && !contains(arns, resyncTarget) {
+		return
+	}
+
+	statuses := parseTargetReplicationStatus(objInfo.UserDefined[targetReplicationStatusKey])
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for _, arn := range arns {
+		if resyncTarget != "" {
+			// Resynced objects are replicated again to the target
+			// being resynced only, whatever their status on it.
+			if arn != resyncTarget {
+				continue
+			}
+		} else if statuses[arn] == replication.Complete {
+			continue
+		}
+		opts.TargetArn = arn
+		rules := cfg.FilterActionableRules(opts)
+		if len(rules) == 0 {
+			logger.LogIf(ctx, fmt.Errorf("no replication rule found for bucket:%s arn:%s", bucket, arn))
+			continue
+		}
+		dest := rules[0].Destination
+		wg.Add(1)
+		go func(arn string, dest replication.Destination) {
+			defer wg.Done()
+			status := replicateObjectToTarget(ctx, objInfo, objectAPI, arn, dest)
+			mu.Lock()
+			statuses[arn] = status
+			mu.Unlock()
+		}(arn, dest)
+	}
+	wg.Wait()
+
+	replicationStatus := aggregateReplicationStatus(statuses, arns)
+	objInfo.UserDefined[targetReplicationStatusKey] = targetReplicationStatusString(statuses)
+	objInfo.UserDefined[xhttp.AmzBucketReplicationStatus] = replicationStatus.String()
+	if objInfo.UserTags != "" {
+		objInfo.UserDefined[xhttp.AmzObjectTagging] = objInfo.UserTags
+	}
+
+	// FIXME: add support for missing replication events
+	// - event.ObjectReplicationNotTracked
+	// - event.ObjectReplicationMissedThreshold
+	// - event.ObjectReplicationReplicatedAfterThreshold
+	var eventName = event.ObjectReplicationComplete
+	if replicationStatus == replication.Failed {
+		eventName = event.ObjectReplicationFailed
+	}
+	sendEvent(eventArgs{
+		EventName:  eventName,
+		BucketName: bucket,
+		Object:     objInfo,
+		Host:       "Internal: [Replication]",
+	})
+	objInfo.metadataOnly = true // Perform only metadata updates.
+	if _, err = objectAPI.CopyObject(ctx, bucket, object, bucket, object, objInfo, ObjectOptions{
+		VersionID: objInfo.VersionID,
+	}, ObjectOptions{
+		VersionID: objInfo.VersionID,
+	}); err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to update replication metadata for %s: %s", objInfo.VersionID, err))
+	}
+}
+
+// mustReplicateExisting returns true if the object version was written
+// before a rule replicating existing objects to any target was added.
+func mustReplicateExisting(ctx context.Context, oi ObjectInfo) bool {
+	if oi.DeleteMarker || !oi.ReplicationStatus.Empty() {
+		return false
+	}
+	cfg, err := getReplicationConfig(ctx, oi.Bucket)
+	if err != nil || cfg == nil {
+		return false
+	}
+	return len(cfg.FilterTargetArns(replication.ObjectOpts{
+		Name:           oi.Name,
+		SSEC:           crypto.SSEC.IsEncrypted(oi.UserDefined),
+		UserTags:       oi.UserTags,
+		ExistingObject: true,
+	})) > 0
+}
 
-	dest := cfg.GetDestination()
+// replicateObjectToTarget replicates the specified version of the object to the destination
+// bucket of a remote target and returns the replication status on the target.
+func replicateObjectToTarget(ctx context.Context, objInfo ObjectInfo, objectAPI ObjectLayer, arn string, dest replication.Destination) (status replication.StatusType) {
+	bucket := objInfo.Bucket
+	object := objInfo.Name
+
+	tgt := globalBucketTargetSys.GetRemoteTargetClient(ctx, arn)
+	if tgt == nil {
+		logger.LogIf(ctx, fmt.Errorf("failed to get target for bucket:%s arn:%s", bucket, arn))
+		globalReplicationStats.failed(bucket, arn)
+		return replication.Failed
+	}
 	if dest.Bucket == "" {
-		gr.Close()
-		return
+		globalReplicationStats.failed(bucket, arn)
+		return replication.Failed
 	}
 
 	rtype := replicateAll
@@ -434,20 +669,52 @@ func replicateObject(ctx context.Context, objInfo ObjectInfo, objectAPI ObjectLa
 	if err == nil {
 		rtype = getReplicationAction(objInfo, oi)
 		if rtype == replicateNone {
-			gr.Close()
 			// object with same VersionID already exists, replication kicked off by
 			// PutObject might have completed.
-			return
+			return replication.Complete
 		}
 	}
 
-	target, err := globalBucketMetadataSys.GetBucketTarget(bucket, cfg.RoleArn)
+	target, err := globalBucketMetadataSys.GetBucketTarget(bucket, arn)
 	if err != nil {
-		logger.LogIf(ctx, fmt.Errorf("failed to get target for replication bucket:%s cfg:%s err:%s", bucket, cfg.RoleArn, err))
-		return
+		logger.LogIf(ctx, fmt.Errorf("failed to get target for replication bucket:%s arn:%s err:%s", bucket, arn, err))
+		globalReplicationStats.failed(bucket, arn)
+		return replication.Failed
+	}
+
+	// Only the metadata is sent when the data is already on the target.
+	sentSize := objInfo.Size
+	if rtype != replicateAll {
+		sentSize = 0
+	}
+	globalReplicationStats.start(bucket, arn, sentSize)
+	startTime := time.Now()
+	defer func() {
+		globalReplicationStats.done(bucket, arn, sentSize, status, time.Since(startTime))
+	}()
+
+	if rtype != replicateAll {
+		// replicate metadata for object tagging/copy with metadata replacement
+		dstOpts := miniogo.PutObjectOptions{Internal: miniogo.AdvancedPutOptions{SourceVersionID: objInfo.VersionID}}
+		if _, err = tgt.CopyObject(ctx, dest.Bucket, object, dest.Bucket, object, getCopyObjMetadata(objInfo, dest), dstOpts); err != nil {
+			return replication.Failed
+		}
+		return replication.Complete
+	}
+
+	gr, err := objectAPI.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, readLock, ObjectOptions{
+		VersionID: objInfo.VersionID,
+	})
+	if err != nil {
+		return replication.Failed
+	}
+	size, err := gr.ObjInfo.GetActualSize()
+	if err != nil {
+		logger.LogIf(ctx, err)
+		gr.Close()
+		return replication.Failed
 	}
 	putOpts := putReplicationOpts(ctx, dest, objInfo)
-	replicationStatus := replication.Complete
 
 	// Setup bandwidth throttling
 	peers, _ := globalEndpoints.peers()
@@ -461,45 +728,73 @@ func replicateObject(ctx context.Context, objInfo ObjectInfo, objectAPI ObjectLa
 		headerSize += len(k) + len(v)
 	}
 	r := bandwidth.NewMonitoredReader(ctx, globalBucketMonitor, objInfo.Bucket, objInfo.Name, gr, headerSize, b, target.BandwidthLimit)
-	if rtype == replicateAll {
-		_, err = tgt.PutObject(ctx, dest.Bucket, object, r, size, "", "", putOpts)
+	if isMultipartObject(gr.ObjInfo) {
+		// Reproduce the parts of the source version so that the
+		// ETag of the replica matches the ETag of the source.
+		err = replicateObjectWithMultipart(ctx, tgt, dest.Bucket, object, r, gr.ObjInfo, putOpts)
 	} else {
-		// replicate metadata for object tagging/copy with metadata replacement
-		dstOpts := miniogo.PutObjectOptions{Internal: miniogo.AdvancedPutOptions{SourceVersionID: objInfo.VersionID}}
-		_, err = tgt.CopyObject(ctx, dest.Bucket, object, dest.Bucket, object, getCopyObjMetadata(objInfo, dest), dstOpts)
+		_, err = tgt.PutObject(ctx, dest.Bucket, object, r, size, "", "", putOpts)
 	}
-
 	r.Close()
 	if err != nil {
-		replicationStatus = replication.Failed
+		return replication.Failed
 	}
-	objInfo.UserDefined[xhttp.AmzBucketReplicationStatus] = replicationStatus.String()
-	if objInfo.UserTags != "" {
-		objInfo.UserDefined[xhttp.AmzObjectTagging] = objInfo.UserTags
+	return replication.Complete
+}
+
+// isMultipartObject returns true if the object was uploaded in parts.
+func isMultipartObject(objInfo ObjectInfo) bool {
+	if len(objInfo.Parts) == 0 {
+		return false
 	}
+	return isEncryptedMultipart(objInfo) || strings.Contains(objInfo.ETag, "-")
+}
 
-	// FIXME: add support for missing replication events
-	// - event.ObjectReplicationNotTracked
-	// - event.ObjectReplicationMissedThreshold
-	// - event.ObjectReplicationReplicatedAfterThreshold
-	var eventName = event.ObjectReplicationComplete
-	if replicationStatus == replication.Failed {
-		eventName = event.ObjectReplicationFailed
+// replicateObjectWithMultipart uploads the object read from r to the remote target
+// in parts with the same numbers and sizes as the parts of the source version.
+func replicateObjectWithMultipart(ctx context.Context, c *miniogo.Core, bucket, object string, r io.Reader, objInfo ObjectInfo, opts miniogo.PutObjectOptions) (err error) {
+	// The version ID of the source cannot be set on the initiation of
+	// the upload, it is sent as metadata instead.
+	meta := make(map[string]string, len(opts.UserMetadata)+1)
+	for k, v := range opts.UserMetadata {
+		meta[k] = v
 	}
-	sendEvent(eventArgs{
-		EventName:  eventName,
-		BucketName: bucket,
-		Object:     objInfo,
-		Host:       "Internal: [Replication]",
-	})
-	objInfo.metadataOnly = true // Perform only metadata updates.
-	if _, err = objectAPI.CopyObject(ctx, bucket, object, bucket, object, objInfo, ObjectOptions{
-		VersionID: objInfo.VersionID,
-	}, ObjectOptions{
-		VersionID: objInfo.VersionID,
-	}); err != nil {
-		logger.LogIf(ctx, fmt.Errorf("Unable to update replication metadata for %s: %s", objInfo.VersionID, err))
+	meta[xhttp.MinIOSourceVersionID] = objInfo.VersionID
+	opts.UserMetadata = meta
+
+	uploadID, err := c.NewMultipartUpload(ctx, bucket, object, opts)
+	if err != nil {
+		return err
 	}
+	defer func() {
+		if err != nil {
+			if aerr := c.AbortMultipartUpload(ctx, bucket, object, uploadID); aerr != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to abort the multipart upload %s of %s/%s on the remote target: %w", uploadID, bucket, object, aerr))
+			}
+		}
+	}()
+
+	parts := make([]miniogo.CompletePart, 0, len(objInfo.Parts))
+	for _, part := range objInfo.Parts {
+		// Parts are sent as they were uploaded, before compression or encryption.
+		size := part.ActualSize
+		if size <= 0 {
+			size = part.Size
+		}
+		pinfo, err := c.PutObjectPart(ctx, bucket, object, uploadID, part.Number, io.LimitReader(r, size), size, "", "", nil)
+		if err != nil {
+			return err
+		}
+		if pinfo.Size != size {
+			return fmt.Errorf("Part %d size mismatch, expected %d, got %d", part.Number, size, pinfo.Size)
+		}
+		parts = append(parts, miniogo.CompletePart{
+			PartNumber: pinfo.PartNumber,
+			ETag:       pinfo.ETag,
+		})
+	}
+	_, err = c.CompleteMultipartUpload(ctx, bucket, object, uploadID, parts)
+	return err
 }
 
 // filterReplicationStatusMetadata filters replication status metadata for COPY
@@ -522,6 +817,7 @@ func filterReplicationStatusMetadata(metadata map[string]string) map[string]stri
 	}
 
 	delKey(xhttp.AmzBucketReplicationStatus)
+	delKey(targetReplicationStatusKey)
 	return dst
 }
 
@@ -529,20 +825,56 @@ func filterReplicationStatusMetadata(metadata map[string]string) map[string]stri
 type DeletedObjectVersionInfo struct {
 	DeletedObject
 	Bucket string
+
+	// Set when a target is resynced, the delete is
+	// replicated again to this target only.
+	ResyncTarget string
 }
 type replicationState struct {
 	// add future metrics here
-	replicaCh       chan ObjectInfo
+	replicaCh       chan replicationTask
 	replicaDeleteCh chan DeletedObjectVersionInfo
+
+	// journal records the queued tasks on the local drives,
+	// tasks are only queued in memory when not set.
+	journal    *replicationJournal
+	resumeOnce sync.Once
+
+	// Resyncs of replication targets running on this server.
+	resyncMu sync.Mutex
+	resyncs  map[string]replicationResyncJob
 }
 
 func (r *replicationState) queueReplicaTask(oi ObjectInfo) {
 	if r == nil {
 		return
 	}
+	t := newObjectReplicationTask(oi)
+	if r.journal == nil {
+		r.sendObject(t)
+		return
+	}
+	if r.journal.add(t) && !r.sendObject(t) {
+		r.journal.notQueued(t)
+	}
+}
+
+// queueReplicaResyncTask queues the replication of an object version to
+// the target being resynced, it waits until there is room in the queue.
+func (r *replicationState) queueReplicaResyncTask(ctx context.Context, oi ObjectInfo, arn string) error {
+	t := newObjectReplicationTask(oi)
+	t.ResyncTarget = arn
+	if r.journal != nil && !r.journal.add(t) {
+		return nil
+	}
 	select {
-	case r.replicaCh <- oi:
-	default:
+	case r.replicaCh <- t:
+		return nil
+	case <-ctx.Done():
+		if r.journal != nil {
+			r.journal.notQueued(t)
+		}
+		return ctx.Err()
 	}
 }
 
@@ -550,9 +882,35 @@ func (r *replicationState) queueReplicaDeleteTask(doi DeletedObjectVersionInfo)
 	if r == nil {
 		return
 	}
+	if r.journal == nil {
+		r.sendDelete(doi)
+		return
+	}
+	t := newDeleteReplicationTask(doi)
+	if r.journal.add(t) && !r.sendDelete(doi) {
+		r.journal.notQueued(t)
+	}
+}
+
+// sendObject queues the replication of an object version
+// in memory, false is returned if the queue is full.
+func (r *replicationState) sendObject(t replicationTask) bool {
+	select {
+	case r.replicaCh <- t:
+		return true
+	default:
+		return false
+	}
+}
+
+// sendDelete queues the replication of a delete
+// in memory, false is returned if the queue is full.
+func (r *replicationState) sendDelete(doi DeletedObjectVersionInfo) bool {
 	select {
 	case r.replicaDeleteCh <- doi:
+		return true
 	default:
+		return false
 	}
 }
 
@@ -572,8 +930,9 @@ func newReplicationState() *replicationState {
 		globalReplicationConcurrent = 1
 	}
 	rs := &replicationState{
-		replicaCh:       make(chan ObjectInfo, 10000),
+		replicaCh:       make(chan replicationTask, 10000),
 		replicaDeleteCh: make(chan DeletedObjectVersionInfo, 10000),
+		resyncs:         make(map[string]replicationResyncJob),
 	}
 	go func() {
 		<-GlobalContext.Done()
@@ -591,16 +950,22 @@ func (r *replicationState) addWorker(ctx context.Context, objectAPI ObjectLayer)
 			select {
 			case <-ctx.Done():
 				return
-			case oi, ok := <-r.replicaCh:
+			case t, ok := <-r.replicaCh:
 				if !ok {
 					return
 				}
-				replicateObject(ctx, oi, objectAPI)
+				replicateObject(ctx, t.objectInfo(), objectAPI, t.ResyncTarget)
+				if r.journal != nil {
+					r.journal.done(t)
+				}
 			case doi, ok := <-r.replicaDeleteCh:
 				if !ok {
 					return
 				}
 				replicateDelete(ctx, doi, objectAPI)
+				if r.journal != nil {
+					r.journal.done(newDeleteReplicationTask(doi))
+				}
 			}
 		}
 	}()
@@ -611,6 +976,10 @@ func initBackgroundReplication(ctx context.Context, objectAPI ObjectLayer) {
 		return
 	}
 
+	// Load the tasks pending when the server stopped, they are
+	// queued again once the bucket sub-systems are initialized.
+	globalReplicationState.startJournal(ctx, localDrivePaths(globalEndpoints))
+
 	// Start with globalReplicationConcurrent.
 	for i := 0; i < globalReplicationConcurrent; i++ {
 		globalReplicationState.addWorker(ctx, objectAPI)
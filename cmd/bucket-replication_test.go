@@ -0,0 +1,231 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/bucket/replication"
+)
+
+func TestTargetReplicationStatus(t *testing.T) {
+	const (
+		arn1 = "arn:minio:replication::id1:dest1"
+		arn2 = "arn:minio:replication::id2:dest2"
+	)
+	statuses := map[string]replication.StatusType{
+		arn2: replication.Failed,
+		arn1: replication.Complete,
+	}
+	s := targetReplicationStatusString(statuses)
+	if want := arn1 + "=COMPLETE;" + arn2 + "=FAILED;"; s != want {
+		t.Fatalf("expected %s, got %s", want, s)
+	}
+	if got := parseTargetReplicationStatus(s); !reflect.DeepEqual(got, statuses) {
+		t.Fatalf("expected %v, got %v", statuses, got)
+	}
+
+	testCases := []struct {
+		statuses map[string]replication.StatusType
+		status   replication.StatusType
+	}{
+		{map[string]replication.StatusType{arn1: replication.Complete, arn2: replication.Complete}, replication.Complete},
+		// A failed target fails the replication, the other targets keep their status.
+		{map[string]replication.StatusType{arn1: replication.Complete, arn2: replication.Failed}, replication.Failed},
+		// Targets without a status have not been replicated to yet.
+		{map[string]replication.StatusType{arn1: replication.Complete}, replication.Pending},
+	}
+	for i, tc := range testCases {
+		if status := aggregateReplicationStatus(tc.statuses, []string{arn1, arn2}); status != tc.status {
+			t.Errorf("test %d: expected %s, got %s", i+1, tc.status, status)
+		}
+	}
+}
+
+func TestReplicateObjectWithMultipart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The test server changes the globals, they are reset once it is stopped.
+	restoreHost, restorePort, restoreAddr := globalMinioHost, globalMinioPort, globalMinioAddr
+	defer func() {
+		resetTestGlobals()
+		globalMinioHost, globalMinioPort, globalMinioAddr = restoreHost, restorePort, restoreAddr
+	}()
+
+	// Versioning is only supported in erasure mode.
+	globalIsErasure = true
+
+	ts := StartTestServer(t, ErasureTestStr)
+	defer ts.Stop()
+
+	u, err := url.Parse(ts.Server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := miniogo.NewCore(u.Host, &miniogo.Options{
+		Creds: credentials.NewStaticV4(ts.AccessKey, ts.SecretKey, ""),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const bucket, object = "target", "object"
+	if err = c.MakeBucket(ctx, bucket, miniogo.MakeBucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.EnableVersioning(ctx, bucket); err != nil {
+		t.Fatal(err)
+	}
+
+	// Source version uploaded in two parts.
+	parts := [][]byte{
+		bytes.Repeat([]byte("a"), 5*humanize.MiByte),
+		bytes.Repeat([]byte("b"), humanize.MiByte),
+	}
+	src := ObjectInfo{
+		Bucket:      "source",
+		Name:        object,
+		VersionID:   mustGetUUID(),
+		ModTime:     time.Unix(1600000000, 123456789).UTC(),
+		UserDefined: map[string]string{},
+	}
+	var completeParts []CompletePart
+	for i, data := range parts {
+		src.Parts = append(src.Parts, ObjectPartInfo{
+			Number:     i + 1,
+			Size:       int64(len(data)),
+			ActualSize: int64(len(data)),
+		})
+		completeParts = append(completeParts, CompletePart{PartNumber: i + 1, ETag: getMD5Hash(data)})
+	}
+	src.ETag = getCompleteMultipartMD5(completeParts)
+	if !isMultipartObject(src) {
+		t.Fatal("expected a multipart object")
+	}
+
+	opts := putReplicationOpts(ctx, replication.Destination{Bucket: bucket}, src)
+	r := bytes.NewReader(bytes.Join(parts, nil))
+	if err = replicateObjectWithMultipart(ctx, c, bucket, object, r, src, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	oi, err := ts.Obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: src.VersionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi.ETag != src.ETag {
+		t.Errorf("expected ETag %s, got %s", src.ETag, oi.ETag)
+	}
+	if !oi.ModTime.Equal(src.ModTime) {
+		t.Errorf("expected modification time %s, got %s", src.ModTime, oi.ModTime)
+	}
+	if oi.ReplicationStatus != replication.Replica {
+		t.Errorf("expected replication status %s, got %s", replication.Replica, oi.ReplicationStatus)
+	}
+	if len(oi.Parts) != len(src.Parts) {
+		t.Fatalf("expected %d parts, got %d", len(src.Parts), len(oi.Parts))
+	}
+	for i, part := range oi.Parts {
+		if part.Number != src.Parts[i].Number || part.ActualSize != src.Parts[i].ActualSize {
+			t.Errorf("expected part %+v, got %+v", src.Parts[i], part)
+		}
+	}
+	if _, ok := oi.UserDefined[multipartReplicaMTimeKey]; ok {
+		t.Error("expected the replica modification time to be removed on completion")
+	}
+	if _, ok := oi.UserDefined[xhttp.AmzMetaMinIOSourceVersionID]; ok {
+		t.Error("expected the source version ID not to be stored as metadata")
+	}
+}
+
+func TestDeleteReplicationStatus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z := prepareErasurePools(ctx, t, 1)
+	const bucket, object = "bucket", "object"
+	if err := z.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("data")
+	oi, err := z.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const (
+		arn1 = "arn:minio:replication::id1:dest1"
+		arn2 = "arn:minio:replication::id2:dest2"
+	)
+	statuses := map[string]replication.StatusType{
+		arn1: replication.Complete,
+		arn2: replication.Failed,
+	}
+
+	// The status on each target is kept with a delete marker.
+	dm, err := z.DeleteObject(ctx, bucket, object, ObjectOptions{
+		Versioned:                     true,
+		DeleteMarkerReplicationStatus: replication.Pending.String(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := getDeleteReplicationStatus(ctx, z, bucket, object, dm.VersionID); len(got) != 0 {
+		t.Fatalf("expected no target status, got %v", got)
+	}
+	if _, err = z.DeleteObject(ctx, bucket, object, ObjectOptions{
+		VersionID:                     dm.VersionID,
+		DeleteMarker:                  true,
+		Versioned:                     true,
+		DeleteMarkerReplicationStatus: replication.Failed.String(),
+		TargetReplicationStatus:       targetReplicationStatusString(statuses),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got := getDeleteReplicationStatus(ctx, z, bucket, object, dm.VersionID); !reflect.DeepEqual(got, statuses) {
+		t.Fatalf("expected %v, got %v", statuses, got)
+	}
+
+	// And with a version being purged.
+	if _, err = z.DeleteObject(ctx, bucket, object, ObjectOptions{
+		VersionID:          oi.VersionID,
+		Versioned:          true,
+		VersionPurgeStatus: Pending,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = z.DeleteObject(ctx, bucket, object, ObjectOptions{
+		VersionID:               oi.VersionID,
+		Versioned:               true,
+		VersionPurgeStatus:      Failed,
+		TargetReplicationStatus: targetReplicationStatusString(statuses),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got := getDeleteReplicationStatus(ctx, z, bucket, object, oi.VersionID); !reflect.DeepEqual(got, statuses) {
+		t.Fatalf("expected %v, got %v", statuses, got)
+	}
+}
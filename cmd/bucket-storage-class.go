@@ -0,0 +1,117 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/minio/cmd/config/storageclass"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// parseBucketStorageClass parses BucketStorageClass from json
+func parseBucketStorageClass(bucket string, data []byte) (*madmin.BucketStorageClass, error) {
+	scCfg := &madmin.BucketStorageClass{}
+	if err := json.Unmarshal(data, scCfg); err != nil {
+		return nil, err
+	}
+	if err := scCfg.Validate(); err != nil {
+		return nil, err
+	}
+	return scCfg, nil
+}
+
+// validateBucketStorageClass checks that the parity of all custom storage
+// classes fits in an erasure set of setDriveCount disks.
+func validateBucketStorageClass(scCfg *madmin.BucketStorageClass, setDriveCount int) error {
+	for _, parity := range scCfg.Classes {
+		if _, err := storageclass.ParseParity(parity, setDriveCount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchStorageClassRule returns the storage class of the first rule
+// matching the object name and its tags, empty if none matches.
+func matchStorageClassRule(scCfg *madmin.BucketStorageClass, object string, userTags string) string {
+	var tagMap map[string]string
+	for _, rule := range scCfg.Rules {
+		if !strings.HasPrefix(object, rule.Prefix) {
+			continue
+		}
+		if len(rule.Tags) > 0 && tagMap == nil {
+			t, err := tags.ParseObjectTags(userTags)
+			if err != nil {
+				return ""
+			}
+			tagMap = t.ToMap()
+		}
+		matched := true
+		for k, v := range rule.Tags {
+			if tagMap[k] != v {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return rule.StorageClass
+		}
+	}
+	return ""
+}
+
+// applyBucketStorageClass records the storage class selected by the rules
+// of the bucket in the metadata of a new object uploaded without one.
+func applyBucketStorageClass(bucket, object string, metadata map[string]string) {
+	if metadata[xhttp.AmzStorageClass] != "" || isMinioMetaBucketName(bucket) {
+		return
+	}
+	scCfg, err := globalBucketMetadataSys.GetStorageClassConfig(bucket)
+	if err != nil {
+		return
+	}
+	if sc := matchStorageClassRule(scCfg, object, metadata[xhttp.AmzObjectTagging]); sc != "" {
+		metadata[xhttp.AmzStorageClass] = sc
+	}
+}
+
+// getParityForSC returns the parity of the storage class for an erasure
+// set of setDriveCount disks, custom storage classes of the bucket take
+// precedence over the server wide configuration.
+func getParityForSC(bucket, sc string, setDriveCount int) int {
+	if sc != "" && !isMinioMetaBucketName(bucket) {
+		if scCfg, err := globalBucketMetadataSys.GetStorageClassConfig(bucket); err == nil {
+			if parity, ok := scCfg.Classes[sc]; ok {
+				// Classes with a parity which does not fit in this
+				// erasure set fall back to the standard parity.
+				if p, err := storageclass.ParseParity(parity, setDriveCount); err == nil {
+					return p
+				}
+			}
+		}
+	}
+
+	parity := globalStorageClass.GetParityForSC(sc)
+	if parity == 0 {
+		parity = getDefaultParityBlocks(setDriveCount)
+	}
+	return parity
+}
@@ -179,9 +179,14 @@ func (sys *BucketTargetSys) RemoveTarget(ctx context.Context, bucket, arnStr str
 		}
 		// reject removal of remote target if replication configuration is present
 		rcfg, err := getReplicationConfig(ctx, bucket)
-		if err == nil && rcfg.RoleArn == arnStr {
-			if _, ok := sys.arnRemotesMap[arnStr]; ok {
-				return BucketRemoteRemoveDisallowed{Bucket: bucket}
+		if err == nil {
+			for _, arn := range rcfg.TargetArns() {
+				if arn != arnStr {
+					continue
+				}
+				if _, ok := sys.arnRemotesMap[arnStr]; ok {
+					return BucketRemoteRemoveDisallowed{Bucket: bucket}
+				}
 			}
 		}
 	}
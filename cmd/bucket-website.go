@@ -0,0 +1,269 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/handlers"
+)
+
+// registerWebsiteRouter - registers the static website endpoints, a
+// bucket with a website configuration is served anonymously on
+// `<bucket>.<website-domain>` for every domain in MINIO_WEBSITE_DOMAIN.
+func registerWebsiteRouter(router *mux.Router) {
+	api := objectAPIHandlers{
+		ObjectAPI: newObjectLayerFn,
+		CacheAPI:  newCachedObjectLayerFn,
+	}
+
+	for _, domainName := range globalWebsiteDomainNames {
+		websiteRouter := router.Host("{bucket:.+}." + domainName).Subrouter()
+
+		// GetWebsiteObject
+		websiteRouter.Methods(http.MethodGet, http.MethodHead).Path("/{object:.*}").HandlerFunc(
+			maxClients(collectAPIStats("getwebsiteobject", httpTraceAll(api.WebsiteHandler))))
+
+		// Website endpoints are read-only, all other requests are rejected.
+		websiteRouter.NewRoute().HandlerFunc(httpTraceAll(api.websiteMethodNotAllowedHandler))
+	}
+}
+
+// getWebsiteBucket - returns the bucket addressed by a request on one of
+// the website domains, empty otherwise.
+func getWebsiteBucket(r *http.Request) string {
+	if len(globalWebsiteDomainNames) == 0 {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(getHost(r))
+	if err != nil {
+		host = getHost(r)
+	}
+	for _, domain := range globalWebsiteDomainNames {
+		if strings.HasSuffix(host, "."+domain) {
+			return strings.TrimSuffix(host, "."+domain)
+		}
+	}
+	return ""
+}
+
+// guessIsWebsiteReq - returns true if the request is on a website endpoint.
+func guessIsWebsiteReq(r *http.Request) bool {
+	if r == nil {
+		return false
+	}
+	return getWebsiteBucket(r) != ""
+}
+
+// writeWebsiteErrorResponse - website endpoints respond with a HTML
+// document instead of the XML error of the REST API.
+func writeWebsiteErrorResponse(ctx context.Context, w http.ResponseWriter, r *http.Request, err APIError) {
+	reqInfo := logger.GetReqInfo(ctx)
+	title := fmt.Sprintf("%d %s", err.HTTPStatusCode, http.StatusText(err.HTTPStatusCode))
+	body := fmt.Sprintf("<html>\n<head><title>%s</title></head>\n<body>\n<h1>%s</h1>\n"+
+		"<ul>\n<li>Code: %s</li>\n<li>Message: %s</li>\n<li>RequestId: %s</li>\n</ul>\n<hr/>\n</body>\n</html>\n",
+		title, title, html.EscapeString(err.Code), html.EscapeString(err.Description),
+		html.EscapeString(reqInfo.RequestID))
+	if r.Method == http.MethodHead {
+		writeErrorResponseHeadersOnly(w, err)
+		return
+	}
+	writeResponse(w, err.HTTPStatusCode, []byte(body), mimeHTML)
+}
+
+// websiteRedirect - redirects the request to location with the given status.
+func websiteRedirect(w http.ResponseWriter, r *http.Request, location string, statusCode int) {
+	w.Header().Set(xhttp.Location, location)
+	w.WriteHeader(statusCode)
+}
+
+func (api objectAPIHandlers) websiteMethodNotAllowedHandler(w http.ResponseWriter, r *http.Request) {
+	writeWebsiteErrorResponse(r.Context(), w, r, errorCodes.ToAPIErr(ErrMethodNotAllowed))
+}
+
+// WebsiteHandler - serves objects of a bucket configured as a static
+// website. RedirectAllRequestsTo and RoutingRules without an error code
+// condition are applied first, then the index document is served for
+// requests on the root or on a "directory". On errors, RoutingRules with
+// a matching error code are applied, otherwise the error document is
+// served with the error status code.
+func (api objectAPIHandlers) WebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetWebsiteObject")
+
+	defer logger.AuditLog(w, r, "GetWebsiteObject", mustGetClaimsFromToken(r))
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeWebsiteErrorResponse(ctx, w, r, errorCodes.ToAPIErr(ErrServerNotInitialized))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object, err := url.PathUnescape(vars["object"])
+	if err != nil {
+		writeWebsiteErrorResponse(ctx, w, r, toAPIError(ctx, err))
+		return
+	}
+
+	if s3utils.CheckValidBucketName(bucket) != nil || isMinioMetaBucketName(bucket) || isMinioReservedBucket(bucket) {
+		writeWebsiteErrorResponse(ctx, w, r, errorCodes.ToAPIErr(ErrNoSuchBucket))
+		return
+	}
+
+	config, err := globalBucketMetadataSys.GetWebsiteConfig(bucket)
+	if err != nil {
+		writeWebsiteErrorResponse(ctx, w, r, toAPIError(ctx, err))
+		return
+	}
+
+	scheme := getURLScheme(globalIsTLS)
+	if redirect := config.RedirectAllRequestsTo; redirect != nil {
+		websiteRedirect(w, r, redirect.Location(object, scheme), http.StatusMovedPermanently)
+		return
+	}
+
+	if rule, ok := config.MatchRoutingRule(object, 0); ok {
+		websiteRedirect(w, r, rule.Location(object, getHost(r), scheme), rule.StatusCode())
+		return
+	}
+
+	key := config.IndexKey(object)
+	err = api.serveWebsiteObject(ctx, w, r, bucket, key, http.StatusOK)
+	if err == nil || isErrPreconditionFailed(err) {
+		return
+	}
+
+	apiErr := toAPIError(ctx, err)
+	if apiErr.HTTPStatusCode == http.StatusNotFound && key == object && object != "" {
+		// Requests on a "directory" without the trailing slash are
+		// redirected to it, when the directory has an index document.
+		indexKey := config.IndexKey(object + SlashSeparator)
+		if _, err = objectAPI.GetObjectInfo(ctx, bucket, indexKey, ObjectOptions{}); err == nil {
+			websiteRedirect(w, r, SlashSeparator+object+SlashSeparator, http.StatusFound)
+			return
+		}
+	}
+
+	if rule, ok := config.MatchRoutingRule(object, apiErr.HTTPStatusCode); ok {
+		websiteRedirect(w, r, rule.Location(object, getHost(r), scheme), rule.StatusCode())
+		return
+	}
+
+	if config.ErrorDocument != nil {
+		if err = api.serveWebsiteObject(ctx, w, r, bucket, config.ErrorDocument.Key, apiErr.HTTPStatusCode); err == nil {
+			return
+		}
+	}
+
+	writeWebsiteErrorResponse(ctx, w, r, apiErr)
+}
+
+// serveWebsiteObject - writes the object with the given status code, an
+// error is returned when nothing has been written to the client yet.
+// Range and conditional headers only apply to successful requests.
+func (api objectAPIHandlers) serveWebsiteObject(ctx context.Context, w http.ResponseWriter, r *http.Request, bucket, object string, statusCode int) error {
+	// Website endpoints only serve objects readable anonymously.
+	if !globalPolicySys.IsAllowed(policy.Args{
+		Action:          policy.GetObjectAction,
+		BucketName:      bucket,
+		ConditionValues: getConditionValues(r, "", "", nil),
+		IsOwner:         false,
+		ObjectName:      object,
+	}) {
+		return PrefixAccessDenied{Bucket: bucket, Object: object}
+	}
+
+	objectAPI := api.ObjectAPI()
+	getObjectNInfo := objectAPI.GetObjectNInfo
+	if api.CacheAPI() != nil {
+		getObjectNInfo = api.CacheAPI().GetObjectNInfo
+	}
+
+	var rs *HTTPRangeSpec
+	var opts ObjectOptions
+	if statusCode == http.StatusOK {
+		if rangeHeader := r.Header.Get(xhttp.Range); rangeHeader != "" {
+			var err error
+			if rs, err = parseRequestRangeSpec(rangeHeader); err != nil {
+				if err == errInvalidRange {
+					return err
+				}
+				// Ignore other parse errors and treat it as a regular
+				// request like Amazon S3.
+				rs = nil
+			}
+		}
+
+		opts.CheckPrecondFn = func(oi ObjectInfo) bool {
+			return checkPreconditions(ctx, w, r, oi, opts)
+		}
+	}
+
+	gr, err := getObjectNInfo(ctx, bucket, object, rs, r.Header, readLock, opts)
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+	objInfo := gr.ObjInfo
+
+	if err = setObjectHeaders(w, objInfo, rs, opts); err != nil {
+		return err
+	}
+
+	if rs != nil {
+		statusCode = http.StatusPartialContent
+	}
+	w.WriteHeader(statusCode)
+
+	if r.Method == http.MethodHead {
+		return nil
+	}
+
+	if _, err = io.Copy(w, gr); err != nil {
+		if !errors.Is(err, context.Canceled) {
+			logger.LogIf(ctx, err)
+		}
+		return nil
+	}
+
+	// Notify object accessed via a GET request.
+	sendEvent(eventArgs{
+		EventName:    event.ObjectAccessedGet,
+		BucketName:   bucket,
+		Object:       objInfo,
+		ReqParams:    extractReqParams(r),
+		RespElements: extractRespElements(w),
+		UserAgent:    r.UserAgent(),
+		Host:         handlers.GetSourceIP(r),
+	})
+	return nil
+}
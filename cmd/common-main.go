@@ -243,6 +243,21 @@ func handleCommonEnvVars() {
 		}
 	}
 
+	websiteDomains := env.Get(config.EnvWebsiteDomain, "")
+	if len(websiteDomains) != 0 {
+		for _, domainName := range strings.Split(websiteDomains, config.ValueSeparator) {
+			if _, ok := dns2.IsDomainName(domainName); !ok {
+				logger.Fatal(config.ErrInvalidDomainValue(nil).Msg("Unknown value `%s`", domainName),
+					"Invalid MINIO_WEBSITE_DOMAIN value in environment variable")
+			}
+			if contains(globalDomainNames, domainName) {
+				logger.Fatal(config.ErrInvalidDomainValue(nil).Msg("`%s` is already used by MINIO_DOMAIN", domainName),
+					"Invalid MINIO_WEBSITE_DOMAIN value in environment variable")
+			}
+			globalWebsiteDomainNames = append(globalWebsiteDomainNames, domainName)
+		}
+	}
+
 	publicIPs := env.Get(config.EnvPublicIPs, "")
 	if len(publicIPs) != 0 {
 		minioEndpoints := strings.Split(publicIPs, config.ValueSeparator)
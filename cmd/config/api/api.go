@@ -36,6 +36,10 @@ const (
 	apiRemoteTransportDeadline = "remote_transport_deadline"
 	apiListQuorum              = "list_quorum"
 	apiExtendListCacheLife     = "extend_list_cache_life"
+	apiBlockPublicAcls         = "block_public_acls"
+	apiIgnorePublicAcls        = "ignore_public_acls"
+	apiBlockPublicPolicy       = "block_public_policy"
+	apiRestrictPublicBuckets   = "restrict_public_buckets"
 
 	EnvAPIRequestsMax             = "MINIO_API_REQUESTS_MAX"
 	EnvAPIRequestsDeadline        = "MINIO_API_REQUESTS_DEADLINE"
@@ -45,6 +49,10 @@ const (
 	EnvAPIListQuorum              = "MINIO_API_LIST_QUORUM"
 	EnvAPIExtendListCacheLife     = "MINIO_API_EXTEND_LIST_CACHE_LIFE"
 	EnvAPISecureCiphers           = "MINIO_API_SECURE_CIPHERS"
+	EnvAPIBlockPublicAcls         = "MINIO_API_BLOCK_PUBLIC_ACLS"
+	EnvAPIIgnorePublicAcls        = "MINIO_API_IGNORE_PUBLIC_ACLS"
+	EnvAPIBlockPublicPolicy       = "MINIO_API_BLOCK_PUBLIC_POLICY"
+	EnvAPIRestrictPublicBuckets   = "MINIO_API_RESTRICT_PUBLIC_BUCKETS"
 )
 
 // Deprecated key and ENVs
@@ -84,6 +92,22 @@ var (
 			Key:   apiExtendListCacheLife,
 			Value: "0s",
 		},
+		config.KV{
+			Key:   apiBlockPublicAcls,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   apiIgnorePublicAcls,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   apiBlockPublicPolicy,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   apiRestrictPublicBuckets,
+			Value: config.EnableOff,
+		},
 	}
 )
 
@@ -96,6 +120,13 @@ type Config struct {
 	RemoteTransportDeadline time.Duration `json:"remote_transport_deadline"`
 	ListQuorum              string        `json:"list_strict_quorum"`
 	ExtendListLife          time.Duration `json:"extend_list_cache_life"`
+
+	// Server wide public access block settings, they apply to all
+	// buckets in addition to the settings of each bucket.
+	BlockPublicAcls       bool `json:"block_public_acls"`
+	IgnorePublicAcls      bool `json:"ignore_public_acls"`
+	BlockPublicPolicy     bool `json:"block_public_policy"`
+	RestrictPublicBuckets bool `json:"restrict_public_buckets"`
 }
 
 // UnmarshalJSON - Validate SS and RRS parity when unmarshalling JSON.
@@ -173,6 +204,26 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 		return cfg, err
 	}
 
+	publicAccessBlock := make(map[string]bool, 4)
+	for _, key := range []struct {
+		env string
+		key string
+	}{
+		{EnvAPIBlockPublicAcls, apiBlockPublicAcls},
+		{EnvAPIIgnorePublicAcls, apiIgnorePublicAcls},
+		{EnvAPIBlockPublicPolicy, apiBlockPublicPolicy},
+		{EnvAPIRestrictPublicBuckets, apiRestrictPublicBuckets},
+	} {
+		value := env.Get(key.env, kvs.Get(key.key))
+		if value == "" {
+			value = config.EnableOff
+		}
+		publicAccessBlock[key.key], err = config.ParseBool(value)
+		if err != nil {
+			return cfg, err
+		}
+	}
+
 	return Config{
 		RequestsMax:             requestsMax,
 		RequestsDeadline:        requestsDeadline,
@@ -181,5 +232,9 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 		RemoteTransportDeadline: remoteTransportDeadline,
 		ListQuorum:              listQuorum,
 		ExtendListLife:          listLife,
+		BlockPublicAcls:         publicAccessBlock[apiBlockPublicAcls],
+		IgnorePublicAcls:        publicAccessBlock[apiIgnorePublicAcls],
+		BlockPublicPolicy:       publicAccessBlock[apiBlockPublicPolicy],
+		RestrictPublicBuckets:   publicAccessBlock[apiRestrictPublicBuckets],
 	}, nil
 }
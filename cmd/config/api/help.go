@@ -45,5 +45,29 @@ var (
 			Optional:    true,
 			Type:        "duration",
 		},
+		config.HelpKV{
+			Key:         apiBlockPublicAcls,
+			Description: `set to "on" to reject ACLs granting public access on all buckets, defaults to "off"`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         apiIgnorePublicAcls,
+			Description: `set to "on" to ignore ACL grants to public groups on all buckets, defaults to "off"`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         apiBlockPublicPolicy,
+			Description: `set to "on" to reject bucket policies granting public access on all buckets, defaults to "off"`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         apiRestrictPublicBuckets,
+			Description: `set to "on" to deny anonymous access to all buckets, even if allowed by the bucket policy, defaults to "off"`,
+			Optional:    true,
+			Type:        "on|off",
+		},
 	}
 )
@@ -23,17 +23,18 @@ const (
 
 // Top level common ENVs
 const (
-	EnvAccessKey    = "MINIO_ACCESS_KEY"
-	EnvSecretKey    = "MINIO_SECRET_KEY"
-	EnvAccessKeyOld = "MINIO_ACCESS_KEY_OLD"
-	EnvSecretKeyOld = "MINIO_SECRET_KEY_OLD"
-	EnvBrowser      = "MINIO_BROWSER"
-	EnvDomain       = "MINIO_DOMAIN"
-	EnvRegionName   = "MINIO_REGION_NAME"
-	EnvPublicIPs    = "MINIO_PUBLIC_IPS"
-	EnvFSOSync      = "MINIO_FS_OSYNC"
-	EnvArgs         = "MINIO_ARGS"
-	EnvDNSWebhook   = "MINIO_DNS_WEBHOOK_ENDPOINT"
+	EnvAccessKey     = "MINIO_ACCESS_KEY"
+	EnvSecretKey     = "MINIO_SECRET_KEY"
+	EnvAccessKeyOld  = "MINIO_ACCESS_KEY_OLD"
+	EnvSecretKeyOld  = "MINIO_SECRET_KEY_OLD"
+	EnvBrowser       = "MINIO_BROWSER"
+	EnvDomain        = "MINIO_DOMAIN"
+	EnvWebsiteDomain = "MINIO_WEBSITE_DOMAIN"
+	EnvRegionName    = "MINIO_REGION_NAME"
+	EnvPublicIPs     = "MINIO_PUBLIC_IPS"
+	EnvFSOSync       = "MINIO_FS_OSYNC"
+	EnvArgs          = "MINIO_ARGS"
+	EnvDNSWebhook    = "MINIO_DNS_WEBHOOK_ENDPOINT"
 
 	EnvUpdate = "MINIO_UPDATE"
 
@@ -27,13 +27,17 @@ import (
 
 // Compression environment variables
 const (
-	Bitrot  = "bitrotscan"
-	Sleep   = "max_sleep"
-	IOCount = "max_io"
+	Bitrot        = "bitrotscan"
+	Sleep         = "max_sleep"
+	IOCount       = "max_io"
+	ScrubInterval = "scrub_interval"
+	ScrubRate     = "scrub_rate"
 
-	EnvBitrot  = "MINIO_HEAL_BITROTSCAN"
-	EnvSleep   = "MINIO_HEAL_MAX_SLEEP"
-	EnvIOCount = "MINIO_HEAL_MAX_IO"
+	EnvBitrot        = "MINIO_HEAL_BITROTSCAN"
+	EnvSleep         = "MINIO_HEAL_MAX_SLEEP"
+	EnvIOCount       = "MINIO_HEAL_MAX_IO"
+	EnvScrubInterval = "MINIO_HEAL_SCRUB_INTERVAL"
+	EnvScrubRate     = "MINIO_HEAL_SCRUB_RATE"
 )
 
 // Config represents the heal settings.
@@ -43,6 +47,14 @@ type Config struct {
 	// maximum sleep duration between objects to slow down heal operation.
 	Sleep   time.Duration `json:"sleep"`
 	IOCount int           `json:"iocount"`
+
+	// ScrubInterval is the time between two scrubs of a drive,
+	// which verify the bitrot hash of every shard, zero disables
+	// scrubbing.
+	ScrubInterval time.Duration `json:"scrubInterval"`
+	// ScrubRate is the maximum number of object versions
+	// verified per second on each drive.
+	ScrubRate int `json:"scrubRate"`
 }
 
 var (
@@ -60,6 +72,14 @@ var (
 			Key:   IOCount,
 			Value: "10",
 		},
+		config.KV{
+			Key:   ScrubInterval,
+			Value: "0s",
+		},
+		config.KV{
+			Key:   ScrubRate,
+			Value: "100",
+		},
 	}
 
 	// Help provides help for config values
@@ -82,6 +102,18 @@ var (
 			Optional:    true,
 			Type:        "int",
 		},
+		config.HelpKV{
+			Key:         ScrubInterval,
+			Description: `time between two scrubs of a drive which verify every shard, 0s disables scrubbing. eg. "720h"`,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         ScrubRate,
+			Description: `maximum number of object versions verified per second on each drive while scrubbing. eg. 50`,
+			Optional:    true,
+			Type:        "int",
+		},
 	}
 )
 
@@ -102,5 +134,19 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 	if err != nil {
 		return cfg, fmt.Errorf("'heal:max_io' value invalid: %w", err)
 	}
+	scrubInterval := env.Get(EnvScrubInterval, kvs.Get(ScrubInterval))
+	if scrubInterval != "" {
+		cfg.ScrubInterval, err = time.ParseDuration(scrubInterval)
+		if err != nil {
+			return cfg, fmt.Errorf("'heal:scrub_interval' value invalid: %w", err)
+		}
+	}
+	scrubRate := env.Get(EnvScrubRate, kvs.Get(ScrubRate))
+	if scrubRate != "" {
+		cfg.ScrubRate, err = strconv.Atoi(scrubRate)
+		if err != nil || cfg.ScrubRate <= 0 {
+			return cfg, fmt.Errorf("'heal:scrub_rate' value invalid: %s", scrubRate)
+		}
+	}
 	return cfg, nil
 }
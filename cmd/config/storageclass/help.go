@@ -39,6 +39,12 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         InlineBlock,
+			Description: `store objects smaller than this size inside their metadata, defaults to "128KiB", "0" disables e.g. "64KiB"`,
+			Optional:    true,
+			Type:        "string",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
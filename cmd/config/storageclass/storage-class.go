@@ -23,6 +23,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/dustin/go-humanize"
 	"github.com/minio/minio/cmd/config"
 	"github.com/minio/minio/pkg/env"
 )
@@ -46,6 +47,7 @@ const (
 	ClassStandard = "standard"
 	ClassRRS      = "rrs"
 	ClassDMA      = "dma"
+	InlineBlock   = "inline_block"
 
 	// Reduced redundancy storage class environment variable
 	RRSEnv = "MINIO_STORAGE_CLASS_RRS"
@@ -53,6 +55,8 @@ const (
 	StandardEnv = "MINIO_STORAGE_CLASS_STANDARD"
 	// DMA storage class environment variable
 	DMAEnv = "MINIO_STORAGE_CLASS_DMA"
+	// Inline block size environment variable
+	InlineBlockEnv = "MINIO_STORAGE_CLASS_INLINE_BLOCK"
 
 	// Supported storage class scheme is EC
 	schemePrefix = "EC"
@@ -65,6 +69,10 @@ const (
 
 	// Default DMA value
 	defaultDMA = DMAWrite
+
+	// Default inline block size, objects smaller than
+	// this are stored inside their metadata.
+	defaultInlineBlock = "128KiB"
 )
 
 // DefaultKVS - default storage class config
@@ -82,6 +90,10 @@ var (
 			Key:   ClassDMA,
 			Value: defaultDMA,
 		},
+		config.KV{
+			Key:   InlineBlock,
+			Value: defaultInlineBlock,
+		},
 	}
 )
 
@@ -96,6 +108,10 @@ type Config struct {
 	Standard StorageClass `json:"standard"`
 	RRS      StorageClass `json:"rrs"`
 	DMA      StorageClass `json:"dma"`
+
+	// Objects smaller than InlineBlock bytes are stored
+	// inside their metadata, zero disables inlining.
+	InlineBlock int64 `json:"inline_block,omitempty"`
 }
 
 // UnmarshalJSON - Validate SS and RRS parity when unmarshalling JSON.
@@ -182,6 +198,22 @@ func parseStorageClass(storageClassEnv string) (sc StorageClass, err error) {
 	}, nil
 }
 
+// ParseParity - parses the parity of a custom storage class in the form
+// "EC:Number of parity disks", which must fit in a set of setDriveCount disks.
+func ParseParity(sc string, setDriveCount int) (parity int, err error) {
+	s, err := parseStorageClass(sc)
+	if err != nil {
+		return 0, err
+	}
+	if s.Parity < minParityDisks {
+		return 0, fmt.Errorf("Storage class parity %d should be greater than or equal to %d", s.Parity, minParityDisks)
+	}
+	if s.Parity > setDriveCount/2 {
+		return 0, fmt.Errorf("Storage class parity %d should be less than or equal to %d", s.Parity, setDriveCount/2)
+	}
+	return s.Parity, nil
+}
+
 // Validates the parity disks.
 func validateParity(ssParity, rrsParity, setDriveCount int) (err error) {
 	if ssParity == 0 && rrsParity == 0 {
@@ -244,6 +276,13 @@ func (sCfg Config) GetDMA() string {
 	return sCfg.DMA.DMA
 }
 
+// ShouldInline - returns true if an object of the given size is
+// small enough to be stored inside its metadata, size is -1 when
+// the size of the object is not known upfront.
+func (sCfg Config) ShouldInline(size int64) bool {
+	return size >= 0 && size < sCfg.InlineBlock
+}
+
 // Enabled returns if etcd is enabled.
 func Enabled(kvs config.KVS) bool {
 	ssc := kvs.Get(ClassStandard)
@@ -293,6 +332,16 @@ func LookupConfig(kvs config.KVS, setDriveCount int) (cfg Config, err error) {
 	}
 	cfg.DMA.DMA = dma
 
+	inlineBlock := env.Get(InlineBlockEnv, kvs.Get(InlineBlock))
+	if inlineBlock == "" {
+		inlineBlock = defaultInlineBlock
+	}
+	block, err := humanize.ParseBytes(inlineBlock)
+	if err != nil {
+		return Config{}, config.ErrStorageClassValue(err)
+	}
+	cfg.InlineBlock = int64(block)
+
 	// Validation is done after parsing both the storage classes. This is needed because we need one
 	// storage class value to deduce the correct value of the other storage class.
 	if err = validateParity(cfg.Standard.Parity, cfg.RRS.Parity, setDriveCount); err != nil {
@@ -20,6 +20,8 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+
+	"github.com/minio/minio/cmd/config"
 )
 
 func TestParseStorageClass(t *testing.T) {
@@ -95,6 +97,36 @@ func TestValidateParity(t *testing.T) {
 	}
 }
 
+func TestParseParity(t *testing.T) {
+	tests := []struct {
+		sc            string
+		setDriveCount int
+		parity        int
+		success       bool
+	}{
+		{"EC:2", 4, 2, true},
+		{"EC:6", 16, 6, true},
+		{"EC:8", 16, 8, true},
+		{"EC:1", 16, 0, false},
+		{"EC:9", 16, 0, false},
+		{"EC:3", 4, 0, false},
+		{"AB:4", 16, 0, false},
+		{"EC", 16, 0, false},
+	}
+	for i, tt := range tests {
+		parity, err := ParseParity(tt.sc, tt.setDriveCount)
+		if err != nil && tt.success {
+			t.Errorf("Test %d, Expected success, got %s", i+1, err)
+		}
+		if err == nil && !tt.success {
+			t.Errorf("Test %d, Expected failure, got success", i+1)
+		}
+		if parity != tt.parity {
+			t.Errorf("Test %d, Expected parity %d, got %d", i+1, tt.parity, parity)
+		}
+	}
+}
+
 func TestParityCount(t *testing.T) {
 	tests := []struct {
 		sc             string
@@ -161,3 +193,28 @@ func TestIsValidStorageClassKind(t *testing.T) {
 		}
 	}
 }
+
+func TestShouldInline(t *testing.T) {
+	cfg, err := LookupConfig(config.KVS{}, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		size   int64
+		inline bool
+	}{
+		{-1, false},
+		{0, true},
+		{128<<10 - 1, true},
+		{128 << 10, false},
+	}
+	for i, test := range tests {
+		if inline := cfg.ShouldInline(test.size); inline != test.inline {
+			t.Errorf("Test %d: expected %v for size %d, got %v", i+1, test.inline, test.size, inline)
+		}
+	}
+
+	if (Config{}).ShouldInline(0) {
+		t.Error("expected inlining to be disabled without an inline block")
+	}
+}
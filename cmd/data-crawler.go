@@ -31,6 +31,7 @@ import (
 
 	"github.com/minio/minio/cmd/config"
 	"github.com/minio/minio/cmd/config/heal"
+	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
 	"github.com/minio/minio/pkg/bucket/replication"
@@ -124,6 +125,9 @@ func runDataCrawler(ctx context.Context, objAPI ObjectLayer) {
 			close(results)
 			logger.LogIf(ctx, err)
 			if err == nil {
+				// Generate inventory reports which are due.
+				startBucketInventory(ctx, objAPI)
+
 				// Store new cycle...
 				nextBloomCycle++
 				var tmp [8]byte
@@ -730,6 +734,8 @@ type sizeSummary struct {
 	pendingSize    int64
 	failedSize     int64
 	replicaSize    int64
+	identities     identitiesUsage
+	replTargets    replicationTargetsUsage
 }
 
 type getSizeFn func(item crawlItem) (sizeSummary, error)
@@ -944,7 +950,8 @@ func (i *crawlItem) objectPath() string {
 	return path.Join(i.prefix, i.objectName)
 }
 
-// healReplication will heal a scanned item that has failed replication.
+// healReplication will heal a scanned item that has failed replication,
+// or which was written before existing objects were replicated.
 func (i *crawlItem) healReplication(ctx context.Context, o ObjectLayer, meta actionMeta, sizeS *sizeSummary) {
 	if meta.oi.DeleteMarker || !meta.oi.VersionPurgeStatus.Empty() {
 		// heal delete marker replication failure or versioned delete replication failure
@@ -955,20 +962,65 @@ func (i *crawlItem) healReplication(ctx context.Context, o ObjectLayer, meta act
 			return
 		}
 	}
+	if mustReplicateExisting(ctx, meta.oi) {
+		globalReplicationState.queueReplicaTask(meta.oi)
+		return
+	}
 	switch meta.oi.ReplicationStatus {
 	case replication.Pending:
 		sizeS.pendingSize += meta.oi.Size
+		sizeS.addReplicationTargets(ctx, meta.oi)
 		globalReplicationState.queueReplicaTask(meta.oi)
 	case replication.Failed:
 		sizeS.failedSize += meta.oi.Size
+		sizeS.addReplicationTargets(ctx, meta.oi)
 		globalReplicationState.queueReplicaTask(meta.oi)
 	case replication.Complete:
 		sizeS.replicatedSize += meta.oi.Size
+		sizeS.addReplicationTargets(ctx, meta.oi)
 	case replication.Replica:
 		sizeS.replicaSize += meta.oi.Size
 	}
 }
 
+// addReplicationTargets adds the object version to the replication
+// backlog or to the replicated size of each of its targets.
+func (s *sizeSummary) addReplicationTargets(ctx context.Context, oi ObjectInfo) {
+	cfg, err := getReplicationConfig(ctx, oi.Bucket)
+	if err != nil || cfg == nil {
+		return
+	}
+	arns := cfg.FilterTargetArns(replication.ObjectOpts{
+		Name:     oi.Name,
+		SSEC:     crypto.SSEC.IsEncrypted(oi.UserDefined),
+		UserTags: oi.UserTags,
+	})
+	statuses := parseTargetReplicationStatus(oi.UserDefined[targetReplicationStatusKey])
+	size := uint64(oi.Size)
+	for _, arn := range arns {
+		status := statuses[arn]
+		if len(statuses) == 0 {
+			// Replicated before the status of each target was tracked.
+			status = oi.ReplicationStatus
+		}
+		if s.replTargets == nil {
+			s.replTargets = make(replicationTargetsUsage, len(arns))
+		}
+		u := s.replTargets[arn]
+		switch status {
+		case replication.Complete:
+			u.ReplicatedSize += size
+		case replication.Failed:
+			u.FailedSize += size
+			u.FailedCount++
+		default:
+			u.PendingSize += size
+			u.PendingCount++
+		}
+		s.replTargets[arn] = u
+	}
+}
+
 // healReplicationDeletes will heal a scanned deleted item that failed to replicate deletes.
 func (i *crawlItem) healReplicationDeletes(ctx context.Context, o ObjectLayer, meta actionMeta) {
 	// handle soft delete and permanent delete failures here.
@@ -54,6 +54,78 @@ type dataUsageEntry struct {
 	Objects                uint64
 	ObjSizes               sizeHistogram
 	Children               dataUsageHashMap
+	Identities             identitiesUsage
+	ReplicationTargets     replicationTargetsUsage
+}
+
+// identitiesUsage is the usage of the IAM identities that uploaded objects,
+// keyed by access key.
+type identitiesUsage map[string]identityUsage
+
+//msgp:tuple identityUsage
+
+// identityUsage is the size and number of the object versions uploaded by
+// an IAM identity.
+type identityUsage struct {
+	Size    int64
+	Objects uint64
+}
+
+// add the usage of other to the identities, a new map is returned
+// as entries may share their map with a flattened copy.
+func (u identitiesUsage) add(other identitiesUsage) identitiesUsage {
+	if len(other) == 0 {
+		return u
+	}
+	dst := make(identitiesUsage, len(u)+len(other))
+	for id, v := range u {
+		dst[id] = v
+	}
+	for id, v := range other {
+		o := dst[id]
+		o.Size += v.Size
+		o.Objects += v.Objects
+		dst[id] = o
+	}
+	return dst
+}
+
+// replicationTargetsUsage is the replication backlog of the remote
+// targets of a bucket, keyed by target ARN.
+type replicationTargetsUsage map[string]replicationTargetUsage
+
+//msgp:tuple replicationTargetUsage
+
+// replicationTargetUsage is the size and number of the object versions
+// pending or failed replication to a target, and the size replicated to it.
+type replicationTargetUsage struct {
+	PendingSize    uint64
+	PendingCount   uint64
+	FailedSize     uint64
+	FailedCount    uint64
+	ReplicatedSize uint64
+}
+
+// add the usage of other to the targets, a new map is returned
+// as entries may share their map with a flattened copy.
+func (u replicationTargetsUsage) add(other replicationTargetsUsage) replicationTargetsUsage {
+	if len(other) == 0 {
+		return u
+	}
+	dst := make(replicationTargetsUsage, len(u)+len(other))
+	for arn, v := range u {
+		dst[arn] = v
+	}
+	for arn, v := range other {
+		o := dst[arn]
+		o.PendingSize += v.PendingSize
+		o.PendingCount += v.PendingCount
+		o.FailedSize += v.FailedSize
+		o.FailedCount += v.FailedCount
+		o.ReplicatedSize += v.ReplicatedSize
+		dst[arn] = o
+	}
+	return dst
 }
 
 // dataUsageCache contains a cache of data usage entries.
@@ -85,6 +157,8 @@ func (e *dataUsageEntry) addSizes(summary sizeSummary) {
 	e.ReplicationFailedSize += uint64(summary.failedSize)
 	e.ReplicationPendingSize += uint64(summary.pendingSize)
 	e.ReplicaSize += uint64(summary.replicaSize)
+	e.Identities = e.Identities.add(summary.identities)
+	e.ReplicationTargets = e.ReplicationTargets.add(summary.replTargets)
 }
 
 // merge other data usage entry into this, excluding children.
@@ -95,6 +169,8 @@ func (e *dataUsageEntry) merge(other dataUsageEntry) {
 	e.ReplicationFailedSize += other.ReplicationFailedSize
 	e.ReplicatedSize += other.ReplicatedSize
 	e.ReplicaSize += other.ReplicaSize
+	e.Identities = e.Identities.add(other.Identities)
+	e.ReplicationTargets = e.ReplicationTargets.add(other.ReplicationTargets)
 
 	for i, v := range other.ObjSizes[:] {
 		e.ObjSizes[i] += v
@@ -238,9 +314,37 @@ func (d *dataUsageCache) dui(path string, buckets []BucketInfo) DataUsageInfo {
 		ReplicaSize:            flat.ReplicaSize,
 		BucketsCount:           uint64(len(e.Children)),
 		BucketsUsage:           d.bucketsUsageInfo(buckets),
+		IdentitiesUsage:        flat.Identities.usageInfo(),
 	}
 }
 
+// usageInfo returns the usage of each identity.
+func (u identitiesUsage) usageInfo() map[string]IdentityUsageInfo {
+	if len(u) == 0 {
+		return nil
+	}
+	dst := make(map[string]IdentityUsageInfo, len(u))
+	for id, v := range u {
+		dst[id] = IdentityUsageInfo{
+			Size:         uint64(v.Size),
+			ObjectsCount: v.Objects,
+		}
+	}
+	return dst
+}
+
+// usageInfo returns the replication backlog of each target.
+func (u replicationTargetsUsage) usageInfo() map[string]ReplicationTargetUsageInfo {
+	if len(u) == 0 {
+		return nil
+	}
+	dst := make(map[string]ReplicationTargetUsageInfo, len(u))
+	for arn, v := range u {
+		dst[arn] = ReplicationTargetUsageInfo(v)
+	}
+	return dst
+}
+
 // replace will add or replace an entry in the cache.
 // If a parent is specified it will be added to that if not already there.
 // If the parent does not exist, it will be added.
@@ -369,6 +473,7 @@ func (d *dataUsageCache) bucketsUsageInfo(buckets []BucketInfo) map[string]Bucke
 			ReplicatedSize:         flat.ReplicatedSize,
 			ReplicationFailedSize:  flat.ReplicationFailedSize,
 			ReplicaSize:            flat.ReplicaSize,
+			ReplicationTargets:     flat.ReplicationTargets.usageInfo(),
 			ObjectSizesHistogram:   flat.ObjSizes.toMap(),
 		}
 	}
@@ -390,6 +495,7 @@ func (d *dataUsageCache) bucketUsageInfo(bucket string) BucketUsageInfo {
 		ReplicatedSize:         flat.ReplicatedSize,
 		ReplicationFailedSize:  flat.ReplicationFailedSize,
 		ReplicaSize:            flat.ReplicaSize,
+		ReplicationTargets:     flat.ReplicationTargets.usageInfo(),
 		ObjectSizesHistogram:   flat.ObjSizes.toMap(),
 	}
 }
@@ -457,6 +563,125 @@ func (d *dataUsageCache) merge(other dataUsageCache) {
 	}
 }
 
+//msgp:tuple dataUsageSnapshot
+
+// dataUsageSnapshot is the usage of a bucket or pool at a point in time.
+type dataUsageSnapshot struct {
+	Time time.Time
+	// Size of the objects of a bucket, used raw capacity of a pool.
+	Size    uint64
+	Objects uint64
+	// Raw capacity of a pool.
+	Capacity uint64
+}
+
+// dataUsageHistory contains usage snapshots of the buckets and pools,
+// oldest first, to estimate their growth.
+type dataUsageHistory struct {
+	LastUpdate time.Time
+	Buckets    map[string][]dataUsageSnapshot
+	// Pools are identified by their command line.
+	Pools map[string][]dataUsageSnapshot
+}
+
+// add snapshots of the buckets and pools taken at t, unless the last
+// snapshots were taken less than dataUsageHistoryInterval before.
+// Buckets and pools without a snapshot are dropped from the history.
+// Returns true if the snapshots were added.
+func (h *dataUsageHistory) add(t time.Time, buckets, pools map[string]dataUsageSnapshot) bool {
+	if t.Sub(h.LastUpdate) < dataUsageHistoryInterval {
+		return false
+	}
+	h.LastUpdate = t
+	h.Buckets = appendUsageSnapshots(h.Buckets, buckets, t)
+	h.Pools = appendUsageSnapshots(h.Pools, pools, t)
+	return true
+}
+
+func appendUsageSnapshots(history map[string][]dataUsageSnapshot, snapshots map[string]dataUsageSnapshot, t time.Time) map[string][]dataUsageSnapshot {
+	dst := make(map[string][]dataUsageSnapshot, len(snapshots))
+	for name, snapshot := range snapshots {
+		snapshot.Time = t
+		series := append(history[name], snapshot)
+		if len(series) > dataUsageHistoryLen {
+			series = series[len(series)-dataUsageHistoryLen:]
+		}
+		dst[name] = series
+	}
+	return dst
+}
+
+// usageGrowth returns the growth per second of the size and of the object
+// count of the snapshots, fitted by least squares. Zero is returned for
+// less than two snapshots.
+func usageGrowth(series []dataUsageSnapshot) (size, objects float64) {
+	if len(series) < 2 {
+		return 0, 0
+	}
+	start := series[0].Time
+	var sumT, sumS, sumO float64
+	for _, s := range series {
+		sumT += s.Time.Sub(start).Seconds()
+		sumS += float64(s.Size)
+		sumO += float64(s.Objects)
+	}
+	n := float64(len(series))
+	meanT, meanS, meanO := sumT/n, sumS/n, sumO/n
+	var varT, covS, covO float64
+	for _, s := range series {
+		dt := s.Time.Sub(start).Seconds() - meanT
+		varT += dt * dt
+		covS += dt * (float64(s.Size) - meanS)
+		covO += dt * (float64(s.Objects) - meanO)
+	}
+	if varT == 0 {
+		return 0, 0
+	}
+	return covS / varT, covO / varT
+}
+
+// load the usage history from dataUsageBucket, an empty history is
+// returned if none is found.
+func (h *dataUsageHistory) load(ctx context.Context, store objectIO) error {
+	var buf bytes.Buffer
+	err := store.GetObject(ctx, dataUsageBucket, dataUsageHistoryName, 0, -1, &buf, "", ObjectOptions{})
+	if err != nil {
+		*h = dataUsageHistory{}
+		if isErrObjectNotFound(err) || isErrBucketNotFound(err) {
+			return nil
+		}
+		return toObjectErr(err, dataUsageBucket, dataUsageHistoryName)
+	}
+	b := buf.Bytes()
+	if len(b) == 0 || b[0] != dataUsageHistoryVer {
+		*h = dataUsageHistory{}
+		logger.LogIf(ctx, errors.New("dataUsageHistory: unknown version"))
+		return nil
+	}
+	if _, err = h.UnmarshalMsg(b[1:]); err != nil {
+		*h = dataUsageHistory{}
+		logger.LogIf(ctx, err)
+	}
+	return nil
+}
+
+// save the usage history to dataUsageBucket.
+func (h *dataUsageHistory) save(ctx context.Context, store objectIO) error {
+	b, err := h.MarshalMsg([]byte{dataUsageHistoryVer})
+	if err != nil {
+		return err
+	}
+	r, err := hash.NewReader(bytes.NewReader(b), int64(len(b)), "", "", int64(len(b)), false)
+	if err != nil {
+		return err
+	}
+	_, err = store.PutObject(ctx, dataUsageBucket, dataUsageHistoryName, NewPutObjReader(r, nil, nil), ObjectOptions{})
+	if isErrBucketNotFound(err) {
+		return nil
+	}
+	return err
+}
+
 type objectIO interface {
 	GetObject(ctx context.Context, bucket, object string, startOffset int64, length int64, writer io.Writer, etag string, opts ObjectOptions) (err error)
 	PutObject(ctx context.Context, bucket, object string, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error)
@@ -510,10 +735,20 @@ func (d *dataUsageCache) save(ctx context.Context, store objectIO, name string)
 	return err
 }
 
+const (
+	// dataUsageHistoryVer indicates the usage history version.
+	dataUsageHistoryVer = 1
+
+	// Snapshots are taken at most once per interval, at the end of a
+	// crawl, and dataUsageHistoryLen snapshots (90 days) are kept.
+	dataUsageHistoryInterval = time.Hour
+	dataUsageHistoryLen      = 90 * 24
+)
+
 // dataUsageCacheVer indicates the cache version.
 // Bumping the cache version will drop data from previous versions
 // and write new data with the new version.
-const dataUsageCacheVer = 3
+const dataUsageCacheVer = 5
 
 // serialize the contents of the cache.
 func (d *dataUsageCache) serializeTo(dst io.Writer) error {
@@ -553,7 +788,7 @@ func (d *dataUsageCache) deserialize(r io.Reader) error {
 		return io.ErrUnexpectedEOF
 	}
 	switch b[0] {
-	case 1, 2:
+	case 1, 2, 3, 4:
 		return errors.New("cache version deprecated (will autoupdate)")
 	case dataUsageCacheVer:
 	default:
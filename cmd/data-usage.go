@@ -33,9 +33,10 @@ const (
 	dataUsageRoot   = SlashSeparator
 	dataUsageBucket = minioMetaBucket + SlashSeparator + bucketMetaPrefix
 
-	dataUsageObjName   = ".usage.json"
-	dataUsageCacheName = ".usage-cache.bin"
-	dataUsageBloomName = ".bloomcycle.bin"
+	dataUsageObjName     = ".usage.json"
+	dataUsageCacheName   = ".usage-cache.bin"
+	dataUsageBloomName   = ".bloomcycle.bin"
+	dataUsageHistoryName = ".usage-history.bin"
 )
 
 // storeDataUsageInBackend will store all objects sent on the gui channel until closed.
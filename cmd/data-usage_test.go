@@ -21,10 +21,12 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"path"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 type usageTestFile struct {
@@ -691,3 +693,63 @@ func TestDataUsageCacheSerialize(t *testing.T) {
 	}
 
 }
+
+func TestDataUsageHistory(t *testing.T) {
+	start := UTCNow()
+	var h dataUsageHistory
+	for i := 0; i < dataUsageHistoryLen+10; i++ {
+		now := start.Add(time.Duration(i) * dataUsageHistoryInterval)
+		buckets := map[string]dataUsageSnapshot{
+			"bucket": {Size: uint64(i) * 100, Objects: uint64(i)},
+		}
+		if i < 5 {
+			buckets["deleted"] = dataUsageSnapshot{Size: 1}
+		}
+		pools := map[string]dataUsageSnapshot{
+			"pool": {Size: uint64(i) * 200, Capacity: 1 << 30},
+		}
+		if !h.add(now, buckets, pools) {
+			t.Fatalf("snapshot %d was not added", i)
+		}
+		// Snapshots are taken at most once per interval.
+		if h.add(now.Add(dataUsageHistoryInterval/2), buckets, pools) {
+			t.Fatalf("snapshot %d was added twice", i)
+		}
+	}
+
+	if _, ok := h.Buckets["deleted"]; ok {
+		t.Fatal("expected the deleted bucket to be dropped")
+	}
+	series := h.Buckets["bucket"]
+	if len(series) != dataUsageHistoryLen {
+		t.Fatalf("expected %d snapshots, got %d", dataUsageHistoryLen, len(series))
+	}
+	if series[0].Size != 1000 {
+		t.Fatalf("expected the oldest snapshots to be dropped, got %#v", series[0])
+	}
+
+	perSecond := float64(time.Second) / float64(dataUsageHistoryInterval)
+	size, objects := usageGrowth(series)
+	if math.Abs(size-100*perSecond) > 1e-9 || math.Abs(objects-perSecond) > 1e-9 {
+		t.Fatalf("unexpected bucket growth %v, %v", size, objects)
+	}
+	size, _ = usageGrowth(h.Pools["pool"])
+	if math.Abs(size-200*perSecond) > 1e-9 {
+		t.Fatalf("unexpected pool growth %v", size)
+	}
+	if size, objects = usageGrowth(series[:1]); size != 0 || objects != 0 {
+		t.Fatalf("expected no growth from a single snapshot, got %v, %v", size, objects)
+	}
+
+	b, err := h.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got dataUsageHistory
+	if _, err = got.UnmarshalMsg(b); err != nil {
+		t.Fatal(err)
+	}
+	if !got.LastUpdate.Equal(h.LastUpdate) || len(got.Buckets["bucket"]) != len(series) || got.Pools["pool"][0].Capacity != 1<<30 {
+		t.Fatalf("unexpected history after a round trip: %v", got.LastUpdate)
+	}
+}
@@ -655,6 +655,11 @@ func (c *cacheObjects) PutObject(ctx context.Context, bucket, object string, r *
 		dcache.Delete(ctx, bucket, object)
 		return putObjectFn(ctx, bucket, object, r, opts)
 	}
+	// conditional writes are evaluated by the backend
+	if opts.CheckPrecondFn != nil {
+		dcache.Delete(ctx, bucket, object)
+		return putObjectFn(ctx, bucket, object, r, opts)
+	}
 	if c.commitWriteback {
 		oi, err := dcache.Put(ctx, bucket, object, r, r.Size(), nil, opts, false)
 		if err != nil {
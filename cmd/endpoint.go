@@ -202,6 +202,7 @@ type ZoneEndpoints struct {
 	SetCount     int
 	DrivesPerSet int
 	Endpoints    Endpoints
+	CmdLine      string
 }
 
 // EndpointServerPools - list of list of endpoints
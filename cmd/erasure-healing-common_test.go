@@ -25,6 +25,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/minio/minio/cmd/config/storageclass"
 	"github.com/minio/minio/pkg/madmin"
 )
 
@@ -95,6 +96,9 @@ func TestListOnlineDisks(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Store the object in part files, which are tampered with below.
+	setTestStorageClass(t, storageclass.Config{})
+
 	obj, disks, err := prepareErasure16(ctx)
 	if err != nil {
 		t.Fatalf("Prepare Erasure backend failed - %v", err)
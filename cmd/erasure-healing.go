@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -337,10 +338,11 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 	defer ObjectPathUpdated(pathJoin(bucket, object))
 
 	cleanFileInfo := func(fi FileInfo) FileInfo {
-		// Returns a copy of the 'fi' with checksums and parts nil'ed.
+		// Returns a copy of the 'fi' with checksums, parts and inline data nil'ed.
 		nfi := fi
 		nfi.Erasure.Checksums = nil
 		nfi.Parts = nil
+		nfi.Data = nil
 		return nfi
 	}
 
@@ -395,13 +397,22 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 				if latestMeta.XLV1 {
 					partPath = pathJoin(object, fmt.Sprintf("part.%d", partNumber))
 				}
-				readers[i] = newBitrotReader(disk, bucket, partPath, tillOffset, checksumAlgo, checksumInfo.Hash, erasure.ShardSize())
+				readers[i] = newBitrotReader(disk, partsMetadata[i].inlineShard(), bucket, partPath, tillOffset, checksumAlgo, checksumInfo.Hash, erasure.ShardSize())
 			}
 			writers := make([]io.Writer, len(outDatedDisks))
+			var inlineBuffers []*bytes.Buffer
+			if latestMeta.InlineData() {
+				inlineBuffers = make([]*bytes.Buffer, len(outDatedDisks))
+			}
 			for i, disk := range outDatedDisks {
 				if disk == OfflineDisk {
 					continue
 				}
+				if latestMeta.InlineData() {
+					inlineBuffers[i] = bytes.NewBuffer(make([]byte, 0, tillOffset))
+					writers[i] = newStreamingBitrotWriterBuffer(inlineBuffers[i], DefaultBitrotAlgorithm, erasure.ShardSize())
+					continue
+				}
 				partPath := pathJoin(tmpID, dataDir, fmt.Sprintf("part.%d", partNumber))
 				writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, partPath, tillOffset, DefaultBitrotAlgorithm, erasure.ShardSize())
 			}
@@ -427,6 +438,9 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 				}
 
 				partsMetadata[i].DataDir = dataDir
+				if latestMeta.InlineData() {
+					partsMetadata[i].Data = inlineBuffers[i].Bytes()
+				}
 				partsMetadata[i].AddObjectPart(partNumber, "", partSize, partActualSize)
 				partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
 					PartNumber: partNumber,
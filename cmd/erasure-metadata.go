@@ -24,6 +24,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/minio/minio/cmd/config/storageclass"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/bucket/replication"
@@ -98,6 +99,25 @@ func (fi FileInfo) IsValid() bool {
 		correctIndexes)
 }
 
+// InlineData - returns true if the erasure shard of the object is
+// stored inline in its metadata, such objects have no data dir.
+func (fi FileInfo) InlineData() bool {
+	return fi.DataDir == "" && !fi.Deleted && !fi.XLV1
+}
+
+// inlineShard - returns the inline erasure shard to read instead of
+// the part files, nil if the object has a data dir.
+func (fi FileInfo) inlineShard() []byte {
+	if !fi.InlineData() {
+		return nil
+	}
+	if fi.Data == nil {
+		// A missing shard fails to read like a missing part file.
+		return []byte{}
+	}
+	return fi.Data
+}
+
 // ToObjectInfo - Converts metadata to object info.
 func (fi FileInfo) ToObjectInfo(bucket, object string) ObjectInfo {
 	object = decodeDirObject(object)
@@ -342,7 +362,12 @@ func objectQuorumFromMeta(ctx context.Context, er erasureObjects, partsMetaData
 	}
 
 	dataBlocks := latestFileInfo.Erasure.DataBlocks
-	parityBlocks := globalStorageClass.GetParityForSC(latestFileInfo.Metadata[xhttp.AmzStorageClass])
+	sc := latestFileInfo.Metadata[xhttp.AmzStorageClass]
+	parityBlocks := globalStorageClass.GetParityForSC(sc)
+	if sc != "" && !storageclass.IsValid(sc) {
+		// Custom storage classes of the bucket.
+		parityBlocks = latestFileInfo.Erasure.ParityBlocks
+	}
 	if parityBlocks == 0 {
 		parityBlocks = dataBlocks
 	}
@@ -29,10 +29,21 @@ import (
 	"github.com/minio/minio-go/v7/pkg/set"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/hash"
 	"github.com/minio/minio/pkg/mimedb"
 	"github.com/minio/minio/pkg/sync/errgroup"
 )
 
+// Records the bucket and object of a multipart upload, the upload
+// directory only carries their hash and the name is needed to move
+// the upload to another pool.
+const multipartUploadObjectKey = ReservedMetadataPrefixLower + "multipart-object"
+
+// Records the modification time of the source version of a replica
+// uploaded in parts, the replica is completed with it.
+const multipartReplicaMTimeKey = ReservedMetadataPrefixLower + "multipart-replica-mtime"
+
 func (er erasureObjects) getUploadIDDir(bucket, object, uploadID string) string {
 	return pathJoin(er.getMultipartSHADir(bucket, object), uploadID)
 }
@@ -241,10 +252,8 @@ func (er erasureObjects) ListMultipartUploads(ctx context.Context, bucket, objec
 func (er erasureObjects) newMultipartUpload(ctx context.Context, bucket string, object string, opts ObjectOptions) (string, error) {
 
 	onlineDisks := er.getDisks()
-	parityBlocks := globalStorageClass.GetParityForSC(opts.UserDefined[xhttp.AmzStorageClass])
-	if parityBlocks == 0 {
-		parityBlocks = len(onlineDisks) / 2
-	}
+	applyBucketStorageClass(bucket, object, opts.UserDefined)
+	parityBlocks := getParityForSC(bucket, opts.UserDefined[xhttp.AmzStorageClass], len(onlineDisks))
 	dataBlocks := len(onlineDisks) - parityBlocks
 
 	fi := newFileInfo(object, dataBlocks, parityBlocks)
@@ -272,6 +281,10 @@ func (er erasureObjects) newMultipartUpload(ctx context.Context, bucket string,
 	fi.DataDir = mustGetUUID()
 	fi.ModTime = UTCNow()
 	fi.Metadata = cloneMSS(opts.UserDefined)
+	fi.Metadata[multipartUploadObjectKey] = pathJoin(bucket, object)
+	if opts.UserDefined[xhttp.AmzBucketReplicationStatus] == replication.Replica.String() {
+		fi.Metadata[multipartReplicaMTimeKey] = opts.MTime.Format(time.RFC3339Nano)
+	}
 
 	uploadID := mustGetUUID()
 	uploadIDPath := er.getUploadIDDir(bucket, object, uploadID)
@@ -497,6 +510,11 @@ func (er erasureObjects) PutObjectPart(ctx context.Context, bucket, object, uplo
 	// Add the current part.
 	fi.AddObjectPart(partID, md5hex, n, data.ActualSize())
 
+	var checksum hash.Checksum
+	if c := r.ContentChecksum(); c != nil {
+		checksum = *c
+	}
+
 	for i, disk := range onlineDisks {
 		if disk == OfflineDisk {
 			continue
@@ -509,6 +527,14 @@ func (er erasureObjects) PutObjectPart(ctx context.Context, bucket, object, uplo
 			Algorithm:  DefaultBitrotAlgorithm,
 			Hash:       bitrotWriterSum(writers[i]),
 		})
+		// Replace the checksum of a previous upload of the part.
+		if partsMetadata[i].Metadata == nil {
+			partsMetadata[i].Metadata = make(map[string]string)
+		}
+		delete(partsMetadata[i].Metadata, objectChecksumPartKey(partID))
+		if checksum.IsSet() {
+			partsMetadata[i].Metadata[objectChecksumPartKey(partID)] = checksum.String()
+		}
 	}
 
 	// Writes update `xl.meta` format for each disk.
@@ -523,6 +549,7 @@ func (er erasureObjects) PutObjectPart(ctx context.Context, bucket, object, uplo
 		LastModified: fi.ModTime,
 		Size:         fi.Size,
 		ActualSize:   data.ActualSize(),
+		Checksum:     checksum,
 	}, nil
 }
 
@@ -647,11 +674,13 @@ func (er erasureObjects) ListObjectParts(ctx context.Context, bucket, object, up
 	}
 	count := maxParts
 	for _, part := range parts {
+		checksum, _ := hash.ParseChecksum(fi.Metadata[objectChecksumPartKey(part.Number)])
 		result.Parts = append(result.Parts, PartInfo{
 			PartNumber:   part.Number,
 			ETag:         part.ETag,
 			LastModified: fi.ModTime,
 			Size:         part.Size,
+			Checksum:     checksum,
 		})
 		count--
 		if count == 0 {
@@ -696,6 +725,22 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 
 	defer ObjectPathUpdated(pathJoin(bucket, object))
 
+	// Conditional writes hold the namespace lock for the whole
+	// transaction, the precondition is evaluated before the upload is
+	// modified and only one of several concurrent writers can succeed.
+	var lk RWLocker
+	if opts.CheckPrecondFn != nil {
+		lk = er.NewNSLock(bucket, object)
+		if err = lk.GetLock(ctx, globalOperationTimeout); err != nil {
+			return oi, err
+		}
+		defer lk.Unlock()
+
+		if err = er.checkWritePrecondition(ctx, bucket, object, opts); err != nil {
+			return oi, err
+		}
+	}
+
 	// Calculate s3 compatible md5sum for complete multipart.
 	s3MD5 := getCompleteMultipartMD5(parts)
 
@@ -794,15 +839,35 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 		fi.ModTime = UTCNow()
 	}
 
+	// Replicas keep the modification time and ETag of the source version.
+	sourceETag := fi.Metadata["etag"]
+	replicaMTime, isReplica := fi.Metadata[multipartReplicaMTimeKey]
+	if isReplica {
+		if mtime, err := time.Parse(time.RFC3339Nano, replicaMTime); err == nil {
+			fi.ModTime = mtime
+		}
+		delete(fi.Metadata, multipartReplicaMTimeKey)
+	}
+
 	// Save successfully calculated md5sum.
 	fi.Metadata["etag"] = s3MD5
 	if opts.UserDefined["etag"] != "" { // preserve ETag if set
 		fi.Metadata["etag"] = opts.UserDefined["etag"]
+	} else if isReplica && sourceETag != "" {
+		fi.Metadata["etag"] = sourceETag
 	}
 
 	// Save the consolidated actual size.
 	fi.Metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
 
+	// The object name is only needed while the upload is in progress.
+	delete(fi.Metadata, multipartUploadObjectKey)
+
+	// Save the checksum of the object computed from the checksums of the parts.
+	if err = completeObjectChecksum(fi.Metadata, parts); err != nil {
+		return oi, err
+	}
+
 	// Update all erasure metadata, make sure to not modify fields like
 	// checksum which are different on each disks.
 	for index := range partsMetadata {
@@ -833,11 +898,13 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 	}
 
 	// Hold namespace to complete the transaction
-	lk := er.NewNSLock(bucket, object)
-	if err = lk.GetLock(ctx, globalOperationTimeout); err != nil {
-		return oi, err
+	if lk == nil {
+		lk = er.NewNSLock(bucket, object)
+		if err = lk.GetLock(ctx, globalOperationTimeout); err != nil {
+			return oi, err
+		}
+		defer lk.Unlock()
 	}
-	defer lk.Unlock()
 
 	// Rename the multipart object to final location.
 	if onlineDisks, err = renameData(ctx, onlineDisks, minioMetaMultipartBucket, uploadIDPath,
@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -298,7 +299,7 @@ func (er erasureObjects) getObjectWithFileInfo(ctx context.Context, bucket, obje
 			}
 			checksumInfo := metaArr[index].Erasure.GetChecksumInfo(partNumber)
 			partPath := pathJoin(object, metaArr[index].DataDir, fmt.Sprintf("part.%d", partNumber))
-			readers[index] = newBitrotReader(disk, bucket, partPath, tillOffset,
+			readers[index] = newBitrotReader(disk, metaArr[index].inlineShard(), bucket, partPath, tillOffset,
 				checksumInfo.Algorithm, checksumInfo.Hash, erasure.ShardSize())
 
 			// Prefer local disks
@@ -436,6 +437,27 @@ func (er erasureObjects) getObjectInfo(ctx context.Context, bucket, object strin
 	return objInfo, nil
 }
 
+// checkWritePrecondition - evaluates the write preconditions of opts
+// against the latest version of the object, a missing object or a
+// delete marker is passed as an empty ObjectInfo. The caller must hold
+// the namespace write lock of the object.
+func (er erasureObjects) checkWritePrecondition(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	if opts.CheckPrecondFn == nil {
+		return nil
+	}
+	oi, err := er.getObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+			return err
+		}
+		oi = ObjectInfo{}
+	}
+	if opts.CheckPrecondFn(oi) {
+		return PreConditionFailed{}
+	}
+	return nil
+}
+
 func undoRename(disks []StorageAPI, srcBucket, srcEntry, dstBucket, dstEntry string, isDir bool, errs []error) {
 	// Undo rename object on disks where RenameFile succeeded.
 
@@ -463,7 +485,10 @@ func undoRename(disks []StorageAPI, srcBucket, srcEntry, dstBucket, dstEntry str
 
 // Similar to rename but renames data from srcEntry to dstEntry at dataDir
 func renameData(ctx context.Context, disks []StorageAPI, srcBucket, srcEntry, dataDir, dstBucket, dstEntry string, writeQuorum int, ignoredErr []error) ([]StorageAPI, error) {
-	dataDir = retainSlash(dataDir)
+	// Inlined objects have no data dir.
+	if dataDir != "" {
+		dataDir = retainSlash(dataDir)
+	}
 	defer ObjectPathUpdated(pathJoin(srcBucket, srcEntry))
 	defer ObjectPathUpdated(pathJoin(dstBucket, dstEntry))
 
@@ -576,10 +601,8 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 	storageDisks := er.getDisks()
 
 	// Get parity and data drive count based on storage class metadata
-	parityDrives := globalStorageClass.GetParityForSC(opts.UserDefined[xhttp.AmzStorageClass])
-	if parityDrives == 0 {
-		parityDrives = getDefaultParityBlocks(len(storageDisks))
-	}
+	applyBucketStorageClass(bucket, object, opts.UserDefined)
+	parityDrives := getParityForSC(bucket, opts.UserDefined[xhttp.AmzStorageClass], len(storageDisks))
 	dataDrives := len(storageDisks) - parityDrives
 
 	// we now know the number of blocks this object needs for data and parity.
@@ -618,7 +641,12 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 			fi.VersionID = mustGetUUID()
 		}
 	}
-	fi.DataDir = mustGetUUID()
+
+	// Small objects are stored inline in xl.meta, without a data dir.
+	inline := globalStorageClass.ShouldInline(data.Size())
+	if !inline {
+		fi.DataDir = mustGetUUID()
+	}
 
 	// Initialize erasure metadata.
 	for index := range partsMetadata {
@@ -655,10 +683,19 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 	tempErasureObj := pathJoin(uniqueID, fi.DataDir, partName)
 
 	writers := make([]io.Writer, len(onlineDisks))
+	var inlineBuffers []*bytes.Buffer
+	if inline {
+		inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
+	}
 	for i, disk := range onlineDisks {
 		if disk == nil {
 			continue
 		}
+		if inline {
+			inlineBuffers[i] = bytes.NewBuffer(make([]byte, 0, bitrotShardFileSize(erasure.ShardFileSize(data.Size()), erasure.ShardSize(), DefaultBitrotAlgorithm)))
+			writers[i] = newStreamingBitrotWriterBuffer(inlineBuffers[i], DefaultBitrotAlgorithm, erasure.ShardSize())
+			continue
+		}
 		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tempErasureObj, erasure.ShardFileSize(data.Size()), DefaultBitrotAlgorithm, erasure.ShardSize())
 	}
 
@@ -680,11 +717,20 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 	}
 	defer lk.Unlock()
 
+	// Conditional writes are evaluated under the namespace lock, so
+	// that only one of several concurrent writers can succeed.
+	if err = er.checkWritePrecondition(ctx, bucket, object, opts); err != nil {
+		return ObjectInfo{}, err
+	}
+
 	for i, w := range writers {
 		if w == nil {
 			onlineDisks[i] = nil
 			continue
 		}
+		if inline {
+			partsMetadata[i].Data = inlineBuffers[i].Bytes()
+		}
 		partsMetadata[i].AddObjectPart(1, "", n, data.ActualSize())
 		partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
 			PartNumber: 1,
@@ -695,6 +741,9 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 	if opts.UserDefined["etag"] == "" {
 		opts.UserDefined["etag"] = r.MD5CurrentHexString()
 	}
+	if checksum := r.ContentChecksum(); checksum != nil {
+		opts.UserDefined[objectChecksumKey] = checksum.String()
+	}
 
 	// Guess content-type from the extension if possible.
 	if opts.UserDefined["content-type"] == "" {
@@ -1007,6 +1056,9 @@ func (er erasureObjects) DeleteObject(ctx context.Context, bucket, object string
 				DeleteMarkerReplicationStatus: opts.DeleteMarkerReplicationStatus,
 				VersionPurgeStatus:            opts.VersionPurgeStatus,
 			}
+			if opts.TargetReplicationStatus != "" {
+				fi.Metadata = map[string]string{targetReplicationStatusKey: opts.TargetReplicationStatus}
+			}
 			if opts.Versioned {
 				fi.VersionID = mustGetUUID()
 				if opts.VersionID != "" {
@@ -1027,7 +1079,7 @@ func (er erasureObjects) DeleteObject(ctx context.Context, bucket, object string
 	}
 
 	// Delete the object version on all disks.
-	if err = er.deleteObjectVersion(ctx, bucket, object, writeQuorum, FileInfo{
+	fi := FileInfo{
 		Name:                          object,
 		VersionID:                     opts.VersionID,
 		MarkDeleted:                   markDelete,
@@ -1036,7 +1088,11 @@ func (er erasureObjects) DeleteObject(ctx context.Context, bucket, object string
 		DeleteMarkerReplicationStatus: opts.DeleteMarkerReplicationStatus,
 		VersionPurgeStatus:            opts.VersionPurgeStatus,
 		TransitionStatus:              opts.TransitionStatus,
-	}); err != nil {
+	}
+	if opts.TargetReplicationStatus != "" {
+		fi.Metadata = map[string]string{targetReplicationStatusKey: opts.TargetReplicationStatus}
+	}
+	if err = er.deleteObjectVersion(ctx, bucket, object, writeQuorum, fi); err != nil {
 		return objInfo, toObjectErr(err, bucket, object)
 	}
 
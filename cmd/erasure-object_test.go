@@ -19,6 +19,7 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"io/ioutil"
 	"os"
@@ -26,6 +27,8 @@ import (
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/minio/cmd/config/storageclass"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/madmin"
 )
 
 func TestRepeatPutObjectPart(t *testing.T) {
@@ -267,6 +270,9 @@ func TestGetObjectNoQuorum(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Store the object in part files, which fail to be read below.
+	setTestStorageClass(t, storageclass.Config{})
+
 	// Create an instance of xl backend.
 	obj, fsDirs, err := prepareErasure16(ctx)
 	if err != nil {
@@ -578,3 +584,215 @@ func testObjectQuorumFromMeta(obj ObjectLayer, instanceType string, dirs []strin
 		})
 	}
 }
+
+func TestPutObjectInline(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	setTestStorageClass(t, storageclass.Config{InlineBlock: 128 * humanize.KiByte})
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Returns the entries of the object directory on every disk.
+	objectEntries := func(object string) [][]string {
+		entries := make([][]string, len(fsDirs))
+		for i, dir := range fsDirs {
+			fis, err := ioutil.ReadDir(pathJoin(dir, bucket, object))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, fi := range fis {
+				entries[i] = append(entries[i], fi.Name())
+			}
+		}
+		return entries
+	}
+	putObject := func(object string, data []byte) {
+		if _, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	getObject := func(object string, data []byte) {
+		var buf bytes.Buffer
+		if err := obj.GetObject(ctx, bucket, object, 0, int64(len(data)), &buf, "", ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), data) {
+			t.Fatalf("unexpected content for %s", object)
+		}
+		buf.Reset()
+		if err := obj.GetObject(ctx, bucket, object, 1, int64(len(data)-2), &buf, "", ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), data[1:len(data)-1]) {
+			t.Fatalf("unexpected content for range of %s", object)
+		}
+	}
+
+	small := bytes.Repeat([]byte("a"), humanize.KiByte)
+	large := bytes.Repeat([]byte("b"), 128*humanize.KiByte)
+
+	putObject("small", small)
+	putObject("large", large)
+	for i, entries := range objectEntries("small") {
+		if len(entries) != 1 || entries[0] != xlStorageFormatFile {
+			t.Fatalf("disk %d: expected only %s for the inlined object, got %v", i, xlStorageFormatFile, entries)
+		}
+	}
+	for i, entries := range objectEntries("large") {
+		if len(entries) != 2 {
+			t.Fatalf("disk %d: expected a data dir for the large object, got %v", i, entries)
+		}
+	}
+	getObject("small", small)
+	getObject("large", large)
+
+	// The inline data is healed along with the metadata.
+	for _, dir := range fsDirs[:2] {
+		if err = os.Remove(pathJoin(dir, bucket, "small", xlStorageFormatFile)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	getObject("small", small)
+	if _, err = obj.HealObject(ctx, bucket, "small", "", madmin.HealOpts{ScanMode: madmin.HealDeepScan}); err != nil {
+		t.Fatal(err)
+	}
+	z := obj.(*erasureServerPools)
+	for i, disk := range z.serverPools[0].sets[0].getDisks() {
+		fi, err := disk.ReadVersion(ctx, bucket, "small", "", true)
+		if err != nil {
+			t.Fatalf("disk %d: %v", i, err)
+		}
+		if !fi.InlineData() || len(fi.Data) == 0 {
+			t.Fatalf("disk %d: expected inline data after heal", i)
+		}
+		if err = disk.VerifyFile(ctx, bucket, "small", fi); err != nil {
+			t.Fatalf("disk %d: %v", i, err)
+		}
+	}
+
+	// Overwriting with an inlined object removes the previous data dir.
+	putObject("large", small)
+	for i, entries := range objectEntries("large") {
+		if len(entries) != 1 || entries[0] != xlStorageFormatFile {
+			t.Fatalf("disk %d: expected only %s after the overwrite, got %v", i, xlStorageFormatFile, entries)
+		}
+	}
+	getObject("large", small)
+
+	// Metadata updates preserve the inline data.
+	if err = obj.PutObjectTags(ctx, bucket, "small", "key=value", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	getObject("small", small)
+
+	if _, err = obj.DeleteObject(ctx, bucket, "small", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.GetObjectInfo(ctx, bucket, "small", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("expected object not found, got %v", err)
+	}
+}
+
+func TestPutObjectBucketStorageClass(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	setTestStorageClass(t, storageclass.Config{})
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	// The bucket metadata is saved through the global object layer.
+	defer setObjectLayer(newObjectLayerFn())
+	setObjectLayer(obj)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	scCfg := madmin.BucketStorageClass{
+		Classes: map[string]string{
+			"SCRATCH": "EC:2",
+			"ARCHIVE": "EC:6",
+		},
+		Rules: []madmin.StorageClassRule{
+			{Prefix: "scratch/", StorageClass: "SCRATCH"},
+			{Tags: map[string]string{"retention": "long"}, StorageClass: "ARCHIVE"},
+		},
+	}
+	data, err := json.Marshal(scCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = globalBucketMetadataSys.Update(bucket, bucketStorageClassConfigFile, data); err != nil {
+		t.Fatal(err)
+	}
+
+	xl := obj.(*erasureServerPools).serverPools[0].sets[0]
+	content := bytes.Repeat([]byte("a"), 1024)
+	testCases := []struct {
+		object       string
+		metadata     map[string]string
+		storageClass string
+		parity       int
+	}{
+		{"scratch/object", nil, "SCRATCH", 2},
+		{"object", map[string]string{xhttp.AmzObjectTagging: "retention=long"}, "ARCHIVE", 6},
+		{"object", map[string]string{xhttp.AmzObjectTagging: "retention=short"}, "", 8},
+		// Storage classes sent by the client take precedence.
+		{"scratch/object", map[string]string{xhttp.AmzStorageClass: storageclass.RRS}, storageclass.RRS, 2},
+		{"scratch/object", map[string]string{xhttp.AmzStorageClass: storageclass.STANDARD}, storageclass.STANDARD, 8},
+	}
+	for i, tc := range testCases {
+		oi, err := obj.PutObject(ctx, bucket, tc.object, mustGetPutObjReader(t, bytes.NewReader(content), int64(len(content)), "", ""), ObjectOptions{UserDefined: tc.metadata})
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if oi.StorageClass != tc.storageClass && !(tc.storageClass == "" && oi.StorageClass == globalMinioDefaultStorageClass) {
+			t.Errorf("Test %d: expected storage class %q, got %q", i+1, tc.storageClass, oi.StorageClass)
+		}
+		fi, _, _, err := xl.getObjectFileInfo(ctx, bucket, tc.object, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if fi.Erasure.ParityBlocks != tc.parity {
+			t.Errorf("Test %d: expected parity %d, got %d", i+1, tc.parity, fi.Erasure.ParityBlocks)
+		}
+	}
+
+	// Multipart uploads follow the same rules.
+	uploadID, err := obj.NewMultipartUpload(ctx, bucket, "scratch/upload", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pi, err := obj.PutObjectPart(ctx, bucket, "scratch/upload", uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(content), int64(len(content)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.CompleteMultipartUpload(ctx, bucket, "scratch/upload", uploadID, []CompletePart{{PartNumber: 1, ETag: pi.ETag}}, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	fi, _, _, err := xl.getObjectFileInfo(ctx, bucket, "scratch/upload", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Erasure.ParityBlocks != 2 || fi.Metadata[xhttp.AmzStorageClass] != "SCRATCH" {
+		t.Fatalf("expected SCRATCH with parity 2, got %q with parity %d", fi.Metadata[xhttp.AmzStorageClass], fi.Erasure.ParityBlocks)
+	}
+}
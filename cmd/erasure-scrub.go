@@ -0,0 +1,260 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Scrub reports are saved per drive position under this prefix.
+	scrubReportPrefix = minioConfigPrefix + "/scrub"
+
+	// Interval between checks for drives due for a scrub.
+	scrubCheckInterval = time.Minute
+	// Interval between saves of the progress of a scrub.
+	scrubSaveInterval = 30 * time.Second
+	// Versions verified per second when no rate is configured.
+	defaultScrubRate = 100
+	// Corruptions kept in the report of a drive.
+	scrubMaxCorruptions = 100
+)
+
+// scrubReportPath - returns the path of the scrub report of the drive.
+func scrubReportPath(p drivePosition) string {
+	return pathJoin(scrubReportPrefix, fmt.Sprintf("pool-%d-set-%d-disk-%d.json", p.pool, p.set, p.disk))
+}
+
+// loadScrubReport - reads the scrub report of the drive, an empty report
+// is returned if the drive was never scrubbed.
+func loadScrubReport(ctx context.Context, objAPI ObjectLayer, p drivePosition) (madmin.ScrubDriveReport, error) {
+	report := madmin.ScrubDriveReport{
+		Endpoint:  p.endpoint,
+		PoolIndex: p.pool,
+		SetIndex:  p.set,
+		DiskIndex: p.disk,
+	}
+	data, err := readConfig(ctx, objAPI, scrubReportPath(p))
+	if err != nil {
+		if err == errConfigNotFound {
+			return report, nil
+		}
+		return report, err
+	}
+	if err = json.Unmarshal(data, &report); err != nil {
+		return report, err
+	}
+	// The drive may have moved to another endpoint.
+	report.Endpoint = p.endpoint
+	return report, nil
+}
+
+// saveScrubReport - writes the scrub report of the drive.
+func saveScrubReport(ctx context.Context, objAPI ObjectLayer, p drivePosition, report madmin.ScrubDriveReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, scrubReportPath(p), data)
+}
+
+// addScrubCorruption - records a corrupted shard in the report, only
+// the most recent corruptions are kept.
+func addScrubCorruption(report *madmin.ScrubDriveReport, c madmin.ScrubCorruption) {
+	report.CorruptedShards++
+	report.TotalCorruptedShards++
+	report.Corruptions = append(report.Corruptions, c)
+	if len(report.Corruptions) > scrubMaxCorruptions {
+		report.Corruptions = report.Corruptions[len(report.Corruptions)-scrubMaxCorruptions:]
+	}
+}
+
+// initScrubber - starts the scrubber which periodically verifies the
+// shards on the local drives of this server.
+func initScrubber(ctx context.Context, objAPI ObjectLayer) {
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		return
+	}
+	go z.runScrubber(ctx)
+}
+
+func (z *erasureServerPools) runScrubber(ctx context.Context) {
+	timer := time.NewTimer(scrubCheckInterval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			globalHealConfigMu.Lock()
+			cfg := globalHealConfig
+			globalHealConfigMu.Unlock()
+
+			if cfg.ScrubInterval > 0 {
+				z.scrubLocalDrives(ctx, cfg.ScrubInterval, cfg.ScrubRate)
+			}
+			timer.Reset(scrubCheckInterval)
+		}
+	}
+}
+
+// scrubLocalDrives - scrubs the local drives which were not scrubbed
+// within the interval, one drive at a time.
+func (z *erasureServerPools) scrubLocalDrives(ctx context.Context, interval time.Duration, rate int) {
+	positions, disks := z.drives()
+	for i, p := range positions {
+		disk := disks[i]
+		if disk == nil || !disk.IsLocal() || !disk.IsOnline() || disk.Healing() {
+			continue
+		}
+		diskID, err := disk.GetDiskID()
+		if err != nil {
+			continue
+		}
+		report, err := loadScrubReport(ctx, z, p)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+		if report.DiskID != diskID {
+			// A new drive, start a new report.
+			report = madmin.ScrubDriveReport{
+				Endpoint:  p.endpoint,
+				PoolIndex: p.pool,
+				SetIndex:  p.set,
+				DiskIndex: p.disk,
+				DiskID:    diskID,
+			}
+		} else if !report.Finished.IsZero() && time.Since(report.Finished) < interval {
+			continue
+		}
+		if err = z.scrubDrive(ctx, disk, p, &report, rate); err != nil {
+			logger.LogIf(ctx, fmt.Errorf("scrub of drive %s failed: %w", p.endpoint, err))
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// scrubDrive - verifies the bitrot hash of the shards of every object
+// version on the drive, at most rate versions per second. Versions with
+// corrupted or missing shards are recorded in the report and healed.
+func (z *erasureServerPools) scrubDrive(ctx context.Context, disk StorageAPI, p drivePosition, report *madmin.ScrubDriveReport, rate int) error {
+	if rate <= 0 {
+		rate = defaultScrubRate
+	}
+	report.Started = UTCNow()
+	report.Finished = time.Time{}
+	report.ObjectsScanned = 0
+	report.BytesScanned = 0
+	report.CorruptedShards = 0
+	if err := saveScrubReport(ctx, z, p, *report); err != nil {
+		return err
+	}
+
+	buckets, err := z.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+
+	throttle := time.NewTicker(time.Second / time.Duration(rate))
+	defer throttle.Stop()
+	lastSave := UTCNow()
+
+	scrubBucket := func(bucket string) error {
+		done := make(chan struct{})
+		defer close(done)
+		entryCh, err := disk.WalkVersions(ctx, bucket, "", "", true, done)
+		if err != nil {
+			if errors.Is(err, errVolumeNotFound) {
+				return nil
+			}
+			return err
+		}
+		for entry := range entryCh {
+			for _, fi := range entry.Versions {
+				if fi.Deleted || fi.TransitionStatus == lifecycle.TransitionComplete {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-throttle.C:
+				}
+				err := disk.VerifyFile(ctx, bucket, fi.Name, fi)
+				report.ObjectsScanned++
+				report.BytesScanned += uint64(fi.Size)
+				switch {
+				case err == nil:
+				case errors.Is(err, errFileCorrupt), errors.Is(err, errFileNotFound):
+					addScrubCorruption(report, madmin.ScrubCorruption{
+						Bucket:    bucket,
+						Object:    fi.Name,
+						VersionID: fi.VersionID,
+						Time:      UTCNow(),
+						Error:     err.Error(),
+					})
+					deepHealObject(bucket, fi.Name, fi.VersionID)
+				default:
+					return err
+				}
+			}
+			if time.Since(lastSave) > scrubSaveInterval {
+				if err := saveScrubReport(ctx, z, p, *report); err != nil {
+					return err
+				}
+				lastSave = UTCNow()
+			}
+		}
+		return nil
+	}
+
+	for _, bucket := range buckets {
+		if err = scrubBucket(bucket.Name); err != nil {
+			// Keep the progress made so far.
+			logger.LogIf(ctx, saveScrubReport(ctx, z, p, *report))
+			return err
+		}
+	}
+
+	report.Finished = UTCNow()
+	return saveScrubReport(ctx, z, p, *report)
+}
+
+// ScrubStatus - returns the scrub report of all drives.
+func (z *erasureServerPools) ScrubStatus(ctx context.Context) ([]madmin.ScrubDriveReport, error) {
+	positions, _ := z.drives()
+	reports := make([]madmin.ScrubDriveReport, len(positions))
+	for i, p := range positions {
+		report, err := loadScrubReport(ctx, z, p)
+		if err != nil {
+			return nil, err
+		}
+		reports[i] = report
+	}
+	return reports, nil
+}
@@ -0,0 +1,110 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestScrubDrive(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Nothing is inlined, so that every version has a part file.
+	z := prepareErasurePools(ctx, t, 1)
+
+	bucket := "bucket"
+	if err := z.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("a"), 1024)
+	for _, object := range []string{"clean", "corrupt"} {
+		if _, err := z.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Flip the shard of the second object on the first drive.
+	positions, drives := z.drives()
+	err := filepath.Walk(filepath.Join(positions[0].endpoint, bucket, "corrupt"), func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.Name() != "part.1" {
+			return err
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		b[len(b)-1] ^= 0xff
+		return ioutil.WriteFile(path, b, 0644)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, p := range positions {
+		report, err := loadScrubReport(ctx, z, p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = z.scrubDrive(ctx, drives[i], p, &report, 1000); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reports, err := z.ScrubStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 4 {
+		t.Fatalf("expected 4 reports, got %d", len(reports))
+	}
+	for i, report := range reports {
+		if report.Started.IsZero() || report.Finished.IsZero() || report.ObjectsScanned != 2 {
+			t.Fatalf("expected a finished scrub of 2 objects, got %#v", report)
+		}
+		var want uint64
+		if report.Endpoint == positions[0].endpoint {
+			want = 1
+		}
+		if report.CorruptedShards != want || report.TotalCorruptedShards != want {
+			t.Fatalf("drive %d: expected %d corrupted shards, got %#v", i, want, report)
+		}
+	}
+	c := reports[0].Corruptions
+	if len(c) != 1 || c[0].Bucket != bucket || c[0].Object != "corrupt" {
+		t.Fatalf("unexpected corruptions %#v", c)
+	}
+
+	// Corruptions accumulate across scrubs, up to a limit.
+	report := reports[0]
+	for i := 0; i < scrubMaxCorruptions; i++ {
+		addScrubCorruption(&report, madmin.ScrubCorruption{Bucket: bucket, Object: "other"})
+	}
+	if len(report.Corruptions) != scrubMaxCorruptions || report.Corruptions[0].Object != "other" {
+		t.Fatalf("expected the oldest corruptions to be dropped, got %d", len(report.Corruptions))
+	}
+	if report.TotalCorruptedShards != scrubMaxCorruptions+1 {
+		t.Fatalf("expected %d corrupted shards, got %d", scrubMaxCorruptions+1, report.TotalCorruptedShards)
+	}
+}
@@ -0,0 +1,140 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// poolsCapacity - returns the used and total raw capacity of each pool.
+func (z *erasureServerPools) poolsCapacity(ctx context.Context) (used, capacity []uint64) {
+	used = make([]uint64, len(z.serverPools))
+	capacity = make([]uint64, len(z.serverPools))
+	for i, pool := range z.serverPools {
+		for _, disk := range pool.StorageUsageInfo(ctx).Disks {
+			used[i] += disk.UsedSpace
+			capacity[i] += disk.TotalSpace
+		}
+	}
+	return used, capacity
+}
+
+// recordUsageHistory - adds snapshots of the usage of all buckets, taken
+// from the merged crawler results, and of all pools to the usage history.
+func (z *erasureServerPools) recordUsageHistory(ctx context.Context, merged dataUsageCache, buckets []BucketInfo) error {
+	var history dataUsageHistory
+	if err := history.load(ctx, z); err != nil {
+		return err
+	}
+
+	bucketSnapshots := make(map[string]dataUsageSnapshot, len(buckets))
+	for _, bucket := range buckets {
+		usage := merged.bucketUsageInfo(bucket.Name)
+		bucketSnapshots[bucket.Name] = dataUsageSnapshot{Size: usage.Size, Objects: usage.ObjectsCount}
+	}
+	used, capacity := z.poolsCapacity(ctx)
+	poolSnapshots := make(map[string]dataUsageSnapshot, len(z.serverPools))
+	for i := range z.serverPools {
+		poolSnapshots[z.poolCmdLine(i)] = dataUsageSnapshot{Size: used[i], Capacity: capacity[i]}
+	}
+
+	if !history.add(UTCNow(), bucketSnapshots, poolSnapshots) {
+		return nil
+	}
+	return history.save(ctx, z)
+}
+
+// projectFull - returns when the usage reaches the limit growing at rate
+// bytes per second, zero if it does not grow.
+func projectFull(now time.Time, usage, limit uint64, rate float64) time.Time {
+	if rate <= 0 {
+		return time.Time{}
+	}
+	if usage >= limit {
+		return now
+	}
+	return now.Add(time.Duration(float64(limit-usage) / rate * float64(time.Second)))
+}
+
+// CapacityForecast - returns the growth of all pools and buckets estimated
+// from the usage history, along with the projected dates when the pools
+// are full and when the bucket quotas are reached.
+func (z *erasureServerPools) CapacityForecast(ctx context.Context) (madmin.CapacityForecast, error) {
+	var history dataUsageHistory
+	if err := history.load(ctx, z); err != nil {
+		return madmin.CapacityForecast{}, err
+	}
+	buckets, err := z.ListBuckets(ctx)
+	if err != nil {
+		return madmin.CapacityForecast{}, err
+	}
+	dataUsageInfo, err := loadDataUsageFromBackend(ctx, z)
+	if err != nil {
+		return madmin.CapacityForecast{}, err
+	}
+
+	const day = float64(24 * time.Hour / time.Second)
+	now := UTCNow()
+	forecast := madmin.CapacityForecast{
+		LastUpdate: history.LastUpdate,
+		Pools:      make([]madmin.PoolCapacityForecast, len(z.serverPools)),
+		Buckets:    make([]madmin.BucketCapacityForecast, 0, len(buckets)),
+	}
+	since := func(series []dataUsageSnapshot) {
+		if len(series) > 0 && (forecast.Since.IsZero() || series[0].Time.Before(forecast.Since)) {
+			forecast.Since = series[0].Time
+		}
+	}
+
+	used, capacity := z.poolsCapacity(ctx)
+	for i := range z.serverPools {
+		series := history.Pools[z.poolCmdLine(i)]
+		since(series)
+		rate, _ := usageGrowth(series)
+		forecast.Pools[i] = madmin.PoolCapacityForecast{
+			ID:         i,
+			CmdLine:    z.poolCmdLine(i),
+			Used:       used[i],
+			Capacity:   capacity[i],
+			GrowthRate: rate * day,
+			Full:       projectFull(now, used[i], capacity[i], rate),
+		}
+	}
+
+	for _, bucket := range buckets {
+		series := history.Buckets[bucket.Name]
+		since(series)
+		rate, objectsRate := usageGrowth(series)
+		usage := dataUsageInfo.BucketsUsage[bucket.Name]
+		bf := madmin.BucketCapacityForecast{
+			Bucket:            bucket.Name,
+			Size:              usage.Size,
+			Objects:           usage.ObjectsCount,
+			GrowthRate:        rate * day,
+			ObjectsGrowthRate: objectsRate * day,
+		}
+		if q, err := globalBucketQuotaSys.Get(bucket.Name); err == nil && q.Quota > 0 {
+			bf.Quota = q.Quota
+			bf.QuotaFull = projectFull(now, usage.Size, q.Quota, rate)
+		}
+		forecast.Buckets = append(forecast.Buckets, bf)
+	}
+	return forecast, nil
+}
@@ -0,0 +1,95 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestCapacityForecast(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z := prepareErasurePools(ctx, t, 1)
+	defer setObjectLayer(newObjectLayerFn())
+	setObjectLayer(z)
+
+	bucket := "bucket"
+	if err := z.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	quota, err := json.Marshal(madmin.BucketQuota{Quota: 1 << 30, Type: madmin.HardQuota})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = globalBucketMetadataSys.Update(bucket, bucketQuotaConfigFile, quota); err != nil {
+		t.Fatal(err)
+	}
+
+	// No history yet, nothing grows.
+	forecast, err := z.CapacityForecast(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(forecast.Pools) != 1 || forecast.Pools[0].Capacity == 0 || !forecast.Pools[0].Full.IsZero() {
+		t.Fatalf("unexpected pool forecast %#v", forecast.Pools)
+	}
+	if len(forecast.Buckets) != 1 || forecast.Buckets[0].Quota != 1<<30 || !forecast.Buckets[0].QuotaFull.IsZero() {
+		t.Fatalf("unexpected bucket forecast %#v", forecast.Buckets)
+	}
+
+	// A day of history where the bucket and pool grow by 1MiB per hour.
+	const growth = 1 << 20
+	used, capacity := z.poolsCapacity(ctx)
+	start := UTCNow().Add(-24 * time.Hour)
+	var history dataUsageHistory
+	for i := 0; i <= 24; i++ {
+		history.add(start.Add(time.Duration(i)*time.Hour),
+			map[string]dataUsageSnapshot{bucket: {Size: uint64(i) * growth}},
+			map[string]dataUsageSnapshot{z.poolCmdLine(0): {Size: used[0] - uint64(24-i)*growth, Capacity: capacity[0]}})
+	}
+	if err = history.save(ctx, z); err != nil {
+		t.Fatal(err)
+	}
+
+	forecast, err = z.CapacityForecast(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !forecast.Since.Equal(start) || !forecast.LastUpdate.Equal(history.LastUpdate) {
+		t.Fatalf("unexpected history range %v - %v", forecast.Since, forecast.LastUpdate)
+	}
+	pool := forecast.Pools[0]
+	if math.Abs(pool.GrowthRate-24*growth) > 1 || pool.Full.IsZero() {
+		t.Fatalf("unexpected pool forecast %#v", pool)
+	}
+	b := forecast.Buckets[0]
+	if math.Abs(b.GrowthRate-24*growth) > 1 {
+		t.Fatalf("unexpected bucket growth %v", b.GrowthRate)
+	}
+	// The bucket is empty according to the crawler, its 1GiB quota
+	// is reached in 1024 hours.
+	if want := UTCNow().Add(1024 * time.Hour); b.QuotaFull.Before(want.Add(-time.Minute)) || b.QuotaFull.After(want.Add(time.Minute)) {
+		t.Fatalf("expected the quota to be reached around %v, got %v", want, b.QuotaFull)
+	}
+}
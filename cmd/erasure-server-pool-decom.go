@@ -0,0 +1,1083 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/sync/errgroup"
+)
+
+const (
+	// Pool metadata is saved on every pool in the meta bucket, the
+	// most recently updated copy wins when loading.
+	poolMetaName    = "pool.json"
+	poolMetaFormat  = 1
+	poolMetaVersion = 1
+
+	// Interval at which the progress of a decommission is saved.
+	decommissionSaveInterval = 30 * time.Second
+)
+
+var (
+	errNoSuchPool                 = errors.New("no such pool")
+	errDecommissionSinglePool     = errors.New("a single pool cannot be decommissioned")
+	errDecommissionNoTargetPool   = errors.New("no pools are left to move the objects to")
+	errDecommissionAlreadyRunning = errors.New("a pool is already being decommissioned")
+	errDecommissionComplete       = errors.New("pool is already decommissioned")
+	errDecommissionNotStarted     = errors.New("pool is not being decommissioned")
+	errDecommissionCanceled       = errors.New("decommission was canceled")
+	errDecommissionTransitioned   = errors.New("transitioned object versions cannot be decommissioned")
+	errDecommissionUnknownUpload  = errors.New("multipart upload does not record its object name")
+)
+
+// poolStatus - status of a pool as persisted in pool.json.
+type poolStatus struct {
+	madmin.PoolStatus
+
+	// Buckets fully moved in the current pass of the decommission,
+	// they are skipped when a decommission is resumed.
+	DecommissionedBuckets []string `json:"decommissionedBuckets,omitempty"`
+}
+
+// poolMeta - status of all pools, persisted in pool.json.
+type poolMeta struct {
+	Format  int          `json:"format"`
+	Version int          `json:"version"`
+	Updated time.Time    `json:"updated"`
+	Pools   []poolStatus `json:"pools"`
+
+	// Status of the last rebalance, if any.
+	Rebalance *rebalanceMeta `json:"rebalance,omitempty"`
+	// Status of the last re-stripe, if any.
+	Restripe *restripeMeta `json:"restripe,omitempty"`
+}
+
+// pool - returns the status of the pool started with cmdLine.
+func (p poolMeta) pool(cmdLine string) *poolStatus {
+	for i := range p.Pools {
+		if p.Pools[i].CmdLine == cmdLine {
+			return &p.Pools[i]
+		}
+	}
+	return nil
+}
+
+// poolCmdLine - returns the command line a pool was started with, pools
+// created without one (tests) are identified by their endpoints.
+func (z *erasureServerPools) poolCmdLine(idx int) string {
+	if z.poolCmdLines[idx] != "" {
+		return z.poolCmdLines[idx]
+	}
+	return strings.Join(z.serverPools[idx].endpointStrings, " ")
+}
+
+// loadPoolMeta - reads pool.json from all pools and returns the most
+// recently updated copy, an empty poolMeta is returned if none is found.
+func (z *erasureServerPools) loadPoolMeta(ctx context.Context) (poolMeta, error) {
+	var meta poolMeta
+	for _, pool := range z.serverPools {
+		var buf bytes.Buffer
+		if err := pool.GetObject(ctx, minioMetaBucket, poolMetaName, 0, -1, &buf, "", ObjectOptions{}); err != nil {
+			if isErrObjectNotFound(err) {
+				continue
+			}
+			return meta, err
+		}
+		var m poolMeta
+		if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+			return meta, err
+		}
+		if m.Format != poolMetaFormat {
+			return meta, fmt.Errorf("unknown pool metadata format %d", m.Format)
+		}
+		if m.Updated.After(meta.Updated) {
+			meta = m
+		}
+	}
+	return meta, nil
+}
+
+// savePoolMeta - writes the in-memory pool metadata to all pools.
+func (z *erasureServerPools) savePoolMeta(ctx context.Context) error {
+	z.poolMetaMutex.RLock()
+	meta := z.poolMeta
+	meta.Updated = UTCNow()
+	data, err := json.Marshal(meta)
+	z.poolMetaMutex.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	g := errgroup.WithNErrs(len(z.serverPools))
+	for index := range z.serverPools {
+		index := index
+		g.Go(func() error {
+			hr, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", getSHA256Hash(data), int64(len(data)), globalCLIContext.StrictS3Compat)
+			if err != nil {
+				return err
+			}
+			_, err = z.serverPools[index].PutObject(ctx, minioMetaBucket, poolMetaName, NewPutObjReader(hr, nil, nil), ObjectOptions{})
+			return err
+		}, index)
+	}
+	for _, err := range g.Wait() {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// initPoolMeta - loads the pool metadata at startup, matching the saved
+// pools to the current ones by their command line, and resumes an
+// ongoing decommission.
+func (z *erasureServerPools) initPoolMeta(ctx context.Context) error {
+	pools := make([]poolStatus, len(z.serverPools))
+	for i := range pools {
+		pools[i] = poolStatus{PoolStatus: madmin.PoolStatus{
+			ID:         i,
+			CmdLine:    z.poolCmdLine(i),
+			LastUpdate: UTCNow(),
+		}}
+	}
+	z.poolMeta = poolMeta{Format: poolMetaFormat, Version: poolMetaVersion, Pools: pools}
+	z.decommissionCancelers = make([]context.CancelFunc, len(pools))
+
+	meta, err := z.loadPoolMeta(ctx)
+	if err != nil {
+		// Placement falls back to all pools until the metadata
+		// is reloaded, do not fail the startup for it.
+		logger.LogIf(ctx, err)
+		return nil
+	}
+
+	for _, saved := range meta.Pools {
+		idx := -1
+		for i := range pools {
+			if pools[i].CmdLine == saved.CmdLine {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			if saved.Decommission == nil || !saved.Decommission.Complete {
+				return fmt.Errorf("pool %s was removed from the command line without being decommissioned", saved.CmdLine)
+			}
+			continue
+		}
+		saved.ID = idx
+		pools[idx] = saved
+	}
+	z.poolMeta.Rebalance = z.alignRebalance(meta.Rebalance)
+	z.poolMeta.Restripe = meta.Restripe
+
+	if z.poolsLeader() {
+		for i := range pools {
+			if pools[i].Decommission.Running() {
+				z.startDecommission(i)
+			}
+		}
+		if z.poolMeta.Rebalance.Running() {
+			z.startRebalance()
+		}
+		if z.poolMeta.Restripe.Running() {
+			z.startRestripe()
+		}
+	}
+	return nil
+}
+
+// ReloadPoolMeta - reloads the pool metadata saved by another node, the
+// decommission, rebalance and re-stripe are started or stopped accordingly
+// on the leader.
+func (z *erasureServerPools) ReloadPoolMeta(ctx context.Context) error {
+	meta, err := z.loadPoolMeta(ctx)
+	if err != nil {
+		return err
+	}
+
+	var start []int
+	var stop []context.CancelFunc
+	z.poolMetaMutex.Lock()
+	for i := range z.poolMeta.Pools {
+		saved := meta.pool(z.poolMeta.Pools[i].CmdLine)
+		if saved == nil {
+			continue
+		}
+		status := *saved
+		status.ID = i
+		running := status.Decommission.Running()
+		if running && z.decommissionCancelers[i] != nil {
+			// The running worker has the latest progress.
+			continue
+		}
+		z.poolMeta.Pools[i] = status
+		if !z.poolsLeader() {
+			continue
+		}
+		switch {
+		case running && z.decommissionCancelers[i] == nil:
+			start = append(start, i)
+		case !running && z.decommissionCancelers[i] != nil:
+			stop = append(stop, z.decommissionCancelers[i])
+			z.decommissionCancelers[i] = nil
+		}
+	}
+
+	startRebalance := false
+	rebalance := z.alignRebalance(meta.Rebalance)
+	// The running worker has the latest progress.
+	if !rebalance.Running() || z.rebalanceCancel == nil {
+		z.poolMeta.Rebalance = rebalance
+		if z.poolsLeader() {
+			switch running := rebalance.Running(); {
+			case running && z.rebalanceCancel == nil:
+				startRebalance = true
+			case !running && z.rebalanceCancel != nil:
+				stop = append(stop, z.rebalanceCancel)
+				z.rebalanceCancel = nil
+			}
+		}
+	}
+
+	startRestripe := false
+	// The running worker has the latest progress.
+	if !meta.Restripe.Running() || z.restripeCancel == nil {
+		z.poolMeta.Restripe = meta.Restripe
+		if z.poolsLeader() {
+			switch running := meta.Restripe.Running(); {
+			case running && z.restripeCancel == nil:
+				startRestripe = true
+			case !running && z.restripeCancel != nil:
+				stop = append(stop, z.restripeCancel)
+				z.restripeCancel = nil
+			}
+		}
+	}
+	z.poolMetaMutex.Unlock()
+
+	for _, cancel := range stop {
+		cancel()
+	}
+	for _, idx := range start {
+		z.startDecommission(idx)
+	}
+	if startRebalance {
+		z.startRebalance()
+	}
+	if startRestripe {
+		z.startRestripe()
+	}
+	return nil
+}
+
+// poolsLeader - decommissions, rebalances and re-stripes run on the first
+// node of the first pool, so that the same node resumes them after a restart.
+func (z *erasureServerPools) poolsLeader() bool {
+	endpoints := z.serverPools[0].endpoints
+	return len(endpoints) > 0 && endpoints[0].IsLocal
+}
+
+// IsSuspended - returns true if no new objects are placed on the pool,
+// a pool stays suspended after a failed decommission until it is canceled.
+func (z *erasureServerPools) IsSuspended(idx int) bool {
+	z.poolMetaMutex.RLock()
+	defer z.poolMetaMutex.RUnlock()
+	if idx >= len(z.poolMeta.Pools) {
+		return false
+	}
+	d := z.poolMeta.Pools[idx].Decommission
+	return d != nil && !d.Canceled
+}
+
+// PoolIndex - returns the index of the pool started with cmdLine.
+func (z *erasureServerPools) PoolIndex(cmdLine string) (int, error) {
+	for i := range z.serverPools {
+		if z.poolCmdLine(i) == cmdLine {
+			return i, nil
+		}
+	}
+	return -1, errNoSuchPool
+}
+
+// Decommission - starts moving all objects of the pool to the remaining
+// pools, no new objects are placed on the pool from now on.
+func (z *erasureServerPools) Decommission(ctx context.Context, idx int) error {
+	if z.SingleZone() {
+		return errDecommissionSinglePool
+	}
+	if idx < 0 || idx >= len(z.serverPools) {
+		return errNoSuchPool
+	}
+
+	// Capture the size before suspending the pool.
+	info := z.serverPools[idx].StorageUsageInfo(ctx)
+	var used, total int64
+	for _, disk := range info.Disks {
+		used += int64(disk.UsedSpace)
+		total += int64(disk.TotalSpace)
+	}
+
+	z.poolMetaMutex.Lock()
+	if z.poolMeta.Rebalance.Running() {
+		z.poolMetaMutex.Unlock()
+		return errRebalanceAlreadyRunning
+	}
+	targets := 0
+	for i, pool := range z.poolMeta.Pools {
+		if pool.Decommission.Running() {
+			z.poolMetaMutex.Unlock()
+			return errDecommissionAlreadyRunning
+		}
+		if i != idx && (pool.Decommission == nil || pool.Decommission.Canceled) {
+			targets++
+		}
+	}
+	pool := &z.poolMeta.Pools[idx]
+	switch {
+	case pool.Decommission != nil && pool.Decommission.Complete:
+		z.poolMetaMutex.Unlock()
+		return errDecommissionComplete
+	case targets == 0:
+		z.poolMetaMutex.Unlock()
+		return errDecommissionNoTargetPool
+	}
+	prev := *pool
+	now := UTCNow()
+	pool.Decommission = &madmin.PoolDecommissionInfo{
+		StartTime:   now,
+		StartSize:   used,
+		TotalSize:   total,
+		CurrentSize: used,
+	}
+	pool.DecommissionedBuckets = nil
+	pool.LastUpdate = now
+	z.poolMetaMutex.Unlock()
+
+	if err := z.savePoolMeta(ctx); err != nil {
+		z.poolMetaMutex.Lock()
+		z.poolMeta.Pools[idx] = prev
+		z.poolMetaMutex.Unlock()
+		return err
+	}
+
+	if z.poolsLeader() {
+		z.startDecommission(idx)
+	}
+	return nil
+}
+
+// DecommissionCancel - cancels an ongoing or failed decommission, the
+// objects already moved stay on the remaining pools.
+func (z *erasureServerPools) DecommissionCancel(ctx context.Context, idx int) error {
+	if idx < 0 || idx >= len(z.serverPools) {
+		return errNoSuchPool
+	}
+
+	z.poolMetaMutex.Lock()
+	pool := &z.poolMeta.Pools[idx]
+	d := pool.Decommission
+	if d == nil || d.Complete || d.Canceled {
+		z.poolMetaMutex.Unlock()
+		return errDecommissionNotStarted
+	}
+	d.Canceled = true
+	pool.LastUpdate = UTCNow()
+	cancel := z.decommissionCancelers[idx]
+	z.decommissionCancelers[idx] = nil
+	z.poolMetaMutex.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	return z.savePoolMeta(ctx)
+}
+
+// Status - returns the status of the pool, nodes other than the leader
+// reload it first since only the leader tracks the progress in memory.
+func (z *erasureServerPools) Status(ctx context.Context, idx int) (madmin.PoolStatus, error) {
+	if idx < 0 || idx >= len(z.serverPools) {
+		return madmin.PoolStatus{}, errNoSuchPool
+	}
+	if !z.poolsLeader() {
+		if err := z.ReloadPoolMeta(ctx); err != nil {
+			return madmin.PoolStatus{}, err
+		}
+	}
+
+	info := z.serverPools[idx].StorageUsageInfo(ctx)
+
+	z.poolMetaMutex.RLock()
+	defer z.poolMetaMutex.RUnlock()
+	status := z.poolMeta.Pools[idx].PoolStatus
+	if status.Decommission != nil {
+		d := *status.Decommission
+		if d.Running() {
+			var used int64
+			for _, disk := range info.Disks {
+				used += int64(disk.UsedSpace)
+			}
+			d.CurrentSize = used
+		}
+		status.Decommission = &d
+	}
+	return status, nil
+}
+
+// startDecommission - starts the decommission worker of the pool unless
+// it is already running.
+func (z *erasureServerPools) startDecommission(idx int) {
+	ctx, cancel := context.WithCancel(GlobalContext)
+	z.poolMetaMutex.Lock()
+	if z.decommissionCancelers[idx] != nil {
+		z.poolMetaMutex.Unlock()
+		cancel()
+		return
+	}
+	z.decommissionCancelers[idx] = cancel
+	z.poolMetaMutex.Unlock()
+
+	go z.decommissionPool(ctx, cancel, idx)
+}
+
+// decommissionPool - moves the objects of the pool in passes over all
+// buckets, until a full pass finds nothing left to move. Multiple passes
+// are needed since objects which already exist on the pool are still
+// updated in place while it is being decommissioned.
+func (z *erasureServerPools) decommissionPool(ctx context.Context, cancel context.CancelFunc, idx int) {
+	// Whoever cancels the worker also removes it, until then
+	// the worker removes itself when it stops.
+	removed := false
+	defer func() {
+		z.poolMetaMutex.Lock()
+		if !removed && ctx.Err() == nil {
+			z.decommissionCancelers[idx] = nil
+		}
+		z.poolMetaMutex.Unlock()
+		cancel()
+	}()
+
+	for {
+		z.poolMetaMutex.Lock()
+		d := z.poolMeta.Pools[idx].Decommission
+		fullPass := len(z.poolMeta.Pools[idx].DecommissionedBuckets) == 0
+		d.ObjectsDecommissionFailed = 0
+		d.BytesFailed = 0
+		z.poolMetaMutex.Unlock()
+
+		moved, failed, err := z.decommissionPass(ctx, idx)
+		if err != nil {
+			if ctx.Err() != nil || err == errDecommissionCanceled {
+				return
+			}
+			logger.LogIf(ctx, err)
+			failed = 1
+		}
+
+		z.poolMetaMutex.Lock()
+		z.poolMeta.Pools[idx].DecommissionedBuckets = nil
+		done := err != nil || (fullPass && moved == 0)
+		if done {
+			d := z.poolMeta.Pools[idx].Decommission
+			d.Complete = failed == 0
+			d.Failed = failed > 0
+			// Allow restarting a failed decommission right away.
+			if ctx.Err() == nil {
+				z.decommissionCancelers[idx] = nil
+				removed = true
+			}
+		}
+		z.poolMeta.Pools[idx].LastUpdate = UTCNow()
+		z.poolMetaMutex.Unlock()
+
+		if err = z.saveDecommissionProgress(ctx, idx); err != nil {
+			if err != errDecommissionCanceled {
+				logger.LogIf(ctx, err)
+			}
+			return
+		}
+		if done {
+			return
+		}
+	}
+}
+
+// saveDecommissionProgress - saves the progress of the decommission of the
+// pool, unless it was canceled meanwhile through another node.
+func (z *erasureServerPools) saveDecommissionProgress(ctx context.Context, idx int) error {
+	z.poolMetaMutex.RLock()
+	cmdLine := z.poolMeta.Pools[idx].CmdLine
+	startTime := z.poolMeta.Pools[idx].Decommission.StartTime
+	z.poolMetaMutex.RUnlock()
+
+	if meta, err := z.loadPoolMeta(ctx); err == nil {
+		if saved := meta.pool(cmdLine); saved != nil && saved.Decommission != nil &&
+			saved.Decommission.Canceled && saved.Decommission.StartTime.Equal(startTime) {
+			z.poolMetaMutex.Lock()
+			z.poolMeta.Pools[idx].Decommission.Canceled = true
+			z.poolMetaMutex.Unlock()
+			return errDecommissionCanceled
+		}
+	}
+	return z.savePoolMeta(ctx)
+}
+
+// decommissionUpdate - records the outcome of moving an object.
+func (z *erasureServerPools) decommissionUpdate(idx int, versions, size int64, err error) {
+	z.poolMetaMutex.Lock()
+	defer z.poolMetaMutex.Unlock()
+	d := z.poolMeta.Pools[idx].Decommission
+	if err != nil {
+		d.ObjectsDecommissionFailed++
+		d.BytesFailed += size
+		return
+	}
+	d.ObjectsDecommissioned += versions
+	d.BytesDone += size
+}
+
+// poolBucket - a bucket, or a prefix of the meta bucket, whose objects
+// are moved off a pool.
+type poolBucket struct {
+	Name   string
+	Prefix string
+}
+
+func (b poolBucket) String() string {
+	return pathJoin(b.Name, b.Prefix)
+}
+
+// decommissionPass - moves all objects and multipart uploads of the pool
+// once, skipping the buckets already moved by an interrupted pass.
+func (z *erasureServerPools) decommissionPass(ctx context.Context, idx int) (moved, failed int64, err error) {
+	bucketsInfo, err := z.serverPools[idx].ListBuckets(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	buckets := make([]poolBucket, 0, len(bucketsInfo)+2)
+	for _, bi := range bucketsInfo {
+		buckets = append(buckets, poolBucket{Name: bi.Name})
+	}
+	buckets = append(buckets,
+		poolBucket{Name: minioMetaBucket, Prefix: minioConfigPrefix},
+		poolBucket{Name: minioMetaBucket, Prefix: bucketConfigPrefix},
+	)
+
+	lastSave := UTCNow()
+	for _, bucket := range buckets {
+		z.poolMetaMutex.RLock()
+		done := false
+		for _, b := range z.poolMeta.Pools[idx].DecommissionedBuckets {
+			if b == bucket.String() {
+				done = true
+				break
+			}
+		}
+		z.poolMetaMutex.RUnlock()
+		if done {
+			continue
+		}
+
+		m, f, err := z.decommissionBucket(ctx, idx, bucket, &lastSave)
+		moved += m
+		failed += f
+		if err != nil {
+			return moved, failed, err
+		}
+
+		z.poolMetaMutex.Lock()
+		z.poolMeta.Pools[idx].DecommissionedBuckets = append(z.poolMeta.Pools[idx].DecommissionedBuckets, bucket.String())
+		z.poolMeta.Pools[idx].LastUpdate = UTCNow()
+		z.poolMetaMutex.Unlock()
+		if err = z.saveDecommissionProgress(ctx, idx); err != nil {
+			return moved, failed, err
+		}
+		lastSave = UTCNow()
+	}
+
+	m, f, err := z.decommissionMultipartUploads(ctx, idx)
+	return moved + m, failed + f, err
+}
+
+// decommissionTarget - returns the erasure set of the remaining pools
+// where an object of the given size is moved to.
+func (z *erasureServerPools) decommissionTarget(ctx context.Context, object string, size int64) (*erasureObjects, error) {
+	// We multiply the size by 2 to account for erasure coding.
+	idx := z.getAvailableZoneIdx(ctx, size*2)
+	if idx < 0 {
+		return nil, toObjectErr(errDiskFull)
+	}
+	return z.serverPools[idx].getHashedSet(object), nil
+}
+
+// decommissionBucket - moves all objects of a bucket from the pool.
+func (z *erasureServerPools) decommissionBucket(ctx context.Context, idx int, bucket poolBucket, lastSave *time.Time) (moved, failed int64, err error) {
+	for _, set := range z.serverPools[idx].sets {
+		m, f, err := z.decommissionSet(ctx, idx, set, bucket, lastSave)
+		moved += m
+		failed += f
+		if err != nil {
+			return moved, failed, err
+		}
+	}
+	return moved, failed, nil
+}
+
+// decommissionSet - moves all objects of a bucket from an erasure set of
+// the pool, objects which fail to move are logged and left in place.
+func (z *erasureServerPools) decommissionSet(ctx context.Context, idx int, set *erasureObjects, bucket poolBucket, lastSave *time.Time) (moved, failed int64, err error) {
+	err = set.walkVersions(ctx, bucket, func(entry FileInfoVersions) error {
+		var size int64
+		for _, version := range entry.Versions {
+			size += version.Size
+		}
+
+		versions := int64(0)
+		dst, err := z.decommissionTarget(ctx, entry.Name, size)
+		if err == nil {
+			versions, size, err = set.moveObject(ctx, dst, bucket.Name, entry)
+		}
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("unable to decommission %s/%s: %w", bucket.Name, entry.Name, err))
+			failed++
+		} else if versions > 0 {
+			moved++
+		}
+		z.decommissionUpdate(idx, versions, size, err)
+
+		if time.Since(*lastSave) > decommissionSaveInterval {
+			if err := z.saveDecommissionProgress(ctx, idx); err != nil {
+				return err
+			}
+			*lastSave = UTCNow()
+		}
+		return nil
+	})
+	return moved, failed, err
+}
+
+// walkVersions - calls fn with all versions of every object of the bucket
+// on this erasure set, in lexical order, until fn returns an error.
+func (er *erasureObjects) walkVersions(ctx context.Context, bucket poolBucket, fn func(entry FileInfoVersions) error) error {
+	walkCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var entryChs []FileInfoVersionsCh
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, disk := range er.getOnlineDisks() {
+		disk := disk
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			entryCh, err := disk.WalkVersions(walkCtx, bucket.Name, bucket.Prefix, "", true, walkCtx.Done())
+			if err != nil {
+				// Disk walk returned error, ignore it.
+				return
+			}
+			mu.Lock()
+			entryChs = append(entryChs, FileInfoVersionsCh{
+				Ch: entryCh,
+			})
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	entriesValid := make([]bool, len(entryChs))
+	entries := make([]FileInfoVersions, len(entryChs))
+
+	for {
+		entry, _, ok := lexicallySortedEntryVersions(entryChs, entries, entriesValid)
+		if !ok {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if len(entry.Versions) == 0 || HasSuffix(entry.Name, SlashSeparator) {
+			// Skip empty directories.
+			continue
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+}
+
+// decommissionMultipartUploads - moves all ongoing multipart uploads from
+// the pool, so that they can be completed on the remaining pools.
+func (z *erasureServerPools) decommissionMultipartUploads(ctx context.Context, idx int) (moved, failed int64, err error) {
+	for _, set := range z.serverPools[idx].sets {
+		var uploadIDPaths []string
+		for _, disk := range set.getLoadBalancedDisks(true) {
+			shaDirs, err := disk.ListDir(ctx, minioMetaMultipartBucket, "", -1)
+			if err != nil {
+				continue
+			}
+			for _, shaDir := range shaDirs {
+				uploadIDDirs, err := disk.ListDir(ctx, minioMetaMultipartBucket, shaDir, -1)
+				if err != nil {
+					continue
+				}
+				for _, uploadIDDir := range uploadIDDirs {
+					uploadIDPaths = append(uploadIDPaths, strings.TrimSuffix(pathJoin(shaDir, uploadIDDir), SlashSeparator))
+				}
+			}
+			break
+		}
+
+		for _, uploadIDPath := range uploadIDPaths {
+			if err = ctx.Err(); err != nil {
+				return moved, failed, err
+			}
+			size, err := set.decommissionMultipartUpload(ctx, uploadIDPath, z.decommissionTarget)
+			if err != nil {
+				if isErrObjectNotFound(err) {
+					// Upload completed or aborted meanwhile.
+					continue
+				}
+				logger.LogIf(ctx, fmt.Errorf("unable to decommission multipart upload %s: %w", uploadIDPath, err))
+				failed++
+			} else {
+				moved++
+			}
+			z.decommissionUpdate(idx, 1, size, err)
+		}
+	}
+	return moved, failed, nil
+}
+
+// moveObject - moves all versions of an object from this erasure
+// set to the dst erasure set, returns the number of versions and bytes moved.
+// Versions are removed from this set only once all of them are copied.
+func (er erasureObjects) moveObject(ctx context.Context, dst *erasureObjects, bucket string, entry FileInfoVersions) (versions, size int64, err error) {
+	object := entry.Name
+	lk := er.NewNSLock(bucket, object)
+	if err = lk.GetLock(ctx, globalOperationTimeout); err != nil {
+		return 0, 0, err
+	}
+	defer lk.Unlock()
+
+	// Writes are routed to the pool which already has the object, the
+	// destination may have it once a version is copied, its xl.meta is
+	// only updated under its own lock.
+	dstLk := dst.NewNSLock(bucket, object)
+	if err = dstLk.GetLock(ctx, globalOperationTimeout); err != nil {
+		return 0, 0, err
+	}
+	defer dstLk.Unlock()
+
+	var moved []FileInfo
+	// Versions are sorted newest first, copy the oldest first.
+	for i := len(entry.Versions) - 1; i >= 0; i-- {
+		versionID := entry.Versions[i].VersionID
+		if versionID == "" && !entry.Versions[i].XLV1 {
+			versionID = nullVersionID
+		}
+		fi, metaArr, onlineDisks, err := er.getObjectFileInfo(ctx, bucket, object, ObjectOptions{VersionID: versionID})
+		if err != nil {
+			if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+				continue
+			}
+			return versions, size, err
+		}
+		switch {
+		case fi.Deleted:
+			err = dst.moveDeleteMarker(ctx, bucket, object, fi)
+		case fi.TransitionStatus == lifecycle.TransitionComplete:
+			err = errDecommissionTransitioned
+		default:
+			err = dst.moveObjectVersion(ctx, er, bucket, object, fi, metaArr, onlineDisks)
+		}
+		if err != nil {
+			return versions, size, toObjectErr(err, bucket, object)
+		}
+		moved = append(moved, fi)
+		versions++
+		size += fi.Size
+	}
+
+	writeQuorum := len(er.getDisks())/2 + 1
+	for _, fi := range moved {
+		if err = er.deleteObjectVersion(ctx, bucket, object, writeQuorum, FileInfo{
+			Name:      object,
+			VersionID: fi.VersionID,
+		}); err != nil {
+			return versions, size, toObjectErr(err, bucket, object)
+		}
+	}
+	return versions, size, nil
+}
+
+// moveDeleteMarker - adds the delete marker fi to the object.
+func (er erasureObjects) moveDeleteMarker(ctx context.Context, bucket, object string, fi FileInfo) error {
+	defer ObjectPathUpdated(pathJoin(bucket, object))
+	disks := er.getDisks()
+	g := errgroup.WithNErrs(len(disks))
+	for index := range disks {
+		index := index
+		g.Go(func() error {
+			if disks[index] == nil {
+				return errDiskNotFound
+			}
+			return disks[index].WriteMetadata(ctx, bucket, object, FileInfo{
+				Name:      object,
+				VersionID: fi.VersionID,
+				Deleted:   true,
+				ModTime:   fi.ModTime,
+			})
+		}, index)
+	}
+	return reduceWriteQuorumErrs(ctx, g.Wait(), objectOpIgnoredErrs, len(disks)/2+1)
+}
+
+// moveObjectVersion - copies the object version srcFi from the src
+// erasure set to this erasure set, keeping its version id, modtime, parts
+// and metadata.
+func (er erasureObjects) moveObjectVersion(ctx context.Context, src erasureObjects, bucket, object string, srcFi FileInfo, srcMetaArr []FileInfo, srcDisks []StorageAPI) error {
+	defer ObjectPathUpdated(pathJoin(bucket, object))
+
+	storageDisks := er.getDisks()
+	parityDrives := getParityForSC(bucket, srcFi.Metadata[xhttp.AmzStorageClass], len(storageDisks))
+	dataDrives := len(storageDisks) - parityDrives
+	writeQuorum := dataDrives
+	if dataDrives == parityDrives {
+		writeQuorum = dataDrives + 1
+	}
+
+	fi := newFileInfo(object, dataDrives, parityDrives)
+	fi.VersionID = srcFi.VersionID
+	// Small objects are stored inline in xl.meta, as on PutObject.
+	if len(srcFi.Parts) != 1 || !globalStorageClass.ShouldInline(srcFi.Parts[0].Size) {
+		fi.DataDir = mustGetUUID()
+	}
+	fi.Size = srcFi.Size
+	fi.ModTime = srcFi.ModTime
+	fi.Metadata = cloneMSS(srcFi.Metadata)
+
+	tempObj := mustGetUUID()
+	defer er.deleteObject(context.Background(), minioMetaTmpBucket, tempObj, writeQuorum)
+
+	onlineDisks, partsMetadata, err := er.moveParts(ctx, src, bucket, object, srcFi, srcMetaArr, srcDisks, fi, tempObj, writeQuorum)
+	if err != nil {
+		return err
+	}
+
+	if onlineDisks, err = writeUniqueFileInfo(ctx, onlineDisks, minioMetaTmpBucket, tempObj, partsMetadata, writeQuorum); err != nil {
+		return err
+	}
+
+	if onlineDisks, err = renameData(ctx, onlineDisks, minioMetaTmpBucket, tempObj, fi.DataDir, bucket, object, writeQuorum, nil); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(onlineDisks); i++ {
+		if onlineDisks[i] != nil && onlineDisks[i].IsOnline() {
+			continue
+		}
+		er.addPartial(bucket, object, fi.VersionID)
+		break
+	}
+	return nil
+}
+
+// decommissionMultipartUpload - moves the multipart upload at uploadIDPath
+// to the erasure set returned by dstFn, returns the size of its parts.
+func (er erasureObjects) decommissionMultipartUpload(ctx context.Context, uploadIDPath string, dstFn func(ctx context.Context, object string, size int64) (*erasureObjects, error)) (int64, error) {
+	readUpload := func() (FileInfo, []FileInfo, []StorageAPI, int, error) {
+		disks := er.getDisks()
+		metaArr, errs := readAllFileInfo(ctx, disks, minioMetaMultipartBucket, uploadIDPath, "")
+		readQuorum, writeQuorum, err := objectQuorumFromMeta(ctx, er, metaArr, errs)
+		if err != nil {
+			return FileInfo{}, nil, nil, 0, err
+		}
+		if reducedErr := reduceReadQuorumErrs(ctx, errs, objectOpIgnoredErrs, readQuorum); reducedErr != nil {
+			return FileInfo{}, nil, nil, 0, toObjectErr(reducedErr, minioMetaMultipartBucket, uploadIDPath)
+		}
+		onlineDisks, modTime := listOnlineDisks(disks, metaArr, errs)
+		fi, err := pickValidFileInfo(ctx, metaArr, modTime, readQuorum)
+		return fi, metaArr, onlineDisks, writeQuorum, err
+	}
+
+	fi, _, _, _, err := readUpload()
+	if err != nil {
+		return 0, err
+	}
+	bucket, object := path2BucketObject(fi.Metadata[multipartUploadObjectKey])
+	if object == "" {
+		return 0, errDecommissionUnknownUpload
+	}
+
+	lk := er.NewNSLock(bucket, pathJoin(object, path.Base(uploadIDPath)))
+	if err = lk.GetLock(ctx, globalOperationTimeout); err != nil {
+		return 0, err
+	}
+	defer lk.Unlock()
+
+	// Parts may have been uploaded before the lock was acquired.
+	fi, metaArr, onlineDisks, writeQuorum, err := readUpload()
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	for _, part := range fi.Parts {
+		size += part.Size
+	}
+
+	dst, err := dstFn(ctx, object, size)
+	if err != nil {
+		return 0, err
+	}
+	if err = dst.decommissionUpload(ctx, er, object, uploadIDPath, fi, metaArr, onlineDisks, size); err != nil {
+		return 0, err
+	}
+	return size, er.deleteObject(ctx, minioMetaMultipartBucket, uploadIDPath, writeQuorum)
+}
+
+// decommissionUpload - copies the multipart upload srcFi from the src
+// erasure set to this erasure set at the same upload path.
+func (er erasureObjects) decommissionUpload(ctx context.Context, src erasureObjects, object, uploadIDPath string, srcFi FileInfo, srcMetaArr []FileInfo, srcDisks []StorageAPI, size int64) error {
+	storageDisks := er.getDisks()
+	bucket, _ := path2BucketObject(srcFi.Metadata[multipartUploadObjectKey])
+	parityDrives := getParityForSC(bucket, srcFi.Metadata[xhttp.AmzStorageClass], len(storageDisks))
+	dataDrives := len(storageDisks) - parityDrives
+	writeQuorum := dataDrives
+	if dataDrives == parityDrives {
+		writeQuorum = dataDrives + 1
+	}
+
+	fi := newFileInfo(object, dataDrives, parityDrives)
+	fi.VersionID = srcFi.VersionID
+	fi.DataDir = srcFi.DataDir
+	fi.ModTime = srcFi.ModTime
+	fi.Metadata = cloneMSS(srcFi.Metadata)
+
+	tempUploadIDPath := mustGetUUID()
+	defer er.deleteObject(context.Background(), minioMetaTmpBucket, tempUploadIDPath, writeQuorum)
+
+	// The parts are read as a single stream of all their data.
+	readFi := srcFi
+	readFi.Size = size
+	onlineDisks, partsMetadata, err := er.moveParts(ctx, src, minioMetaMultipartBucket, uploadIDPath, readFi, srcMetaArr, srcDisks, fi, tempUploadIDPath, writeQuorum)
+	if err != nil {
+		return err
+	}
+
+	if onlineDisks, err = writeUniqueFileInfo(ctx, onlineDisks, minioMetaTmpBucket, tempUploadIDPath, partsMetadata, writeQuorum); err != nil {
+		return err
+	}
+
+	_, err = rename(ctx, onlineDisks, minioMetaTmpBucket, tempUploadIDPath, minioMetaMultipartBucket, uploadIDPath, true, writeQuorum, nil)
+	return err
+}
+
+// moveParts - reads all parts of srcFi from the src erasure set and
+// erasure codes them with the layout of fi into tempObj on this erasure set,
+// or into the inline data of the metadata if fi has no data dir. Part numbers,
+// etags and actual sizes are preserved. Returns the disks which
+// hold all parts along with their metadata.
+func (er erasureObjects) moveParts(ctx context.Context, src erasureObjects, bucket, object string, srcFi FileInfo, srcMetaArr []FileInfo, srcDisks []StorageAPI, fi FileInfo, tempObj string, writeQuorum int) ([]StorageAPI, []FileInfo, error) {
+	storageDisks := er.getDisks()
+	partsMetadata := make([]FileInfo, len(storageDisks))
+	for index := range partsMetadata {
+		partsMetadata[index] = fi
+	}
+	onlineDisks, partsMetadata := shuffleDisksAndPartsMetadata(storageDisks, partsMetadata, fi.Erasure.Distribution)
+
+	erasure, err := NewErasure(ctx, fi.Erasure.DataBlocks, fi.Erasure.ParityBlocks, fi.Erasure.BlockSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		var err error
+		if srcFi.Size > 0 {
+			err = src.getObjectWithFileInfo(ctx, bucket, object, 0, srcFi.Size, pw, srcFi, srcMetaArr, srcDisks)
+		}
+		pw.CloseWithError(err)
+	}()
+	defer pr.Close()
+
+	buffer := er.bp.Get()
+	defer er.bp.Put(buffer)
+	if len(buffer) > int(fi.Erasure.BlockSize) {
+		buffer = buffer[:fi.Erasure.BlockSize]
+	}
+
+	for _, part := range srcFi.Parts {
+		partPath := pathJoin(tempObj, fi.DataDir, fmt.Sprintf("part.%d", part.Number))
+		writers := make([]io.Writer, len(onlineDisks))
+		var inlineBuffers []*bytes.Buffer
+		if fi.InlineData() {
+			inlineBuffers = make([]*bytes.Buffer, len(onlineDisks))
+		}
+		for i, disk := range onlineDisks {
+			if disk == nil {
+				continue
+			}
+			if fi.InlineData() {
+				inlineBuffers[i] = bytes.NewBuffer(make([]byte, 0, bitrotShardFileSize(erasure.ShardFileSize(part.Size), erasure.ShardSize(), DefaultBitrotAlgorithm)))
+				writers[i] = newStreamingBitrotWriterBuffer(inlineBuffers[i], DefaultBitrotAlgorithm, erasure.ShardSize())
+				continue
+			}
+			writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, partPath, erasure.ShardFileSize(part.Size), DefaultBitrotAlgorithm, erasure.ShardSize())
+		}
+
+		n, err := erasure.Encode(ctx, io.LimitReader(pr, part.Size), writers, buffer, writeQuorum)
+		closeBitrotWriters(writers)
+		if err != nil {
+			return nil, nil, toObjectErr(err, minioMetaTmpBucket, partPath)
+		}
+		if n < part.Size {
+			return nil, nil, IncompleteBody{Bucket: bucket, Object: object}
+		}
+
+		for i, w := range writers {
+			if w == nil {
+				onlineDisks[i] = nil
+				continue
+			}
+			if fi.InlineData() {
+				partsMetadata[i].Data = inlineBuffers[i].Bytes()
+			}
+			partsMetadata[i].AddObjectPart(part.Number, part.ETag, n, part.ActualSize)
+			partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
+				PartNumber: part.Number,
+				Algorithm:  DefaultBitrotAlgorithm,
+				Hash:       bitrotWriterSum(w),
+			})
+		}
+	}
+	return onlineDisks, partsMetadata, nil
+}
@@ -0,0 +1,260 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDecommissionPool(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z := prepareErasurePools(ctx, t, 2)
+
+	if err := z.Decommission(ctx, 2); err != errNoSuchPool {
+		t.Fatalf("expected %v, got %v", errNoSuchPool, err)
+	}
+
+	bucket := "bucket"
+	if err := z.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Place all objects on the first pool.
+	src := z.serverPools[0]
+	type version struct {
+		object    string
+		versionID string
+		data      []byte
+		deleted   bool
+	}
+	var versions []version
+	for _, object := range []string{"a", "dir/b", "dir/c"} {
+		for i := 0; i < 2; i++ {
+			data := bytes.Repeat([]byte(object), (i+1)*1024)
+			oi, err := src.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			versions = append(versions, version{object: object, versionID: oi.VersionID, data: data})
+		}
+	}
+	oi, err := src.DeleteObject(ctx, bucket, "dir/c", ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	versions = append(versions, version{object: "dir/c", versionID: oi.VersionID, deleted: true})
+
+	uploadID, err := src.NewMultipartUpload(ctx, bucket, "upload", ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	part := bytes.Repeat([]byte("p"), 1024)
+	pi, err := src.PutObjectPart(ctx, bucket, "upload", uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(part), int64(len(part)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = z.Decommission(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.Decommission(ctx, 1); err != errDecommissionAlreadyRunning {
+		t.Fatalf("expected %v, got %v", errDecommissionAlreadyRunning, err)
+	}
+
+	deadline := time.Now().Add(time.Minute)
+	for {
+		status, err := z.Status(ctx, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !status.Decommission.Running() {
+			if !status.Decommission.Complete {
+				t.Fatalf("decommission did not complete: %#v", status.Decommission)
+			}
+			// 7 versions and 1 upload, along with any server
+			// configuration placed on the pool.
+			if status.Decommission.ObjectsDecommissioned < 8 {
+				t.Fatalf("expected at least 8 objects decommissioned, got %d", status.Decommission.ObjectsDecommissioned)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the decommission")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	for _, v := range versions {
+		if _, err = src.GetObjectInfo(ctx, bucket, v.object, ObjectOptions{VersionID: v.versionID}); !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+			t.Fatalf("%s (%s) is still on the decommissioned pool: %v", v.object, v.versionID, err)
+		}
+		oi, err := z.serverPools[1].GetObjectInfo(ctx, bucket, v.object, ObjectOptions{VersionID: v.versionID})
+		if v.deleted {
+			if _, ok := err.(MethodNotAllowed); !ok || !oi.DeleteMarker {
+				t.Fatalf("expected delete marker for %s (%s), got %v", v.object, v.versionID, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err = z.GetObject(ctx, bucket, v.object, 0, -1, &buf, "", ObjectOptions{VersionID: v.versionID}); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), v.data) {
+			t.Fatalf("unexpected content for %s (%s)", v.object, v.versionID)
+		}
+	}
+
+	// The upload is completed on the remaining pool.
+	oi, err = z.CompleteMultipartUpload(ctx, bucket, "upload", uploadID, []CompletePart{{PartNumber: 1, ETag: pi.ETag}}, ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi.Size != int64(len(part)) {
+		t.Fatalf("expected size %d, got %d", len(part), oi.Size)
+	}
+
+	// No new objects are placed on the decommissioned pool.
+	for i := 0; i < 10; i++ {
+		if idx := z.getAvailableZoneIdx(ctx, 1); idx != 1 {
+			t.Fatalf("expected pool 1, got %d", idx)
+		}
+	}
+
+	meta, err := z.loadPoolMeta(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := meta.pool(z.poolCmdLine(0)); p == nil || p.Decommission == nil || !p.Decommission.Complete {
+		t.Fatalf("expected the completed decommission to be saved, got %#v", p)
+	}
+
+	if err = z.Decommission(ctx, 0); err != errDecommissionComplete {
+		t.Fatalf("expected %v, got %v", errDecommissionComplete, err)
+	}
+	if err = z.Decommission(ctx, 1); err != errDecommissionNoTargetPool {
+		t.Fatalf("expected %v, got %v", errDecommissionNoTargetPool, err)
+	}
+}
+
+func TestMoveObjectConcurrentPut(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z := prepareErasurePools(ctx, t, 2)
+	bucket := "bucket"
+	if err := z.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte("a"), 1024)
+
+	// The destination is not written to while a writer holds its lock.
+	object := "locked"
+	oi, err := z.serverPools[1].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := z.serverPools[1].getHashedSet(object)
+	dst := z.serverPools[0].getHashedSet(object)
+	lk := dst.NewNSLock(bucket, object)
+	if err = lk.GetLock(ctx, globalOperationTimeout); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := src.moveObject(ctx, dst, bucket, FileInfoVersions{Name: object, Versions: []FileInfo{{VersionID: oi.VersionID}}})
+		done <- err
+	}()
+	time.Sleep(200 * time.Millisecond)
+	if _, _, _, err = dst.getObjectFileInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(toObjectErr(err, bucket, object)) {
+		t.Fatalf("expected the destination not to be written to, got %v", err)
+	}
+	lk.Unlock()
+	if err = <-done; err != nil {
+		t.Fatal(err)
+	}
+	if _, err = dst.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: oi.VersionID}); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 5; i++ {
+		object := fmt.Sprintf("object-%d", i)
+		entry := FileInfoVersions{Name: object}
+		var versionIDs []string
+		for j := 0; j < 2; j++ {
+			oi, err := z.serverPools[1].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			versionIDs = append(versionIDs, oi.VersionID)
+			entry.Versions = append([]FileInfo{{VersionID: oi.VersionID}}, entry.Versions...)
+		}
+
+		// Writes are routed to the first pool with the object, the
+		// destination once a version is copied, no version is lost.
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		errs := make(chan error, 5)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			src := z.serverPools[1].getHashedSet(object)
+			dst := z.serverPools[0].getHashedSet(object)
+			_, _, err := src.moveObject(ctx, dst, bucket, entry)
+			errs <- err
+		}()
+		for j := 0; j < 4; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				oi, err := z.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+				mu.Lock()
+				versionIDs = append(versionIDs, oi.VersionID)
+				mu.Unlock()
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for _, versionID := range versionIDs {
+			if _, err := z.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: versionID}); err != nil {
+				t.Fatalf("%s (%s): %v", object, versionID, err)
+			}
+		}
+		for _, version := range entry.Versions {
+			if _, err := z.serverPools[1].GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: version.VersionID}); !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+				t.Fatalf("%s (%s) was not moved: %v", object, version.VersionID, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,196 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+var (
+	errNoSuchDrive        = errors.New("drive is not part of any pool")
+	errDriveOffline       = errors.New("drive is offline")
+	errDriveHealing       = errors.New("drive is being healed")
+	errDriveNotReplacing  = errors.New("drive is not being replaced")
+	errDriveNotHealing    = errors.New("drive is not being healed")
+	errDriveAlreadyPaused = errors.New("drive heal is already paused")
+	errDriveNotPaused     = errors.New("drive heal is not paused")
+)
+
+// drivePosition - position of a drive in the server pools.
+type drivePosition struct {
+	pool, set, disk int
+	endpoint        string
+}
+
+// drives - returns the position of all drives along with the drives,
+// offline drives are nil.
+func (z *erasureServerPools) drives() ([]drivePosition, []StorageAPI) {
+	var positions []drivePosition
+	var disks []StorageAPI
+	for i, pool := range z.serverPools {
+		for j, set := range pool.sets {
+			setDisks := set.getDisks()
+			for k, endpoint := range set.getEndpoints() {
+				positions = append(positions, drivePosition{pool: i, set: j, disk: k, endpoint: endpoint})
+				disks = append(disks, setDisks[k])
+			}
+		}
+	}
+	return positions, disks
+}
+
+// driveTracker - returns the healing tracker of the online drive with
+// the endpoint, a nil tracker if the drive is active.
+func (z *erasureServerPools) driveTracker(ctx context.Context, endpoint string) (*healingTracker, StorageAPI, error) {
+	positions, disks := z.drives()
+	for i, p := range positions {
+		if p.endpoint != endpoint {
+			continue
+		}
+		disk := disks[i]
+		if disk == nil || !disk.IsOnline() {
+			return nil, nil, errDriveOffline
+		}
+		tracker, err := loadHealingTracker(ctx, disk)
+		if errors.Is(err, errFileNotFound) || errors.Is(err, errVolumeNotFound) {
+			return nil, disk, nil
+		}
+		return tracker, disk, err
+	}
+	return nil, nil, errNoSuchDrive
+}
+
+// DrivesStatus - returns the lifecycle state of all drives, along with the
+// heal progress of the drives being healed.
+func (z *erasureServerPools) DrivesStatus(ctx context.Context) []madmin.DriveStatus {
+	z.poolMetaMutex.RLock()
+	decommissioned := make([]bool, len(z.serverPools))
+	for i := range z.serverPools {
+		if p := z.poolMeta.pool(z.poolCmdLine(i)); p != nil && p.Decommission != nil {
+			decommissioned[i] = p.Decommission.Complete
+		}
+	}
+	z.poolMetaMutex.RUnlock()
+
+	positions, disks := z.drives()
+	statuses := make([]madmin.DriveStatus, len(positions))
+	var wg sync.WaitGroup
+	for i, p := range positions {
+		statuses[i] = madmin.DriveStatus{
+			Endpoint:  p.endpoint,
+			PoolIndex: p.pool,
+			SetIndex:  p.set,
+			DiskIndex: p.disk,
+			State:     madmin.DriveActive,
+		}
+		switch {
+		case decommissioned[p.pool]:
+			statuses[i].State = madmin.DriveDecommissioned
+			continue
+		case disks[i] == nil || !disks[i].IsOnline():
+			statuses[i].State = madmin.DriveOffline
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tracker, err := loadHealingTracker(ctx, disks[i])
+			switch {
+			case err == nil:
+				statuses[i].State = madmin.DriveLifecycle(tracker.State)
+				if statuses[i].State == madmin.DriveHealing {
+					statuses[i].Heal = tracker.toHealProgress()
+				}
+			case errors.Is(err, errFileNotFound) || errors.Is(err, errVolumeNotFound):
+			default:
+				statuses[i].State = madmin.DriveOffline
+			}
+		}(i)
+	}
+	wg.Wait()
+	return statuses
+}
+
+// ReplaceDrive - marks an online drive as being replaced, it does not serve
+// reads until it is swapped for a new drive which is healed.
+func (z *erasureServerPools) ReplaceDrive(ctx context.Context, endpoint string) error {
+	tracker, disk, err := z.driveTracker(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	if tracker != nil {
+		if tracker.State == string(madmin.DriveReplacing) {
+			return nil
+		}
+		return errDriveHealing
+	}
+	diskID, err := disk.GetDiskID()
+	if err != nil {
+		return err
+	}
+	tracker = &healingTracker{
+		ID:    diskID,
+		State: string(madmin.DriveReplacing),
+		disk:  disk,
+	}
+	return tracker.save(ctx)
+}
+
+// CancelReplaceDrive - makes a drive marked as being replaced active again.
+func (z *erasureServerPools) CancelReplaceDrive(ctx context.Context, endpoint string) error {
+	tracker, _, err := z.driveTracker(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	if tracker == nil || tracker.State != string(madmin.DriveReplacing) {
+		return errDriveNotReplacing
+	}
+	return tracker.delete(ctx)
+}
+
+// PauseDriveHeal - pauses the heal of a new drive, the healing server
+// notices it on its next progress update.
+func (z *erasureServerPools) PauseDriveHeal(ctx context.Context, endpoint string) error {
+	return z.setDriveHealPaused(ctx, endpoint, true)
+}
+
+// ResumeDriveHeal - resumes a paused heal of a new drive.
+func (z *erasureServerPools) ResumeDriveHeal(ctx context.Context, endpoint string) error {
+	return z.setDriveHealPaused(ctx, endpoint, false)
+}
+
+func (z *erasureServerPools) setDriveHealPaused(ctx context.Context, endpoint string, paused bool) error {
+	tracker, _, err := z.driveTracker(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	if tracker == nil || tracker.State != string(madmin.DriveHealing) {
+		return errDriveNotHealing
+	}
+	if tracker.Paused == paused {
+		if paused {
+			return errDriveAlreadyPaused
+		}
+		return errDriveNotPaused
+	}
+	return tracker.setPaused(ctx, paused)
+}
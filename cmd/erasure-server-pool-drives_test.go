@@ -0,0 +1,184 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestDriveLifecycle(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z := prepareErasurePools(ctx, t, 1)
+
+	driveState := func(endpoint string) madmin.DriveStatus {
+		for _, status := range z.DrivesStatus(ctx) {
+			if status.Endpoint == endpoint {
+				return status
+			}
+		}
+		t.Fatalf("drive %s not found", endpoint)
+		return madmin.DriveStatus{}
+	}
+
+	statuses := z.DrivesStatus(ctx)
+	if len(statuses) != 4 {
+		t.Fatalf("expected 4 drives, got %d", len(statuses))
+	}
+	for _, status := range statuses {
+		if status.State != madmin.DriveActive {
+			t.Fatalf("expected all drives to be active, got %#v", status)
+		}
+	}
+
+	endpoint := statuses[0].Endpoint
+	disk := z.serverPools[0].sets[0].getDisks()[0]
+
+	err := z.ReplaceDrive(ctx, "/no/such/drive")
+	if err != errNoSuchDrive {
+		t.Fatalf("expected %v, got %v", errNoSuchDrive, err)
+	}
+
+	// A drive marked for replacement does not serve reads.
+	if err = z.ReplaceDrive(ctx, endpoint); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.ReplaceDrive(ctx, endpoint); err != nil {
+		t.Fatal(err)
+	}
+	if status := driveState(endpoint); status.State != madmin.DriveReplacing {
+		t.Fatalf("expected %s, got %s", madmin.DriveReplacing, status.State)
+	}
+	if !disk.Healing() {
+		t.Fatal("expected the drive marked for replacement to be excluded from reads")
+	}
+	if err = z.PauseDriveHeal(ctx, endpoint); err != errDriveNotHealing {
+		t.Fatalf("expected %v, got %v", errDriveNotHealing, err)
+	}
+	if err = z.CancelReplaceDrive(ctx, endpoint); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.CancelReplaceDrive(ctx, endpoint); err != errDriveNotReplacing {
+		t.Fatalf("expected %v, got %v", errDriveNotReplacing, err)
+	}
+	if status := driveState(endpoint); status.State != madmin.DriveActive {
+		t.Fatalf("expected %s, got %s", madmin.DriveActive, status.State)
+	}
+
+	// A new drive being healed reports its progress.
+	diskID, err := disk.GetDiskID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = saveHealingTracker(disk, diskID); err != nil {
+		t.Fatal(err)
+	}
+	tracker, err := loadHealingTracker(ctx, disk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker.Started = UTCNow().Add(-time.Minute)
+	tracker.BytesTotal = 300
+	tracker.BytesHealed = 100
+	tracker.ObjectsHealed = 1
+	tracker.HealedBuckets = []string{"bucket"}
+	if err = tracker.update(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.ReplaceDrive(ctx, endpoint); err != errDriveHealing {
+		t.Fatalf("expected %v, got %v", errDriveHealing, err)
+	}
+	status := driveState(endpoint)
+	if status.State != madmin.DriveHealing || status.Heal == nil {
+		t.Fatalf("expected a healing drive with progress, got %#v", status)
+	}
+	if status.Heal.ObjectsHealed != 1 || status.Heal.BytesHealed != 100 {
+		t.Fatalf("unexpected heal progress %#v", status.Heal)
+	}
+	// Two thirds of the bytes are left, at the same rate.
+	if eta := status.Heal.ETA.Sub(status.Heal.LastUpdate); eta < 119*time.Second || eta > 121*time.Second {
+		t.Fatalf("expected an ETA of two minutes, got %s", eta)
+	}
+
+	if err = z.PauseDriveHeal(ctx, endpoint); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.PauseDriveHeal(ctx, endpoint); err != errDriveAlreadyPaused {
+		t.Fatalf("expected %v, got %v", errDriveAlreadyPaused, err)
+	}
+	if status := driveState(endpoint); !status.Heal.Paused {
+		t.Fatalf("expected a paused heal, got %#v", status.Heal)
+	}
+
+	// Progress saved by the healing server does not resume the heal.
+	if err = tracker.save(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if status := driveState(endpoint); !status.Heal.Paused {
+		t.Fatalf("expected the heal to stay paused, got %#v", status.Heal)
+	}
+
+	// The healing server waits while the heal is paused.
+	done := make(chan error, 1)
+	go func() {
+		done <- tracker.update(ctx, true)
+	}()
+	select {
+	case err = <-done:
+		t.Fatalf("expected the heal to wait while paused, got %v", err)
+	case <-time.After(time.Second):
+	}
+	if err = z.ResumeDriveHeal(ctx, endpoint); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err = <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(2 * healingTrackerUpdateInterval):
+		t.Fatal("timed out waiting for the heal to resume")
+	}
+	if !tracker.isHealed("bucket") || tracker.Paused {
+		t.Fatalf("unexpected tracker %#v", tracker)
+	}
+
+	// Failing to save the progress does not stop the heal.
+	saved := *tracker
+	saved.disk = newNaughtyDisk(disk, nil, errFaultyDisk)
+	if err = saved.update(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = z.PauseDriveHeal(ctx, endpoint); err != nil {
+		t.Fatal(err)
+	}
+	if err = tracker.delete(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if status := driveState(endpoint); status.State != madmin.DriveActive {
+		t.Fatalf("expected %s, got %s", madmin.DriveActive, status.State)
+	}
+	if tracker.isPaused(ctx) {
+		t.Fatal("expected the pause to be removed with the tracker")
+	}
+}
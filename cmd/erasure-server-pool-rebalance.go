@@ -0,0 +1,451 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Pools are rebalanced until their utilization is
+	// within this fraction of the goal.
+	rebalanceBand = 0.05
+
+	// Interval at which the progress of a rebalance is saved.
+	rebalanceSaveInterval = 30 * time.Second
+
+	// Interval at which the usage of a pool is sampled while
+	// objects are moved off it.
+	rebalanceCheckInterval = 10 * time.Second
+)
+
+var (
+	// Throttles moving objects, the rebalance waits the time
+	// taken to move an object multiplied by the factor.
+	rebalanceSleeper = newDynamicSleeper(5, time.Second)
+
+	errRebalanceSinglePool     = errors.New("a single pool cannot be rebalanced")
+	errRebalanceAlreadyRunning = errors.New("a rebalance is already running")
+	errRebalanceNotStarted     = errors.New("no rebalance is running")
+	errRebalanceStopped        = errors.New("rebalance was stopped")
+	errRebalancePoolDone       = errors.New("pool is rebalanced")
+)
+
+// rebalanceMeta - status of a rebalance as persisted in pool.json.
+type rebalanceMeta struct {
+	madmin.RebalanceStatus
+
+	// Buckets fully rebalanced per pool command line,
+	// they are skipped when a rebalance is resumed.
+	RebalancedBuckets map[string][]string `json:"rebalancedBuckets,omitempty"`
+}
+
+// Running - returns true if the rebalance is in progress.
+func (r *rebalanceMeta) Running() bool {
+	return r != nil && r.RebalanceStatus.Running()
+}
+
+// alignRebalance - orders the pools of a saved rebalance like the current
+// pools, pools which were added since do not participate.
+func (z *erasureServerPools) alignRebalance(saved *rebalanceMeta) *rebalanceMeta {
+	if saved == nil {
+		return nil
+	}
+	r := *saved
+	r.Pools = make([]madmin.RebalancePoolStatus, len(z.serverPools))
+	for i := range r.Pools {
+		r.Pools[i] = madmin.RebalancePoolStatus{ID: i, CmdLine: z.poolCmdLine(i)}
+		for _, pool := range saved.Pools {
+			if pool.CmdLine == r.Pools[i].CmdLine {
+				pool.ID = i
+				r.Pools[i] = pool
+				break
+			}
+		}
+	}
+	return &r
+}
+
+// poolsUsage - returns the fraction of the capacity in use of each pool,
+// along with the fraction in use across the pools which are not suspended.
+func (z *erasureServerPools) poolsUsage(ctx context.Context) (usage []float64, goal float64) {
+	usage = make([]float64, len(z.serverPools))
+	var totalUsed, totalCapacity uint64
+	for i, pool := range z.serverPools {
+		info := pool.StorageUsageInfo(ctx)
+		var used, capacity uint64
+		for _, disk := range info.Disks {
+			used += disk.UsedSpace
+			capacity += disk.TotalSpace
+		}
+		if capacity > 0 {
+			usage[i] = float64(used) / float64(capacity)
+		}
+		if !z.IsSuspended(i) {
+			totalUsed += used
+			totalCapacity += capacity
+		}
+	}
+	if totalCapacity > 0 {
+		goal = float64(totalUsed) / float64(totalCapacity)
+	}
+	return usage, goal
+}
+
+// RebalanceStart - starts moving objects from the pools which are fuller
+// than the goal, the fraction of the total capacity in use, to the rest
+// of the pools. Returns the id of the rebalance.
+func (z *erasureServerPools) RebalanceStart(ctx context.Context) (string, error) {
+	if z.SingleZone() {
+		return "", errRebalanceSinglePool
+	}
+
+	usage, goal := z.poolsUsage(ctx)
+
+	z.poolMetaMutex.Lock()
+	if z.poolMeta.Rebalance.Running() {
+		z.poolMetaMutex.Unlock()
+		return "", errRebalanceAlreadyRunning
+	}
+	now := UTCNow()
+	r := &rebalanceMeta{RebalanceStatus: madmin.RebalanceStatus{
+		ID:        mustGetUUID(),
+		StartTime: now,
+		Goal:      goal,
+		Pools:     make([]madmin.RebalancePoolStatus, len(z.serverPools)),
+	}}
+	participating := false
+	for i, pool := range z.poolMeta.Pools {
+		if pool.Decommission.Running() {
+			z.poolMetaMutex.Unlock()
+			return "", errDecommissionAlreadyRunning
+		}
+		suspended := pool.Decommission != nil && !pool.Decommission.Canceled
+		r.Pools[i] = madmin.RebalancePoolStatus{
+			ID:            i,
+			CmdLine:       pool.CmdLine,
+			Used:          usage[i],
+			Participating: !suspended && usage[i] > goal+rebalanceBand,
+		}
+		participating = participating || r.Pools[i].Participating
+	}
+	if !participating {
+		// Pools are already balanced.
+		r.Complete = true
+		r.StopTime = now
+	}
+	prev := z.poolMeta.Rebalance
+	z.poolMeta.Rebalance = r
+	z.poolMetaMutex.Unlock()
+
+	if err := z.savePoolMeta(ctx); err != nil {
+		z.poolMetaMutex.Lock()
+		z.poolMeta.Rebalance = prev
+		z.poolMetaMutex.Unlock()
+		return "", err
+	}
+
+	if r.Running() && z.poolsLeader() {
+		z.startRebalance()
+	}
+	return r.ID, nil
+}
+
+// RebalanceStop - stops an ongoing rebalance, the objects already moved
+// stay on their new pools.
+func (z *erasureServerPools) RebalanceStop(ctx context.Context) error {
+	z.poolMetaMutex.Lock()
+	r := z.poolMeta.Rebalance
+	if !r.Running() {
+		z.poolMetaMutex.Unlock()
+		return errRebalanceNotStarted
+	}
+	r.Stopped = true
+	r.StopTime = UTCNow()
+	cancel := z.rebalanceCancel
+	z.rebalanceCancel = nil
+	z.poolMetaMutex.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	return z.savePoolMeta(ctx)
+}
+
+// RebalanceStatus - returns the status of the last rebalance, nodes other
+// than the leader reload it first since only the leader tracks the progress
+// in memory.
+func (z *erasureServerPools) RebalanceStatus(ctx context.Context) (madmin.RebalanceStatus, error) {
+	if !z.poolsLeader() {
+		if err := z.ReloadPoolMeta(ctx); err != nil {
+			return madmin.RebalanceStatus{}, err
+		}
+	}
+
+	usage, _ := z.poolsUsage(ctx)
+
+	z.poolMetaMutex.RLock()
+	defer z.poolMetaMutex.RUnlock()
+	if z.poolMeta.Rebalance == nil {
+		return madmin.RebalanceStatus{}, errRebalanceNotStarted
+	}
+	status := z.poolMeta.Rebalance.RebalanceStatus
+	status.Pools = append([]madmin.RebalancePoolStatus(nil), status.Pools...)
+	for i := range status.Pools {
+		status.Pools[i].Used = usage[i]
+	}
+	return status, nil
+}
+
+// startRebalance - starts the rebalance worker unless it is already running.
+func (z *erasureServerPools) startRebalance() {
+	ctx, cancel := context.WithCancel(GlobalContext)
+	z.poolMetaMutex.Lock()
+	if z.rebalanceCancel != nil {
+		z.poolMetaMutex.Unlock()
+		cancel()
+		return
+	}
+	z.rebalanceCancel = cancel
+	z.poolMetaMutex.Unlock()
+
+	go z.rebalancePools(ctx, cancel)
+}
+
+// rebalancePools - moves objects off each participating pool, until the
+// pool is within the utilization band of the goal or all its objects
+// were considered.
+func (z *erasureServerPools) rebalancePools(ctx context.Context, cancel context.CancelFunc) {
+	// Whoever cancels the worker also removes it, until then
+	// the worker removes itself when it stops.
+	removed := false
+	defer func() {
+		z.poolMetaMutex.Lock()
+		if !removed && ctx.Err() == nil {
+			z.rebalanceCancel = nil
+		}
+		z.poolMetaMutex.Unlock()
+		cancel()
+	}()
+
+	var err error
+	for idx := range z.serverPools {
+		z.poolMetaMutex.RLock()
+		pool := z.poolMeta.Rebalance.Pools[idx]
+		z.poolMetaMutex.RUnlock()
+		if !pool.Participating || pool.Done {
+			continue
+		}
+
+		if err = z.rebalancePool(ctx, idx); err != nil {
+			break
+		}
+
+		z.poolMetaMutex.Lock()
+		z.poolMeta.Rebalance.Pools[idx].Done = true
+		z.poolMetaMutex.Unlock()
+		if err = z.saveRebalanceProgress(ctx); err != nil {
+			break
+		}
+	}
+	if err != nil {
+		if ctx.Err() != nil || err == errRebalanceStopped {
+			return
+		}
+		logger.LogIf(ctx, err)
+	}
+
+	z.poolMetaMutex.Lock()
+	r := z.poolMeta.Rebalance
+	r.Complete = err == nil
+	r.Failed = err != nil
+	r.StopTime = UTCNow()
+	// Allow starting another rebalance right away.
+	if ctx.Err() == nil {
+		z.rebalanceCancel = nil
+		removed = true
+	}
+	z.poolMetaMutex.Unlock()
+
+	if err = z.saveRebalanceProgress(ctx); err != nil && err != errRebalanceStopped {
+		logger.LogIf(ctx, err)
+	}
+}
+
+// saveRebalanceProgress - saves the progress of the rebalance, unless it
+// was stopped meanwhile through another node.
+func (z *erasureServerPools) saveRebalanceProgress(ctx context.Context) error {
+	z.poolMetaMutex.RLock()
+	id := z.poolMeta.Rebalance.ID
+	z.poolMetaMutex.RUnlock()
+
+	if meta, err := z.loadPoolMeta(ctx); err == nil {
+		if saved := meta.Rebalance; saved != nil && saved.ID == id && saved.Stopped {
+			z.poolMetaMutex.Lock()
+			z.poolMeta.Rebalance.Stopped = true
+			z.poolMeta.Rebalance.StopTime = saved.StopTime
+			z.poolMetaMutex.Unlock()
+			return errRebalanceStopped
+		}
+	}
+	return z.savePoolMeta(ctx)
+}
+
+// rebalanceNeeded - returns true while the pool is fuller than the goal.
+func (z *erasureServerPools) rebalanceNeeded(ctx context.Context, idx int) bool {
+	info := z.serverPools[idx].StorageUsageInfo(ctx)
+	var used, capacity uint64
+	for _, disk := range info.Disks {
+		used += disk.UsedSpace
+		capacity += disk.TotalSpace
+	}
+	if capacity == 0 {
+		return false
+	}
+
+	z.poolMetaMutex.RLock()
+	goal := z.poolMeta.Rebalance.Goal
+	z.poolMetaMutex.RUnlock()
+	return float64(used)/float64(capacity) > goal+rebalanceBand
+}
+
+// rebalanceTarget - returns the erasure set of a pool which does not
+// participate in the rebalance, where an object of the given size is
+// moved to.
+func (z *erasureServerPools) rebalanceTarget(ctx context.Context, object string, size int64) (*erasureObjects, error) {
+	// We multiply the size by 2 to account for erasure coding.
+	serverPools := z.getServerPoolsAvailableSpace(ctx, size*2)
+	z.poolMetaMutex.RLock()
+	for i := range serverPools {
+		if z.poolMeta.Rebalance.Pools[i].Participating {
+			serverPools[i].Available = 0
+		}
+	}
+	z.poolMetaMutex.RUnlock()
+
+	idx := serverPools.pick(ctx)
+	if idx < 0 {
+		return nil, toObjectErr(errDiskFull)
+	}
+	return z.serverPools[idx].getHashedSet(object), nil
+}
+
+// rebalanceUpdate - records the outcome of moving an object.
+func (z *erasureServerPools) rebalanceUpdate(idx int, versions, size int64, err error) {
+	z.poolMetaMutex.Lock()
+	defer z.poolMetaMutex.Unlock()
+	pool := &z.poolMeta.Rebalance.Pools[idx]
+	if err != nil {
+		pool.ObjectsFailed++
+		return
+	}
+	pool.ObjectsMoved += versions
+	pool.BytesMoved += size
+}
+
+// rebalancePool - moves objects off the pool bucket by bucket, skipping
+// the buckets already rebalanced before a restart.
+func (z *erasureServerPools) rebalancePool(ctx context.Context, idx int) error {
+	buckets, err := z.serverPools[idx].ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+
+	lastSave := UTCNow()
+	// The usage of the pool is only sampled every rebalanceCheckInterval,
+	// it asks every drive of the pool for its usage.
+	var lastCheck time.Time
+	for _, bi := range buckets {
+		z.poolMetaMutex.RLock()
+		cmdLine := z.poolMeta.Rebalance.Pools[idx].CmdLine
+		done := false
+		for _, b := range z.poolMeta.Rebalance.RebalancedBuckets[cmdLine] {
+			if b == bi.Name {
+				done = true
+				break
+			}
+		}
+		z.poolMetaMutex.RUnlock()
+		if done {
+			continue
+		}
+
+		bucket := poolBucket{Name: bi.Name}
+		for _, set := range z.serverPools[idx].sets {
+			set := set
+			err = set.walkVersions(ctx, bucket, func(entry FileInfoVersions) error {
+				if time.Since(lastCheck) > rebalanceCheckInterval {
+					if !z.rebalanceNeeded(ctx, idx) {
+						return errRebalancePoolDone
+					}
+					lastCheck = UTCNow()
+				}
+
+				wait := rebalanceSleeper.Timer(ctx)
+				defer wait()
+
+				var size int64
+				for _, version := range entry.Versions {
+					size += version.Size
+				}
+
+				versions := int64(0)
+				dst, err := z.rebalanceTarget(ctx, entry.Name, size)
+				if err == nil {
+					versions, size, err = set.moveObject(ctx, dst, bucket.Name, entry)
+				}
+				if err != nil {
+					logger.LogIf(ctx, fmt.Errorf("unable to rebalance %s/%s: %w", bucket.Name, entry.Name, err))
+				}
+				z.rebalanceUpdate(idx, versions, size, err)
+
+				if time.Since(lastSave) > rebalanceSaveInterval {
+					if err := z.saveRebalanceProgress(ctx); err != nil {
+						return err
+					}
+					lastSave = UTCNow()
+				}
+				return nil
+			})
+			if err == errRebalancePoolDone {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		z.poolMetaMutex.Lock()
+		r := z.poolMeta.Rebalance
+		if r.RebalancedBuckets == nil {
+			r.RebalancedBuckets = make(map[string][]string)
+		}
+		r.RebalancedBuckets[cmdLine] = append(r.RebalancedBuckets[cmdLine], bi.Name)
+		z.poolMetaMutex.Unlock()
+		if err = z.saveRebalanceProgress(ctx); err != nil {
+			return err
+		}
+		lastSave = UTCNow()
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestRebalancePools(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z := prepareErasurePools(ctx, t, 2)
+
+	if _, err := z.RebalanceStatus(ctx); err != errRebalanceNotStarted {
+		t.Fatalf("expected %v, got %v", errRebalanceNotStarted, err)
+	}
+	if err := z.RebalanceStop(ctx); err != errRebalanceNotStarted {
+		t.Fatalf("expected %v, got %v", errRebalanceNotStarted, err)
+	}
+
+	// Both pools share the same filesystem, so they are balanced.
+	id, err := z.RebalanceStart(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err := z.RebalanceStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.ID != id || !status.Complete || status.Pools[0].Participating || status.Pools[1].Participating {
+		t.Fatalf("expected a complete rebalance without participating pools, got %#v", status)
+	}
+
+	bucket := "bucket"
+	if err = z.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	src := z.serverPools[0]
+	data := bytes.Repeat([]byte("a"), 1024)
+	var versionIDs []string
+	for i := 0; i < 3; i++ {
+		oi, err := src.PutObject(ctx, bucket, "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		versionIDs = append(versionIDs, oi.VersionID)
+	}
+
+	// Rebalance with a goal which keeps the first pool above it,
+	// so that all of its objects are moved.
+	z.poolMetaMutex.Lock()
+	z.poolMeta.Rebalance = &rebalanceMeta{RebalanceStatus: madmin.RebalanceStatus{
+		ID:        mustGetUUID(),
+		StartTime: UTCNow(),
+		Goal:      -1,
+		Pools: []madmin.RebalancePoolStatus{
+			{ID: 0, CmdLine: z.poolCmdLine(0), Participating: true},
+			{ID: 1, CmdLine: z.poolCmdLine(1)},
+		},
+	}}
+	z.poolMetaMutex.Unlock()
+	if err = z.savePoolMeta(ctx); err != nil {
+		t.Fatal(err)
+	}
+	z.startRebalance()
+
+	if _, err = z.RebalanceStart(ctx); err != errRebalanceAlreadyRunning {
+		t.Fatalf("expected %v, got %v", errRebalanceAlreadyRunning, err)
+	}
+	if err = z.Decommission(ctx, 0); err != errRebalanceAlreadyRunning {
+		t.Fatalf("expected %v, got %v", errRebalanceAlreadyRunning, err)
+	}
+
+	deadline := time.Now().Add(time.Minute)
+	for {
+		status, err = z.RebalanceStatus(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !status.Running() {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the rebalance")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if !status.Complete || !status.Pools[0].Done {
+		t.Fatalf("rebalance did not complete: %#v", status)
+	}
+	if status.Pools[0].ObjectsMoved != 3 || status.Pools[0].BytesMoved != 3*int64(len(data)) {
+		t.Fatalf("expected 3 versions moved, got %#v", status.Pools[0])
+	}
+
+	for _, versionID := range versionIDs {
+		if _, err = src.GetObjectInfo(ctx, bucket, "object", ObjectOptions{VersionID: versionID}); !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+			t.Fatalf("version %s is still on the first pool: %v", versionID, err)
+		}
+		var buf bytes.Buffer
+		if err = z.GetObject(ctx, bucket, "object", 0, -1, &buf, "", ObjectOptions{VersionID: versionID}); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), data) {
+			t.Fatalf("unexpected content for version %s", versionID)
+		}
+	}
+
+	meta, err := z.loadPoolMeta(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Rebalance == nil || meta.Rebalance.ID != status.ID || !meta.Rebalance.Complete {
+		t.Fatalf("expected the completed rebalance to be saved, got %#v", meta.Rebalance)
+	}
+}
@@ -0,0 +1,351 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Interval at which the progress of a re-stripe is saved.
+const restripeSaveInterval = 30 * time.Second
+
+var (
+	// Throttles rewriting objects, the re-stripe waits the time
+	// taken to rewrite an object multiplied by the factor.
+	restripeSleeper = newDynamicSleeper(5, time.Second)
+
+	errRestripeAlreadyRunning = errors.New("a re-stripe is already running")
+	errRestripeNotStarted     = errors.New("no re-stripe is running")
+	errRestripeStopped        = errors.New("re-stripe was stopped")
+	errRestripePrefixNoBucket = errors.New("a re-stripe prefix requires a bucket")
+)
+
+// restripeMeta - status of a re-stripe as persisted in pool.json.
+type restripeMeta struct {
+	madmin.RestripeStatus
+
+	// Buckets fully re-striped on all pools, they are
+	// skipped when a re-stripe is resumed.
+	RestripedBuckets []string `json:"restripedBuckets,omitempty"`
+}
+
+// Running - returns true if the re-stripe is in progress.
+func (r *restripeMeta) Running() bool {
+	return r != nil && r.RestripeStatus.Running()
+}
+
+// RestripeStart - starts rewriting the object versions of the bucket under
+// the prefix, or of all buckets if bucket is empty, which are not erasure
+// coded with the current parity and block size. Returns the id of the
+// re-stripe.
+func (z *erasureServerPools) RestripeStart(ctx context.Context, bucket, prefix string) (string, error) {
+	if bucket == "" && prefix != "" {
+		return "", errRestripePrefixNoBucket
+	}
+	if bucket != "" {
+		if _, err := z.GetBucketInfo(ctx, bucket); err != nil {
+			return "", err
+		}
+	}
+
+	z.poolMetaMutex.Lock()
+	if z.poolMeta.Restripe.Running() {
+		z.poolMetaMutex.Unlock()
+		return "", errRestripeAlreadyRunning
+	}
+	r := &restripeMeta{RestripeStatus: madmin.RestripeStatus{
+		ID:        mustGetUUID(),
+		Bucket:    bucket,
+		Prefix:    prefix,
+		StartTime: UTCNow(),
+	}}
+	prev := z.poolMeta.Restripe
+	z.poolMeta.Restripe = r
+	z.poolMetaMutex.Unlock()
+
+	if err := z.savePoolMeta(ctx); err != nil {
+		z.poolMetaMutex.Lock()
+		z.poolMeta.Restripe = prev
+		z.poolMetaMutex.Unlock()
+		return "", err
+	}
+
+	if z.poolsLeader() {
+		z.startRestripe()
+	}
+	return r.ID, nil
+}
+
+// RestripeStop - stops an ongoing re-stripe, the versions already
+// rewritten keep their new layout.
+func (z *erasureServerPools) RestripeStop(ctx context.Context) error {
+	z.poolMetaMutex.Lock()
+	r := z.poolMeta.Restripe
+	if !r.Running() {
+		z.poolMetaMutex.Unlock()
+		return errRestripeNotStarted
+	}
+	r.Stopped = true
+	r.StopTime = UTCNow()
+	cancel := z.restripeCancel
+	z.restripeCancel = nil
+	z.poolMetaMutex.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	return z.savePoolMeta(ctx)
+}
+
+// RestripeStatus - returns the status of the last re-stripe, nodes other
+// than the leader reload it first since only the leader tracks the progress
+// in memory.
+func (z *erasureServerPools) RestripeStatus(ctx context.Context) (madmin.RestripeStatus, error) {
+	if !z.poolsLeader() {
+		if err := z.ReloadPoolMeta(ctx); err != nil {
+			return madmin.RestripeStatus{}, err
+		}
+	}
+
+	z.poolMetaMutex.RLock()
+	defer z.poolMetaMutex.RUnlock()
+	if z.poolMeta.Restripe == nil {
+		return madmin.RestripeStatus{}, errRestripeNotStarted
+	}
+	return z.poolMeta.Restripe.RestripeStatus, nil
+}
+
+// startRestripe - starts the re-stripe worker unless it is already running.
+func (z *erasureServerPools) startRestripe() {
+	ctx, cancel := context.WithCancel(GlobalContext)
+	z.poolMetaMutex.Lock()
+	if z.restripeCancel != nil {
+		z.poolMetaMutex.Unlock()
+		cancel()
+		return
+	}
+	z.restripeCancel = cancel
+	z.poolMetaMutex.Unlock()
+
+	go z.restripePools(ctx, cancel)
+}
+
+// restripePools - rewrites the selected objects bucket by bucket, skipping
+// the buckets already re-striped before a restart.
+func (z *erasureServerPools) restripePools(ctx context.Context, cancel context.CancelFunc) {
+	// Whoever cancels the worker also removes it, until then
+	// the worker removes itself when it stops.
+	removed := false
+	defer func() {
+		z.poolMetaMutex.Lock()
+		if !removed && ctx.Err() == nil {
+			z.restripeCancel = nil
+		}
+		z.poolMetaMutex.Unlock()
+		cancel()
+	}()
+
+	err := z.restripeBuckets(ctx)
+	if err != nil {
+		if ctx.Err() != nil || err == errRestripeStopped {
+			return
+		}
+		logger.LogIf(ctx, err)
+	}
+
+	z.poolMetaMutex.Lock()
+	r := z.poolMeta.Restripe
+	r.Complete = err == nil
+	r.Failed = err != nil
+	r.StopTime = UTCNow()
+	// Allow starting another re-stripe right away.
+	if ctx.Err() == nil {
+		z.restripeCancel = nil
+		removed = true
+	}
+	z.poolMetaMutex.Unlock()
+
+	if err = z.saveRestripeProgress(ctx); err != nil && err != errRestripeStopped {
+		logger.LogIf(ctx, err)
+	}
+}
+
+// saveRestripeProgress - saves the progress of the re-stripe, unless it
+// was stopped meanwhile through another node.
+func (z *erasureServerPools) saveRestripeProgress(ctx context.Context) error {
+	z.poolMetaMutex.RLock()
+	id := z.poolMeta.Restripe.ID
+	z.poolMetaMutex.RUnlock()
+
+	if meta, err := z.loadPoolMeta(ctx); err == nil {
+		if saved := meta.Restripe; saved != nil && saved.ID == id && saved.Stopped {
+			z.poolMetaMutex.Lock()
+			z.poolMeta.Restripe.Stopped = true
+			z.poolMeta.Restripe.StopTime = saved.StopTime
+			z.poolMetaMutex.Unlock()
+			return errRestripeStopped
+		}
+	}
+	return z.savePoolMeta(ctx)
+}
+
+// restripeUpdate - records the outcome of re-striping an object.
+func (z *erasureServerPools) restripeUpdate(versions, size, skipped int64, err error) {
+	z.poolMetaMutex.Lock()
+	defer z.poolMetaMutex.Unlock()
+	r := z.poolMeta.Restripe
+	r.ObjectsSkipped += skipped
+	if err != nil {
+		r.ObjectsFailed++
+		return
+	}
+	r.ObjectsRestriped += versions
+	r.BytesRestriped += size
+}
+
+// restripeBuckets - rewrites the objects of the selected buckets on all
+// pools, except the suspended pools whose objects are being moved off
+// with the current layout anyway.
+func (z *erasureServerPools) restripeBuckets(ctx context.Context) error {
+	z.poolMetaMutex.RLock()
+	bucketName, prefix := z.poolMeta.Restripe.Bucket, z.poolMeta.Restripe.Prefix
+	z.poolMetaMutex.RUnlock()
+
+	var buckets []string
+	if bucketName != "" {
+		buckets = []string{bucketName}
+	} else {
+		bis, err := z.ListBuckets(ctx)
+		if err != nil {
+			return err
+		}
+		for _, bi := range bis {
+			buckets = append(buckets, bi.Name)
+		}
+	}
+
+	lastSave := UTCNow()
+	for _, name := range buckets {
+		z.poolMetaMutex.RLock()
+		done := false
+		for _, b := range z.poolMeta.Restripe.RestripedBuckets {
+			if b == name {
+				done = true
+				break
+			}
+		}
+		z.poolMetaMutex.RUnlock()
+		if done {
+			continue
+		}
+
+		bucket := poolBucket{Name: name, Prefix: prefix}
+		for idx, pool := range z.serverPools {
+			if z.IsSuspended(idx) {
+				continue
+			}
+			for _, set := range pool.sets {
+				set := set
+				err := set.walkVersions(ctx, bucket, func(entry FileInfoVersions) error {
+					wait := restripeSleeper.Timer(ctx)
+					defer wait()
+
+					versions, size, skipped, err := set.restripeObject(ctx, bucket.Name, entry)
+					if err != nil {
+						logger.LogIf(ctx, fmt.Errorf("unable to re-stripe %s/%s: %w", bucket.Name, entry.Name, err))
+					}
+					z.restripeUpdate(versions, size, skipped, err)
+
+					if time.Since(lastSave) > restripeSaveInterval {
+						if err := z.saveRestripeProgress(ctx); err != nil {
+							return err
+						}
+						lastSave = UTCNow()
+					}
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		z.poolMetaMutex.Lock()
+		r := z.poolMeta.Restripe
+		r.RestripedBuckets = append(r.RestripedBuckets, name)
+		z.poolMetaMutex.Unlock()
+		if err := z.saveRestripeProgress(ctx); err != nil {
+			return err
+		}
+		lastSave = UTCNow()
+	}
+	return nil
+}
+
+// restripeObject - rewrites in place the versions of an object which are
+// not erasure coded with the current parity and block size, keeping their
+// version id, modtime, parts and metadata. Returns the number of versions
+// and bytes rewritten along with the number of versions left as they are.
+func (er erasureObjects) restripeObject(ctx context.Context, bucket string, entry FileInfoVersions) (versions, size, skipped int64, err error) {
+	object := entry.Name
+	lk := er.NewNSLock(bucket, object)
+	if err = lk.GetLock(ctx, globalOperationTimeout); err != nil {
+		return 0, 0, 0, err
+	}
+	defer lk.Unlock()
+
+	setDriveCount := len(er.getDisks())
+	for _, version := range entry.Versions {
+		if version.Deleted || version.TransitionStatus == lifecycle.TransitionComplete {
+			// Delete markers and transitioned versions have no data here.
+			continue
+		}
+		versionID := version.VersionID
+		if versionID == "" && !version.XLV1 {
+			versionID = nullVersionID
+		}
+		fi, metaArr, onlineDisks, err := er.getObjectFileInfo(ctx, bucket, object, ObjectOptions{VersionID: versionID})
+		if err != nil {
+			if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+				continue
+			}
+			return versions, size, skipped, err
+		}
+		if fi.Deleted || fi.TransitionStatus == lifecycle.TransitionComplete {
+			continue
+		}
+		parity := getParityForSC(bucket, fi.Metadata[xhttp.AmzStorageClass], setDriveCount)
+		if fi.Erasure.ParityBlocks == parity && fi.Erasure.BlockSize == blockSizeV1 {
+			skipped++
+			continue
+		}
+		if err = er.moveObjectVersion(ctx, er, bucket, object, fi, metaArr, onlineDisks); err != nil {
+			return versions, size, skipped, toObjectErr(err, bucket, object)
+		}
+		versions++
+		size += fi.Size
+	}
+	return versions, size, skipped, nil
+}
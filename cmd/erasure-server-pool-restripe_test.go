@@ -0,0 +1,178 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/config/storageclass"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestRestripe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Objects are written with a parity of 2, then re-striped
+	// with the default parity of 4 of the 8 drive set below.
+	setTestStorageClass(t, storageclass.Config{Standard: storageclass.StorageClass{Parity: 2}})
+
+	disks, err := getRandomDisks(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+
+	obj, _, err := initObjectLayer(ctx, mustGetZoneEndpoints(disks...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := obj.(*erasureServerPools)
+
+	if _, err = z.RestripeStatus(ctx); err != errRestripeNotStarted {
+		t.Fatalf("expected %v, got %v", errRestripeNotStarted, err)
+	}
+	if err = z.RestripeStop(ctx); err != errRestripeNotStarted {
+		t.Fatalf("expected %v, got %v", errRestripeNotStarted, err)
+	}
+	if _, err = z.RestripeStart(ctx, "", "prefix/"); err != errRestripePrefixNoBucket {
+		t.Fatalf("expected %v, got %v", errRestripePrefixNoBucket, err)
+	}
+	if _, err = z.RestripeStart(ctx, "nosuchbucket", ""); !isErrBucketNotFound(err) {
+		t.Fatalf("expected bucket not found, got %v", err)
+	}
+
+	bucket, otherBucket := "bucket", "other"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err = obj.MakeBucketWithLocation(ctx, otherBucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte("a"), 1024)
+	var versions []ObjectInfo
+	for i := 0; i < 2; i++ {
+		oi, err := obj.PutObject(ctx, bucket, "a/object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		versions = append(versions, oi)
+	}
+	if _, err = obj.PutObject(ctx, bucket, "b/object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.PutObject(ctx, otherBucket, "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	parity := func(bucket, object, versionID string) int {
+		t.Helper()
+		fi, _, _, err := z.serverPools[0].sets[0].getObjectFileInfo(ctx, bucket, object, ObjectOptions{VersionID: versionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fi.Erasure.ParityBlocks
+	}
+
+	restripe := func(bucket, prefix string) madmin.RestripeStatus {
+		t.Helper()
+		id, err := z.RestripeStart(ctx, bucket, prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+		deadline := time.Now().Add(time.Minute)
+		for {
+			status, err := z.RestripeStatus(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.ID != id {
+				t.Fatalf("expected re-stripe %s, got %s", id, status.ID)
+			}
+			if !status.Running() {
+				if !status.Complete {
+					t.Fatalf("re-stripe did not complete: %#v", status)
+				}
+				return status
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("timed out waiting for the re-stripe")
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+
+	globalStorageClass = storageclass.Config{}
+
+	status := restripe(bucket, "a/")
+	if status.ObjectsRestriped != 2 || status.BytesRestriped != 2*int64(len(data)) || status.ObjectsSkipped != 0 || status.ObjectsFailed != 0 {
+		t.Fatalf("expected 2 versions re-striped, got %#v", status)
+	}
+	for _, version := range versions {
+		if p := parity(bucket, "a/object", version.VersionID); p != 4 {
+			t.Fatalf("expected parity 4 for version %s, got %d", version.VersionID, p)
+		}
+		oi, err := obj.GetObjectInfo(ctx, bucket, "a/object", ObjectOptions{VersionID: version.VersionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !oi.ModTime.Equal(version.ModTime) || oi.ETag != version.ETag {
+			t.Fatalf("expected version %s to be preserved, got %#v", version.VersionID, oi)
+		}
+		var buf bytes.Buffer
+		if err = obj.GetObject(ctx, bucket, "a/object", 0, -1, &buf, "", ObjectOptions{VersionID: version.VersionID}); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), data) {
+			t.Fatalf("unexpected content for version %s", version.VersionID)
+		}
+	}
+	// The data of the rewritten versions replaces their old data.
+	entries, err := ioutil.ReadDir(filepath.Join(disks[0], bucket, "a/object"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(versions)+1 {
+		t.Fatalf("expected xl.meta and %d data dirs, got %d entries", len(versions), len(entries))
+	}
+	if p := parity(bucket, "b/object", ""); p != 2 {
+		t.Fatalf("expected objects outside the prefix to keep parity 2, got %d", p)
+	}
+
+	// Re-stripe all buckets, the versions already re-striped are skipped.
+	status = restripe("", "")
+	if status.ObjectsRestriped != 2 || status.ObjectsSkipped != 2 || status.ObjectsFailed != 0 {
+		t.Fatalf("expected 2 versions re-striped and 2 skipped, got %#v", status)
+	}
+	if p := parity(otherBucket, "object", ""); p != 4 {
+		t.Fatalf("expected parity 4, got %d", p)
+	}
+
+	meta, err := z.loadPoolMeta(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Restripe == nil || meta.Restripe.ID != status.ID || !meta.Restripe.Complete {
+		t.Fatalf("expected the completed re-stripe to be saved, got %#v", meta.Restripe)
+	}
+}
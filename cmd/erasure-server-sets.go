@@ -43,6 +43,25 @@ type erasureServerPools struct {
 
 	serverPools []*erasureSets
 
+	// Command line each pool was started with.
+	poolCmdLines []string
+
+	// Status of all pools, persisted in pool.json.
+	poolMetaMutex sync.RWMutex
+	poolMeta      poolMeta
+
+	// Cancels the decommission worker of a pool, only
+	// set on the node which runs the decommission.
+	decommissionCancelers []context.CancelFunc
+
+	// Cancels the rebalance worker, only set on
+	// the node which runs the rebalance.
+	rebalanceCancel context.CancelFunc
+
+	// Cancels the re-stripe worker, only set on
+	// the node which runs the re-stripe.
+	restripeCancel context.CancelFunc
+
 	// Shut down async operations
 	shutdown context.CancelFunc
 }
@@ -59,7 +78,10 @@ func newErasureServerPools(ctx context.Context, endpointServerPools EndpointServ
 
 		formats      = make([]*formatErasureV3, len(endpointServerPools))
 		storageDisks = make([][]StorageAPI, len(endpointServerPools))
-		z            = &erasureServerPools{serverPools: make([]*erasureSets, len(endpointServerPools))}
+		z            = &erasureServerPools{
+			serverPools:  make([]*erasureSets, len(endpointServerPools)),
+			poolCmdLines: make([]string, len(endpointServerPools)),
+		}
 	)
 
 	var localDrives []string
@@ -83,6 +105,10 @@ func newErasureServerPools(ctx context.Context, endpointServerPools EndpointServ
 		if err != nil {
 			return nil, err
 		}
+		z.poolCmdLines[i] = ep.CmdLine
+	}
+	if err = z.initPoolMeta(ctx); err != nil {
+		return nil, err
 	}
 	ctx, z.shutdown = context.WithCancel(ctx)
 	go intDataUpdateTracker.start(ctx, localDrives...)
@@ -93,6 +119,33 @@ func (z *erasureServerPools) NewNSLock(bucket string, objects ...string) RWLocke
 	return z.serverPools[0].NewNSLock(bucket, objects...)
 }
 
+// newPlacementLock - returns a lock spanning the pools on the placement
+// of an object, distinct from the namespace lock of the object taken by
+// the pool it is written to.
+func (z *erasureServerPools) newPlacementLock(bucket, object string) RWLocker {
+	return z.NewNSLock(minioMetaBucket, pathJoin("placement", bucket, object))
+}
+
+// checkWritePrecondition - evaluates the write preconditions of opts
+// against the latest version of the object in any pool, the caller
+// must hold the placement lock of the object.
+func (z *erasureServerPools) checkWritePrecondition(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	if opts.CheckPrecondFn == nil {
+		return nil
+	}
+	oi, err := z.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+			return err
+		}
+		oi = ObjectInfo{}
+	}
+	if opts.CheckPrecondFn(oi) {
+		return PreConditionFailed{}
+	}
+	return nil
+}
+
 // GetDisksID will return disks by their ID.
 func (z *erasureServerPools) GetDisksID(ids ...string) []StorageAPI {
 	idMap := make(map[string]struct{})
@@ -146,15 +199,20 @@ func (p serverPoolsAvailableSpace) TotalAvailable() uint64 {
 // getAvailableZoneIdx will return an index that can hold size bytes.
 // -1 is returned if no serverPools have available space for the size given.
 func (z *erasureServerPools) getAvailableZoneIdx(ctx context.Context, size int64) int {
-	serverPools := z.getServerPoolsAvailableSpace(ctx, size)
-	total := serverPools.TotalAvailable()
+	return z.getServerPoolsAvailableSpace(ctx, size).pick(ctx)
+}
+
+// pick returns the index of a zone chosen at random, weighted by the
+// available space of each zone. -1 is returned if no space is available.
+func (p serverPoolsAvailableSpace) pick(ctx context.Context) int {
+	total := p.TotalAvailable()
 	if total == 0 {
 		return -1
 	}
 	// choose when we reach this many
 	choose := rand.Uint64() % total
 	atTotal := uint64(0)
-	for _, zone := range serverPools {
+	for _, zone := range p {
 		atTotal += zone.Available
 		if atTotal > choose && zone.Available > 0 {
 			return zone.Index
@@ -208,6 +266,10 @@ func (z *erasureServerPools) getServerPoolsAvailableSpace(ctx context.Context, s
 				available = 0
 			}
 		}
+		// No new objects are placed on a pool being decommissioned.
+		if z.IsSuspended(i) {
+			available = 0
+		}
 		serverPools[i] = zoneAvailableSpace{
 			Index:     i,
 			Available: available,
@@ -408,6 +470,9 @@ func (z *erasureServerPools) CrawlAndGetDataUsage(ctx context.Context, bf *bloom
 					for _, b := range allBuckets {
 						enforceFIFOQuotaBucket(ctx, z, b.Name, allMerged.bucketUsageInfo(b.Name))
 					}
+					if allMerged.root() != nil {
+						logger.LogIf(ctx, z.recordUsageHistory(ctx, allMerged, allBuckets))
+					}
 				}
 				close(v)
 				return
@@ -551,6 +616,17 @@ func (z *erasureServerPools) PutObject(ctx context.Context, bucket string, objec
 		return z.serverPools[0].PutObject(ctx, bucket, object, data, opts)
 	}
 
+	// Concurrent conditional writes of a new object may be placed on
+	// different pools, they are serialized across the pools so that
+	// only one of them can succeed.
+	if opts.CheckPrecondFn != nil {
+		lk := z.newPlacementLock(bucket, object)
+		if err := lk.GetLock(ctx, globalOperationTimeout); err != nil {
+			return ObjectInfo{}, err
+		}
+		defer lk.Unlock()
+	}
+
 	idx, err := z.getZoneIdx(ctx, bucket, object, opts, data.Size())
 	if err != nil {
 		return ObjectInfo{}, err
@@ -954,6 +1030,20 @@ func (z *erasureServerPools) CompleteMultipartUpload(ctx context.Context, bucket
 		return z.serverPools[0].CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
 	}
 
+	// Conditional writes are evaluated against the object in any pool
+	// before it is purged, under a lock spanning the pools.
+	if opts.CheckPrecondFn != nil {
+		lk := z.newPlacementLock(bucket, object)
+		if err = lk.GetLock(ctx, globalOperationTimeout); err != nil {
+			return objInfo, err
+		}
+		defer lk.Unlock()
+
+		if err = z.checkWritePrecondition(ctx, bucket, object, opts); err != nil {
+			return objInfo, err
+		}
+	}
+
 	// Purge any existing object.
 	for _, zone := range z.serverPools {
 		zone.DeleteObject(ctx, bucket, object, opts)
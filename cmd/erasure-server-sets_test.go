@@ -0,0 +1,119 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
+)
+
+func TestConditionalWritesAcrossPools(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	z := prepareErasurePools(ctx, t, 2)
+	bucket := "bucket"
+	if err := z.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := http.NewRequest(http.MethodPut, "http://localhost", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Header.Set(xhttp.IfNoneMatch, "*")
+	opts := ObjectOptions{CheckPrecondFn: checkPreconditionsPUT(r)}
+
+	// Only one of the writers of a new object wins, whatever the
+	// pool picked for each of them.
+	data := []byte("data")
+	for i := 0; i < 5; i++ {
+		object := fmt.Sprintf("object-%d", i)
+		var wg sync.WaitGroup
+		errs := make([]error, 8)
+		for j := range errs {
+			wg.Add(1)
+			go func(j int) {
+				defer wg.Done()
+				_, errs[j] = z.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts)
+			}(j)
+		}
+		wg.Wait()
+		var won int
+		for _, err := range errs {
+			switch err.(type) {
+			case nil:
+				won++
+			case PreConditionFailed:
+			default:
+				t.Fatal(err)
+			}
+		}
+		if won != 1 {
+			t.Fatalf("%s: expected a single writer to win, got %d", object, won)
+		}
+	}
+
+	// Uploads started on both pools, only one of them completes.
+	object := "upload"
+	var uploads []string
+	var parts [][]CompletePart
+	for _, pool := range z.serverPools {
+		uploadID, err := pool.NewMultipartUpload(ctx, bucket, object, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		pi, err := pool.PutObjectPart(ctx, bucket, object, uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		uploads = append(uploads, uploadID)
+		parts = append(parts, []CompletePart{{PartNumber: 1, ETag: pi.ETag}})
+	}
+	var wg sync.WaitGroup
+	errs := make([]error, len(uploads))
+	for i := range uploads {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = z.CompleteMultipartUpload(ctx, bucket, object, uploads[i], parts[i], opts)
+		}(i)
+	}
+	wg.Wait()
+	var won int
+	for _, err := range errs {
+		switch err.(type) {
+		case nil:
+			won++
+		case PreConditionFailed:
+		default:
+			t.Fatal(err)
+		}
+	}
+	if won != 1 {
+		t.Fatalf("expected a single upload to complete, got %d", won)
+	}
+	if _, err = z.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil {
+		t.Fatalf("expected the object of the completed upload to be kept, got %v", err)
+	}
+}
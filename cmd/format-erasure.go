@@ -30,6 +30,7 @@ import (
 	"github.com/minio/minio/cmd/config/storageclass"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/color"
+	"github.com/minio/minio/pkg/madmin"
 	"github.com/minio/minio/pkg/sync/errgroup"
 	sha256 "github.com/minio/sha256-simd"
 )
@@ -341,15 +342,11 @@ func loadFormatErasureAll(storageDisks []StorageAPI, heal bool) ([]*formatErasur
 
 func saveHealingTracker(disk StorageAPI, diskID string) error {
 	htracker := healingTracker{
-		ID: diskID,
+		ID:    diskID,
+		State: string(madmin.DriveHealing),
+		disk:  disk,
 	}
-	htrackerBytes, err := htracker.MarshalMsg(nil)
-	if err != nil {
-		return err
-	}
-	return disk.WriteAll(context.TODO(), minioMetaBucket,
-		pathJoin(bucketMetaPrefix, slashSeparator, healingTrackerFilename),
-		htrackerBytes)
+	return htracker.save(context.TODO())
 }
 
 func saveFormatErasure(disk StorageAPI, format *formatErasureV3, heal bool) error {
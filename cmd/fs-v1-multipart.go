@@ -31,6 +31,7 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/hash"
 	mioutil "github.com/minio/minio/pkg/ioutil"
 	"github.com/minio/minio/pkg/trie"
 )
@@ -338,6 +339,10 @@ func (fs *FSObjects) PutObjectPart(ctx context.Context, bucket, object, uploadID
 	}
 
 	etag := r.MD5CurrentHexString()
+	var checksum hash.Checksum
+	if c := r.ContentChecksum(); c != nil {
+		checksum = *c
+	}
 
 	if etag == "" {
 		etag = GenETag()
@@ -365,6 +370,7 @@ func (fs *FSObjects) PutObjectPart(ctx context.Context, bucket, object, uploadID
 		ETag:         etag,
 		Size:         fi.Size(),
 		ActualSize:   data.ActualSize(),
+		Checksum:     checksum,
 	}, nil
 }
 
@@ -720,6 +726,12 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 	}
 	defer destLock.Unlock()
 
+	// Conditional writes are evaluated under the namespace lock, so
+	// that only one of several concurrent writers can succeed.
+	if err = fs.checkWritePrecondition(ctx, bucket, object, opts); err != nil {
+		return oi, err
+	}
+
 	bucketMetaDir := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix)
 	fsMetaPath := pathJoin(bucketMetaDir, bucket, object, fs.metaJSONFile)
 	metaFile, err := fs.rwPool.Write(fsMetaPath)
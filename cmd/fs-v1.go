@@ -355,11 +355,12 @@ func (fs *FSObjects) crawlBucket(ctx context.Context, bucket string, cache dataU
 
 		oi := fsMeta.ToObjectInfo(bucket, object, fi)
 		sz := item.applyActions(ctx, fs, actionMeta{oi: oi})
-		if sz >= 0 {
-			return sizeSummary{totalSize: sz}, nil
+		if sz < 0 {
+			sz = fi.Size()
 		}
-
-		return sizeSummary{totalSize: fi.Size()}, nil
+		sizeS := sizeSummary{totalSize: sz}
+		sizeS.addWriter(oi.UserDefined, sz)
+		return sizeS, nil
 	})
 
 	return cache, err
@@ -1112,6 +1113,12 @@ func (fs *FSObjects) PutObject(ctx context.Context, bucket string, object string
 	defer lk.Unlock()
 	defer ObjectPathUpdated(path.Join(bucket, object))
 
+	// Conditional writes are evaluated under the namespace lock, so
+	// that only one of several concurrent writers can succeed.
+	if err := fs.checkWritePrecondition(ctx, bucket, object, opts); err != nil {
+		return objInfo, err
+	}
+
 	atomic.AddInt64(&fs.activeIOCount, 1)
 	defer func() {
 		atomic.AddInt64(&fs.activeIOCount, -1)
@@ -1120,6 +1127,27 @@ func (fs *FSObjects) PutObject(ctx context.Context, bucket string, object string
 	return fs.putObject(ctx, bucket, object, r, opts)
 }
 
+// checkWritePrecondition - evaluates the write preconditions of opts
+// against the object, a missing object is passed as an empty
+// ObjectInfo. The caller must hold the namespace write lock of the
+// object.
+func (fs *FSObjects) checkWritePrecondition(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	if opts.CheckPrecondFn == nil {
+		return nil
+	}
+	oi, err := fs.getObjectInfo(ctx, bucket, object)
+	if err != nil {
+		if err != errFileNotFound {
+			return toObjectErr(err, bucket, object)
+		}
+		oi = ObjectInfo{}
+	}
+	if opts.CheckPrecondFn(oi) {
+		return PreConditionFailed{}
+	}
+	return nil
+}
+
 // putObject - wrapper for PutObject
 func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, retErr error) {
 	data := r.Reader
@@ -1219,6 +1247,9 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 		return ObjectInfo{}, toObjectErr(err, bucket, object)
 	}
 	fsMeta.Meta["etag"] = r.MD5CurrentHexString()
+	if checksum := r.ContentChecksum(); checksum != nil {
+		fsMeta.Meta[objectChecksumKey] = checksum.String()
+	}
 
 	// Should return IncompleteBody{} error when reader has fewer
 	// bytes than specified in request header.
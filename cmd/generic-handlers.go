@@ -271,8 +271,9 @@ func guessIsRPCReq(req *http.Request) bool {
 }
 
 func (h browserRedirectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// Re-direction is handled specifically for browser requests.
-	if guessIsBrowserReq(r) {
+	// Re-direction is handled specifically for browser requests,
+	// website endpoints serve their own index documents.
+	if guessIsBrowserReq(r) && !guessIsWebsiteReq(r) {
 		// Fetch the redirect location if any.
 		redirectLocation := getRedirectLocation(r.URL.Path)
 		if redirectLocation != "" {
@@ -338,8 +339,10 @@ func setReservedBucketHandler(h http.Handler) http.Handler {
 
 func (h minioReservedBucketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch {
-	case guessIsRPCReq(r), guessIsBrowserReq(r), guessIsHealthCheckReq(r), guessIsMetricsReq(r), isAdminReq(r):
-		// Allow access to reserved buckets
+	case guessIsRPCReq(r), guessIsBrowserReq(r), guessIsHealthCheckReq(r), guessIsMetricsReq(r), isAdminReq(r),
+		guessIsWebsiteReq(r):
+		// Allow access to reserved buckets, website requests
+		// address the bucket in the host.
 	default:
 		// For all other requests reject access to reserved buckets
 		bucketName, _ := request2BucketObjectName(r)
@@ -435,21 +438,13 @@ func setIgnoreResourcesHandler(h http.Handler) http.Handler {
 }
 
 var supportedDummyBucketAPIs = map[string][]string{
-	"acl":            {http.MethodPut, http.MethodGet},
-	"cors":           {http.MethodGet},
-	"website":        {http.MethodGet, http.MethodDelete},
-	"logging":        {http.MethodGet},
 	"accelerate":     {http.MethodGet},
 	"requestPayment": {http.MethodGet},
 }
 
 // List of not implemented bucket queries
 var notImplementedBucketResourceNames = map[string]struct{}{
-	"cors":           {},
 	"metrics":        {},
-	"website":        {},
-	"logging":        {},
-	"inventory":      {},
 	"accelerate":     {},
 	"requestPayment": {},
 }
@@ -473,9 +468,7 @@ func ignoreNotImplementedBucketResources(req *http.Request) bool {
 	return false
 }
 
-var supportedDummyObjectAPIs = map[string][]string{
-	"acl": {http.MethodGet, http.MethodPut},
-}
+var supportedDummyObjectAPIs = map[string][]string{}
 
 // List of not implemented object APIs
 var notImplementedObjectResourceNames = map[string]struct{}{
@@ -627,7 +620,7 @@ func (f bucketForwardingHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	if globalDNSConfig == nil || len(globalDomainNames) == 0 ||
 		guessIsHealthCheckReq(r) || guessIsMetricsReq(r) ||
 		guessIsRPCReq(r) || guessIsLoginSTSReq(r) || isAdminReq(r) ||
-		!globalBucketFederation {
+		guessIsWebsiteReq(r) || !globalBucketFederation {
 		f.handler.ServeHTTP(w, r)
 		return
 	}
@@ -118,7 +118,7 @@ func mustGetHealSequence(ctx context.Context) *healSequence {
 }
 
 // healErasureSet lists and heals all objects in a specific erasure set
-func healErasureSet(ctx context.Context, setIndex int, buckets []BucketInfo, disks []StorageAPI) error {
+func healErasureSet(ctx context.Context, setIndex int, buckets []BucketInfo, disks []StorageAPI, tracker *healingTracker) error {
 	bgSeq := mustGetHealSequence(ctx)
 
 	buckets = append(buckets, BucketInfo{
@@ -135,6 +135,14 @@ func healErasureSet(ctx context.Context, setIndex int, buckets []BucketInfo, dis
 
 	// Heal all buckets with all objects
 	for _, bucket := range buckets {
+		if tracker.isHealed(bucket.Name) {
+			continue
+		}
+		tracker.Bucket = bucket.Name
+		if err := tracker.update(ctx, true); err != nil {
+			return err
+		}
+
 		// Heal current bucket
 		if err := bgSeq.queueHealTask(healSource{
 			bucket: bucket.Name,
@@ -174,18 +182,33 @@ func healErasureSet(ctx context.Context, setIndex int, buckets []BucketInfo, dis
 			}
 
 			for _, version := range entry.Versions {
-				if err := bgSeq.queueHealTask(healSource{
+				err := bgSeq.queueHealTask(healSource{
 					bucket:    bucket.Name,
 					object:    version.Name,
 					versionID: version.VersionID,
-				}, madmin.HealItemObject); err != nil {
+				}, madmin.HealItemObject)
+				switch {
+				case err == nil:
+					tracker.ObjectsHealed++
+					tracker.BytesHealed += uint64(version.Size)
+				case isErrObjectNotFound(err) || isErrVersionNotFound(err):
+					// Deleted in the meantime.
+				default:
+					tracker.ObjectsFailed++
 					logger.LogIf(ctx, err)
 				}
 			}
+
+			if err := tracker.update(ctx, false); err != nil {
+				return err
+			}
 		}
+
+		tracker.HealedBuckets = append(tracker.HealedBuckets, bucket.Name)
 	}
 
-	return nil
+	tracker.Bucket = ""
+	return tracker.update(ctx, true)
 }
 
 // deepHealObject heals given object path in deep to fix bitrot.
@@ -101,6 +101,24 @@ const (
 	// Maximum size of default bucket encryption configuration allowed
 	maxBucketSSEConfigSize = 1 * humanize.MiByte
 
+	// Maximum size of bucket CORS configuration allowed
+	maxBucketCorsConfigSize = 64 * humanize.KiByte
+
+	// Maximum size of bucket website configuration allowed
+	maxBucketWebsiteConfigSize = 64 * humanize.KiByte
+
+	// Maximum size of bucket logging configuration allowed
+	maxBucketLoggingConfigSize = 64 * humanize.KiByte
+
+	// Maximum size of a bucket inventory configuration allowed
+	maxBucketInventoryConfigSize = 64 * humanize.KiByte
+
+	// Maximum size of bucket public access block configuration allowed
+	maxBucketPublicAccessBlockConfigSize = 64 * humanize.KiByte
+
+	// Maximum size of bucket ownership controls configuration allowed
+	maxBucketOwnershipControlsConfigSize = 64 * humanize.KiByte
+
 	// diskFillFraction is the fraction of a disk we allow to be filled.
 	diskFillFraction = 0.95
 )
@@ -159,6 +177,7 @@ var (
 	globalLifecycleSys       *LifecycleSys
 	globalBucketSSEConfigSys *BucketSSEConfigSys
 	globalBucketTargetSys    *BucketTargetSys
+	globalBucketLoggingSys   *BucketLoggingSys
 	// globalAPIConfig controls S3 API requests throttling,
 	// healthcheck readiness deadlines and cors settings.
 	globalAPIConfig = apiConfig{listQuorum: 3}
@@ -211,14 +230,16 @@ var (
 
 	globalPublicCerts []*x509.Certificate
 
-	globalDomainNames []string      // Root domains for virtual host style requests
-	globalDomainIPs   set.StringSet // Root domain IP address(s) for a distributed MinIO deployment
+	globalDomainNames        []string      // Root domains for virtual host style requests
+	globalWebsiteDomainNames []string      // Root domains for static website requests
+	globalDomainIPs          set.StringSet // Root domain IP address(s) for a distributed MinIO deployment
 
 	globalOperationTimeout       = newDynamicTimeout(10*time.Minute, 5*time.Minute) // default timeout for general ops
 	globalDeleteOperationTimeout = newDynamicTimeout(5*time.Minute, 1*time.Minute)  // default time for delete ops
 
 	globalBucketObjectLockSys *BucketObjectLockSys
 	globalBucketQuotaSys      *BucketQuotaSys
+	globalIAMQuotaSys         *IAMQuotaSys
 	globalBucketVersioningSys *BucketVersioningSys
 
 	// Disk cache drives
@@ -23,6 +23,7 @@ import (
 
 	"github.com/minio/minio/cmd/config/api"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/publicaccess"
 	"github.com/minio/minio/pkg/sys"
 )
 
@@ -36,6 +37,8 @@ type apiConfig struct {
 	extendListLife   time.Duration
 	corsAllowOrigins []string
 	setDriveCount    int
+
+	publicAccessBlock publicaccess.Config
 }
 
 func (t *apiConfig) init(cfg api.Config, setDriveCount int) {
@@ -45,6 +48,12 @@ func (t *apiConfig) init(cfg api.Config, setDriveCount int) {
 	t.clusterDeadline = cfg.ClusterDeadline
 	t.corsAllowOrigins = cfg.CorsAllowOrigin
 	t.setDriveCount = setDriveCount
+	t.publicAccessBlock = publicaccess.Config{
+		BlockPublicAcls:       cfg.BlockPublicAcls,
+		IgnorePublicAcls:      cfg.IgnorePublicAcls,
+		BlockPublicPolicy:     cfg.BlockPublicPolicy,
+		RestrictPublicBuckets: cfg.RestrictPublicBuckets,
+	}
 
 	var apiRequestsMaxPerNode int
 	if cfg.RequestsMax <= 0 {
@@ -107,6 +116,13 @@ func (t *apiConfig) getCorsAllowOrigins() []string {
 	return corsAllowOrigins
 }
 
+func (t *apiConfig) getPublicAccessBlock() publicaccess.Config {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.publicAccessBlock
+}
+
 func (t *apiConfig) getClusterDeadline() time.Duration {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
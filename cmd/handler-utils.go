@@ -30,6 +30,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/gorilla/mux"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
@@ -217,6 +218,21 @@ func getReqAccessCred(r *http.Request, region string) (cred auth.Credentials) {
 	return cred
 }
 
+// getPostPolicyCred - returns the credentials which signed the policy of
+// a POST policy upload, the signature must be verified beforehand.
+func getPostPolicyCred(formValues http.Header) (cred auth.Credentials) {
+	accessKey := formValues.Get(xhttp.AmzAccessKeyID)
+	if _, ok := formValues["Signature"]; !ok {
+		credHeader, s3Err := parseCredentialHeader("Credential="+formValues.Get(xhttp.AmzCredential), globalServerRegion, serviceS3)
+		if s3Err != ErrNone {
+			return cred
+		}
+		accessKey = credHeader.accessKey
+	}
+	cred, _, _ = checkKeyValid(accessKey)
+	return cred
+}
+
 // Extract request params to be sent with event notifiation.
 func extractReqParams(r *http.Request) map[string]string {
 	if r == nil {
@@ -376,9 +392,15 @@ func collectAPIStats(api string, f http.HandlerFunc) http.HandlerFunc {
 
 		statsWriter := logger.NewResponseWriter(w)
 
+		// Error codes of failed requests are recorded in the
+		// access logs of buckets with access logging enabled.
+		loggingConfig := globalBucketLoggingSys.Enabled(mux.Vars(r)["bucket"])
+		statsWriter.LogErrBody = loggingConfig != nil
+
 		f.ServeHTTP(statsWriter, r)
 
 		globalHTTPStats.updateStats(api, r, statsWriter)
+		globalBucketLoggingSys.Log(loggingConfig, r, statsWriter)
 	}
 }
 
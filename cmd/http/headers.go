@@ -41,6 +41,20 @@ const (
 	Range              = "Range"
 )
 
+// Standard HTTP cross-origin resource sharing constants
+const (
+	Origin                        = "Origin"
+	Vary                          = "Vary"
+	AccessControlRequestMethod    = "Access-Control-Request-Method"
+	AccessControlRequestHeaders   = "Access-Control-Request-Headers"
+	AccessControlAllowOrigin      = "Access-Control-Allow-Origin"
+	AccessControlAllowMethods     = "Access-Control-Allow-Methods"
+	AccessControlAllowHeaders     = "Access-Control-Allow-Headers"
+	AccessControlAllowCredentials = "Access-Control-Allow-Credentials"
+	AccessControlExposeHeaders    = "Access-Control-Expose-Headers"
+	AccessControlMaxAge           = "Access-Control-Max-Age"
+)
+
 // Non standard S3 HTTP response constants
 const (
 	XCache       = "X-Cache"
@@ -94,7 +108,7 @@ const (
 	// Object date/time of expiration
 	AmzExpiration = "x-amz-expiration"
 
-	// Dummy putBucketACL
+	// S3 canned ACL
 	AmzACL = "x-amz-acl"
 
 	// Signature V4 related contants.
@@ -107,6 +121,16 @@ const (
 	AmzCredential           = "X-Amz-Credential"
 	AmzSecurityToken        = "X-Amz-Security-Token"
 	AmzDecodedContentLength = "X-Amz-Decoded-Content-Length"
+	AmzTrailer              = "X-Amz-Trailer"
+
+	// Flexible checksums related constants.
+	AmzChecksumMode      = "X-Amz-Checksum-Mode"
+	AmzChecksumAlgorithm = "X-Amz-Checksum-Algorithm"
+
+	// S3 object attributes
+	AmzObjectAttributes = "X-Amz-Object-Attributes"
+	AmzMaxParts         = "X-Amz-Max-Parts"
+	AmzPartNumberMarker = "X-Amz-Part-Number-Marker"
 
 	AmzMetaUnencryptedContentLength = "X-Amz-Meta-X-Amz-Unencrypted-Content-Length"
 	AmzMetaUnencryptedContentMD5    = "X-Amz-Meta-X-Amz-Unencrypted-Content-Md5"
@@ -147,6 +171,11 @@ const (
 	// Header indicates if the etag should be preserved by client
 	MinIOSourceETag = "x-minio-source-etag"
 
+	// Metadata indicates the version ID of the source of a replica uploaded in parts,
+	// the version ID cannot be set on the request initiating the multipart upload.
+	MinIOSourceVersionID        = "X-Minio-Source-Version-Id"
+	AmzMetaMinIOSourceVersionID = "X-Amz-Meta-" + MinIOSourceVersionID
+
 	// Writes expected write quorum
 	MinIOWriteQuorum = "x-minio-write-quorum"
 
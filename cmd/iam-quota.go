@@ -0,0 +1,307 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// IAM quotas configuration file.
+const iamQuotaConfigFile = iamConfigPrefix + "/quotas.json"
+
+// addWriter adds an object version of size bytes to the usage of the
+// identities which wrote it, as recorded by setObjectWriterMetadata.
+func (s *sizeSummary) addWriter(metadata map[string]string, size int64) {
+	for _, key := range []string{objectWriterMetadataKey, objectServiceAccountMetadataKey} {
+		id, ok := metadata[key]
+		if !ok || id == "" {
+			continue
+		}
+		if s.identities == nil {
+			s.identities = make(identitiesUsage, 2)
+		}
+		u := s.identities[id]
+		u.Size += size
+		u.Objects++
+		s.identities[id] = u
+	}
+}
+
+// iamQuotaConfig holds the quotas of IAM users, service accounts and groups.
+type iamQuotaConfig struct {
+	Users  map[string]madmin.IAMQuota `json:"users,omitempty"`
+	Groups map[string]madmin.IAMQuota `json:"groups,omitempty"`
+}
+
+// IAMQuotaSys - quotas of IAM users, service accounts and groups.
+type IAMQuotaSys struct {
+	// Serializes updates of the configuration.
+	mu sync.Mutex
+
+	configCache timedValue
+	usageCache  timedValue
+}
+
+// NewIAMQuotaSys returns initialized IAMQuotaSys
+func NewIAMQuotaSys() *IAMQuotaSys {
+	return &IAMQuotaSys{}
+}
+
+// loadIAMQuotaConfig - reads the IAM quotas from the backend.
+func loadIAMQuotaConfig(ctx context.Context, objAPI ObjectLayer) (iamQuotaConfig, error) {
+	var config iamQuotaConfig
+	data, err := readConfig(ctx, objAPI, iamQuotaConfigFile)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return config, nil
+		}
+		return config, err
+	}
+	err = json.Unmarshal(data, &config)
+	return config, err
+}
+
+// Get - returns the IAM quotas configuration, it is re-read from the
+// backend periodically to pick up updates made on other servers.
+func (sys *IAMQuotaSys) Get(objAPI ObjectLayer) (iamQuotaConfig, error) {
+	sys.configCache.Once.Do(func() {
+		sys.configCache.TTL = 10 * time.Second
+		sys.configCache.Update = func() (interface{}, error) {
+			ctx, done := context.WithTimeout(context.Background(), 5*time.Second)
+			defer done()
+			return loadIAMQuotaConfig(ctx, objAPI)
+		}
+	})
+
+	v, err := sys.configCache.Get()
+	if err != nil {
+		return iamQuotaConfig{}, err
+	}
+	return v.(iamQuotaConfig), nil
+}
+
+// update - applies fn to the IAM quotas configuration and saves it.
+func (sys *IAMQuotaSys) update(ctx context.Context, objAPI ObjectLayer, fn func(config *iamQuotaConfig)) error {
+	sys.mu.Lock()
+	defer sys.mu.Unlock()
+
+	config, err := loadIAMQuotaConfig(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	fn(&config)
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	if err = saveConfig(ctx, objAPI, iamQuotaConfigFile, data); err != nil {
+		return err
+	}
+	sys.configCache.Invalidate()
+	return nil
+}
+
+// setQuota sets or, when empty, removes the quota of name in quotas.
+func setQuota(quotas map[string]madmin.IAMQuota, name string, quota madmin.IAMQuota) map[string]madmin.IAMQuota {
+	if quota.IsEmpty() {
+		delete(quotas, name)
+		return quotas
+	}
+	if quotas == nil {
+		quotas = make(map[string]madmin.IAMQuota)
+	}
+	quotas[name] = quota
+	return quotas
+}
+
+// SetUserQuota - sets the quota of a user or service account.
+func (sys *IAMQuotaSys) SetUserQuota(ctx context.Context, objAPI ObjectLayer, accessKey string, quota madmin.IAMQuota) error {
+	return sys.update(ctx, objAPI, func(config *iamQuotaConfig) {
+		config.Users = setQuota(config.Users, accessKey, quota)
+	})
+}
+
+// SetGroupQuota - sets the quota shared by the members of a group.
+func (sys *IAMQuotaSys) SetGroupQuota(ctx context.Context, objAPI ObjectLayer, group string, quota madmin.IAMQuota) error {
+	return sys.update(ctx, objAPI, func(config *iamQuotaConfig) {
+		config.Groups = setQuota(config.Groups, group, quota)
+	})
+}
+
+// usage - returns the usage of each identity as last computed by the crawler.
+func (sys *IAMQuotaSys) usage(objAPI ObjectLayer) (map[string]IdentityUsageInfo, error) {
+	sys.usageCache.Once.Do(func() {
+		sys.usageCache.TTL = 1 * time.Second
+		sys.usageCache.Update = func() (interface{}, error) {
+			ctx, done := context.WithTimeout(context.Background(), 5*time.Second)
+			defer done()
+			return loadDataUsageFromBackend(ctx, objAPI)
+		}
+	})
+
+	v, err := sys.usageCache.Get()
+	if err != nil {
+		return nil, err
+	}
+	return v.(DataUsageInfo).IdentitiesUsage, nil
+}
+
+// groupUsage - returns the usage of the members of a group, groups of
+// external identity providers have no known members.
+func groupUsage(usage map[string]IdentityUsageInfo, group string) (u IdentityUsageInfo) {
+	gd, err := globalIAMSys.GetGroupDescription(group)
+	if err != nil {
+		return u
+	}
+	for _, member := range gd.Members {
+		u.Size += usage[member].Size
+		u.ObjectsCount += usage[member].ObjectsCount
+	}
+	return u
+}
+
+// GetUserQuota - returns the quota and usage of a user or service account.
+func (sys *IAMQuotaSys) GetUserQuota(objAPI ObjectLayer, accessKey string) (info madmin.IAMQuotaInfo, err error) {
+	config, err := sys.Get(objAPI)
+	if err != nil {
+		return info, err
+	}
+	usage, err := sys.usage(objAPI)
+	if err != nil {
+		return info, err
+	}
+	info.IAMQuota = config.Users[accessKey]
+	info.Size = usage[accessKey].Size
+	info.ObjectsCount = usage[accessKey].ObjectsCount
+	return info, nil
+}
+
+// GetGroupQuota - returns the quota of a group and the usage of its members.
+func (sys *IAMQuotaSys) GetGroupQuota(objAPI ObjectLayer, group string) (info madmin.IAMQuotaInfo, err error) {
+	config, err := sys.Get(objAPI)
+	if err != nil {
+		return info, err
+	}
+	usage, err := sys.usage(objAPI)
+	if err != nil {
+		return info, err
+	}
+	u := groupUsage(usage, group)
+	info.IAMQuota = config.Groups[group]
+	info.Size = u.Size
+	info.ObjectsCount = u.ObjectsCount
+	return info, nil
+}
+
+// exceedsIAMQuota returns true if adding size bytes to usage exceeds the quota.
+func exceedsIAMQuota(q madmin.IAMQuota, u IdentityUsageInfo, size int64) bool {
+	if q.Quota > 0 && u.Size+uint64(size) >= q.Quota {
+		return true
+	}
+	return q.ObjectsQuota > 0 && u.ObjectsCount >= q.ObjectsQuota
+}
+
+func (sys *IAMQuotaSys) check(cred auth.Credentials, size int64) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	config, err := sys.Get(objAPI)
+	if err != nil {
+		return err
+	}
+	if len(config.Users) == 0 && len(config.Groups) == 0 {
+		return nil
+	}
+
+	// The identities the upload counts towards, as recorded
+	// by setObjectWriterMetadata.
+	user := getACLRequesterID(cred, cred.AccessKey == globalActiveCred.AccessKey)
+	if user == "" || user == aclOwner().ID {
+		return nil
+	}
+	users := []string{user}
+	if cred.IsServiceAccount() {
+		users = append(users, cred.AccessKey)
+	}
+	groups := cred.Groups
+	if ui, err := globalIAMSys.GetUserInfo(user); err == nil {
+		groups = append(groups, ui.MemberOf...)
+	}
+
+	var usage map[string]IdentityUsageInfo
+	getUsage := func() (map[string]IdentityUsageInfo, error) {
+		if usage == nil {
+			usage, err = sys.usage(objAPI)
+		}
+		return usage, err
+	}
+
+	for _, id := range users {
+		q, ok := config.Users[id]
+		if !ok {
+			continue
+		}
+		usage, err := getUsage()
+		if err != nil {
+			return err
+		}
+		if exceedsIAMQuota(q, usage[id], size) {
+			return IAMQuotaExceeded{Identity: id}
+		}
+	}
+	for _, group := range groups {
+		q, ok := config.Groups[group]
+		if !ok {
+			continue
+		}
+		usage, err := getUsage()
+		if err != nil {
+			return err
+		}
+		if exceedsIAMQuota(q, groupUsage(usage, group), size) {
+			return IAMQuotaExceeded{Identity: group}
+		}
+	}
+	return nil
+}
+
+// enforceIAMQuota - returns an error if adding size bytes exceeds the quotas
+// of the identity making the request or of the groups it is a member of.
+func enforceIAMQuota(r *http.Request, size int64) error {
+	return enforceIAMQuotaFor(getReqAccessCred(r, globalServerRegion), size)
+}
+
+// enforceIAMQuotaFor - returns an error if adding size bytes exceeds the
+// quotas of the identity of cred or of the groups it is a member of.
+func enforceIAMQuotaFor(cred auth.Credentials, size int64) error {
+	if size < 0 || globalIsGateway {
+		return nil
+	}
+
+	return globalIAMQuotaSys.check(cred, size)
+}
@@ -0,0 +1,140 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestIdentitiesUsage(t *testing.T) {
+	var user, sa sizeSummary
+	user.addWriter(map[string]string{objectWriterMetadataKey: "user1"}, 100)
+	sa.addWriter(map[string]string{
+		objectWriterMetadataKey:         "user1",
+		objectServiceAccountMetadataKey: "sa1",
+	}, 10)
+	sa.addWriter(map[string]string{
+		objectWriterMetadataKey:         "user1",
+		objectServiceAccountMetadataKey: "sa1",
+		objectBucketOwnedMetadataKey:    "true",
+	}, 20)
+	// Objects uploaded by the root user are not recorded.
+	sa.addWriter(map[string]string{}, 1000)
+
+	bucket := "bucket"
+	cache := dataUsageCache{Info: dataUsageCacheInfo{Name: dataUsageRoot}}
+	cache.replace(dataUsageRoot, "", dataUsageEntry{})
+	cache.replace(bucket, dataUsageRoot, dataUsageEntry{})
+	var e1, e2 dataUsageEntry
+	e1.addSizes(user)
+	e2.addSizes(sa)
+	cache.replace(pathJoin(bucket, "dir1"), bucket, e1)
+	cache.replace(pathJoin(bucket, "dir2"), bucket, e2)
+
+	want := map[string]IdentityUsageInfo{
+		"user1": {Size: 130, ObjectsCount: 3},
+		"sa1":   {Size: 30, ObjectsCount: 2},
+	}
+	dui := cache.dui(dataUsageRoot, []BucketInfo{{Name: bucket}})
+	if !reflect.DeepEqual(dui.IdentitiesUsage, want) {
+		t.Fatalf("expected %v, got %v", want, dui.IdentitiesUsage)
+	}
+
+	// Flattening must not modify the cached entries.
+	if got := cache.find(pathJoin(bucket, "dir1")).Identities; !reflect.DeepEqual(got, identitiesUsage{"user1": {Size: 100, Objects: 1}}) {
+		t.Fatalf("cached entry modified: %v", got)
+	}
+}
+
+func TestIAMQuota(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj := prepareErasurePools(ctx, t, 1)
+
+	updates := make(chan DataUsageInfo, 1)
+	updates <- DataUsageInfo{IdentitiesUsage: map[string]IdentityUsageInfo{
+		"user1": {Size: 130, ObjectsCount: 3},
+		"sa1":   {Size: 30, ObjectsCount: 2},
+	}}
+	close(updates)
+	storeDataUsageInBackend(ctx, obj, updates)
+
+	sys := NewIAMQuotaSys()
+	if err := sys.SetUserQuota(ctx, obj, "user1", madmin.IAMQuota{Quota: 1000, ObjectsQuota: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sys.SetUserQuota(ctx, obj, "sa1", madmin.IAMQuota{Quota: 100}); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := sys.GetUserQuota(obj, "user1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := madmin.IAMQuotaInfo{
+		IAMQuota:     madmin.IAMQuota{Quota: 1000, ObjectsQuota: 3},
+		Size:         130,
+		ObjectsCount: 3,
+	}
+	if info != want {
+		t.Fatalf("expected %v, got %v", want, info)
+	}
+
+	// The configuration is read back from the backend.
+	config, err := loadIAMQuotaConfig(ctx, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Users) != 2 {
+		t.Fatalf("expected 2 user quotas, got %v", config.Users)
+	}
+
+	// Empty quotas are removed.
+	if err = sys.SetUserQuota(ctx, obj, "sa1", madmin.IAMQuota{}); err != nil {
+		t.Fatal(err)
+	}
+	config, err = sys.Get(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := config.Users["sa1"]; ok || len(config.Users) != 1 {
+		t.Fatalf("expected quota of sa1 to be removed, got %v", config.Users)
+	}
+
+	testCases := []struct {
+		quota    madmin.IAMQuota
+		usage    IdentityUsageInfo
+		size     int64
+		exceeded bool
+	}{
+		{madmin.IAMQuota{}, IdentityUsageInfo{Size: 100, ObjectsCount: 10}, 100, false},
+		{madmin.IAMQuota{Quota: 1000}, IdentityUsageInfo{Size: 100}, 100, false},
+		{madmin.IAMQuota{Quota: 1000}, IdentityUsageInfo{Size: 900}, 100, true},
+		{madmin.IAMQuota{ObjectsQuota: 3}, IdentityUsageInfo{ObjectsCount: 2}, 1, false},
+		{madmin.IAMQuota{ObjectsQuota: 3}, IdentityUsageInfo{ObjectsCount: 3}, 1, true},
+	}
+	for i, tc := range testCases {
+		if got := exceedsIAMQuota(tc.quota, tc.usage, tc.size); got != tc.exceeded {
+			t.Errorf("test %d: expected exceeded %v, got %v", i+1, tc.exceeded, got)
+		}
+	}
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/minio/minio/cmd/config"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/policy"
 	iampolicy "github.com/minio/minio/pkg/iam/policy"
 	"github.com/minio/minio/pkg/madmin"
 )
@@ -1587,37 +1588,37 @@ func (sys *IAMSys) policyDBGet(name string, isGroup bool) ([]string, error) {
 	return policies, nil
 }
 
-// IsAllowedServiceAccount - checks if the given service account is allowed to perform
-// actions. The permission of the parent user is checked first
-func (sys *IAMSys) IsAllowedServiceAccount(args iampolicy.Args, parent string) bool {
+// evaluateServiceAccount - evaluates the policies of the given service account,
+// the permission of the parent user is checked first.
+func (sys *IAMSys) evaluateServiceAccount(args iampolicy.Args, parent string) policy.Decision {
 	// Now check if we have a subject claim
 	p, ok := args.Claims[parentClaim]
 	if ok {
 		parentInClaim, ok := p.(string)
 		if !ok {
 			// Reject malformed/malicious requests.
-			return false
+			return policy.Denied
 		}
 		// The parent claim in the session token should be equal
 		// to the parent detected in the backend
 		if parentInClaim != parent {
-			return false
+			return policy.Denied
 		}
 	} else {
 		// This is needed so a malicious user cannot
 		// use a leaked session key of another user
 		// to widen its privileges.
-		return false
+		return policy.Denied
 	}
 
 	// Check if the parent is allowed to perform this action, reject if not
 	parentUserPolicies, err := sys.PolicyDBGet(parent, false)
 	if err != nil {
-		return false
+		return policy.Denied
 	}
 
 	if len(parentUserPolicies) == 0 {
-		return false
+		return policy.NoMatch
 	}
 
 	var availablePolicies []iampolicy.Policy
@@ -1633,7 +1634,7 @@ func (sys *IAMSys) IsAllowedServiceAccount(args iampolicy.Args, parent string) b
 	sys.store.runlock()
 
 	if len(availablePolicies) == 0 {
-		return false
+		return policy.NoMatch
 	}
 
 	combinedPolicy := availablePolicies[0]
@@ -1647,31 +1648,31 @@ func (sys *IAMSys) IsAllowedServiceAccount(args iampolicy.Args, parent string) b
 
 	saPolicyClaim, ok := args.Claims[iamPolicyClaimNameSA()]
 	if !ok {
-		return false
+		return policy.Denied
 	}
 
 	saPolicyClaimStr, ok := saPolicyClaim.(string)
 	if !ok {
 		// Sub policy if set, should be a string reject
 		// malformed/malicious requests.
-		return false
+		return policy.Denied
 	}
 
 	if saPolicyClaimStr == "inherited-policy" {
-		return combinedPolicy.IsAllowed(parentArgs)
+		return combinedPolicy.Evaluate(parentArgs)
 	}
 
 	// Now check if we have a sessionPolicy.
 	spolicy, ok := args.Claims[iampolicy.SessionPolicyName]
 	if !ok {
-		return false
+		return policy.Denied
 	}
 
 	spolicyStr, ok := spolicy.(string)
 	if !ok {
 		// Sub policy if set, should be a string reject
 		// malformed/malicious requests.
-		return false
+		return policy.Denied
 	}
 
 	// Check if policy is parseable.
@@ -1679,26 +1680,26 @@ func (sys *IAMSys) IsAllowedServiceAccount(args iampolicy.Args, parent string) b
 	if err != nil {
 		// Log any error in input session policy config.
 		logger.LogIf(GlobalContext, err)
-		return false
+		return policy.Denied
 	}
 
 	// Policy without Version string value reject it.
 	if subPolicy.Version == "" {
-		return false
+		return policy.Denied
 	}
 
-	return combinedPolicy.IsAllowed(parentArgs) && subPolicy.IsAllowed(parentArgs)
+	return evaluateSessionPolicy(combinedPolicy.Evaluate(parentArgs), subPolicy.Evaluate(parentArgs))
 }
 
-// IsAllowedLDAPSTS - checks for LDAP specific claims and values
-func (sys *IAMSys) IsAllowedLDAPSTS(args iampolicy.Args) bool {
+// evaluateLDAPSTS - checks for LDAP specific claims and values
+func (sys *IAMSys) evaluateLDAPSTS(args iampolicy.Args) policy.Decision {
 	userIface, ok := args.Claims[ldapUser]
 	if !ok {
-		return false
+		return policy.Denied
 	}
 	user, ok := userIface.(string)
 	if !ok {
-		return false
+		return policy.Denied
 	}
 
 	sys.store.rlock()
@@ -1707,7 +1708,7 @@ func (sys *IAMSys) IsAllowedLDAPSTS(args iampolicy.Args) bool {
 	var groups []string
 	cred, ok := sys.iamUsersMap[args.AccountName]
 	if !ok {
-		return false
+		return policy.Denied
 	}
 	groups = cred.Groups
 
@@ -1720,7 +1721,7 @@ func (sys *IAMSys) IsAllowedLDAPSTS(args iampolicy.Args) bool {
 			p, found := sys.iamPolicyDocsMap[pname]
 			if !found {
 				logger.LogIf(GlobalContext, fmt.Errorf("expected policy (%s) missing for the LDAPUser %s, rejecting the request", pname, user))
-				return false
+				return policy.Denied
 			}
 			policies = append(policies, p)
 		}
@@ -1734,13 +1735,13 @@ func (sys *IAMSys) IsAllowedLDAPSTS(args iampolicy.Args) bool {
 			p, found := sys.iamPolicyDocsMap[pname]
 			if !found {
 				logger.LogIf(GlobalContext, fmt.Errorf("expected policy (%s) missing for the LDAPGroup %s, rejecting the request", pname, group))
-				return false
+				return policy.Denied
 			}
 			policies = append(policies, p)
 		}
 	}
 	if len(policies) == 0 {
-		return false
+		return policy.NoMatch
 	}
 	combinedPolicy := policies[0]
 	for i := 1; i < len(policies); i++ {
@@ -1748,29 +1749,29 @@ func (sys *IAMSys) IsAllowedLDAPSTS(args iampolicy.Args) bool {
 			append(combinedPolicy.Statements,
 				policies[i].Statements...)
 	}
-	return combinedPolicy.IsAllowed(args)
+	return combinedPolicy.Evaluate(args)
 }
 
-// IsAllowedSTS is meant for STS based temporary credentials,
+// evaluateSTS is meant for STS based temporary credentials,
 // which implements claims validation and verification other than
 // applying policies.
-func (sys *IAMSys) IsAllowedSTS(args iampolicy.Args) bool {
+func (sys *IAMSys) evaluateSTS(args iampolicy.Args) policy.Decision {
 	// If it is an LDAP request, check that user and group
 	// policies allow the request.
 	if sys.usersSysType == LDAPUsersSysType {
-		return sys.IsAllowedLDAPSTS(args)
+		return sys.evaluateLDAPSTS(args)
 	}
 
 	policies, ok := args.GetPolicies(iamPolicyClaimNameOpenID())
 	if !ok {
 		// When claims are set, it should have a policy claim field.
-		return false
+		return policy.Denied
 	}
 
 	// When claims are set, it should have policies as claim.
 	if policies.IsEmpty() {
 		// No policy, no access!
-		return false
+		return policy.NoMatch
 	}
 
 	sys.store.rlock()
@@ -1780,14 +1781,14 @@ func (sys *IAMSys) IsAllowedSTS(args iampolicy.Args) bool {
 	mp, ok := sys.iamUserPolicyMap[args.AccountName]
 	if !ok {
 		// No policy set for the user that we can find, no access!
-		return false
+		return policy.NoMatch
 	}
 
 	if !policies.Equals(mp.policySet()) {
 		// When claims has a policy, it should match the
 		// policy of args.AccountName which server remembers.
 		// if not reject such requests.
-		return false
+		return policy.Denied
 	}
 
 	var availablePolicies []iampolicy.Policy
@@ -1796,7 +1797,7 @@ func (sys *IAMSys) IsAllowedSTS(args iampolicy.Args) bool {
 		if !found {
 			// all policies presented in the claim should exist
 			logger.LogIf(GlobalContext, fmt.Errorf("expected policy (%s) missing from the JWT claim %s, rejecting the request", pname, iamPolicyClaimNameOpenID()))
-			return false
+			return policy.Denied
 		}
 		availablePolicies = append(availablePolicies, p)
 	}
@@ -1814,7 +1815,7 @@ func (sys *IAMSys) IsAllowedSTS(args iampolicy.Args) bool {
 		if !ok {
 			// Sub policy if set, should be a string reject
 			// malformed/malicious requests.
-			return false
+			return policy.Denied
 		}
 
 		// Check if policy is parseable.
@@ -1822,21 +1823,33 @@ func (sys *IAMSys) IsAllowedSTS(args iampolicy.Args) bool {
 		if err != nil {
 			// Log any error in input session policy config.
 			logger.LogIf(GlobalContext, err)
-			return false
+			return policy.Denied
 		}
 
 		// Policy without Version string value reject it.
 		if subPolicy.Version == "" {
-			return false
+			return policy.Denied
 		}
 
 		// Sub policy is set and valid.
-		return combinedPolicy.IsAllowed(args) && subPolicy.IsAllowed(args)
+		return evaluateSessionPolicy(combinedPolicy.Evaluate(args), subPolicy.Evaluate(args))
 	}
 
 	// Sub policy not set, this is most common since subPolicy
 	// is optional, use the inherited policies.
-	return combinedPolicy.IsAllowed(args)
+	return combinedPolicy.Evaluate(args)
+}
+
+// evaluateSessionPolicy - a request is allowed only if both the policies of
+// the credential and its session policy allow it.
+func evaluateSessionPolicy(decision, sessionDecision policy.Decision) policy.Decision {
+	if decision == policy.Denied || sessionDecision == policy.Denied {
+		return policy.Denied
+	}
+	if decision == policy.Allowed && sessionDecision == policy.Allowed {
+		return policy.Allowed
+	}
+	return policy.NoMatch
 }
 
 // GetCombinedPolicy returns a combined policy combining all policies
@@ -1866,53 +1879,63 @@ func (sys *IAMSys) GetCombinedPolicy(policies ...string) iampolicy.Policy {
 	return combinedPolicy
 }
 
-// IsAllowed - checks given policy args is allowed to continue the Rest API.
-func (sys *IAMSys) IsAllowed(args iampolicy.Args) bool {
+// Evaluate - returns whether given policy args are explicitly denied, allowed
+// or not matched by the policies of the credential. Requests with invalid
+// claims and requests not allowed by OPA are denied.
+func (sys *IAMSys) Evaluate(args iampolicy.Args) policy.Decision {
 	// If opa is configured, use OPA always.
 	if globalPolicyOPA != nil {
 		ok, err := globalPolicyOPA.IsAllowed(args)
 		if err != nil {
 			logger.LogIf(GlobalContext, err)
 		}
-		return ok
+		if ok {
+			return policy.Allowed
+		}
+		return policy.Denied
 	}
 
 	// Policies don't apply to the owner.
 	if args.IsOwner {
-		return true
+		return policy.Allowed
 	}
 
 	// If the credential is temporary, perform STS related checks.
 	ok, err := sys.IsTempUser(args.AccountName)
 	if err != nil {
-		return false
+		return policy.Denied
 	}
 	if ok {
-		return sys.IsAllowedSTS(args)
+		return sys.evaluateSTS(args)
 	}
 
 	// If the credential is for a service account, perform related check
 	ok, parentUser, err := sys.IsServiceAccount(args.AccountName)
 	if err != nil {
-		return false
+		return policy.Denied
 	}
 	if ok {
-		return sys.IsAllowedServiceAccount(args, parentUser)
+		return sys.evaluateServiceAccount(args, parentUser)
 	}
 
 	// Continue with the assumption of a regular user
 	policies, err := sys.PolicyDBGet(args.AccountName, false)
 	if err != nil {
-		return false
+		return policy.Denied
 	}
 
 	if len(policies) == 0 {
 		// No policy found.
-		return false
+		return policy.NoMatch
 	}
 
 	// Policies were found, evaluate all of them.
-	return sys.GetCombinedPolicy(policies...).IsAllowed(args)
+	return sys.GetCombinedPolicy(policies...).Evaluate(args)
+}
+
+// IsAllowed - checks given policy args is allowed to continue the Rest API.
+func (sys *IAMSys) IsAllowed(args iampolicy.Args) bool {
+	return sys.Evaluate(args) == policy.Allowed
 }
 
 // Set default canned policies only if not already overridden by users.
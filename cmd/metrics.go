@@ -92,6 +92,7 @@ func (c *minioCollector) Collect(ch chan<- prometheus.Metric) {
 	cacheMetricsPrometheus(ch)
 	gatewayMetricsPrometheus(ch)
 	healingMetricsPrometheus(ch)
+	replicationMetricsPrometheus(ch)
 }
 
 // collects healing specific metrics for MinIO instance in Prometheus specific format
@@ -485,6 +486,142 @@ func bucketUsageMetricsPrometheus(ch chan<- prometheus.Metric) {
 				k,
 			)
 		}
+		for arn, u := range usageInfo.ReplicationTargets {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("bucket", "replication_target", "pending_size"),
+					"Total capacity pending to be replicated to the target",
+					[]string{"bucket", "target_arn"}, nil),
+				prometheus.GaugeValue,
+				float64(u.PendingSize),
+				bucket,
+				arn,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("bucket", "replication_target", "pending_count"),
+					"Total number of objects pending to be replicated to the target",
+					[]string{"bucket", "target_arn"}, nil),
+				prometheus.GaugeValue,
+				float64(u.PendingCount),
+				bucket,
+				arn,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("bucket", "replication_target", "failed_size"),
+					"Total capacity failed to replicate to the target",
+					[]string{"bucket", "target_arn"}, nil),
+				prometheus.GaugeValue,
+				float64(u.FailedSize),
+				bucket,
+				arn,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("bucket", "replication_target", "failed_count"),
+					"Total number of objects failed to replicate to the target",
+					[]string{"bucket", "target_arn"}, nil),
+				prometheus.GaugeValue,
+				float64(u.FailedCount),
+				bucket,
+				arn,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("bucket", "replication_target", "successful_size"),
+					"Total capacity replicated to the target",
+					[]string{"bucket", "target_arn"}, nil),
+				prometheus.GaugeValue,
+				float64(u.ReplicatedSize),
+				bucket,
+				arn,
+			)
+		}
+	}
+}
+
+// collects the replications performed by this MinIO server in Prometheus
+// specific format and sends to given channel
+func replicationMetricsPrometheus(ch chan<- prometheus.Metric) {
+	if globalIsGateway {
+		return
+	}
+
+	labels := []string{"bucket", "target_arn"}
+	for _, bucket := range globalReplicationStats.bucketNames() {
+		for arn, t := range globalReplicationStats.bucketStats(bucket) {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("replication", "active", "count"),
+					"Total number of objects being replicated to the target",
+					labels, nil),
+				prometheus.GaugeValue,
+				float64(t.ActiveCount),
+				bucket,
+				arn,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("replication", "active", "size"),
+					"Total capacity being replicated to the target",
+					labels, nil),
+				prometheus.GaugeValue,
+				float64(t.ActiveSize),
+				bucket,
+				arn,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("replication", "completed", "count"),
+					"Total number of objects replicated to the target since server start",
+					labels, nil),
+				prometheus.CounterValue,
+				float64(t.CompletedCount),
+				bucket,
+				arn,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("replication", "completed", "size"),
+					"Total capacity replicated to the target since server start",
+					labels, nil),
+				prometheus.CounterValue,
+				float64(t.CompletedSize),
+				bucket,
+				arn,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("replication", "failures", "count"),
+					"Total number of objects failed to replicate to the target since server start",
+					labels, nil),
+				prometheus.CounterValue,
+				float64(t.FailuresCount),
+				bucket,
+				arn,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("replication", "failures", "size"),
+					"Total capacity failed to replicate to the target since server start",
+					labels, nil),
+				prometheus.CounterValue,
+				float64(t.FailuresSize),
+				bucket,
+				arn,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("replication", "latency", "ms"),
+					"Average latency of the replications to the target in the last minute",
+					labels, nil),
+				prometheus.GaugeValue,
+				float64(t.Latency.Avg)/float64(time.Millisecond),
+				bucket,
+				arn,
+			)
+		}
 	}
 }
 
@@ -666,6 +666,26 @@ func (sys *NotificationSys) LoadBucketMetadata(ctx context.Context, bucketName s
 	}
 }
 
+// ReloadPoolMeta - calls ReloadPoolMeta call on all peers
+func (sys *NotificationSys) ReloadPoolMeta(ctx context.Context) {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.ReloadPoolMeta()
+		}, idx, *client.host)
+	}
+	for _, nErr := range ng.Wait() {
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", nErr.Host.String())
+		if nErr.Err != nil {
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), nErr.Err)
+		}
+	}
+}
+
 // DeleteBucketMetadata - calls DeleteBucketMetadata call on all peers
 func (sys *NotificationSys) DeleteBucketMetadata(ctx context.Context, bucketName string) {
 	globalBucketMetadataSys.Remove(bucketName)
@@ -1240,6 +1260,57 @@ func (sys *NotificationSys) ServerInfo() []madmin.ServerProperties {
 	return reply
 }
 
+// ReplicationQueueInfo - returns the replication tasks queued on the peers.
+func (sys *NotificationSys) ReplicationQueueInfo() []madmin.ReplicationQueueInfo {
+	reply := make([]madmin.ReplicationQueueInfo, len(sys.peerClients))
+	var wg sync.WaitGroup
+	for i, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(client *peerRESTClient, idx int) {
+			defer wg.Done()
+			info, err := client.ReplicationQueueInfo()
+			if err != nil {
+				info.Node = client.host.String()
+				info.Error = err.Error()
+			}
+			reply[idx] = info
+		}(client, i)
+	}
+	wg.Wait()
+	return reply
+}
+
+// GetReplicationStats - returns the replications of the bucket performed by the peers.
+func (sys *NotificationSys) GetReplicationStats(ctx context.Context, bucket string) []map[string]madmin.ReplicationTargetStats {
+	stats := make([]map[string]madmin.ReplicationTargetStats, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index := range sys.peerClients {
+		if sys.peerClients[index] == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			stats[index], err = sys.peerClients[index].GetReplicationStats(bucket)
+			return err
+		}, index)
+	}
+
+	for index, err := range g.Wait() {
+		if err == nil {
+			continue
+		}
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
+			sys.peerClients[index].host.String())
+		ctx := logger.SetReqInfo(ctx, reqInfo)
+		logger.LogOnceIf(ctx, err, sys.peerClients[index].host.String())
+	}
+	return stats
+}
+
 // GetLocalDiskIDs - return disk ids of the local disks of the peers.
 func (sys *NotificationSys) GetLocalDiskIDs(ctx context.Context) (localDiskIDs [][]string) {
 	localDiskIDs = make([][]string, len(sys.peerClients))
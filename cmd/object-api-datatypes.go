@@ -99,6 +99,19 @@ type BucketUsageInfo struct {
 	ReplicaSize            uint64            `json:"objectReplicaTotalSize"`
 	ObjectsCount           uint64            `json:"objectsCount"`
 	ObjectSizesHistogram   map[string]uint64 `json:"objectsSizesHistogram"`
+
+	// Replication backlog of each remote target, keyed by target ARN.
+	ReplicationTargets map[string]ReplicationTargetUsageInfo `json:"replicationTargets,omitempty"`
+}
+
+// ReplicationTargetUsageInfo - size and number of the object versions pending
+// or failed replication to a remote target, and size replicated to it.
+type ReplicationTargetUsageInfo struct {
+	PendingSize    uint64 `json:"pendingSize"`
+	PendingCount   uint64 `json:"pendingCount"`
+	FailedSize     uint64 `json:"failedSize"`
+	FailedCount    uint64 `json:"failedCount"`
+	ReplicatedSize uint64 `json:"replicatedSize"`
 }
 
 // DataUsageInfo represents data usage stats of the underlying Object API
@@ -134,10 +147,20 @@ type DataUsageInfo struct {
 	// - object size histogram per bucket
 	BucketsUsage map[string]BucketUsageInfo `json:"bucketsUsageInfo"`
 
+	// Usage of the objects uploaded by each IAM user and service account,
+	// the usage of service accounts also counts towards their parent user.
+	IdentitiesUsage map[string]IdentityUsageInfo `json:"identitiesUsageInfo,omitempty"`
+
 	// Deprecated kept here for backward compatibility reasons.
 	BucketSizes map[string]uint64 `json:"bucketsSizes"`
 }
 
+// IdentityUsageInfo - usage of the objects uploaded by an IAM identity.
+type IdentityUsageInfo struct {
+	Size         uint64 `json:"size"`
+	ObjectsCount uint64 `json:"objectsCount"`
+}
+
 // BucketInfo - represents bucket metadata.
 type BucketInfo struct {
 	// Name of the bucket.
@@ -460,6 +483,9 @@ type PartInfo struct {
 
 	// Decompressed Size.
 	ActualSize int64
+
+	// Checksum of the part, if any.
+	Checksum hash.Checksum
 }
 
 // CompletePart - represents the part that was completed, this is sent by the client
@@ -471,6 +497,9 @@ type CompletePart struct {
 
 	// Entity tag returned when the part was uploaded.
 	ETag string
+
+	// Checksum returned when the part was uploaded.
+	ObjectChecksums
 }
 
 // CompletedParts - is a collection satisfying sort.Interface.
@@ -373,6 +373,69 @@ func (e BucketTaggingNotFound) Error() string {
 	return "No bucket tags found for bucket: " + e.Bucket
 }
 
+// BucketCorsConfigNotFound - no bucket CORS config found
+type BucketCorsConfigNotFound GenericError
+
+func (e BucketCorsConfigNotFound) Error() string {
+	return "No bucket CORS configuration found for bucket: " + e.Bucket
+}
+
+// BucketWebsiteConfigNotFound - no bucket website config found
+type BucketWebsiteConfigNotFound GenericError
+
+func (e BucketWebsiteConfigNotFound) Error() string {
+	return "No bucket website configuration found for bucket: " + e.Bucket
+}
+
+// BucketLoggingConfigNotFound - no bucket logging config found
+type BucketLoggingConfigNotFound GenericError
+
+func (e BucketLoggingConfigNotFound) Error() string {
+	return "No bucket logging configuration found for bucket: " + e.Bucket
+}
+
+// BucketACLConfigNotFound - no bucket ACL config found
+type BucketACLConfigNotFound GenericError
+
+func (e BucketACLConfigNotFound) Error() string {
+	return "No bucket ACL configuration found for bucket: " + e.Bucket
+}
+
+// BucketInventoryConfigNotFound - no bucket inventory config found
+type BucketInventoryConfigNotFound GenericError
+
+func (e BucketInventoryConfigNotFound) Error() string {
+	return "No bucket inventory configuration found for bucket: " + e.Bucket
+}
+
+// BucketPublicAccessBlockConfigNotFound - no bucket public access block config found
+type BucketPublicAccessBlockConfigNotFound GenericError
+
+func (e BucketPublicAccessBlockConfigNotFound) Error() string {
+	return "No public access block configuration found for bucket: " + e.Bucket
+}
+
+// BucketOwnershipControlsConfigNotFound - no bucket ownership controls config found
+type BucketOwnershipControlsConfigNotFound GenericError
+
+func (e BucketOwnershipControlsConfigNotFound) Error() string {
+	return "No ownership controls configuration found for bucket: " + e.Bucket
+}
+
+// BucketStorageClassConfigNotFound - no bucket storage class config found
+type BucketStorageClassConfigNotFound GenericError
+
+func (e BucketStorageClassConfigNotFound) Error() string {
+	return "No storage class configuration found for bucket: " + e.Bucket
+}
+
+// ACLNotSupported - ACLs are disabled by the ownership controls of the bucket
+type ACLNotSupported GenericError
+
+func (e ACLNotSupported) Error() string {
+	return "The bucket does not allow ACLs: " + e.Bucket
+}
+
 // BucketObjectLockConfigNotFound - no bucket object lock config found
 type BucketObjectLockConfigNotFound GenericError
 
@@ -394,6 +457,15 @@ func (e BucketQuotaExceeded) Error() string {
 	return "Bucket quota exceeded for bucket: " + e.Bucket
 }
 
+// IAMQuotaExceeded - quota of an IAM user, service account or group exceeded.
+type IAMQuotaExceeded struct {
+	Identity string
+}
+
+func (e IAMQuotaExceeded) Error() string {
+	return "Quota exceeded for: " + e.Identity
+}
+
 // BucketReplicationConfigNotFound - no bucket replication config found
 type BucketReplicationConfigNotFound GenericError
 
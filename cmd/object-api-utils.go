@@ -795,9 +795,10 @@ type SealMD5CurrFn func([]byte) []byte
 // PutObjReader is a type that wraps sio.EncryptReader and
 // underlying hash.Reader in a struct
 type PutObjReader struct {
-	*hash.Reader              // actual data stream
-	rawReader    *hash.Reader // original data stream
-	sealMD5Fn    SealMD5CurrFn
+	*hash.Reader                // actual data stream
+	rawReader      *hash.Reader // original data stream
+	checksumReader *hash.Reader // data stream verifying the content checksum
+	sealMD5Fn      SealMD5CurrFn
 }
 
 // Size returns the absolute number of bytes the Reader
@@ -834,11 +835,20 @@ func (p *PutObjReader) MD5CurrentHexString() string {
 	return hex.EncodeToString(md5sumCurr)
 }
 
+// ContentChecksum returns the checksum of the content sent by the
+// client, nil if the client did not request a checksum.
+func (p *PutObjReader) ContentChecksum() *hash.Checksum {
+	if p.checksumReader == nil {
+		return nil
+	}
+	return p.checksumReader.ContentChecksum()
+}
+
 // NewPutObjReader returns a new PutObjReader and holds
 // reference to underlying data stream from client and the encrypted
 // data reader
 func NewPutObjReader(rawReader *hash.Reader, encReader *hash.Reader, key *crypto.ObjectKey) *PutObjReader {
-	p := PutObjReader{Reader: rawReader, rawReader: rawReader}
+	p := PutObjReader{Reader: rawReader, rawReader: rawReader, checksumReader: rawReader}
 
 	if key != nil && encReader != nil {
 		p.sealMD5Fn = sealETagFn(*key)
@@ -0,0 +1,190 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/hash"
+)
+
+// Content checksums are stored in the internal metadata of objects
+// and multipart uploads.
+const (
+	// Checksum of the object, as <type>:<base64>[-N].
+	objectChecksumKey = ReservedMetadataPrefixLower + "checksum"
+
+	// Comma separated checksums of the parts of a multipart object.
+	objectChecksumPartsKey = ReservedMetadataPrefixLower + "checksum-parts"
+
+	// Checksum algorithm of a multipart upload.
+	objectChecksumAlgorithmKey = ReservedMetadataPrefixLower + "checksum-algorithm"
+
+	// Checksums of the uploaded parts of a multipart upload.
+	objectChecksumPartKeyPrefix = ReservedMetadataPrefixLower + "checksum-part-"
+)
+
+// Value of x-amz-checksum-mode enabling checksums in responses.
+const checksumModeEnabled = "ENABLED"
+
+// ObjectChecksums - checksums of an object or a part in XML requests
+// and responses, at most one of them is set.
+type ObjectChecksums struct {
+	ChecksumCRC32  string `xml:",omitempty"`
+	ChecksumCRC32C string `xml:",omitempty"`
+	ChecksumSHA1   string `xml:",omitempty"`
+	ChecksumSHA256 string `xml:",omitempty"`
+}
+
+func newObjectChecksums(c hash.Checksum) (checksums ObjectChecksums) {
+	switch c.Type {
+	case hash.ChecksumCRC32:
+		checksums.ChecksumCRC32 = c.Encoded
+	case hash.ChecksumCRC32C:
+		checksums.ChecksumCRC32C = c.Encoded
+	case hash.ChecksumSHA1:
+		checksums.ChecksumSHA1 = c.Encoded
+	case hash.ChecksumSHA256:
+		checksums.ChecksumSHA256 = c.Encoded
+	}
+	return checksums
+}
+
+// Checksum - returns the checksum which is set, an unset checksum if
+// none is set.
+func (c ObjectChecksums) Checksum() (checksum hash.Checksum, err error) {
+	for t, encoded := range map[hash.ChecksumType]string{
+		hash.ChecksumCRC32:  c.ChecksumCRC32,
+		hash.ChecksumCRC32C: c.ChecksumCRC32C,
+		hash.ChecksumSHA1:   c.ChecksumSHA1,
+		hash.ChecksumSHA256: c.ChecksumSHA256,
+	} {
+		if encoded == "" {
+			continue
+		}
+		if checksum.IsSet() {
+			return hash.Checksum{}, hash.ErrInvalidChecksum
+		}
+		if checksum, err = hash.NewChecksum(t, encoded); err != nil {
+			return hash.Checksum{}, err
+		}
+	}
+	return checksum, nil
+}
+
+func objectChecksumPartKey(partID int) string {
+	return objectChecksumPartKeyPrefix + strconv.Itoa(partID)
+}
+
+// getObjectChecksum - returns the checksum of the object, or of a part
+// of the object when partNumber is positive.
+func getObjectChecksum(userDefined map[string]string, partNumber int) (hash.Checksum, bool) {
+	checksum, err := hash.ParseChecksum(userDefined[objectChecksumKey])
+	if err != nil {
+		return hash.Checksum{}, false
+	}
+	if partNumber <= 0 {
+		return checksum, true
+	}
+	parts := userDefined[objectChecksumPartsKey]
+	if parts == "" {
+		// Single part object.
+		return checksum, partNumber == 1
+	}
+	encoded := strings.Split(parts, ",")
+	if partNumber > len(encoded) {
+		return hash.Checksum{}, false
+	}
+	checksum, err = hash.NewChecksum(checksum.Type, encoded[partNumber-1])
+	return checksum, err == nil
+}
+
+// getUploadChecksumAlgorithm - returns the checksum algorithm of a
+// multipart upload, empty if none was requested.
+func getUploadChecksumAlgorithm(userDefined map[string]string) hash.ChecksumType {
+	return hash.ChecksumType(userDefined[objectChecksumAlgorithmKey])
+}
+
+// completeObjectChecksum - verifies the checksums of the parts sent by
+// the client against the uploaded parts, and sets the checksum of the
+// multipart object when all parts have checksums of the same type. The
+// checksums of the uploaded parts are removed from the metadata.
+func completeObjectChecksum(metadata map[string]string, parts []CompletePart) error {
+	defer func() {
+		for k := range metadata {
+			if strings.HasPrefix(k, objectChecksumPartKeyPrefix) {
+				delete(metadata, k)
+			}
+		}
+		delete(metadata, objectChecksumAlgorithmKey)
+	}()
+
+	checksums := make([]hash.Checksum, 0, len(parts))
+	for _, part := range parts {
+		uploaded, _ := hash.ParseChecksum(metadata[objectChecksumPartKey(part.PartNumber)])
+		sent, err := part.Checksum()
+		if err != nil {
+			return err
+		}
+		if sent.IsSet() && sent != uploaded {
+			return InvalidPart{
+				PartNumber: part.PartNumber,
+				ExpETag:    uploaded.Encoded,
+				GotETag:    sent.Encoded,
+			}
+		}
+		checksums = append(checksums, uploaded)
+	}
+
+	checksum, err := hash.CompositeChecksum(checksums)
+	if err != nil {
+		// Not all parts have checksums of the same type.
+		if algorithm := getUploadChecksumAlgorithm(metadata); algorithm != "" {
+			return err
+		}
+		return nil
+	}
+	encoded := make([]string, len(checksums))
+	for i := range checksums {
+		encoded[i] = checksums[i].Encoded
+	}
+	metadata[objectChecksumKey] = checksum.String()
+	metadata[objectChecksumPartsKey] = strings.Join(encoded, ",")
+	return nil
+}
+
+// setChecksumHeader - sets the x-amz-checksum-* header of the checksum.
+func setChecksumHeader(w http.ResponseWriter, c *hash.Checksum) {
+	if c != nil && c.IsSet() {
+		w.Header().Set(c.Type.Header(), c.Encoded)
+	}
+}
+
+// setObjectChecksumHeaders - sets the checksum headers of GET and HEAD
+// responses when they are requested with x-amz-checksum-mode.
+func setObjectChecksumHeaders(w http.ResponseWriter, r *http.Request, objInfo ObjectInfo, rs *HTTPRangeSpec, opts ObjectOptions) {
+	// Checksums are of the whole object or part, not of ranges.
+	if r.Header.Get(xhttp.AmzChecksumMode) != checksumModeEnabled || rs != nil {
+		return
+	}
+	if checksum, ok := getObjectChecksum(objInfo.UserDefined, opts.PartNumber); ok {
+		setChecksumHeader(w, &checksum)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
 	"github.com/minio/minio/pkg/event"
@@ -222,6 +223,45 @@ func checkPreconditions(ctx context.Context, w http.ResponseWriter, r *http.Requ
 	return false
 }
 
+// Returns the precondition check of a PutObject or CompleteMultipartUpload
+// request, nil if the request is not conditional. The check is evaluated by
+// the object layer while it holds the write lock of the object, a missing
+// object is passed as an empty ObjectInfo. The check returns true if the
+// write should not proceed.
+// Preconditions supported are:
+//  If-Match
+//  If-None-Match
+func checkPreconditionsPUT(r *http.Request) CheckPreconditionFn {
+	ifMatchETagHeader := r.Header.Get(xhttp.IfMatch)
+	ifNoneMatchETagHeader := r.Header.Get(xhttp.IfNoneMatch)
+	if ifMatchETagHeader == "" && ifNoneMatchETagHeader == "" {
+		return nil
+	}
+	return func(objInfo ObjectInfo) bool {
+		exists := objInfo.Name != ""
+		if exists && crypto.IsEncrypted(objInfo.UserDefined) {
+			objInfo.ETag = getDecryptedETag(r.Header, objInfo, false)
+		}
+
+		// If-Match : Write the object only if it exists and its entity tag (ETag)
+		// is the same as the one specified, '*' matches any existing object.
+		if ifMatchETagHeader != "" {
+			if !exists || (ifMatchETagHeader != "*" && !isETagEqual(objInfo.ETag, ifMatchETagHeader)) {
+				return true
+			}
+		}
+
+		// If-None-Match : Write the object only if its entity tag (ETag) is different
+		// from the one specified, '*' only matches if the object does not exist.
+		if ifNoneMatchETagHeader != "" && exists {
+			if ifNoneMatchETagHeader == "*" || isETagEqual(objInfo.ETag, ifNoneMatchETagHeader) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 // returns true if object was modified after givenTime.
 func ifModifiedSince(objTime time.Time, givenTime time.Time) bool {
 	// The Date-Modified header truncates sub-second precision, so
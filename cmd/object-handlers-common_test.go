@@ -17,7 +17,10 @@
 package cmd
 
 import (
+	"net/http"
 	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
 )
 
 // Tests - canonicalizeETag()
@@ -51,3 +54,50 @@ func TestCanonicalizeETag(t *testing.T) {
 		}
 	}
 }
+
+// Tests - checkPreconditionsPUT()
+func TestCheckPreconditionsPUT(t *testing.T) {
+	existing := ObjectInfo{Bucket: "bucket", Name: "object", ETag: "abcd"}
+	testCases := []struct {
+		ifMatch     string
+		ifNoneMatch string
+		objInfo     ObjectInfo
+		failed      bool
+	}{
+		// Create if absent.
+		{ifNoneMatch: "*", objInfo: ObjectInfo{}, failed: false},
+		{ifNoneMatch: "*", objInfo: existing, failed: true},
+		// Compare and swap.
+		{ifMatch: "\"abcd\"", objInfo: existing, failed: false},
+		{ifMatch: "efgh", objInfo: existing, failed: true},
+		{ifMatch: "abcd", objInfo: ObjectInfo{}, failed: true},
+		{ifMatch: "*", objInfo: existing, failed: false},
+		{ifMatch: "*", objInfo: ObjectInfo{}, failed: true},
+		// Write unless the object has given ETag.
+		{ifNoneMatch: "abcd", objInfo: existing, failed: true},
+		{ifNoneMatch: "efgh", objInfo: existing, failed: false},
+	}
+	for i, test := range testCases {
+		r, err := http.NewRequest(http.MethodPut, "http://localhost/bucket/object", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.ifMatch != "" {
+			r.Header.Set(xhttp.IfMatch, test.ifMatch)
+		}
+		if test.ifNoneMatch != "" {
+			r.Header.Set(xhttp.IfNoneMatch, test.ifNoneMatch)
+		}
+		if failed := checkPreconditionsPUT(r)(test.objInfo); failed != test.failed {
+			t.Errorf("Test %d: expected %t, got %t", i+1, test.failed, failed)
+		}
+	}
+
+	r, err := http.NewRequest(http.MethodPut, "http://localhost/bucket/object", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkPreconditionsPUT(r) != nil {
+		t.Fatal("Expected no precondition check for unconditional writes")
+	}
+}
@@ -38,6 +38,7 @@ import (
 	miniogo "github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/cmd/config/dns"
 	"github.com/minio/minio/cmd/config/storageclass"
@@ -134,7 +135,8 @@ func (api objectAPIHandlers) SelectObjectContentHandler(w http.ResponseWriter, r
 
 	// Check for auth type to return S3 compatible error.
 	// type to return the correct error (NoSuchKey vs AccessDenied)
-	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+	s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object)
+	if s3Error != ErrNone && s3Error != ErrCheckObjectACL {
 		if getRequestAuthType(r) == authTypeAnonymous {
 			// As per "Permission" section in
 			// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectGET.html
@@ -198,6 +200,11 @@ func (api objectAPIHandlers) SelectObjectContentHandler(w http.ResponseWriter, r
 	}
 
 	objInfo, err := getObjectInfo(ctx, bucket, object, opts)
+	if s3Error == ErrCheckObjectACL && (err != nil || !isAllowedByObjectACL(r, policy.GetObjectAction, objInfo)) {
+		// Do not reveal whether the object exists.
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+		return
+	}
 	if err != nil {
 		if globalBucketVersioningSys.Enabled(bucket) {
 			// Versioning enabled quite possibly object is deleted might be delete-marker
@@ -334,7 +341,8 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 
 	// Check for auth type to return S3 compatible error.
 	// type to return the correct error (NoSuchKey vs AccessDenied)
-	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+	s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object)
+	if s3Error != ErrNone && s3Error != ErrCheckObjectACL {
 		if getRequestAuthType(r) == authTypeAnonymous {
 			// As per "Permission" section in
 			// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectGET.html
@@ -401,6 +409,13 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 
 	// Validate pre-conditions if any.
 	opts.CheckPrecondFn = func(oi ObjectInfo) bool {
+		// Pre-conditions are not evaluated for requesters
+		// not allowed to read the object.
+		if s3Error == ErrCheckObjectACL && !isAllowedByObjectACL(r, policy.GetObjectAction, oi) {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+			return true
+		}
+
 		if objectAPI.IsEncryptionSupported() {
 			if _, err := DecryptObjectInfo(&oi, r); err != nil {
 				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
@@ -420,6 +435,11 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 		if isErrPreconditionFailed(err) {
 			return
 		}
+		if s3Error == ErrCheckObjectACL {
+			// Do not reveal whether the object exists.
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+			return
+		}
 		if globalBucketVersioningSys.Enabled(bucket) && gr != nil {
 			// Versioning enabled quite possibly object is deleted might be delete-marker
 			// if present set the headers, no idea why AWS S3 sets these headers.
@@ -434,6 +454,11 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 	defer gr.Close()
 	objInfo := gr.ObjInfo
 
+	if s3Error == ErrCheckObjectACL && !isAllowedByObjectACL(r, policy.GetObjectAction, objInfo) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// filter object lock metadata if permission does not permit
 	getRetPerms := checkRequestAuthType(ctx, r, policy.GetObjectRetentionAction, bucket, object)
 	legalHoldPerms := checkRequestAuthType(ctx, r, policy.GetObjectLegalHoldAction, bucket, object)
@@ -464,6 +489,9 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 		setPartsCountHeaders(w, objInfo)
 	}
 
+	// Set checksum headers if requested.
+	setObjectChecksumHeaders(w, r, objInfo, rs, opts)
+
 	setHeadGetRespHeaders(w, r.URL.Query())
 
 	statusCodeWritten := false
@@ -541,7 +569,8 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+	s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object)
+	if s3Error != ErrNone && s3Error != ErrCheckObjectACL {
 		if getRequestAuthType(r) == authTypeAnonymous {
 			// As per "Permission" section in
 			// https://docs.aws.amazon.com/AmazonS3/latest/API/RESTObjectHEAD.html
@@ -573,6 +602,11 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 	}
 
 	objInfo, err := getObjectInfo(ctx, bucket, object, opts)
+	if s3Error == ErrCheckObjectACL && (err != nil || !isAllowedByObjectACL(r, policy.GetObjectAction, objInfo)) {
+		// Do not reveal whether the object exists.
+		writeErrorResponseHeadersOnly(w, errorCodes.ToAPIErr(ErrAccessDenied))
+		return
+	}
 	if err != nil {
 		if globalBucketVersioningSys.Enabled(bucket) {
 			if !objInfo.VersionPurgeStatus.Empty() {
@@ -664,6 +698,9 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 		setPartsCountHeaders(w, objInfo)
 	}
 
+	// Set checksum headers if requested.
+	setObjectChecksumHeaders(w, r, objInfo, rs, opts)
+
 	// Set any additional requested response headers.
 	setHeadGetRespHeaders(w, r.URL.Query())
 
@@ -686,6 +723,139 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 	})
 }
 
+// Attributes of GetObjectAttributes.
+const (
+	objectAttributeETag         = "ETag"
+	objectAttributeChecksum     = "Checksum"
+	objectAttributeObjectParts  = "ObjectParts"
+	objectAttributeStorageClass = "StorageClass"
+	objectAttributeObjectSize   = "ObjectSize"
+)
+
+var supportedObjectAttributes = set.CreateStringSet(objectAttributeETag, objectAttributeChecksum,
+	objectAttributeObjectParts, objectAttributeStorageClass, objectAttributeObjectSize)
+
+// GetObjectAttributesHandler - GET Object?attributes
+// -----------
+// Retrieves the requested attributes of an object, i.e. its ETag,
+// checksum, parts, storage class and size, without returning the
+// object itself.
+func (api objectAPIHandlers) GetObjectAttributesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetObjectAttributes")
+
+	defer logger.AuditLog(w, r, "GetObjectAttributes", mustGetClaimsFromToken(r))
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if crypto.S3.IsRequested(r.Header) || crypto.S3KMS.IsRequested(r.Header) { // If SSE-S3 or SSE-KMS present -> AWS fails with undefined error
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrBadRequest), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if _, ok := crypto.IsRequested(r.Header); !objectAPI.IsEncryptionSupported() && ok {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrBadRequest), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object, err := url.PathUnescape(vars["object"])
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAttributesAction, bucket, object)
+	if s3Error != ErrNone && s3Error != ErrCheckObjectACL {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Parse the requested attributes, at least one is required.
+	attributes := set.NewStringSet()
+	for _, value := range r.Header.Values(xhttp.AmzObjectAttributes) {
+		for _, attribute := range strings.Split(value, ",") {
+			attribute = strings.TrimSpace(attribute)
+			if !supportedObjectAttributes.Contains(attribute) {
+				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidAttributeName), r.URL, guessIsBrowserReq(r))
+				return
+			}
+			attributes.Add(attribute)
+		}
+	}
+	if attributes.IsEmpty() {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidAttributeName), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	maxParts := maxPartsList
+	if value := r.Header.Get(xhttp.AmzMaxParts); value != "" {
+		if maxParts, err = strconv.Atoi(value); err != nil || maxParts < 0 {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidMaxParts), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		if maxParts > maxPartsList {
+			maxParts = maxPartsList
+		}
+	}
+	var partNumberMarker int
+	if value := r.Header.Get(xhttp.AmzPartNumberMarker); value != "" {
+		if partNumberMarker, err = strconv.Atoi(value); err != nil || partNumberMarker < 0 {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidPartNumberMarker), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
+	getObjectInfo := objectAPI.GetObjectInfo
+	if api.CacheAPI() != nil {
+		getObjectInfo = api.CacheAPI().GetObjectInfo
+	}
+
+	opts, err := getOpts(ctx, r, bucket, object)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	objInfo, err := getObjectInfo(ctx, bucket, object, opts)
+	if s3Error == ErrCheckObjectACL && (err != nil || !isAllowedByObjectACL(r, policy.GetObjectAttributesAction, objInfo)) {
+		// Do not reveal whether the object exists.
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if err != nil {
+		if objInfo.VersionID != "" && objInfo.DeleteMarker {
+			w.Header()[xhttp.AmzVersionID] = []string{objInfo.VersionID}
+			w.Header()[xhttp.AmzDeleteMarker] = []string{strconv.FormatBool(objInfo.DeleteMarker)}
+		}
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if objectAPI.IsEncryptionSupported() {
+		if _, err = DecryptObjectInfo(&objInfo, r); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		if crypto.SSEC.IsEncrypted(objInfo.UserDefined) {
+			// Validate the SSE-C Key set in the header.
+			if _, err = crypto.SSEC.UnsealObjectKey(r.Header, objInfo.UserDefined, bucket, object); err != nil {
+				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+				return
+			}
+		}
+	}
+
+	w.Header().Set(xhttp.LastModified, objInfo.ModTime.UTC().Format(http.TimeFormat))
+	if objInfo.VersionID != "" {
+		w.Header()[xhttp.AmzVersionID] = []string{objInfo.VersionID}
+	}
+
+	response := generateGetObjectAttributesResponse(objInfo, attributes, partNumberMarker, maxParts)
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
+
 // Extract metadata relevant for an CopyObject operation based on conditional
 // header values specified in X-Amz-Metadata-Directive.
 func getCpObjMetadataFromHeader(ctx context.Context, r *http.Request, userMeta map[string]string) (map[string]string, error) {
@@ -856,8 +1026,10 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		}
 	}
 
-	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, srcBucket, srcObject); s3Error != ErrNone {
-		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+	// The object ACL of the source object may grant reading it.
+	srcS3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, srcBucket, srcObject)
+	if srcS3Error != ErrNone && srcS3Error != ErrCheckObjectACL {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(srcS3Error), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
@@ -917,6 +1089,12 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	}
 
 	checkCopyPrecondFn := func(o ObjectInfo) bool {
+		// Pre-conditions are not evaluated for requesters
+		// not allowed to read the source object.
+		if srcS3Error == ErrCheckObjectACL && !isAllowedByObjectACL(r, policy.GetObjectAction, o) {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+			return true
+		}
 		if objectAPI.IsEncryptionSupported() {
 			if _, err := DecryptObjectInfo(&o, r); err != nil {
 				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
@@ -943,6 +1121,11 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		if isErrPreconditionFailed(err) {
 			return
 		}
+		if srcS3Error == ErrCheckObjectACL {
+			// Do not reveal whether the source object exists.
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+			return
+		}
 		if globalBucketVersioningSys.Enabled(srcBucket) && gr != nil {
 			// Versioning enabled quite possibly object is deleted might be delete-marker
 			// if present set the headers, no idea why AWS S3 sets these headers.
@@ -957,6 +1140,11 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	defer gr.Close()
 	srcInfo := gr.ObjInfo
 
+	if srcS3Error == ErrCheckObjectACL && !isAllowedByObjectACL(r, policy.GetObjectAction, srcInfo) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// maximum Upload size for object in a single CopyObject operation.
 	if isMaxObjectSize(srcInfo.Size) {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrEntityTooLarge), r.URL, guessIsBrowserReq(r))
@@ -990,7 +1178,11 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	}
 
 	if !cpSrcDstSame {
-		if err := enforceBucketQuota(ctx, dstBucket, actualSize); err != nil {
+		if err := enforceBucketQuota(ctx, dstBucket, dstObject, actualSize); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		if err := enforceIAMQuota(r, actualSize); err != nil {
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 			return
 		}
@@ -1168,6 +1360,14 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	}
 	srcInfo.UserDefined = filterReplicationStatusMetadata(srcInfo.UserDefined)
 
+	// The ACL of the source object is not copied, the copy is private
+	// unless an ACL is set by the request.
+	if err = setObjectACLFromRequest(ctx, r, dstBucket, dstObject, srcInfo.UserDefined); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	setObjectWriterMetadata(r, dstBucket, srcInfo.UserDefined)
+
 	srcInfo.UserDefined = objectlock.FilterObjectLockMetadata(srcInfo.UserDefined, true, true)
 	retPerms := isPutActionAllowed(ctx, getRequestAuthType(r), dstBucket, dstObject, r, iampolicy.PutObjectRetentionAction)
 	holdPerms := isPutActionAllowed(ctx, getRequestAuthType(r), dstBucket, dstObject, r, iampolicy.PutObjectLegalHoldAction)
@@ -1359,7 +1559,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	/// if Content-Length is unknown/missing, deny the request
 	size := r.ContentLength
 	rAuthType := getRequestAuthType(r)
-	if rAuthType == authTypeStreamingSigned {
+	if rAuthType == authTypeStreamingSigned || isRequestUnsignedTrailerV4(r) {
 		if sizeStr, ok := r.Header[xhttp.AmzDecodedContentLength]; ok {
 			if sizeStr[0] == "" {
 				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL, guessIsBrowserReq(r))
@@ -1403,6 +1603,12 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		metadata[xhttp.AmzObjectTagging] = objTags
 	}
 
+	if err = setObjectACLFromRequest(ctx, r, bucket, object, metadata); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	setObjectWriterMetadata(r, bucket, metadata)
+
 	var (
 		md5hex    = hex.EncodeToString(md5Bytes)
 		sha256hex = ""
@@ -1441,9 +1647,16 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		if !skipContentSha256Cksum(r) {
 			sha256hex = getContentSha256Cksum(r, serviceS3)
 		}
+		if isRequestUnsignedTrailerV4(r) {
+			reader = newUnsignedV4ChunkedReader(r)
+		}
 	}
 
-	if err := enforceBucketQuota(ctx, bucket, size); err != nil {
+	if err := enforceBucketQuota(ctx, bucket, object, size); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if err := enforceIAMQuota(r, size); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
@@ -1457,6 +1670,9 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 
 	actualSize := size
 
+	// Reader of the uncompressed content verifying its checksum.
+	var checksumReader *hash.Reader
+
 	if objectAPI.IsCompressionSupported() && isCompressible(r.Header, object) && size > 0 {
 		// Storing the compression metadata.
 		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
@@ -1467,6 +1683,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 			return
 		}
+		checksumReader = actualReader
 
 		// Set compression metrics.
 		s2c := newS2CompressReader(actualReader)
@@ -1482,6 +1699,13 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	if checksumReader == nil {
+		checksumReader = hashReader
+	}
+	if err = checksumReader.AddChecksum(r, ""); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
 
 	rawReader := hashReader
 	pReader := NewPutObjReader(rawReader, nil, nil)
@@ -1493,6 +1717,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	opts.CheckPrecondFn = checkPreconditionsPUT(r)
 
 	if api.CacheAPI() != nil {
 		putObject = api.CacheAPI().PutObject
@@ -1552,6 +1777,8 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
+	pReader.checksumReader = checksumReader
+
 	// Ensure that metadata does not contain sensitive information
 	crypto.RemoveSensitiveEntries(metadata)
 
@@ -1585,6 +1812,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		globalReplicationState.queueReplicaTask(objInfo)
 	}
 	setPutObjHeaders(w, objInfo, false)
+	setChecksumHeader(w, pReader.ContentChecksum())
 
 	writeSuccessResponseHeadersOnly(w)
 
@@ -1657,6 +1885,15 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		}
 	}
 
+	// Validate the checksum algorithm of the parts if present
+	var checksumAlgorithm hash.ChecksumType
+	if algorithm := r.Header.Get(xhttp.AmzChecksumAlgorithm); algorithm != "" {
+		if checksumAlgorithm, err = hash.NewChecksumType(algorithm); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
 	var encMetadata = map[string]string{}
 
 	if objectAPI.IsEncryptionSupported() {
@@ -1678,6 +1915,12 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		return
 	}
 
+	if err = setObjectACLFromRequest(ctx, r, bucket, object, metadata); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	setObjectWriterMetadata(r, bucket, metadata)
+
 	retPerms := isPutActionAllowed(ctx, getRequestAuthType(r), bucket, object, r, iampolicy.PutObjectRetentionAction)
 	holdPerms := isPutActionAllowed(ctx, getRequestAuthType(r), bucket, object, r, iampolicy.PutObjectLegalHoldAction)
 
@@ -1701,6 +1944,16 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 	if mustReplicate(ctx, r, bucket, object, metadata, "") {
 		metadata[xhttp.AmzBucketReplicationStatus] = replication.Pending.String()
 	}
+	var replicaVersionID string
+	if r.Header.Get(xhttp.AmzBucketReplicationStatus) == replication.Replica.String() {
+		if s3Err = isPutActionAllowed(ctx, getRequestAuthType(r), bucket, object, r, iampolicy.ReplicateObjectAction); s3Err != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		// Replicas are created with the version ID of their source.
+		replicaVersionID = metadata[xhttp.AmzMetaMinIOSourceVersionID]
+	}
+	delete(metadata, xhttp.AmzMetaMinIOSourceVersionID)
 	// We need to preserve the encryption headers set in EncryptRequest,
 	// so we do not want to override them, copy them instead.
 	for k, v := range encMetadata {
@@ -1715,11 +1968,26 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
 	}
 
+	if checksumAlgorithm != "" {
+		metadata[objectChecksumAlgorithmKey] = string(checksumAlgorithm)
+	}
+
 	opts, err := putOpts(ctx, r, bucket, object, metadata)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	if replicaVersionID != "" && opts.Versioned {
+		if _, err = uuid.Parse(replicaVersionID); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, InvalidVersionID{
+				Bucket:    bucket,
+				Object:    object,
+				VersionID: replicaVersionID,
+			}), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		opts.VersionID = replicaVersionID
+	}
 	newMultipartUpload := objectAPI.NewMultipartUpload
 
 	uploadID, err := newMultipartUpload(ctx, bucket, object, opts)
@@ -1728,6 +1996,10 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		return
 	}
 
+	if checksumAlgorithm != "" {
+		w.Header().Set(xhttp.AmzChecksumAlgorithm, string(checksumAlgorithm))
+	}
+
 	response := generateInitiateMultipartUploadResponse(bucket, object, uploadID)
 	encodedSuccessResponse := encodeResponse(response)
 
@@ -1798,8 +2070,10 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 		}
 	}
 
-	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, srcBucket, srcObject); s3Error != ErrNone {
-		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+	// The object ACL of the source object may grant reading it.
+	srcS3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, srcBucket, srcObject)
+	if srcS3Error != ErrNone && srcS3Error != ErrCheckObjectACL {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(srcS3Error), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
@@ -1851,6 +2125,12 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	}
 
 	checkCopyPartPrecondFn := func(o ObjectInfo) bool {
+		// Pre-conditions are not evaluated for requesters
+		// not allowed to read the source object.
+		if srcS3Error == ErrCheckObjectACL && !isAllowedByObjectACL(r, policy.GetObjectAction, o) {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+			return true
+		}
 		if objectAPI.IsEncryptionSupported() {
 			if _, err := DecryptObjectInfo(&o, r); err != nil {
 				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
@@ -1875,6 +2155,11 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 		if isErrPreconditionFailed(err) {
 			return
 		}
+		if srcS3Error == ErrCheckObjectACL {
+			// Do not reveal whether the source object exists.
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+			return
+		}
 		if globalBucketVersioningSys.Enabled(srcBucket) && gr != nil {
 			// Versioning enabled quite possibly object is deleted might be delete-marker
 			// if present set the headers, no idea why AWS S3 sets these headers.
@@ -1889,6 +2174,11 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	defer gr.Close()
 	srcInfo := gr.ObjInfo
 
+	if srcS3Error == ErrCheckObjectACL && !isAllowedByObjectACL(r, policy.GetObjectAction, srcInfo) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	actualPartSize := srcInfo.Size
 	if crypto.IsEncrypted(srcInfo.UserDefined) {
 		actualPartSize, err = srcInfo.DecryptedSize()
@@ -1898,7 +2188,11 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 		}
 	}
 
-	if err := enforceBucketQuota(ctx, dstBucket, actualPartSize); err != nil {
+	if err := enforceBucketQuota(ctx, dstBucket, dstObject, actualPartSize); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if err := enforceIAMQuota(r, actualPartSize); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
@@ -1961,16 +2255,27 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 		return
 	}
 
+	// Parts of uploads with a checksum algorithm always have checksums.
+	checksumReader, err := hash.NewReader(gr, actualPartSize, "", "", actualPartSize, globalCLIContext.StrictS3Compat)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if err = checksumReader.AddChecksum(r, getUploadChecksumAlgorithm(mi.UserDefined)); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// Read compression metadata preserved in the init multipart for the decision.
 	_, isCompressed := mi.UserDefined[ReservedMetadataPrefix+"compression"]
 	// Compress only if the compression is enabled during initial multipart.
 	if isCompressed {
-		s2c := newS2CompressReader(gr)
+		s2c := newS2CompressReader(checksumReader)
 		defer s2c.Close()
 		reader = s2c
 		length = -1
 	} else {
-		reader = gr
+		reader = checksumReader
 	}
 
 	srcInfo.Reader, err = hash.NewReader(reader, length, "", "", actualPartSize, globalCLIContext.StrictS3Compat)
@@ -2030,6 +2335,8 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 		pReader = NewPutObjReader(rawReader, srcInfo.Reader, &objectEncryptionKey)
 	}
 
+	pReader.checksumReader = checksumReader
+
 	srcInfo.PutObjReader = pReader
 	// Copy source object to destination, if source and destination
 	// object is same then only metadata is updated.
@@ -2098,7 +2405,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 
 	rAuthType := getRequestAuthType(r)
 	// For auth type streaming signature, we need to gather a different content length.
-	if rAuthType == authTypeStreamingSigned {
+	if rAuthType == authTypeStreamingSigned || isRequestUnsignedTrailerV4(r) {
 		if sizeStr, ok := r.Header[xhttp.AmzDecodedContentLength]; ok {
 			if sizeStr[0] == "" {
 				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL, guessIsBrowserReq(r))
@@ -2171,9 +2478,16 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		if !skipContentSha256Cksum(r) {
 			sha256hex = getContentSha256Cksum(r, serviceS3)
 		}
+		if isRequestUnsignedTrailerV4(r) {
+			reader = newUnsignedV4ChunkedReader(r)
+		}
 	}
 
-	if err := enforceBucketQuota(ctx, bucket, size); err != nil {
+	if err := enforceBucketQuota(ctx, bucket, object, size); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if err := enforceIAMQuota(r, size); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
@@ -2199,12 +2513,16 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 	// Read compression metadata preserved in the init multipart for the decision.
 	_, isCompressed := mi.UserDefined[ReservedMetadataPrefix+"compression"]
 
+	// Reader of the uncompressed content verifying its checksum.
+	var checksumReader *hash.Reader
+
 	if objectAPI.IsCompressionSupported() && isCompressed {
 		actualReader, err := hash.NewReader(reader, size, md5hex, sha256hex, actualSize, globalCLIContext.StrictS3Compat)
 		if err != nil {
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 			return
 		}
+		checksumReader = actualReader
 
 		// Set compression metrics.
 		s2c := newS2CompressReader(actualReader)
@@ -2220,6 +2538,14 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	if checksumReader == nil {
+		checksumReader = hashReader
+	}
+	// Parts of uploads with a checksum algorithm always have checksums.
+	if err = checksumReader.AddChecksum(r, getUploadChecksumAlgorithm(mi.UserDefined)); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
 	rawReader := hashReader
 	pReader := NewPutObjReader(rawReader, nil, nil)
 
@@ -2276,6 +2602,8 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		pReader = NewPutObjReader(rawReader, hashReader, &objectEncryptionKey)
 	}
 
+	pReader.checksumReader = checksumReader
+
 	putObjectPart := objectAPI.PutObjectPart
 
 	partInfo, err := putObjectPart(ctx, bucket, object, uploadID, partID, pReader, opts)
@@ -2294,6 +2622,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 	// clients expect the ETag header key to be literally "ETag" - not "Etag" (case-sensitive).
 	// Therefore, we have to set the ETag directly as map entry.
 	w.Header()[xhttp.ETag] = []string{"\"" + etag + "\""}
+	setChecksumHeader(w, &partInfo.Checksum)
 
 	writeSuccessResponseHeadersOnly(w)
 }
@@ -2541,6 +2870,17 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		return
 	}
 
+	// The parts were checked against the size quotas as they were
+	// uploaded, the object counts against the object quotas now.
+	if err := enforceBucketQuota(ctx, bucket, object, 0); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if err := enforceIAMQuota(r, 0); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	var objectEncryptionKey []byte
 	var isEncrypted, ssec bool
 	if objectAPI.IsEncryptionSupported() {
@@ -2622,7 +2962,9 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 
 	w = &whiteSpaceWriter{ResponseWriter: w, Flusher: w.(http.Flusher)}
 	completeDoneCh := sendWhiteSpace(w)
-	objInfo, err := completeMultiPartUpload(ctx, bucket, object, uploadID, completeParts, ObjectOptions{})
+	objInfo, err := completeMultiPartUpload(ctx, bucket, object, uploadID, completeParts, ObjectOptions{
+		CheckPrecondFn: checkPreconditionsPUT(r),
+	})
 	// Stop writing white spaces to the client. Note that close(doneCh) style is not used as it
 	// can cause white space to be written after we send XML response in a race condition.
 	headerWritten := <-completeDoneCh
@@ -2639,6 +2981,9 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 	location := getObjectLocation(r, globalDomainNames, bucket, object)
 	// Generate complete multipart response.
 	response := generateCompleteMultpartUploadResponse(bucket, object, location, objInfo.ETag)
+	if checksum, ok := getObjectChecksum(objInfo.UserDefined, 0); ok {
+		response.ObjectChecksums = newObjectChecksums(checksum)
+	}
 	var encodedSuccessResponse []byte
 	if !headerWritten {
 		encodedSuccessResponse = encodeResponse(response)
@@ -112,6 +112,30 @@ func (client *peerRESTClient) ServerInfo() (info madmin.ServerProperties, err er
 	return info, err
 }
 
+// ReplicationQueueInfo - fetch the replication tasks queued on the peer.
+func (client *peerRESTClient) ReplicationQueueInfo() (info madmin.ReplicationQueueInfo, err error) {
+	respBody, err := client.call(peerRESTMethodReplicationQueueInfo, nil, nil, -1)
+	if err != nil {
+		return
+	}
+	defer http.DrainBody(respBody)
+	err = gob.NewDecoder(respBody).Decode(&info)
+	return info, err
+}
+
+// GetReplicationStats - fetch the replications of the bucket performed by the peer.
+func (client *peerRESTClient) GetReplicationStats(bucket string) (stats map[string]madmin.ReplicationTargetStats, err error) {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	respBody, err := client.call(peerRESTMethodGetReplicationStats, values, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+	err = gob.NewDecoder(respBody).Decode(&stats)
+	return stats, err
+}
+
 type networkOverloadedErr struct{}
 
 var networkOverloaded networkOverloadedErr
@@ -446,6 +470,16 @@ func (client *peerRESTClient) LoadBucketMetadata(bucket string) error {
 	return nil
 }
 
+// ReloadPoolMeta - reload the status of all pools
+func (client *peerRESTClient) ReloadPoolMeta() error {
+	respBody, err := client.call(peerRESTMethodReloadPoolMeta, nil, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
 // DeleteBucketMetadata - Delete bucket metadata
 func (client *peerRESTClient) DeleteBucketMetadata(bucket string) error {
 	values := make(url.Values)
@@ -17,7 +17,7 @@
 package cmd
 
 const (
-	peerRESTVersion       = "v11"
+	peerRESTVersion       = "v12"
 	peerRESTVersionPrefix = SlashSeparator + peerRESTVersion
 	peerRESTPrefix        = minioReservedBucketPath + "/peer"
 	peerRESTPath          = peerRESTPrefix + peerRESTVersionPrefix
@@ -58,6 +58,9 @@ const (
 	peerRESTMethodGetBandwidth           = "/bandwidth"
 	peerRESTMethodGetMetacacheListing    = "/getmetacache"
 	peerRESTMethodUpdateMetacacheListing = "/updatemetacache"
+	peerRESTMethodReloadPoolMeta         = "/reloadpoolmeta"
+	peerRESTMethodReplicationQueueInfo   = "/replicationqueueinfo"
+	peerRESTMethodGetReplicationStats    = "/replicationstats"
 )
 
 const (
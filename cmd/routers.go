@@ -93,6 +93,11 @@ func configureServerHandler(endpointServerPools EndpointServerPools) (http.Handl
 		registerDistErasureRouters(router, endpointServerPools)
 	}
 
+	// Add website router, registered before all public routers as
+	// website domains take precedence over them. The internode
+	// routers above are never served on website domains.
+	registerWebsiteRouter(router)
+
 	// Add STS router always.
 	registerSTSRouter(router)
 
@@ -208,11 +208,17 @@ func newAllSubsystems() {
 	// Create new bucket quota subsystem
 	globalBucketQuotaSys = NewBucketQuotaSys()
 
+	// Create new IAM quota subsystem
+	globalIAMQuotaSys = NewIAMQuotaSys()
+
 	// Create new bucket versioning subsystem
 	globalBucketVersioningSys = NewBucketVersioningSys()
 
 	// Create new bucket replication subsytem
 	globalBucketTargetSys = NewBucketTargetSys()
+
+	// Create new bucket access logging subsystem
+	globalBucketLoggingSys = NewBucketLoggingSys()
 }
 
 func initServer(ctx context.Context, newObject ObjectLayer) error {
@@ -351,6 +357,15 @@ func initAllSubsystems(ctx context.Context, newObject ObjectLayer) (err error) {
 	// Initialize bucket targets sub-system.
 	globalBucketTargetSys.Init(ctx, buckets, newObject)
 
+	// Initialize bucket access logging sub-system.
+	globalBucketLoggingSys.Init(ctx, newObject)
+
+	// Queue the replication tasks pending when the server stopped.
+	globalReplicationState.resumeJournal(GlobalContext)
+
+	// Resume the resyncs of replication targets interrupted by the restart.
+	go globalReplicationState.resumeResyncs(GlobalContext, newObject, buckets)
+
 	return nil
 }
 
@@ -463,6 +478,7 @@ func serverMain(ctx *cli.Context) {
 	// Enable background operations for erasure coding
 	if globalIsErasure {
 		initAutoHeal(GlobalContext, newObject)
+		initScrubber(GlobalContext, newObject)
 		initBackgroundReplication(GlobalContext, newObject)
 		initBackgroundTransition(GlobalContext, newObject)
 	}
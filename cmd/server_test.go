@@ -18,23 +18,38 @@ package cmd
 
 import (
 	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/gorilla/mux"
 	"github.com/minio/minio-go/v7/pkg/set"
 	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/bucket/acl"
+	"github.com/minio/minio/pkg/bucket/inventory"
+	"github.com/minio/minio/pkg/bucket/logging"
+	"github.com/minio/minio/pkg/bucket/ownership"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/publicaccess"
+	"github.com/minio/minio/pkg/bucket/website"
+	"github.com/minio/minio/pkg/hash"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
 )
 
 // API suite container common to both FS and Erasure.
@@ -75,6 +90,18 @@ func verifyError(c *check, response *http.Response, code, description string, st
 func runAllTests(suite *TestSuiteCommon, c *check) {
 	suite.SetUpSuite(c)
 	suite.TestCors(c)
+	suite.TestBucketCors(c)
+	suite.TestBucketWebsite(c)
+	suite.TestBucketLogging(c)
+	suite.TestBucketACL(c)
+	suite.TestCopyObjectACL(c)
+	suite.TestBucketInventory(c)
+	suite.TestBucketPublicAccessBlock(c)
+	suite.TestObjectChecksum(c)
+	suite.TestObjectAttributes(c)
+	suite.TestConditionalWrites(c)
+	suite.TestBucketOwnershipControls(c)
+	suite.TestIAMQuotaUploads(c)
 	suite.TestObjectDir(c)
 	suite.TestBucketPolicy(c)
 	suite.TestDeleteBucket(c)
@@ -238,6 +265,1333 @@ func (s *TestSuiteCommon) TestCors(c *check) {
 
 }
 
+// TestBucketCors - verifies that preflight and actual requests on a bucket
+// are evaluated against the bucket CORS configuration.
+func (s *TestSuiteCommon) TestBucketCors(c *check) {
+	// Signature V2 test signer does not sign the `cors` sub-resource.
+	if s.signer == signerV2 {
+		return
+	}
+
+	bucketName := getRandomBucketName()
+	// HTTP request to create the bucket.
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// No CORS configuration yet.
+	request, err = newTestSignedRequest(http.MethodGet, getBucketCorsURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	verifyError(c, response, "NoSuchCORSConfiguration", "The CORS configuration does not exist", http.StatusNotFound)
+
+	corsConfig := []byte(`<CORSConfiguration><CORSRule><AllowedOrigin>http://www.example.com</AllowedOrigin><AllowedMethod>PUT</AllowedMethod><AllowedHeader>*</AllowedHeader><ExposeHeader>ETag</ExposeHeader><MaxAgeSeconds>3000</MaxAgeSeconds></CORSRule></CORSConfiguration>`)
+	request, err = newTestSignedRequest(http.MethodPut, getBucketCorsURL(s.endPoint, bucketName),
+		int64(len(corsConfig)), bytes.NewReader(corsConfig), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	request.Header.Set("Content-Md5", getMD5HashBase64(corsConfig))
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// Preflight request from an allowed origin.
+	request, err = http.NewRequest(http.MethodOptions, getPutObjectURL(s.endPoint, bucketName, "object"), nil)
+	c.Assert(err, nil)
+	request.Header.Set("Origin", "http://www.example.com")
+	request.Header.Set("Access-Control-Request-Method", http.MethodPut)
+	request.Header.Set("Access-Control-Request-Headers", "content-type")
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	c.Assert(response.Header.Get("Access-Control-Allow-Origin"), "http://www.example.com")
+	c.Assert(response.Header.Get("Access-Control-Allow-Methods"), http.MethodPut)
+	c.Assert(response.Header.Get("Access-Control-Allow-Headers"), "content-type")
+	c.Assert(response.Header.Get("Access-Control-Max-Age"), "3000")
+
+	// Preflight request for a method which is not allowed.
+	request, err = http.NewRequest(http.MethodOptions, getPutObjectURL(s.endPoint, bucketName, "object"), nil)
+	c.Assert(err, nil)
+	request.Header.Set("Origin", "http://www.example.com")
+	request.Header.Set("Access-Control-Request-Method", http.MethodDelete)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusForbidden)
+	c.Assert(response.Header.Get("Access-Control-Allow-Origin"), "")
+
+	// Remove the CORS configuration.
+	request, err = newTestSignedRequest(http.MethodDelete, getBucketCorsURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNoContent)
+}
+
+// TestBucketWebsite - verifies the website configuration APIs and that
+// website endpoints resolve index documents, error documents and routing
+// rules.
+func (s *TestSuiteCommon) TestBucketWebsite(c *check) {
+	bucketName := getRandomBucketName()
+	// HTTP request to create the bucket.
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// No website configuration yet.
+	request, err = newTestSignedRequest(http.MethodGet, getBucketWebsiteURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	verifyError(c, response, "NoSuchWebsiteConfiguration", "The specified bucket does not have a website configuration", http.StatusNotFound)
+
+	websiteConfig := `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><ErrorDocument><Key>error.html</Key></ErrorDocument><RoutingRules><RoutingRule><Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition><Redirect><ReplaceKeyPrefixWith>documents/</ReplaceKeyPrefixWith></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`
+	request, err = newTestSignedRequest(http.MethodPut, getBucketWebsiteURL(s.endPoint, bucketName),
+		int64(len(websiteConfig)), strings.NewReader(websiteConfig), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	request, err = newTestSignedRequest(http.MethodGet, getBucketWebsiteURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	config, err := website.ParseConfig(response.Body)
+	c.Assert(err, nil)
+	c.Assert(config.IndexDocument.Suffix, "index.html")
+	c.Assert(config.ErrorDocument.Key, "error.html")
+
+	objects := map[string]string{
+		"index.html":        "root index",
+		"error.html":        "error document",
+		"photos/index.html": "photos index",
+	}
+	for objectName, data := range objects {
+		request, err = newTestSignedRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, objectName),
+			int64(len(data)), strings.NewReader(data), s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+
+		response, err = s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+	}
+
+	// Website endpoints only serve objects readable anonymously.
+	bucketPolicy := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Action":["s3:GetObject"],"Effect":"Allow","Principal":{"AWS":["*"]},"Resource":["arn:aws:s3:::%s/*"]}]}`, bucketName)
+	request, err = newTestSignedRequest(http.MethodPut, getPutPolicyURL(s.endPoint, bucketName),
+		int64(len(bucketPolicy)), strings.NewReader(bucketPolicy), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNoContent)
+
+	websiteDomainNames := globalWebsiteDomainNames
+	globalWebsiteDomainNames = []string{"website.minio.io"}
+	defer func() { globalWebsiteDomainNames = websiteDomainNames }()
+
+	router := mux.NewRouter().SkipClean(true).UseEncodedPath()
+	registerWebsiteRouter(router)
+
+	testCases := []struct {
+		path             string
+		expectedStatus   int
+		expectedBody     string
+		expectedLocation string
+	}{
+		{"/", http.StatusOK, "root index", ""},
+		{"/photos/", http.StatusOK, "photos index", ""},
+		{"/photos", http.StatusFound, "", "/photos/"},
+		{"/missing.html", http.StatusNotFound, "error document", ""},
+		{"/docs/a.html", http.StatusMovedPermanently, "", "://" + bucketName + ".website.minio.io/documents/a.html"},
+	}
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "http://"+bucketName+".website.minio.io"+testCase.path, nil)
+		router.ServeHTTP(rec, req)
+		c.Assert(rec.Code, testCase.expectedStatus)
+		if testCase.expectedBody != "" && rec.Body.String() != testCase.expectedBody {
+			c.Fatalf("Test %d: expected body %s, got %s", i+1, testCase.expectedBody, rec.Body.String())
+		}
+		if !strings.HasSuffix(rec.Header().Get(xhttp.Location), testCase.expectedLocation) {
+			c.Fatalf("Test %d: expected location %s, got %s", i+1, testCase.expectedLocation, rec.Header().Get(xhttp.Location))
+		}
+	}
+
+	// Remove the website configuration.
+	request, err = newTestSignedRequest(http.MethodDelete, getBucketWebsiteURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNoContent)
+}
+
+// TestBucketLogging - verifies the logging status APIs and that access
+// logs of requests are delivered into the target bucket.
+func (s *TestSuiteCommon) TestBucketLogging(c *check) {
+	bucketName := getRandomBucketName()
+	targetBucketName := getRandomBucketName()
+	for _, bucket := range []string{bucketName, targetBucketName} {
+		request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucket),
+			0, nil, s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+	}
+
+	// Access logging is disabled by default.
+	request, err := newTestSignedRequest(http.MethodGet, getBucketLoggingURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	status, err := logging.ParseConfig(response.Body)
+	c.Assert(err, nil)
+	c.Assert(status.Enabled(), false)
+
+	// Target bucket must exist.
+	loggingStatus := `<BucketLoggingStatus><LoggingEnabled><TargetBucket>non-existent-logs</TargetBucket><TargetPrefix>access/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`
+	request, err = newTestSignedRequest(http.MethodPut, getBucketLoggingURL(s.endPoint, bucketName),
+		int64(len(loggingStatus)), strings.NewReader(loggingStatus), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	verifyError(c, response, "InvalidTargetBucketForLogging", "The target bucket for logging does not exist", http.StatusBadRequest)
+
+	loggingStatus = fmt.Sprintf(`<BucketLoggingStatus><LoggingEnabled><TargetBucket>%s</TargetBucket><TargetPrefix>access/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`, targetBucketName)
+	request, err = newTestSignedRequest(http.MethodPut, getBucketLoggingURL(s.endPoint, bucketName),
+		int64(len(loggingStatus)), strings.NewReader(loggingStatus), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	request, err = newTestSignedRequest(http.MethodGet, getBucketLoggingURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	status, err = logging.ParseConfig(response.Body)
+	c.Assert(err, nil)
+	c.Assert(status.LoggingEnabled.TargetBucket, targetBucketName)
+	c.Assert(status.LoggingEnabled.TargetPrefix, "access/")
+
+	// Generate a successful and a failed request.
+	data := "hello world"
+	request, err = newTestSignedRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "object"),
+		int64(len(data)), strings.NewReader(data), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	request, err = newTestSignedRequest(http.MethodGet, getGetObjectURL(s.endPoint, bucketName, "missing-object"),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNotFound)
+
+	objAPI := newObjectLayerFn()
+	globalBucketLoggingSys.flush(GlobalContext, objAPI)
+
+	result, err := objAPI.ListObjects(GlobalContext, targetBucketName, "access/", "", "", 10)
+	c.Assert(err, nil)
+	c.Assert(len(result.Objects), 1)
+
+	var buf bytes.Buffer
+	err = objAPI.GetObject(GlobalContext, targetBucketName, result.Objects[0].Name, 0, -1, &buf, "", ObjectOptions{})
+	c.Assert(err, nil)
+	accessLogs := buf.String()
+	if !strings.Contains(accessLogs, " "+bucketName+" ") ||
+		!strings.Contains(accessLogs, "REST.PUT.OBJECT object") ||
+		!strings.Contains(accessLogs, "REST.GET.OBJECT missing-object") ||
+		!strings.Contains(accessLogs, " 404 NoSuchKey ") {
+		c.Fatalf("unexpected access logs %s", accessLogs)
+	}
+
+	// Disable access logging.
+	loggingStatus = `<BucketLoggingStatus></BucketLoggingStatus>`
+	request, err = newTestSignedRequest(http.MethodPut, getBucketLoggingURL(s.endPoint, bucketName),
+		int64(len(loggingStatus)), strings.NewReader(loggingStatus), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+}
+
+// TestBucketACL - verifies that bucket and object ACLs are stored and
+// grant access to anonymous requests.
+func (s *TestSuiteCommon) TestBucketACL(c *check) {
+	bucketName := getRandomBucketName()
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// newACLRequest - returns a signed request setting the given ACL header.
+	newACLRequest := func(method, urlStr string, body []byte, header, value string) *http.Request {
+		request, err := newTestRequest(method, urlStr, int64(len(body)), bytes.NewReader(body))
+		c.Assert(err, nil)
+		if header != "" {
+			request.Header.Set(header, value)
+		}
+		if s.signer == signerV4 {
+			err = signRequestV4(request, s.accessKey, s.secretKey)
+		} else {
+			err = signRequestV2(request, s.accessKey, s.secretKey)
+		}
+		c.Assert(err, nil)
+		return request
+	}
+
+	// assertAnonymousGet - verifies the status of an anonymous request.
+	assertAnonymousGet := func(urlStr string, statusCode int) {
+		request, err := newTestRequest(http.MethodGet, urlStr, 0, nil)
+		c.Assert(err, nil)
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, statusCode)
+	}
+
+	data := []byte("hello world")
+	response, err = s.client.Do(newACLRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "public-object"),
+		data, acl.AmzACL, acl.CannedPublicRead))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	response, err = s.client.Do(newACLRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "private-object"),
+		data, "", ""))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	assertAnonymousGet(getGetObjectURL(s.endPoint, bucketName, "public-object"), http.StatusOK)
+	assertAnonymousGet(getGetObjectURL(s.endPoint, bucketName, "private-object"), http.StatusForbidden)
+
+	response, err = s.client.Do(newACLRequest(http.MethodGet, getObjectACLURL(s.endPoint, bucketName, "public-object"),
+		nil, "", ""))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	objectACL, err := acl.ParseAccessControlPolicy(response.Body)
+	c.Assert(err, nil)
+	c.Assert(len(objectACL.AccessControlList.Grants), 2)
+	c.Assert(objectACL.IsAllowed("", acl.PermissionRead), true)
+
+	// Share the private object with an access control policy.
+	response, err = s.client.Do(newACLRequest(http.MethodPut, getObjectACLURL(s.endPoint, bucketName, "private-object"),
+		[]byte(fmt.Sprintf(`<AccessControlPolicy><Owner><ID>%s</ID></Owner><AccessControlList>`+
+			`<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="Group"><URI>%s</URI></Grantee><Permission>READ</Permission></Grant>`+
+			`</AccessControlList></AccessControlPolicy>`, globalMinioDefaultOwnerID, acl.AllUsersGroup)), "", ""))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	assertAnonymousGet(getGetObjectURL(s.endPoint, bucketName, "private-object"), http.StatusOK)
+
+	// Requests for missing objects do not reveal that they do not exist.
+	assertAnonymousGet(getGetObjectURL(s.endPoint, bucketName, "missing-object"), http.StatusForbidden)
+
+	// ACLs do not override an explicit deny of the bucket policy.
+	bucketPolicy := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Action":["s3:GetObject"],"Effect":"Deny","Principal":{"AWS":["*"]},"Resource":["arn:aws:s3:::%s/private-object"]}]}`, bucketName)
+	response, err = s.client.Do(newACLRequest(http.MethodPut, getPutPolicyURL(s.endPoint, bucketName),
+		[]byte(bucketPolicy), "", ""))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNoContent)
+	assertAnonymousGet(getGetObjectURL(s.endPoint, bucketName, "private-object"), http.StatusForbidden)
+
+	// Make the public object private again.
+	response, err = s.client.Do(newACLRequest(http.MethodPut, getObjectACLURL(s.endPoint, bucketName, "public-object"),
+		nil, acl.AmzACL, acl.CannedPrivate))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	assertAnonymousGet(getGetObjectURL(s.endPoint, bucketName, "public-object"), http.StatusForbidden)
+
+	// Unknown canned ACLs are rejected.
+	response, err = s.client.Do(newACLRequest(http.MethodPut, getObjectACLURL(s.endPoint, bucketName, "public-object"),
+		nil, acl.AmzACL, "public"))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusBadRequest)
+
+	// Bucket ACLs grant listing the bucket.
+	listURL := getListObjectsV1URL(s.endPoint, bucketName, "", "1000", "")
+	assertAnonymousGet(listURL, http.StatusForbidden)
+
+	response, err = s.client.Do(newACLRequest(http.MethodPut, getBucketACLURL(s.endPoint, bucketName),
+		nil, acl.AmzACL, acl.CannedPublicRead))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	assertAnonymousGet(listURL, http.StatusOK)
+
+	response, err = s.client.Do(newACLRequest(http.MethodGet, getBucketACLURL(s.endPoint, bucketName),
+		nil, "", ""))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	bucketACL, err := acl.ParseAccessControlPolicy(response.Body)
+	c.Assert(err, nil)
+	c.Assert(bucketACL.Owner.ID, globalMinioDefaultOwnerID)
+	c.Assert(bucketACL.IsAllowed("", acl.PermissionRead), true)
+
+	response, err = s.client.Do(newACLRequest(http.MethodPut, getBucketACLURL(s.endPoint, bucketName),
+		nil, acl.AmzACL, acl.CannedPrivate))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	assertAnonymousGet(listURL, http.StatusForbidden)
+}
+
+// TestCopyObjectACL - verifies the object ACL of the source object of a
+// copy grants reading it.
+func (s *TestSuiteCommon) TestCopyObjectACL(c *check) {
+	bucketName := getRandomBucketName()
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// The user may only write objects, reading them needs an ACL grant.
+	userAccessKey, userSecretKey := "copyacluser", "copyacluser-secret"
+	c.Assert(globalIAMSys.SetUser(userAccessKey, madmin.UserInfo{
+		SecretKey: userSecretKey,
+		Status:    madmin.AccountEnabled,
+	}), nil)
+	writeOnly, err := iampolicy.ParseConfig(strings.NewReader(
+		`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:PutObject"],"Resource":["arn:aws:s3:::*"]}]}`))
+	c.Assert(err, nil)
+	c.Assert(globalIAMSys.SetPolicy("copyaclwriteonly", *writeOnly), nil)
+	c.Assert(globalIAMSys.PolicyDBSet(userAccessKey, "copyaclwriteonly", false), nil)
+
+	// newRequest - returns a request signed with the given credentials
+	// setting the given header.
+	newRequest := func(accessKey, secretKey, method, urlStr string, body []byte, header, value string) *http.Request {
+		request, err := newTestRequest(method, urlStr, int64(len(body)), bytes.NewReader(body))
+		c.Assert(err, nil)
+		if header != "" {
+			request.Header.Set(header, value)
+		}
+		if s.signer == signerV4 {
+			err = signRequestV4(request, accessKey, secretKey)
+		} else {
+			err = signRequestV2(request, accessKey, secretKey)
+		}
+		c.Assert(err, nil)
+		return request
+	}
+
+	data := []byte("hello world")
+	response, err = s.client.Do(newRequest(s.accessKey, s.secretKey, http.MethodPut,
+		getPutObjectURL(s.endPoint, bucketName, "granted-object"), data, acl.AmzGrantRead, fmt.Sprintf(`id="%s"`, userAccessKey)))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	response, err = s.client.Do(newRequest(s.accessKey, s.secretKey, http.MethodPut,
+		getPutObjectURL(s.endPoint, bucketName, "private-object"), data, "", ""))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	for _, source := range []struct {
+		object     string
+		statusCode int
+	}{
+		{"granted-object", http.StatusOK},
+		{"private-object", http.StatusForbidden},
+		{"missing-object", http.StatusForbidden},
+	} {
+		copySource := pathJoin(SlashSeparator, bucketName, source.object)
+		response, err = s.client.Do(newRequest(userAccessKey, userSecretKey, http.MethodPut,
+			getCopyObjectURL(s.endPoint, bucketName, "copy-"+source.object), nil, xhttp.AmzCopySource, copySource))
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, source.statusCode)
+
+		response, err = s.client.Do(newRequest(userAccessKey, userSecretKey, http.MethodPost,
+			getNewMultipartURL(s.endPoint, bucketName, "part-"+source.object), nil, "", ""))
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+		initResponse := &InitiateMultipartUploadResponse{}
+		c.Assert(xml.NewDecoder(response.Body).Decode(initResponse), nil)
+		response, err = s.client.Do(newRequest(userAccessKey, userSecretKey, http.MethodPut,
+			getCopyObjectPartURL(s.endPoint, bucketName, "part-"+source.object, initResponse.UploadID, "1"), nil, xhttp.AmzCopySource, copySource))
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, source.statusCode)
+	}
+}
+
+// TestBucketPublicAccessBlock - verifies the public access block
+// configuration APIs and that public ACLs and policies are blocked.
+func (s *TestSuiteCommon) TestBucketPublicAccessBlock(c *check) {
+	bucketName := getRandomBucketName()
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// newRequest - returns a signed request setting the given header.
+	newRequest := func(method, urlStr string, body []byte, header, value string) *http.Request {
+		request, err := newTestRequest(method, urlStr, int64(len(body)), bytes.NewReader(body))
+		c.Assert(err, nil)
+		if header != "" {
+			request.Header.Set(header, value)
+		}
+		if s.signer == signerV4 {
+			err = signRequestV4(request, s.accessKey, s.secretKey)
+		} else {
+			err = signRequestV2(request, s.accessKey, s.secretKey)
+		}
+		c.Assert(err, nil)
+		return request
+	}
+
+	// putPublicAccessBlock - sets the public access block configuration.
+	putPublicAccessBlock := func(blockPublicAcls, ignorePublicAcls, blockPublicPolicy, restrictPublicBuckets bool) {
+		config := fmt.Sprintf(`<PublicAccessBlockConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">`+
+			`<BlockPublicAcls>%t</BlockPublicAcls><IgnorePublicAcls>%t</IgnorePublicAcls>`+
+			`<BlockPublicPolicy>%t</BlockPublicPolicy><RestrictPublicBuckets>%t</RestrictPublicBuckets>`+
+			`</PublicAccessBlockConfiguration>`, blockPublicAcls, ignorePublicAcls, blockPublicPolicy, restrictPublicBuckets)
+		response, err := s.client.Do(newRequest(http.MethodPut, getBucketPublicAccessBlockURL(s.endPoint, bucketName),
+			[]byte(config), "", ""))
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+	}
+
+	// assertAnonymousGet - verifies the status of an anonymous request.
+	assertAnonymousGet := func(urlStr string, statusCode int) {
+		request, err := newTestRequest(http.MethodGet, urlStr, 0, nil)
+		c.Assert(err, nil)
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, statusCode)
+	}
+
+	response, err = s.client.Do(newRequest(http.MethodGet, getBucketPublicAccessBlockURL(s.endPoint, bucketName), nil, "", ""))
+	c.Assert(err, nil)
+	verifyError(c, response, "NoSuchPublicAccessBlockConfiguration", "The public access block configuration was not found", http.StatusNotFound)
+
+	data := []byte("hello world")
+	objectURL := getPutObjectURL(s.endPoint, bucketName, "public-object")
+	response, err = s.client.Do(newRequest(http.MethodPut, objectURL, data, acl.AmzACL, acl.CannedPublicRead))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	assertAnonymousGet(objectURL, http.StatusOK)
+
+	// Grants to public groups are ignored.
+	putPublicAccessBlock(false, true, false, false)
+	assertAnonymousGet(objectURL, http.StatusForbidden)
+
+	response, err = s.client.Do(newRequest(http.MethodGet, getBucketPublicAccessBlockURL(s.endPoint, bucketName), nil, "", ""))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	config, err := publicaccess.ParseConfig(response.Body)
+	c.Assert(err, nil)
+	c.Assert(config.IgnorePublicAcls, true)
+	c.Assert(config.BlockPublicAcls, false)
+
+	// Public ACLs are rejected.
+	putPublicAccessBlock(true, false, false, false)
+	assertAnonymousGet(objectURL, http.StatusOK)
+	response, err = s.client.Do(newRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "new-object"),
+		data, acl.AmzACL, acl.CannedPublicRead))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusForbidden)
+	response, err = s.client.Do(newRequest(http.MethodPut, getBucketACLURL(s.endPoint, bucketName),
+		nil, acl.AmzACL, acl.CannedPublicRead))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusForbidden)
+	response, err = s.client.Do(newRequest(http.MethodPut, getObjectACLURL(s.endPoint, bucketName, "public-object"),
+		nil, acl.AmzACL, acl.CannedPrivate))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// Public bucket policies are rejected.
+	putPublicAccessBlock(false, false, true, false)
+	bucketPolicy := []byte(fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Action":["s3:GetObject"],"Effect":"Allow","Principal":{"AWS":["*"]},"Resource":["arn:aws:s3:::%s/*"]}]}`, bucketName))
+	response, err = s.client.Do(newRequest(http.MethodPut, getPutPolicyURL(s.endPoint, bucketName), bucketPolicy, "", ""))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusForbidden)
+
+	// Public bucket policies do not apply to buckets restricting public access.
+	putPublicAccessBlock(false, false, false, true)
+	response, err = s.client.Do(newRequest(http.MethodPut, getPutPolicyURL(s.endPoint, bucketName), bucketPolicy, "", ""))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNoContent)
+	assertAnonymousGet(objectURL, http.StatusForbidden)
+
+	response, err = s.client.Do(newRequest(http.MethodDelete, getBucketPublicAccessBlockURL(s.endPoint, bucketName), nil, "", ""))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNoContent)
+	assertAnonymousGet(objectURL, http.StatusOK)
+
+	response, err = s.client.Do(newRequest(http.MethodGet, getBucketPublicAccessBlockURL(s.endPoint, bucketName), nil, "", ""))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNotFound)
+}
+
+// TestObjectChecksum - tests the flexible content checksums of objects.
+func (s *TestSuiteCommon) TestObjectChecksum(c *check) {
+	bucketName := getRandomBucketName()
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// newRequest - returns a signed request setting the given headers.
+	newRequest := func(method, urlStr string, body []byte, headers map[string]string) *http.Request {
+		request, err := newTestRequest(method, urlStr, int64(len(body)), bytes.NewReader(body))
+		c.Assert(err, nil)
+		for k, v := range headers {
+			request.Header.Set(k, v)
+		}
+		if s.signer == signerV4 {
+			err = signRequestV4(request, s.accessKey, s.secretKey)
+		} else {
+			err = signRequestV2(request, s.accessKey, s.secretKey)
+		}
+		c.Assert(err, nil)
+		return request
+	}
+
+	data := []byte("hello world")
+	h := hash.ChecksumCRC32.Hasher()
+	h.Write(data)
+	checksum := base64.StdEncoding.EncodeToString(h.Sum(nil))
+	checksumHeader := hash.ChecksumCRC32.Header()
+
+	objectURL := getPutObjectURL(s.endPoint, bucketName, "object")
+	response, err = s.client.Do(newRequest(http.MethodPut, objectURL, data, map[string]string{checksumHeader: "AAAAAA=="}))
+	c.Assert(err, nil)
+	verifyError(c, response, "XAmzContentChecksumMismatch", "The provided 'x-amz-checksum' header does not match what was computed.", http.StatusBadRequest)
+
+	response, err = s.client.Do(newRequest(http.MethodPut, objectURL, data, map[string]string{checksumHeader: checksum}))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	c.Assert(response.Header.Get(checksumHeader), checksum)
+
+	// Checksums are only returned when requested.
+	response, err = s.client.Do(newRequest(http.MethodHead, objectURL, nil, nil))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	c.Assert(response.Header.Get(checksumHeader), "")
+
+	response, err = s.client.Do(newRequest(http.MethodGet, objectURL, nil, map[string]string{xhttp.AmzChecksumMode: checksumModeEnabled}))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	c.Assert(response.Header.Get(checksumHeader), checksum)
+
+	// Trailing checksum of an unsigned aws-chunked payload.
+	if s.signer == signerV4 {
+		body := fmt.Sprintf("%x\r\n%s\r\n0\r\n%s:%s\r\n\r\n", len(data), data, checksumHeader, checksum)
+		request, err = newTestRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "trailer"), int64(len(body)), strings.NewReader(body))
+		c.Assert(err, nil)
+		// The Content-Md5 set by newTestRequest is of the encoded payload.
+		request.Header.Del(xhttp.ContentMD5)
+		request.Header.Set(xhttp.AmzContentSha256, streamingUnsignedTrailer)
+		request.Header.Set(xhttp.AmzTrailer, checksumHeader)
+		request.Header.Set(xhttp.AmzDecodedContentLength, strconv.Itoa(len(data)))
+		request.Header.Set(xhttp.ContentEncoding, streamingContentEncoding)
+		c.Assert(signRequestV4(request, s.accessKey, s.secretKey), nil)
+		response, err = s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+		c.Assert(response.Header.Get(checksumHeader), checksum)
+	}
+
+	// Parts of uploads with a checksum algorithm always have checksums.
+	objectURL = getPutObjectURL(s.endPoint, bucketName, "multipart")
+	response, err = s.client.Do(newRequest(http.MethodPost, getNewMultipartURL(s.endPoint, bucketName, "multipart"), nil,
+		map[string]string{xhttp.AmzChecksumAlgorithm: "crc32"}))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	c.Assert(response.Header.Get(xhttp.AmzChecksumAlgorithm), string(hash.ChecksumCRC32))
+	initResponse := &InitiateMultipartUploadResponse{}
+	c.Assert(xml.NewDecoder(response.Body).Decode(initResponse), nil)
+
+	response, err = s.client.Do(newRequest(http.MethodPut, getPartUploadURL(s.endPoint, bucketName, "multipart", initResponse.UploadID, "1"), data, nil))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	c.Assert(response.Header.Get(checksumHeader), checksum)
+
+	completeUpload := CompleteMultipartUpload{Parts: []CompletePart{{
+		PartNumber:      1,
+		ETag:            response.Header.Get(xhttp.ETag),
+		ObjectChecksums: ObjectChecksums{ChecksumCRC32: checksum},
+	}}}
+	completeBytes, err := xml.Marshal(completeUpload)
+	c.Assert(err, nil)
+	response, err = s.client.Do(newRequest(http.MethodPost, getCompleteMultipartUploadURL(s.endPoint, bucketName, "multipart", initResponse.UploadID), completeBytes, nil))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	completeResponse := &CompleteMultipartUploadResponse{}
+	c.Assert(xml.NewDecoder(response.Body).Decode(completeResponse), nil)
+
+	// Checksums of multipart objects are only recorded in erasure mode.
+	if s.serverType == "FS" {
+		return
+	}
+	composite, err := hash.CompositeChecksum([]hash.Checksum{{Type: hash.ChecksumCRC32, Encoded: checksum}})
+	c.Assert(err, nil)
+	c.Assert(completeResponse.ChecksumCRC32, composite.Encoded)
+
+	response, err = s.client.Do(newRequest(http.MethodHead, objectURL, nil, map[string]string{xhttp.AmzChecksumMode: checksumModeEnabled}))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	c.Assert(response.Header.Get(checksumHeader), composite.Encoded)
+
+	response, err = s.client.Do(newRequest(http.MethodHead, objectURL+"?partNumber=1", nil, map[string]string{xhttp.AmzChecksumMode: checksumModeEnabled}))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusPartialContent)
+	c.Assert(response.Header.Get(checksumHeader), checksum)
+}
+
+// TestObjectAttributes - verifies that GetObjectAttributes returns the
+// requested attributes of a multipart object.
+func (s *TestSuiteCommon) TestObjectAttributes(c *check) {
+	bucketName := getRandomBucketName()
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// newRequest - returns a signed request setting the given headers.
+	newRequest := func(method, urlStr string, body []byte, headers map[string]string) *http.Request {
+		request, err := newTestRequest(method, urlStr, int64(len(body)), bytes.NewReader(body))
+		c.Assert(err, nil)
+		for k, v := range headers {
+			request.Header.Set(k, v)
+		}
+		if s.signer == signerV4 {
+			err = signRequestV4(request, s.accessKey, s.secretKey)
+		} else {
+			err = signRequestV2(request, s.accessKey, s.secretKey)
+		}
+		c.Assert(err, nil)
+		return request
+	}
+
+	response, err = s.client.Do(newRequest(http.MethodPost, getNewMultipartURL(s.endPoint, bucketName, "object"), nil,
+		map[string]string{xhttp.AmzChecksumAlgorithm: "crc32"}))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	initResponse := &InitiateMultipartUploadResponse{}
+	c.Assert(xml.NewDecoder(response.Body).Decode(initResponse), nil)
+
+	parts := [][]byte{bytes.Repeat([]byte("a"), globalMinPartSize), []byte("hello world")}
+	var completeUpload CompleteMultipartUpload
+	for i, data := range parts {
+		partNumber := strconv.Itoa(i + 1)
+		response, err = s.client.Do(newRequest(http.MethodPut, getPartUploadURL(s.endPoint, bucketName, "object", initResponse.UploadID, partNumber), data, nil))
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+		completeUpload.Parts = append(completeUpload.Parts, CompletePart{
+			PartNumber:      i + 1,
+			ETag:            response.Header.Get(xhttp.ETag),
+			ObjectChecksums: ObjectChecksums{ChecksumCRC32: response.Header.Get(hash.ChecksumCRC32.Header())},
+		})
+	}
+	completeBytes, err := xml.Marshal(completeUpload)
+	c.Assert(err, nil)
+	response, err = s.client.Do(newRequest(http.MethodPost, getCompleteMultipartUploadURL(s.endPoint, bucketName, "object", initResponse.UploadID), completeBytes, nil))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	completeResponse := &CompleteMultipartUploadResponse{}
+	c.Assert(xml.NewDecoder(response.Body).Decode(completeResponse), nil)
+
+	attributesURL := getObjectAttributesURL(s.endPoint, bucketName, "object")
+
+	// At least one known attribute is required.
+	response, err = s.client.Do(newRequest(http.MethodGet, attributesURL, nil, nil))
+	c.Assert(err, nil)
+	verifyError(c, response, "InvalidArgument", "Invalid attribute name specified.", http.StatusBadRequest)
+
+	response, err = s.client.Do(newRequest(http.MethodGet, attributesURL, nil, map[string]string{xhttp.AmzObjectAttributes: "ETag,Owner"}))
+	c.Assert(err, nil)
+	verifyError(c, response, "InvalidArgument", "Invalid attribute name specified.", http.StatusBadRequest)
+
+	response, err = s.client.Do(newRequest(http.MethodGet, attributesURL, nil, map[string]string{
+		xhttp.AmzObjectAttributes: "ETag, Checksum, ObjectParts, StorageClass, ObjectSize",
+		xhttp.AmzMaxParts:         "1",
+	}))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	attributes := &GetObjectAttributesResponse{}
+	c.Assert(xml.NewDecoder(response.Body).Decode(attributes), nil)
+	c.Assert(attributes.ETag, canonicalizeETag(completeResponse.ETag))
+	c.Assert(attributes.StorageClass, "STANDARD")
+	c.Assert(*attributes.ObjectSize, int64(globalMinPartSize+len("hello world")))
+	c.Assert(attributes.ObjectParts.PartsCount, 2)
+	c.Assert(attributes.ObjectParts.IsTruncated, true)
+	c.Assert(attributes.ObjectParts.NextPartNumberMarker, 1)
+	c.Assert(len(attributes.ObjectParts.Parts), 1)
+	c.Assert(attributes.ObjectParts.Parts[0].Size, int64(globalMinPartSize))
+
+	response, err = s.client.Do(newRequest(http.MethodGet, attributesURL, nil, map[string]string{
+		xhttp.AmzObjectAttributes: "ObjectParts",
+		xhttp.AmzPartNumberMarker: "1",
+	}))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	attributes = &GetObjectAttributesResponse{}
+	c.Assert(xml.NewDecoder(response.Body).Decode(attributes), nil)
+	c.Assert(attributes.ETag, "")
+	c.Assert(attributes.ObjectParts.IsTruncated, false)
+	c.Assert(len(attributes.ObjectParts.Parts), 1)
+	c.Assert(attributes.ObjectParts.Parts[0].PartNumber, 2)
+	c.Assert(attributes.ObjectParts.Parts[0].Size, int64(len("hello world")))
+
+	// Checksums of multipart objects are only recorded in erasure mode.
+	if s.serverType == "FS" {
+		return
+	}
+	c.Assert(attributes.ObjectParts.Parts[0].ChecksumCRC32, completeUpload.Parts[1].ChecksumCRC32)
+	c.Assert(attributes.Checksum == nil, true)
+
+	response, err = s.client.Do(newRequest(http.MethodGet, attributesURL, nil, map[string]string{xhttp.AmzObjectAttributes: "Checksum"}))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	attributes = &GetObjectAttributesResponse{}
+	c.Assert(xml.NewDecoder(response.Body).Decode(attributes), nil)
+	c.Assert(attributes.Checksum.ChecksumCRC32, completeResponse.ChecksumCRC32)
+}
+
+// TestConditionalWrites - verifies If-None-Match and If-Match on
+// PutObject and CompleteMultipartUpload.
+func (s *TestSuiteCommon) TestConditionalWrites(c *check) {
+	bucketName := getRandomBucketName()
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// newRequest - returns a signed request setting the given headers.
+	newRequest := func(method, urlStr string, body []byte, headers map[string]string) *http.Request {
+		request, err := newTestRequest(method, urlStr, int64(len(body)), bytes.NewReader(body))
+		c.Assert(err, nil)
+		for k, v := range headers {
+			request.Header.Set(k, v)
+		}
+		if s.signer == signerV4 {
+			err = signRequestV4(request, s.accessKey, s.secretKey)
+		} else {
+			err = signRequestV2(request, s.accessKey, s.secretKey)
+		}
+		c.Assert(err, nil)
+		return request
+	}
+
+	// Only one of several concurrent writers creates the object.
+	objectURL := getPutObjectURL(s.endPoint, bucketName, "lease")
+	statusCh := make(chan int, 10)
+	var wg sync.WaitGroup
+	for i := 0; i < cap(statusCh); i++ {
+		request := newRequest(http.MethodPut, objectURL, []byte(fmt.Sprintf("owner-%d", i)), map[string]string{xhttp.IfNoneMatch: "*"})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := s.client.Do(request)
+			if err != nil {
+				statusCh <- 0
+				return
+			}
+			response.Body.Close()
+			statusCh <- response.StatusCode
+		}()
+	}
+	wg.Wait()
+	close(statusCh)
+	var created int
+	for status := range statusCh {
+		switch status {
+		case http.StatusOK:
+			created++
+		case http.StatusPreconditionFailed:
+		default:
+			c.Fatalf("unexpected status %d", status)
+		}
+	}
+	c.Assert(created, 1)
+
+	response, err = s.client.Do(newRequest(http.MethodHead, objectURL, nil, nil))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	etag := response.Header.Get(xhttp.ETag)
+
+	// Compare and swap.
+	response, err = s.client.Do(newRequest(http.MethodPut, objectURL, []byte("renewed"), map[string]string{xhttp.IfMatch: "\"00000000000000000000000000000000\""}))
+	c.Assert(err, nil)
+	verifyError(c, response, "PreconditionFailed", "At least one of the pre-conditions you specified did not hold", http.StatusPreconditionFailed)
+
+	response, err = s.client.Do(newRequest(http.MethodPut, objectURL, []byte("renewed"), map[string]string{xhttp.IfMatch: etag}))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	c.Assert(response.Header.Get(xhttp.ETag) != etag, true)
+
+	// The swapped ETag no longer matches.
+	response, err = s.client.Do(newRequest(http.MethodPut, objectURL, []byte("stolen"), map[string]string{xhttp.IfMatch: etag}))
+	c.Assert(err, nil)
+	verifyError(c, response, "PreconditionFailed", "At least one of the pre-conditions you specified did not hold", http.StatusPreconditionFailed)
+
+	// If-Match requires an existing object.
+	response, err = s.client.Do(newRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "missing"), []byte("data"), map[string]string{xhttp.IfMatch: "*"}))
+	c.Assert(err, nil)
+	verifyError(c, response, "PreconditionFailed", "At least one of the pre-conditions you specified did not hold", http.StatusPreconditionFailed)
+
+	// Multipart uploads are evaluated on completion.
+	response, err = s.client.Do(newRequest(http.MethodPost, getNewMultipartURL(s.endPoint, bucketName, "lease"), nil, nil))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	initResponse := &InitiateMultipartUploadResponse{}
+	c.Assert(xml.NewDecoder(response.Body).Decode(initResponse), nil)
+
+	response, err = s.client.Do(newRequest(http.MethodPut, getPartUploadURL(s.endPoint, bucketName, "lease", initResponse.UploadID, "1"), []byte("multipart"), nil))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	completeBytes, err := xml.Marshal(CompleteMultipartUpload{Parts: []CompletePart{{PartNumber: 1, ETag: response.Header.Get(xhttp.ETag)}}})
+	c.Assert(err, nil)
+
+	completeURL := getCompleteMultipartUploadURL(s.endPoint, bucketName, "lease", initResponse.UploadID)
+	response, err = s.client.Do(newRequest(http.MethodPost, completeURL, completeBytes, map[string]string{xhttp.IfNoneMatch: "*"}))
+	c.Assert(err, nil)
+	verifyError(c, response, "PreconditionFailed", "At least one of the pre-conditions you specified did not hold", http.StatusPreconditionFailed)
+
+	// The upload is kept after a failed precondition.
+	response, err = s.client.Do(newRequest(http.MethodHead, objectURL, nil, nil))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	response, err = s.client.Do(newRequest(http.MethodPost, completeURL, completeBytes, map[string]string{xhttp.IfMatch: response.Header.Get(xhttp.ETag)}))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+}
+
+// TestBucketOwnershipControls - verifies the ownership controls APIs and
+// the owners of objects uploaded by different users.
+func (s *TestSuiteCommon) TestBucketOwnershipControls(c *check) {
+	bucketName := getRandomBucketName()
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	userAccessKey, userSecretKey := "ownershipuser", "ownershipuser-secret"
+	c.Assert(globalIAMSys.SetUser(userAccessKey, madmin.UserInfo{
+		SecretKey: userSecretKey,
+		Status:    madmin.AccountEnabled,
+	}), nil)
+	c.Assert(globalIAMSys.SetPolicy("ownershipreadwrite", iampolicy.ReadWrite), nil)
+	c.Assert(globalIAMSys.PolicyDBSet(userAccessKey, "ownershipreadwrite", false), nil)
+
+	// newRequest - returns a request signed with the given credentials
+	// setting the given header.
+	newRequest := func(accessKey, secretKey, method, urlStr string, body []byte, header, value string) *http.Request {
+		request, err := newTestRequest(method, urlStr, int64(len(body)), bytes.NewReader(body))
+		c.Assert(err, nil)
+		if header != "" {
+			request.Header.Set(header, value)
+		}
+		if s.signer == signerV4 {
+			err = signRequestV4(request, accessKey, secretKey)
+		} else {
+			err = signRequestV2(request, accessKey, secretKey)
+		}
+		c.Assert(err, nil)
+		return request
+	}
+
+	// putOwnershipControls - sets the object ownership of the bucket.
+	putOwnershipControls := func(objectOwnership ownership.ObjectOwnership) *http.Response {
+		config := fmt.Sprintf(`<OwnershipControls xmlns="http://s3.amazonaws.com/doc/2006-03-01/">`+
+			`<Rule><ObjectOwnership>%s</ObjectOwnership></Rule></OwnershipControls>`, objectOwnership)
+		response, err := s.client.Do(newRequest(s.accessKey, s.secretKey, http.MethodPut,
+			getBucketOwnershipControlsURL(s.endPoint, bucketName), []byte(config), "", ""))
+		c.Assert(err, nil)
+		return response
+	}
+
+	// listOwners - returns the owners of the objects in the bucket.
+	listOwners := func() map[string]string {
+		response, err := s.client.Do(newRequest(s.accessKey, s.secretKey, http.MethodGet,
+			getListObjectsV2URL(s.endPoint, bucketName, "", "", "true", ""), nil, "", ""))
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+		listResponse := &ListObjectsV2Response{}
+		c.Assert(xml.NewDecoder(response.Body).Decode(listResponse), nil)
+		owners := make(map[string]string)
+		for _, object := range listResponse.Contents {
+			owners[object.Key] = object.Owner.ID
+		}
+		return owners
+	}
+
+	response, err = s.client.Do(newRequest(s.accessKey, s.secretKey, http.MethodGet,
+		getBucketOwnershipControlsURL(s.endPoint, bucketName), nil, "", ""))
+	c.Assert(err, nil)
+	verifyError(c, response, "OwnershipControlsNotFoundError", "The bucket ownership controls were not found", http.StatusNotFound)
+
+	// The writer owns the objects it uploads by default.
+	data := []byte("hello world")
+	for _, upload := range []struct {
+		accessKey, secretKey, object string
+	}{
+		{s.accessKey, s.secretKey, "root-object"},
+		{userAccessKey, userSecretKey, "user-object"},
+	} {
+		response, err = s.client.Do(newRequest(upload.accessKey, upload.secretKey, http.MethodPut,
+			getPutObjectURL(s.endPoint, bucketName, upload.object), data, "", ""))
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+	}
+	request, err = newPostRequestV4(s.endPoint, bucketName, "post-object", data, userAccessKey, userSecretKey)
+	c.Assert(err, nil)
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNoContent)
+	c.Assert(listOwners(), map[string]string{
+		"root-object":            globalMinioDefaultOwnerID,
+		"user-object":            userAccessKey,
+		"post-object/upload.txt": userAccessKey,
+	})
+
+	response, err = s.client.Do(newRequest(s.accessKey, s.secretKey, http.MethodGet,
+		getObjectACLURL(s.endPoint, bucketName, "user-object"), nil, "", ""))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	objectACL := &acl.AccessControlPolicy{}
+	c.Assert(xml.NewDecoder(response.Body).Decode(objectACL), nil)
+	c.Assert(objectACL.Owner.ID, userAccessKey)
+
+	// The ACL of the object can be written back with its owner, but the
+	// owner can not be changed.
+	aclData, err := xml.Marshal(objectACL)
+	c.Assert(err, nil)
+	response, err = s.client.Do(newRequest(s.accessKey, s.secretKey, http.MethodPut,
+		getObjectACLURL(s.endPoint, bucketName, "user-object"), aclData, "", ""))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	objectACL.Owner = aclOwner()
+	aclData, err = xml.Marshal(objectACL)
+	c.Assert(err, nil)
+	response, err = s.client.Do(newRequest(s.accessKey, s.secretKey, http.MethodPut,
+		getObjectACLURL(s.endPoint, bucketName, "user-object"), aclData, "", ""))
+	c.Assert(err, nil)
+	verifyError(c, response, "AccessDenied", "Access Denied.", http.StatusForbidden)
+
+	// The bucket owner owns objects uploaded with bucket-owner-full-control.
+	response = putOwnershipControls(ownership.BucketOwnerPreferred)
+	c.Assert(response.StatusCode, http.StatusOK)
+	response, err = s.client.Do(newRequest(userAccessKey, userSecretKey, http.MethodPut,
+		getPutObjectURL(s.endPoint, bucketName, "preferred-object"), data, acl.AmzACL, acl.CannedBucketOwnerFullControl))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	c.Assert(listOwners()["preferred-object"], globalMinioDefaultOwnerID)
+
+	// ACLs can not be disabled while the bucket ACL grants access.
+	response, err = s.client.Do(newRequest(s.accessKey, s.secretKey, http.MethodPut,
+		getBucketACLURL(s.endPoint, bucketName), nil, acl.AmzACL, acl.CannedPublicRead))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	response = putOwnershipControls(ownership.BucketOwnerEnforced)
+	verifyError(c, response, "InvalidBucketAclWithObjectOwnership", "Bucket cannot have ACLs set with ObjectOwnership's BucketOwnerEnforced setting", http.StatusBadRequest)
+
+	response, err = s.client.Do(newRequest(s.accessKey, s.secretKey, http.MethodPut,
+		getBucketACLURL(s.endPoint, bucketName), nil, acl.AmzACL, acl.CannedPrivate))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	response = putOwnershipControls(ownership.BucketOwnerEnforced)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	response, err = s.client.Do(newRequest(s.accessKey, s.secretKey, http.MethodGet,
+		getBucketOwnershipControlsURL(s.endPoint, bucketName), nil, "", ""))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	config, err := ownership.ParseConfig(response.Body)
+	c.Assert(err, nil)
+	c.Assert(config.ObjectOwnership(), ownership.BucketOwnerEnforced)
+
+	// The bucket owner owns all objects and ACLs are rejected.
+	c.Assert(listOwners()["user-object"], globalMinioDefaultOwnerID)
+	response, err = s.client.Do(newRequest(userAccessKey, userSecretKey, http.MethodPut,
+		getPutObjectURL(s.endPoint, bucketName, "enforced-object"), data, acl.AmzACL, acl.CannedPublicRead))
+	c.Assert(err, nil)
+	verifyError(c, response, "AccessControlListNotSupported", "The bucket does not allow ACLs", http.StatusBadRequest)
+	response, err = s.client.Do(newRequest(s.accessKey, s.secretKey, http.MethodPut,
+		getBucketACLURL(s.endPoint, bucketName), nil, acl.AmzACL, acl.CannedPublicRead))
+	c.Assert(err, nil)
+	verifyError(c, response, "AccessControlListNotSupported", "The bucket does not allow ACLs", http.StatusBadRequest)
+	response, err = s.client.Do(newRequest(userAccessKey, userSecretKey, http.MethodPut,
+		getPutObjectURL(s.endPoint, bucketName, "enforced-object"), data, acl.AmzACL, acl.CannedBucketOwnerFullControl))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// Objects uploaded by the writer are owned by it again.
+	response, err = s.client.Do(newRequest(s.accessKey, s.secretKey, http.MethodDelete,
+		getBucketOwnershipControlsURL(s.endPoint, bucketName), nil, "", ""))
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNoContent)
+	c.Assert(listOwners(), map[string]string{
+		"root-object":            globalMinioDefaultOwnerID,
+		"user-object":            userAccessKey,
+		"post-object/upload.txt": userAccessKey,
+		"preferred-object":       globalMinioDefaultOwnerID,
+		"enforced-object":        globalMinioDefaultOwnerID,
+	})
+}
+
+// TestIAMQuotaUploads - verifies the IAM quota of a user is enforced on
+// the objects it uploads with PUT and POST policy requests.
+func (s *TestSuiteCommon) TestIAMQuotaUploads(c *check) {
+	bucketName := getRandomBucketName()
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	userAccessKey, userSecretKey := "quotauser", "quotauser-secret"
+	c.Assert(globalIAMSys.SetUser(userAccessKey, madmin.UserInfo{
+		SecretKey: userSecretKey,
+		Status:    madmin.AccountEnabled,
+	}), nil)
+	c.Assert(globalIAMSys.SetPolicy("quotareadwrite", iampolicy.ReadWrite), nil)
+	c.Assert(globalIAMSys.PolicyDBSet(userAccessKey, "quotareadwrite", false), nil)
+	c.Assert(globalIAMQuotaSys.SetUserQuota(GlobalContext, newObjectLayerFn(), userAccessKey,
+		madmin.IAMQuota{Quota: 5}), nil)
+
+	data := []byte("hello world")
+	request, err = newTestSignedRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "put-object"),
+		int64(len(data)), bytes.NewReader(data), userAccessKey, userSecretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	verifyError(c, response, "XMinioAdminIAMQuotaExceeded", "User or group quota exceeded", http.StatusBadRequest)
+
+	request, err = newPostRequestV4(s.endPoint, bucketName, "post-object", data, userAccessKey, userSecretKey)
+	c.Assert(err, nil)
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	verifyError(c, response, "XMinioAdminIAMQuotaExceeded", "User or group quota exceeded", http.StatusBadRequest)
+
+	// The root user has no quota.
+	request, err = newPostRequestV4(s.endPoint, bucketName, "post-object", data, s.accessKey, s.secretKey)
+	c.Assert(err, nil)
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNoContent)
+}
+
+// TestBucketInventory - verifies the inventory configuration APIs and
+// the inventory reports generated for a configuration.
+func (s *TestSuiteCommon) TestBucketInventory(c *check) {
+	bucketName := getRandomBucketName()
+	dstBucketName := getRandomBucketName()
+	for _, bucket := range []string{bucketName, dstBucketName} {
+		request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucket),
+			0, nil, s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+	}
+
+	data := "hello world"
+	request, err := newTestSignedRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "photos/object"),
+		int64(len(data)), strings.NewReader(data), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// No inventory configuration exists yet.
+	request, err = newTestSignedRequest(http.MethodGet, getBucketInventoryURL(s.endPoint, bucketName, "report1"),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	verifyError(c, response, "NoSuchConfiguration", "The specified configuration does not exist.", http.StatusNotFound)
+
+	inventoryConfig := `<InventoryConfiguration><Id>report1</Id><IsEnabled>true</IsEnabled>` +
+		`<Destination><S3BucketDestination><Bucket>arn:aws:s3:::%s</Bucket><Format>CSV</Format><Prefix>reports</Prefix></S3BucketDestination></Destination>` +
+		`<Filter><Prefix>photos/</Prefix></Filter><IncludedObjectVersions>Current</IncludedObjectVersions>` +
+		`<OptionalFields><Field>Size</Field><Field>ETag</Field></OptionalFields>` +
+		`<Schedule><Frequency>Daily</Frequency></Schedule></InventoryConfiguration>`
+
+	// Destination bucket must exist.
+	config := fmt.Sprintf(inventoryConfig, "non-existent-reports")
+	request, err = newTestSignedRequest(http.MethodPut, getBucketInventoryURL(s.endPoint, bucketName, "report1"),
+		int64(len(config)), strings.NewReader(config), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	verifyError(c, response, "InvalidArgument", "The destination bucket of the inventory configuration does not exist", http.StatusBadRequest)
+
+	// Id must match the id query parameter.
+	config = fmt.Sprintf(inventoryConfig, dstBucketName)
+	request, err = newTestSignedRequest(http.MethodPut, getBucketInventoryURL(s.endPoint, bucketName, "report2"),
+		int64(len(config)), strings.NewReader(config), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	verifyError(c, response, "InvalidArgument", "The Id of the inventory configuration does not match the id query parameter", http.StatusBadRequest)
+
+	request, err = newTestSignedRequest(http.MethodPut, getBucketInventoryURL(s.endPoint, bucketName, "report1"),
+		int64(len(config)), strings.NewReader(config), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	request, err = newTestSignedRequest(http.MethodGet, getBucketInventoryURL(s.endPoint, bucketName, "report1"),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	parsedConfig, err := inventory.ParseConfig(response.Body)
+	c.Assert(err, nil)
+	c.Assert(parsedConfig.DestinationBucket(), dstBucketName)
+
+	request, err = newTestSignedRequest(http.MethodGet, getBucketInventoryURL(s.endPoint, bucketName, ""),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	var listResult ListInventoryConfigurationsResult
+	c.Assert(xmlDecoder(response.Body, &listResult, response.ContentLength), nil)
+	c.Assert(len(listResult.InventoryConfigurations), 1)
+	c.Assert(listResult.InventoryConfigurations[0].ID, "report1")
+
+	request, err = newTestSignedRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "docs/object"),
+		int64(len(data)), strings.NewReader(data), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// Generate the inventory reports of the configuration and of a
+	// configuration covering the whole bucket from one listing.
+	bucketConfig := *parsedConfig
+	bucketConfig.ID = "report2"
+	bucketConfig.Filter = nil
+	objAPI := newObjectLayerFn()
+	created := UTCNow()
+	errs := generateInventoryReports(GlobalContext, objAPI, bucketName, []inventory.Config{*parsedConfig, bucketConfig}, created)
+	c.Assert(errs, []error{nil, nil})
+
+	var buf bytes.Buffer
+	err = objAPI.GetObject(GlobalContext, dstBucketName, inventory.ManifestObjectName(*parsedConfig, bucketName, created, inventory.ManifestName),
+		0, -1, &buf, "", ObjectOptions{})
+	c.Assert(err, nil)
+	var manifest inventory.Manifest
+	c.Assert(json.Unmarshal(buf.Bytes(), &manifest), nil)
+	c.Assert(manifest.SourceBucket, bucketName)
+	c.Assert(manifest.FileSchema, "Bucket, Key, Size, ETag")
+	c.Assert(len(manifest.Files), 1)
+
+	buf.Reset()
+	err = objAPI.GetObject(GlobalContext, dstBucketName, manifest.Files[0].Key, 0, -1, &buf, "", ObjectOptions{})
+	c.Assert(err, nil)
+	gzipReader, err := gzip.NewReader(&buf)
+	c.Assert(err, nil)
+	records, err := ioutil.ReadAll(gzipReader)
+	c.Assert(err, nil)
+	if !strings.HasPrefix(string(records), bucketName+",photos%2Fobject,11,") || strings.Count(string(records), "\n") != 1 {
+		c.Fatalf("unexpected inventory records %s", records)
+	}
+
+	buf.Reset()
+	err = objAPI.GetObject(GlobalContext, dstBucketName, inventory.ManifestObjectName(bucketConfig, bucketName, created, inventory.ManifestName),
+		0, -1, &buf, "", ObjectOptions{})
+	c.Assert(err, nil)
+	manifest = inventory.Manifest{}
+	c.Assert(json.Unmarshal(buf.Bytes(), &manifest), nil)
+	c.Assert(len(manifest.Files), 1)
+
+	buf.Reset()
+	err = objAPI.GetObject(GlobalContext, dstBucketName, manifest.Files[0].Key, 0, -1, &buf, "", ObjectOptions{})
+	c.Assert(err, nil)
+	gzipReader, err = gzip.NewReader(&buf)
+	c.Assert(err, nil)
+	records, err = ioutil.ReadAll(gzipReader)
+	c.Assert(err, nil)
+	if strings.Count(string(records), "\n") != 2 {
+		c.Fatalf("unexpected inventory records %s", records)
+	}
+
+	request, err = newTestSignedRequest(http.MethodDelete, getBucketInventoryURL(s.endPoint, bucketName, "report1"),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNoContent)
+
+	request, err = newTestSignedRequest(http.MethodDelete, getBucketInventoryURL(s.endPoint, bucketName, "report1"),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	verifyError(c, response, "NoSuchConfiguration", "The specified configuration does not exist.", http.StatusNotFound)
+}
+
 func (s *TestSuiteCommon) TestObjectDir(c *check) {
 	bucketName := getRandomBucketName()
 	// HTTP request to create the bucket.
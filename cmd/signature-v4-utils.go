@@ -54,8 +54,9 @@ func skipContentSha256Cksum(r *http.Request) bool {
 	}
 
 	// If x-amz-content-sha256 is set and the value is not
-	// 'UNSIGNED-PAYLOAD' we should validate the content sha256.
-	return !(ok && v[0] != unsignedPayload)
+	// 'UNSIGNED-PAYLOAD' or 'STREAMING-UNSIGNED-PAYLOAD-TRAILER'
+	// we should validate the content sha256.
+	return !(ok && v[0] != unsignedPayload && v[0] != streamingUnsignedTrailer)
 }
 
 // Returns SHA256 for calculating canonical-request.
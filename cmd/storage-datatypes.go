@@ -146,6 +146,10 @@ type FileInfo struct {
 	MarkDeleted                   bool // mark this version as deleted
 	DeleteMarkerReplicationStatus string
 	VersionPurgeStatus            VersionPurgeStatusType
+
+	// Data of the erasure shard on this disk, set only
+	// for objects stored inline in their metadata.
+	Data []byte
 }
 
 // VersionPurgeStatusKey denotes purge status in metadata
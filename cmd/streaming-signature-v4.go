@@ -36,10 +36,16 @@ import (
 
 // Streaming AWS Signature Version '4' constants.
 const (
-	emptySHA256              = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
-	streamingContentSHA256   = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
-	signV4ChunkedAlgorithm   = "AWS4-HMAC-SHA256-PAYLOAD"
-	streamingContentEncoding = "aws-chunked"
+	emptySHA256                   = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	streamingContentSHA256        = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+	streamingContentSHA256Trailer = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER"
+	streamingUnsignedTrailer      = "STREAMING-UNSIGNED-PAYLOAD-TRAILER"
+	signV4ChunkedAlgorithm        = "AWS4-HMAC-SHA256-PAYLOAD"
+	signV4TrailerAlgorithm        = "AWS4-HMAC-SHA256-TRAILER"
+	streamingContentEncoding      = "aws-chunked"
+
+	// Trailer header carrying the signature of the trailer.
+	trailerSignatureHeader = "x-amz-trailer-signature"
 )
 
 // getChunkSignature - get chunk signature.
@@ -61,6 +67,22 @@ func getChunkSignature(cred auth.Credentials, seedSignature string, region strin
 	return newSignature
 }
 
+// getTrailerSignature - get the signature of the trailer sent after
+// the last chunk, trailer holds the trailer lines as name:value\n.
+func getTrailerSignature(cred auth.Credentials, seedSignature string, region string, date time.Time, trailer []byte) string {
+	// Calculate string to sign.
+	stringToSign := signV4TrailerAlgorithm + "\n" +
+		date.Format(iso8601Format) + "\n" +
+		getScope(date, region) + "\n" +
+		seedSignature + "\n" +
+		getSHA256Hash(trailer)
+
+	// Get hmac signing key.
+	signingKey := getSigningKey(cred.SecretKey, date, region, serviceS3)
+
+	return getSignature(signingKey, stringToSign)
+}
+
 // calculateSeedSignature - Calculate seed signature in accordance with
 //     - http://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-streaming.html
 // returns signature, error otherwise if the signature mismatches or any other
@@ -79,10 +101,11 @@ func calculateSeedSignature(r *http.Request) (cred auth.Credentials, signature s
 	}
 
 	// Payload streaming.
-	payload := streamingContentSHA256
+	payload := req.Header.Get(xhttp.AmzContentSha256)
 
 	// Payload for STREAMING signature should be 'STREAMING-AWS4-HMAC-SHA256-PAYLOAD'
-	if payload != req.Header.Get(xhttp.AmzContentSha256) {
+	// or 'STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER'
+	if payload != streamingContentSHA256 && payload != streamingContentSHA256Trailer {
 		return cred, "", "", time.Time{}, ErrContentSHA256Mismatch
 	}
 
@@ -167,9 +190,34 @@ func newSignV4ChunkedReader(req *http.Request) (io.ReadCloser, APIErrorCode) {
 		region:            region,
 		chunkSHA256Writer: sha256.New(),
 		state:             readChunkHeader,
+		trailer:           requestTrailer(req),
 	}, ErrNone
 }
 
+// newUnsignedV4ChunkedReader returns a new s3ChunkedReader decoding
+// the unsigned "aws-chunked" payload of a request with a trailing
+// checksum, the request itself is signed.
+func newUnsignedV4ChunkedReader(req *http.Request) io.ReadCloser {
+	return &s3ChunkedReader{
+		reader:   bufio.NewReader(req.Body),
+		unsigned: true,
+		state:    readChunkHeader,
+		trailer:  requestTrailer(req),
+	}
+}
+
+// requestTrailer - returns the trailer of the request to store the
+// trailer sent after the last chunk, nil if the request has no trailer.
+func requestTrailer(req *http.Request) http.Header {
+	if req.Header.Get(xhttp.AmzTrailer) == "" {
+		return nil
+	}
+	if req.Trailer == nil {
+		req.Trailer = make(http.Header)
+	}
+	return req.Trailer
+}
+
 // Represents the overall state that is required for decoding a
 // AWS Signature V4 chunked reader.
 type s3ChunkedReader struct {
@@ -184,6 +232,9 @@ type s3ChunkedReader struct {
 	chunkSHA256Writer hash.Hash // Calculates sha256 of chunk data.
 	n                 uint64    // Unread bytes in chunk
 	err               error
+
+	unsigned bool        // Chunks are not signed.
+	trailer  http.Header // Trailer sent after the last chunk, if any.
 }
 
 // Read chunk reads the chunk token signature portion.
@@ -213,6 +264,7 @@ const (
 	readChunkTrailer
 	readChunk
 	verifyChunk
+	readTrailer
 	eofChunk
 )
 
@@ -227,6 +279,8 @@ func (cs chunkState) String() string {
 		stateString = "readChunk"
 	case verifyChunk:
 		stateString = "verifyChunk"
+	case readTrailer:
+		stateString = "readTrailer"
 	case eofChunk:
 		stateString = "eofChunk"
 
@@ -256,9 +310,12 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 			}
 			cr.state = readChunk
 		case readChunkTrailer:
-			cr.err = readCRLF(cr.reader)
-			if cr.err != nil {
-				return 0, errMalformedEncoding
+			// The trailer directly follows the last chunk.
+			if !cr.lastChunk || cr.trailer == nil {
+				cr.err = readCRLF(cr.reader)
+				if cr.err != nil {
+					return 0, errMalformedEncoding
+				}
 			}
 			cr.state = verifyChunk
 		case readChunk:
@@ -282,8 +339,10 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 				return 0, cr.err
 			}
 
-			// Calculate sha256.
-			cr.chunkSHA256Writer.Write(rbuf[:n0])
+			// Calculate sha256 of signed chunks.
+			if !cr.unsigned {
+				cr.chunkSHA256Writer.Write(rbuf[:n0])
+			}
 			// Update the bytes read into request buffer so far.
 			n += n0
 			buf = buf[n0:]
@@ -296,6 +355,16 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 				continue
 			}
 		case verifyChunk:
+			if cr.unsigned {
+				cr.state = readChunkHeader
+				if cr.lastChunk {
+					cr.state = eofChunk
+					if cr.trailer != nil {
+						cr.state = readTrailer
+					}
+				}
+				continue
+			}
 			// Calculate the hashed chunk.
 			hashedChunk := hex.EncodeToString(cr.chunkSHA256Writer.Sum(nil))
 			// Calculate the chunk signature.
@@ -311,15 +380,69 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 			cr.chunkSHA256Writer.Reset()
 			if cr.lastChunk {
 				cr.state = eofChunk
+				if cr.trailer != nil {
+					cr.state = readTrailer
+				}
 			} else {
 				cr.state = readChunkHeader
 			}
+		case readTrailer:
+			if cr.err = cr.readS3Trailer(); cr.err != nil {
+				return 0, cr.err
+			}
+			cr.state = eofChunk
 		case eofChunk:
 			return n, io.EOF
 		}
 	}
 }
 
+// readS3Trailer - reads the trailer lines following the last chunk
+// until an empty line, and verifies the trailer signature of signed
+// payloads.
+func (cr *s3ChunkedReader) readS3Trailer() error {
+	var trailer bytes.Buffer
+	var signature string
+	for {
+		line, err := cr.reader.ReadSlice('\n')
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			} else if err == bufio.ErrBufferFull {
+				err = errLineTooLong
+			}
+			return err
+		}
+		line = trimTrailingWhitespace(line)
+		if len(line) == 0 {
+			break
+		}
+		if trailer.Len()+len(line) >= maxLineLength {
+			return errLineTooLong
+		}
+		i := bytes.IndexByte(line, ':')
+		if i <= 0 {
+			return errMalformedEncoding
+		}
+		name, value := string(line[:i]), string(line[i+1:])
+		if http.CanonicalHeaderKey(name) == http.CanonicalHeaderKey(trailerSignatureHeader) {
+			signature = value
+			continue
+		}
+		cr.trailer.Set(name, value)
+		trailer.Write(line)
+		trailer.WriteByte('\n')
+	}
+	if cr.unsigned {
+		return nil
+	}
+	newSignature := getTrailerSignature(cr.cred, cr.seedSignature, cr.region, cr.seedDate, trailer.Bytes())
+	if !compareSignatureV4(signature, newSignature) {
+		return errSignatureMismatch
+	}
+	return nil
+}
+
 // readCRLF - check if reader only has '\r\n' CRLF character.
 // returns malformed encoding if it doesn't.
 func readCRLF(reader io.Reader) error {
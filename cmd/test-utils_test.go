@@ -58,6 +58,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/s3utils"
 	"github.com/minio/minio-go/v7/pkg/signer"
 	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/config/storageclass"
 	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
@@ -458,6 +459,47 @@ func resetGlobalIAMSys() {
 	globalIAMSys = nil
 }
 
+// resets the storage class configuration loaded by the server config.
+func resetGlobalStorageClass() {
+	globalStorageClass = storageclass.Config{}
+}
+
+// setTestStorageClass sets the storage class configuration for the
+// duration of the test, whatever was loaded by the previous tests.
+func setTestStorageClass(t *testing.T, cfg storageclass.Config) {
+	t.Helper()
+	restore := globalStorageClass
+	t.Cleanup(func() {
+		globalStorageClass = restore
+	})
+	globalStorageClass = cfg
+}
+
+// prepareErasurePools returns an object layer of nPools pools with a
+// single 4 drive set each, using the default parity and no inlining
+// whatever the storage class loaded by the previous tests. The drives
+// are removed once the test is done.
+func prepareErasurePools(ctx context.Context, t *testing.T, nPools int) *erasureServerPools {
+	t.Helper()
+	setTestStorageClass(t, storageclass.Config{})
+
+	var pools EndpointServerPools
+	for i := 0; i < nPools; i++ {
+		disks, err := getRandomDisks(4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { removeRoots(disks) })
+		pools = append(pools, mustGetZoneEndpoints(disks...)...)
+	}
+
+	obj, _, err := initObjectLayer(ctx, pools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return obj.(*erasureServerPools)
+}
+
 // Resets all the globals used modified in tests.
 // Resetting ensures that the changes made to globals by one test doesn't affect others.
 func resetTestGlobals() {
@@ -475,6 +517,8 @@ func resetTestGlobals() {
 	resetGlobalHealState()
 	// Reset globalIAMSys to `nil`
 	resetGlobalIAMSys()
+	// Reset the storage class configuration.
+	resetGlobalStorageClass()
 }
 
 // Configure the server for the test run.
@@ -1330,6 +1374,13 @@ func getPutObjectURL(endPoint, bucketName, objectName string) string {
 	return makeTestTargetURL(endPoint, bucketName, objectName, url.Values{})
 }
 
+// return URL for fetching the attributes of an object.
+func getObjectAttributesURL(endPoint, bucketName, objectName string) string {
+	queryValues := url.Values{}
+	queryValues.Set("attributes", "")
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValues)
+}
+
 func getPutObjectPartURL(endPoint, bucketName, objectName, uploadID, partNumber string) string {
 	queryValues := url.Values{}
 	queryValues.Set("uploadId", uploadID)
@@ -1441,6 +1492,66 @@ func getBucketLifecycleURL(endPoint, bucketName string) (ret string) {
 	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
 }
 
+// return URL For set/get CORS configuration of the bucket.
+func getBucketCorsURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("cors", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for the website configuration of the bucket.
+func getBucketWebsiteURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("website", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for the logging status of the bucket.
+func getBucketLoggingURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("logging", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for the ACL of the bucket.
+func getBucketACLURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("acl", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for the ACL of the object.
+func getObjectACLURL(endPoint, bucketName, objectName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("acl", "")
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValue)
+}
+
+// return URL for an inventory configuration of the bucket, all
+// inventory configurations are listed if id is empty.
+func getBucketInventoryURL(endPoint, bucketName, id string) string {
+	queryValue := url.Values{}
+	queryValue.Set("inventory", "")
+	if id != "" {
+		queryValue.Set("id", id)
+	}
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for the public access block configuration of the bucket.
+func getBucketPublicAccessBlockURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("publicAccessBlock", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for the ownership controls of the bucket.
+func getBucketOwnershipControlsURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("ownershipControls", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
 // return URL for listing objects in the bucket with V1 legacy API.
 func getListObjectsV1URL(endPoint, bucketName, prefix, maxKeys, encodingType string) string {
 	queryValue := url.Values{}
@@ -2392,7 +2503,7 @@ func uploadTestObject(t *testing.T, apiRouter http.Handler, creds auth.Credentia
 				if etag == "" {
 					t.Fatalf("Unexpected empty etag")
 				}
-				cp = append(cp, CompletePart{partID, etag[1 : len(etag)-1]})
+				cp = append(cp, CompletePart{PartNumber: partID, ETag: etag[1 : len(etag)-1]})
 			} else {
 				t.Fatalf("Missing etag header")
 			}
@@ -1181,7 +1181,11 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := enforceBucketQuota(ctx, bucket, size); err != nil {
+	if err := enforceBucketQuota(ctx, bucket, object, size); err != nil {
+		writeWebErrorResponse(w, err)
+		return
+	}
+	if err := enforceIAMQuota(r, size); err != nil {
 		writeWebErrorResponse(w, err)
 		return
 	}
@@ -1192,6 +1196,7 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	setObjectWriterMetadata(r, bucket, metadata)
 
 	var pReader *PutObjReader
 	var reader io.Reader = r.Body
@@ -2048,6 +2053,12 @@ func (web *webAPIHandlers) SetBucketPolicy(r *http.Request, args *SetBucketPolic
 			return toJSONError(ctx, err, args.BucketName)
 		}
 
+		// Reject policies granting access to everyone if public policies
+		// are blocked for the bucket.
+		if bucketPolicy.IsPublic() && getPublicAccessBlock(args.BucketName).BlockPublicPolicy {
+			return toJSONError(ctx, errAccessDenied)
+		}
+
 		configData, err := json.Marshal(bucketPolicy)
 		if err != nil {
 			return toJSONError(ctx, err, args.BucketName)
@@ -27,6 +27,7 @@ import (
 	"github.com/google/uuid"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/lifecycle"
 )
 
 var (
@@ -35,6 +36,11 @@ var (
 
 	// XLv2 version 1
 	xlVersionV1 = [4]byte{'1', ' ', ' ', ' '}
+
+	// XLv2 version 2, metadata with versions storing their
+	// data inline. Servers reading version 1 only refuse it
+	// instead of looking for the data dir of these versions.
+	xlVersionV2 = [4]byte{'2', ' ', ' ', ' '}
 )
 
 func checkXL2V1(buf []byte) error {
@@ -46,8 +52,8 @@ func checkXL2V1(buf []byte) error {
 		return fmt.Errorf("xlMeta: unknown XLv2 header, expected %v, got %v", xlHeader[:4], buf[:4])
 	}
 
-	if !bytes.Equal(buf[4:8], xlVersionV1[:]) {
-		return fmt.Errorf("xlMeta: unknown XLv2 version, expected %v, got %v", xlVersionV1[:4], buf[4:8])
+	if !bytes.Equal(buf[4:8], xlVersionV1[:]) && !bytes.Equal(buf[4:8], xlVersionV2[:]) {
+		return fmt.Errorf("xlMeta: unknown XLv2 version, expected %v or %v, got %v", xlVersionV1[:4], xlVersionV2[:4], buf[4:8])
 	}
 
 	return nil
@@ -82,6 +88,9 @@ func isXL2V1Format(buf []byte) bool {
 //         ├── legacy
 //         │   └── part.1
 //         └── xl.meta
+//
+// Versions of small objects have no data dir, their erasure
+// shard is stored inline as part of the version in xl.meta.
 
 //go:generate msgp -file=$GOFILE -unexported
 
@@ -163,6 +172,14 @@ type xlMetaV2Object struct {
 	ModTime            int64             `json:"MTime" msg:"MTime"`                               // Object version modified time
 	MetaSys            map[string][]byte `json:"MetaSys,omitempty" msg:"MetaSys,omitempty"`       // Object version internal metadata
 	MetaUser           map[string]string `json:"MetaUsr,omitempty" msg:"MetaUsr,omitempty"`       // Object version metadata set by user
+	Data               []byte            `json:"Data,omitempty" msg:"Data,omitempty"`             // Object version inline data, if no data dir
+}
+
+// inlineData returns true if the erasure shard of the version
+// is stored inline, such versions have no data dir.
+func (j xlMetaV2Object) inlineData() bool {
+	var dd uuid.UUID
+	return bytes.Equal(j.DataDir[:], dd[:])
 }
 
 // xlMetaV2Version describes the jouranal entry, Type defines
@@ -227,6 +244,52 @@ func (z *xlMetaV2) Load(buf []byte) error {
 	return err
 }
 
+// AppendTo appends the header and the message pack of the metadata to
+// dst, version 2 of the header is used only if a version has inline data.
+func (z *xlMetaV2) AppendTo(dst []byte) ([]byte, error) {
+	version := xlVersionV1
+	if z.hasInlineData() {
+		version = xlVersionV2
+	}
+	dst = append(dst, xlHeader[:]...)
+	dst = append(dst, version[:]...)
+	return z.MarshalMsg(dst)
+}
+
+// hasInlineData returns true if a version stores its data inline.
+func (z *xlMetaV2) hasInlineData() bool {
+	for _, version := range z.Versions {
+		if version.Type == ObjectType && version.ObjectV2.Data != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// xlMetaV2TrimData returns the metadata in buf without the inline data
+// of its versions, for the metadata returned by listings. The metadata
+// is returned as is if it has no inline data.
+func xlMetaV2TrimData(buf []byte) []byte {
+	if len(buf) < 8 || !bytes.Equal(buf[4:8], xlVersionV2[:]) {
+		// Only version 2 has inline data.
+		return buf
+	}
+	var xlMeta xlMetaV2
+	if err := xlMeta.Load(buf); err != nil {
+		return buf
+	}
+	for i := range xlMeta.Versions {
+		if xlMeta.Versions[i].Type == ObjectType {
+			xlMeta.Versions[i].ObjectV2.Data = nil
+		}
+	}
+	trimmed, err := xlMeta.AppendTo(nil)
+	if err != nil {
+		return buf
+	}
+	return trimmed
+}
+
 // AddVersion adds a new version
 func (z *xlMetaV2) AddVersion(fi FileInfo) error {
 	if fi.VersionID == "" {
@@ -281,6 +344,7 @@ func (z *xlMetaV2) AddVersion(fi FileInfo) error {
 			PartActualSizes:    make([]int64, len(fi.Parts)),
 			MetaSys:            make(map[string][]byte),
 			MetaUser:           make(map[string]string, len(fi.Metadata)),
+			Data:               fi.Data,
 		}
 
 		for i := range fi.Erasure.Distribution {
@@ -324,6 +388,11 @@ func (z *xlMetaV2) AddVersion(fi FileInfo) error {
 			}
 		case ObjectType:
 			if bytes.Equal(version.ObjectV2.VersionID[:], uv[:]) {
+				// Metadata updates of an inlined version may
+				// not carry its data, preserve it.
+				if ventry.Type == ObjectType && ventry.ObjectV2.inlineData() && version.ObjectV2.inlineData() && ventry.ObjectV2.Data == nil {
+					ventry.ObjectV2.Data = version.ObjectV2.Data
+				}
 				z.Versions[i] = ventry
 				return nil
 			}
@@ -363,6 +432,9 @@ func (j xlMetaV2DeleteMarker) ToFileInfo(volume, path string) (FileInfo, error)
 		DeleteMarkerReplicationStatus: string(j.MetaSys[xhttp.AmzBucketReplicationStatus]),
 		VersionPurgeStatus:            VersionPurgeStatusType(string(j.MetaSys[VersionPurgeStatusKey])),
 	}
+	if st, ok := j.MetaSys[targetReplicationStatusKey]; ok {
+		fi.Metadata = map[string]string{targetReplicationStatusKey: string(st)}
+	}
 	return fi, nil
 }
 
@@ -424,7 +496,10 @@ func (j xlMetaV2Object) ToFileInfo(volume, path string) (FileInfo, error) {
 	for i := range j.ErasureDist {
 		fi.Erasure.Distribution[i] = int(j.ErasureDist[i])
 	}
-	fi.DataDir = uuid.UUID(j.DataDir).String()
+	if !j.inlineData() {
+		fi.DataDir = uuid.UUID(j.DataDir).String()
+	}
+	fi.Data = j.Data
 
 	if st, ok := j.MetaSys[ReservedMetadataPrefixLower+"transition-status"]; ok {
 		fi.TransitionStatus = string(st)
@@ -489,6 +564,9 @@ func (z *xlMetaV2) DeleteVersion(fi FileInfo) (string, bool, error) {
 		if !fi.VersionPurgeStatus.Empty() {
 			ventry.DeleteMarker.MetaSys[VersionPurgeStatusKey] = []byte(fi.VersionPurgeStatus)
 		}
+		if st, ok := fi.Metadata[targetReplicationStatusKey]; ok {
+			ventry.DeleteMarker.MetaSys[targetReplicationStatusKey] = []byte(st)
+		}
 	}
 
 	for i, version := range z.Versions {
@@ -517,12 +595,16 @@ func (z *xlMetaV2) DeleteVersion(fi FileInfo) (string, bool, error) {
 					}
 					delete(z.Versions[i].DeleteMarker.MetaSys, xhttp.AmzBucketReplicationStatus)
 					delete(z.Versions[i].DeleteMarker.MetaSys, VersionPurgeStatusKey)
+					delete(z.Versions[i].DeleteMarker.MetaSys, targetReplicationStatusKey)
 					if fi.DeleteMarkerReplicationStatus != "" {
 						z.Versions[i].DeleteMarker.MetaSys[xhttp.AmzBucketReplicationStatus] = []byte(fi.DeleteMarkerReplicationStatus)
 					}
 					if !fi.VersionPurgeStatus.Empty() {
 						z.Versions[i].DeleteMarker.MetaSys[VersionPurgeStatusKey] = []byte(fi.VersionPurgeStatus)
 					}
+					if st, ok := fi.Metadata[targetReplicationStatusKey]; ok {
+						z.Versions[i].DeleteMarker.MetaSys[targetReplicationStatusKey] = []byte(st)
+					}
 				} else {
 					z.Versions = append(z.Versions[:i], z.Versions[i+1:]...)
 					if fi.MarkDeleted && (fi.VersionPurgeStatus.Empty() || (fi.VersionPurgeStatus != Complete)) {
@@ -534,6 +616,9 @@ func (z *xlMetaV2) DeleteVersion(fi FileInfo) (string, bool, error) {
 		case ObjectType:
 			if bytes.Equal(version.ObjectV2.VersionID[:], uv[:]) && updateVersion {
 				z.Versions[i].ObjectV2.MetaSys[VersionPurgeStatusKey] = []byte(fi.VersionPurgeStatus)
+				if st, ok := fi.Metadata[targetReplicationStatusKey]; ok {
+					z.Versions[i].ObjectV2.MetaSys[targetReplicationStatusKey] = []byte(st)
+				}
 				return "", len(z.Versions) == 0, nil
 			}
 		}
@@ -561,9 +646,24 @@ func (z *xlMetaV2) DeleteVersion(fi FileInfo) (string, bool, error) {
 			if bytes.Equal(version.ObjectV2.VersionID[:], uv[:]) {
 				if fi.TransitionStatus != "" {
 					z.Versions[i].ObjectV2.MetaSys[ReservedMetadataPrefixLower+"transition-status"] = []byte(fi.TransitionStatus)
+					if version.ObjectV2.inlineData() {
+						// The inline data is gone once the
+						// transition is complete.
+						if fi.TransitionStatus == lifecycle.TransitionComplete {
+							z.Versions[i].ObjectV2.Data = nil
+						}
+						return "", len(z.Versions) == 0, nil
+					}
 					return uuid.UUID(version.ObjectV2.DataDir).String(), len(z.Versions) == 0, nil
 				}
 				z.Versions = append(z.Versions[:i], z.Versions[i+1:]...)
+				if version.ObjectV2.inlineData() {
+					if fi.Deleted {
+						z.Versions = append(z.Versions, ventry)
+					}
+					// Inlined versions have no data dir to remove.
+					return "", len(z.Versions) == 0, nil
+				}
 				if findDataDir(version.ObjectV2.DataDir, z.Versions) > 0 {
 					if fi.Deleted {
 						z.Versions = append(z.Versions, ventry)
@@ -801,6 +801,12 @@ func (z *xlMetaV2Object) DecodeMsg(dc *msgp.Reader) (err error) {
 				}
 				z.MetaUser[za0010] = za0011
 			}
+		case "Data":
+			z.Data, err = dc.ReadBytes(z.Data)
+			if err != nil {
+				err = msgp.WrapError(err, "Data")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -815,8 +821,8 @@ func (z *xlMetaV2Object) DecodeMsg(dc *msgp.Reader) (err error) {
 // EncodeMsg implements msgp.Encodable
 func (z *xlMetaV2Object) EncodeMsg(en *msgp.Writer) (err error) {
 	// omitempty: check for empty values
-	zb0001Len := uint32(17)
-	var zb0001Mask uint32 /* 17 bits */
+	zb0001Len := uint32(18)
+	var zb0001Mask uint32 /* 18 bits */
 	if z.PartActualSizes == nil {
 		zb0001Len--
 		zb0001Mask |= 0x1000
@@ -829,6 +835,10 @@ func (z *xlMetaV2Object) EncodeMsg(en *msgp.Writer) (err error) {
 		zb0001Len--
 		zb0001Mask |= 0x10000
 	}
+	if z.Data == nil {
+		zb0001Len--
+		zb0001Mask |= 0x20000
+	}
 	// variable map header, size zb0001Len
 	err = en.WriteMapHeader(zb0001Len)
 	if err != nil {
@@ -1072,6 +1082,18 @@ func (z *xlMetaV2Object) EncodeMsg(en *msgp.Writer) (err error) {
 			}
 		}
 	}
+	if (zb0001Mask & 0x20000) == 0 { // if not empty
+		// write "Data"
+		err = en.Append(0xa4, 0x44, 0x61, 0x74, 0x61)
+		if err != nil {
+			return
+		}
+		err = en.WriteBytes(z.Data)
+		if err != nil {
+			err = msgp.WrapError(err, "Data")
+			return
+		}
+	}
 	return
 }
 
@@ -1079,8 +1101,8 @@ func (z *xlMetaV2Object) EncodeMsg(en *msgp.Writer) (err error) {
 func (z *xlMetaV2Object) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
 	// omitempty: check for empty values
-	zb0001Len := uint32(17)
-	var zb0001Mask uint32 /* 17 bits */
+	zb0001Len := uint32(18)
+	var zb0001Mask uint32 /* 18 bits */
 	if z.PartActualSizes == nil {
 		zb0001Len--
 		zb0001Mask |= 0x1000
@@ -1093,6 +1115,10 @@ func (z *xlMetaV2Object) MarshalMsg(b []byte) (o []byte, err error) {
 		zb0001Len--
 		zb0001Mask |= 0x10000
 	}
+	if z.Data == nil {
+		zb0001Len--
+		zb0001Mask |= 0x20000
+	}
 	// variable map header, size zb0001Len
 	o = msgp.AppendMapHeader(o, zb0001Len)
 	if zb0001Len == 0 {
@@ -1178,6 +1204,11 @@ func (z *xlMetaV2Object) MarshalMsg(b []byte) (o []byte, err error) {
 			o = msgp.AppendString(o, za0011)
 		}
 	}
+	if (zb0001Mask & 0x20000) == 0 { // if not empty
+		// string "Data"
+		o = append(o, 0xa4, 0x44, 0x61, 0x74, 0x61)
+		o = msgp.AppendBytes(o, z.Data)
+	}
 	return
 }
 
@@ -1422,6 +1453,12 @@ func (z *xlMetaV2Object) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				}
 				z.MetaUser[za0010] = za0011
 			}
+		case "Data":
+			z.Data, bts, err = msgp.ReadBytesBytes(bts, z.Data)
+			if err != nil {
+				err = msgp.WrapError(err, "Data")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -1454,6 +1491,7 @@ func (z *xlMetaV2Object) Msgsize() (s int) {
 			s += msgp.StringPrefixSize + len(za0010) + msgp.StringPrefixSize + len(za0011)
 		}
 	}
+	s += 5 + msgp.BytesPrefixSize + len(z.Data)
 	return
 }
 
@@ -316,3 +316,106 @@ func TestGetPartSizeFromIdx(t *testing.T) {
 		}
 	}
 }
+
+func TestXLMetaV2InlineData(t *testing.T) {
+	fi := FileInfo{
+		VersionID: mustGetUUID(),
+		ModTime:   UTCNow(),
+		Size:      5,
+		Erasure: ErasureInfo{
+			DataBlocks:   2,
+			ParityBlocks: 2,
+			BlockSize:    blockSizeV1,
+			Index:        1,
+			Distribution: []int{1, 2, 3, 4},
+		},
+		Parts: []ObjectPartInfo{{Number: 1, Size: 5, ActualSize: 5}},
+		Data:  []byte("shard"),
+	}
+
+	load := func(z xlMetaV2) xlMetaV2 {
+		buf, err := z.AppendTo(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var loaded xlMetaV2
+		if err = loaded.Load(buf); err != nil {
+			t.Fatal(err)
+		}
+		return loaded
+	}
+
+	z, err := newXLMetaV2(fi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z = load(z)
+	got, err := z.ToFileInfo("bucket", "object", fi.VersionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.DataDir != "" || !got.InlineData() || !bytes.Equal(got.Data, fi.Data) {
+		t.Fatalf("expected inline data %q without data dir, got %q in %q", fi.Data, got.Data, got.DataDir)
+	}
+
+	// Metadata with inline data is written with version 2 of the
+	// header, listings get the metadata without the inline data.
+	buf, err := z.AppendTo(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf[4:8], xlVersionV2[:]) {
+		t.Fatalf("expected XLv2 version %v, got %v", xlVersionV2, buf[4:8])
+	}
+	trimmed := xlMetaV2TrimData(buf)
+	if !bytes.Equal(trimmed[4:8], xlVersionV1[:]) {
+		t.Fatalf("expected XLv2 version %v once trimmed, got %v", xlVersionV1, trimmed[4:8])
+	}
+	trimmedFi, err := getFileInfo(trimmed, "bucket", "object", fi.VersionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trimmedFi.Data != nil || trimmedFi.Size != fi.Size {
+		t.Fatalf("expected the size %d without the inline data, got %d and %q", fi.Size, trimmedFi.Size, trimmedFi.Data)
+	}
+
+	// Metadata updates without the data preserve it.
+	update := got
+	update.Data = nil
+	update.Metadata = map[string]string{"etag": "abcd"}
+	if err = z.AddVersion(update); err != nil {
+		t.Fatal(err)
+	}
+	z = load(z)
+	if got, err = z.ToFileInfo("bucket", "object", fi.VersionID); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.Data, fi.Data) || got.Metadata["etag"] != "abcd" {
+		t.Fatalf("expected inline data to be preserved, got %q", got.Data)
+	}
+
+	// Versions with a data dir carry no inline data.
+	other := fi
+	other.VersionID = mustGetUUID()
+	other.DataDir = mustGetUUID()
+	other.Data = nil
+	if err = z.AddVersion(other); err != nil {
+		t.Fatal(err)
+	}
+	z = load(z)
+	if got, err = z.ToFileInfo("bucket", "object", other.VersionID); err != nil {
+		t.Fatal(err)
+	}
+	if got.DataDir != other.DataDir || got.InlineData() || got.Data != nil {
+		t.Fatalf("expected data dir %s without inline data, got %q", other.DataDir, got.DataDir)
+	}
+
+	// Inlined versions have no data dir to remove.
+	dataDir, lastVersion, err := z.DeleteVersion(FileInfo{VersionID: fi.VersionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dataDir != "" || lastVersion {
+		t.Fatalf("expected no data dir to remove, got %q (last version %v)", dataDir, lastVersion)
+	}
+}
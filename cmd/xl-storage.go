@@ -411,6 +411,7 @@ func (s *xlStorage) CrawlAndGetDataUsage(ctx context.Context, cache dataUsageCac
 					}
 				}
 				totalSize += size
+				sizeS.addWriter(oi.UserDefined, size)
 			}
 			item.healReplication(ctx, objAPI, actionMeta{oi: version.ToObjectInfo(item.bucket, item.objectPath())}, &sizeS)
 		}
@@ -999,7 +1000,7 @@ func (s *xlStorage) DeleteVersion(ctx context.Context, volume, path string, fi F
 		return err
 	}
 
-	buf, err = xlMeta.MarshalMsg(append(xlHeader[:], xlVersionV1[:]...))
+	buf, err = xlMeta.AppendTo(nil)
 	if err != nil {
 		return err
 	}
@@ -1051,7 +1052,7 @@ func (s *xlStorage) WriteMetadata(ctx context.Context, volume, path string, fi F
 		if err != nil {
 			return err
 		}
-		buf, err = xlMeta.MarshalMsg(append(xlHeader[:], xlVersionV1[:]...))
+		buf, err = xlMeta.AppendTo(nil)
 		if err != nil {
 			return err
 		}
@@ -1062,7 +1063,7 @@ func (s *xlStorage) WriteMetadata(ctx context.Context, volume, path string, fi F
 		if err = xlMeta.AddVersion(fi); err != nil {
 			return err
 		}
-		buf, err = xlMeta.MarshalMsg(append(xlHeader[:], xlVersionV1[:]...))
+		buf, err = xlMeta.AppendTo(nil)
 		if err != nil {
 			return err
 		}
@@ -1760,6 +1761,13 @@ func (s *xlStorage) CheckParts(ctx context.Context, volume string, path string,
 	}
 
 	for _, part := range fi.Parts {
+		if fi.InlineData() {
+			// Check if the inline shard is truncated.
+			if int64(len(fi.Data)) < fi.Erasure.ShardFileSize(part.Size) {
+				return errFileCorrupt
+			}
+			continue
+		}
 		partPath := pathJoin(path, fi.DataDir, fmt.Sprintf("part.%d", part.Number))
 		if fi.XLV1 {
 			partPath = pathJoin(path, fmt.Sprintf("part.%d", part.Number))
@@ -2160,21 +2168,25 @@ func (s *xlStorage) RenameData(ctx context.Context, srcVolume, srcPath, dataDir,
 	}
 
 	var oldDstDataPath string
-	if fi.VersionID == "" {
+	versionID := fi.VersionID
+	if versionID == "" {
 		// return the latest "null" versionId info
-		ofi, err := xlMeta.ToFileInfo(dstVolume, dstPath, nullVersionID)
-		if err == nil && !ofi.Deleted {
-			// Purge the destination path as we are not preserving anything
-			// versioned object was not requested.
-			oldDstDataPath = pathJoin(dstVolumeDir, dstPath, ofi.DataDir)
-		}
+		versionID = nullVersionID
+	}
+	// A version is overwritten either as the "null" version or, when it
+	// is rewritten in place (re-stripe), with its own version id.
+	ofi, err := xlMeta.ToFileInfo(dstVolume, dstPath, versionID)
+	if err == nil && !ofi.Deleted && ofi.DataDir != "" && ofi.DataDir != fi.DataDir {
+		// Purge the destination path as we are not preserving anything
+		// of the overwritten version.
+		oldDstDataPath = pathJoin(dstVolumeDir, dstPath, ofi.DataDir)
 	}
 
 	if err = xlMeta.AddVersion(fi); err != nil {
 		return err
 	}
 
-	dstBuf, err = xlMeta.MarshalMsg(append(xlHeader[:], xlVersionV1[:]...))
+	dstBuf, err = xlMeta.AppendTo(nil)
 	if err != nil {
 		return errFileCorrupt
 	}
@@ -2183,9 +2195,14 @@ func (s *xlStorage) RenameData(ctx context.Context, srcVolume, srcPath, dataDir,
 		return err
 	}
 
-	// Commit data
-	if srcDataPath != "" {
+	// Remove the data of the overwritten version, there is none
+	// to remove if it was inlined.
+	if oldDstDataPath != "" {
 		removeAll(oldDstDataPath)
+	}
+
+	// Commit data, there is none to commit if it is inlined.
+	if srcDataPath != "" {
 		removeAll(dstDataPath)
 		if err = renameAll(srcDataPath, dstDataPath); err != nil {
 			return osErrToFileErr(err)
@@ -2322,9 +2339,6 @@ func (s *xlStorage) bitrotVerify(partPath string, partSize int64, algo BitrotAlg
 		return nil
 	}
 
-	buf := make([]byte, shardSize)
-	h := algo.New()
-	hashBuf := make([]byte, h.Size())
 	fi, err := file.Stat()
 	if err != nil {
 		// Unable to stat on the file, return an expected error
@@ -2332,40 +2346,7 @@ func (s *xlStorage) bitrotVerify(partPath string, partSize int64, algo BitrotAlg
 		return err
 	}
 
-	size := fi.Size()
-
-	// Calculate the size of the bitrot file and compare
-	// it with the actual file size.
-	if size != bitrotShardFileSize(partSize, shardSize, algo) {
-		return errFileCorrupt
-	}
-
-	var n int
-	for {
-		if size == 0 {
-			return nil
-		}
-		h.Reset()
-		n, err = file.Read(hashBuf)
-		if err != nil {
-			// Read's failed for object with right size, file is corrupt.
-			return err
-		}
-		size -= int64(n)
-		if size < int64(len(buf)) {
-			buf = buf[:size]
-		}
-		n, err = file.Read(buf)
-		if err != nil {
-			// Read's failed for object with right size, at different offsets.
-			return err
-		}
-		size -= int64(n)
-		h.Write(buf)
-		if !bytes.Equal(h.Sum(nil), hashBuf) {
-			return errFileCorrupt
-		}
-	}
+	return bitrotVerify(file, fi.Size(), partSize, algo, shardSize)
 }
 
 func (s *xlStorage) VerifyFile(ctx context.Context, volume, path string, fi FileInfo) (err error) {
@@ -2399,10 +2380,17 @@ func (s *xlStorage) VerifyFile(ctx context.Context, volume, path string, fi File
 		if fi.XLV1 {
 			partPath = pathJoin(volumeDir, path, fmt.Sprintf("part.%d", part.Number))
 		}
-		if err := s.bitrotVerify(partPath,
-			erasure.ShardFileSize(part.Size),
-			checksumInfo.Algorithm,
-			checksumInfo.Hash, erasure.ShardSize()); err != nil {
+		if fi.InlineData() {
+			err = bitrotVerify(bytes.NewReader(fi.Data), int64(len(fi.Data)),
+				erasure.ShardFileSize(part.Size),
+				checksumInfo.Algorithm, erasure.ShardSize())
+		} else {
+			err = s.bitrotVerify(partPath,
+				erasure.ShardFileSize(part.Size),
+				checksumInfo.Algorithm,
+				checksumInfo.Hash, erasure.ShardSize())
+		}
+		if err != nil {
 			if !IsErr(err, []error{
 				errFileNotFound,
 				errVolumeNotFound,
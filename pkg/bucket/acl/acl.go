@@ -0,0 +1,252 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package acl
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// Maximum number of grants in an access control list.
+const maxGrants = 100
+
+// Permission - permission granted to a grantee.
+type Permission string
+
+// Supported permissions.
+const (
+	PermissionFullControl Permission = "FULL_CONTROL"
+	PermissionRead        Permission = "READ"
+	PermissionWrite       Permission = "WRITE"
+	PermissionReadACP     Permission = "READ_ACP"
+	PermissionWriteACP    Permission = "WRITE_ACP"
+)
+
+// IsValid - returns true if the permission is supported.
+func (p Permission) IsValid() bool {
+	switch p {
+	case PermissionFullControl, PermissionRead, PermissionWrite, PermissionReadACP, PermissionWriteACP:
+		return true
+	}
+	return false
+}
+
+// Grantee types.
+const (
+	GranteeCanonicalUser = "CanonicalUser"
+	GranteeGroup         = "Group"
+	GranteeEmail         = "AmazonCustomerByEmail"
+)
+
+// Predefined groups.
+const (
+	AllUsersGroup           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	AuthenticatedUsersGroup = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+	LogDeliveryGroup        = "http://acs.amazonaws.com/groups/s3/LogDelivery"
+)
+
+const xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// Owner - owner of a bucket or an object.
+type Owner struct {
+	ID          string `xml:"ID"`
+	DisplayName string `xml:"DisplayName,omitempty"`
+}
+
+// Grantee - the user or group a permission is granted to.
+type Grantee struct {
+	XMLNS        string `xml:"xmlns:xsi,attr"`
+	Type         string `xml:"xsi:type,attr"`
+	ID           string `xml:"ID,omitempty"`
+	DisplayName  string `xml:"DisplayName,omitempty"`
+	EmailAddress string `xml:"EmailAddress,omitempty"`
+	URI          string `xml:"URI,omitempty"`
+}
+
+// MarshalXML - encodes the grantee with its xsi:type attribute.
+func (g Grantee) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type granteeWrapper Grantee
+	g.XMLNS = xsiNamespace
+	return e.EncodeElement(granteeWrapper(g), start)
+}
+
+// UnmarshalXML - decodes the grantee, the type of the grantee is
+// given by the xsi:type attribute.
+func (g *Grantee) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type granteeWrapper Grantee
+	var wrapper granteeWrapper
+	if err := d.DecodeElement(&wrapper, &start); err != nil {
+		return err
+	}
+	*g = Grantee(wrapper)
+	g.XMLNS = xsiNamespace
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "type" {
+			g.Type = attr.Value
+		}
+	}
+	return nil
+}
+
+// Validate - validates the grantee.
+func (g Grantee) Validate() error {
+	switch g.Type {
+	case GranteeCanonicalUser:
+		if g.ID == "" {
+			return Errorf("ID must be specified for grantees of type %s", g.Type)
+		}
+	case GranteeGroup:
+		switch g.URI {
+		case AllUsersGroup, AuthenticatedUsersGroup, LogDeliveryGroup:
+		default:
+			return Errorf("unknown group %s", g.URI)
+		}
+	case GranteeEmail:
+		return Errorf("grantees of type %s are not supported", g.Type)
+	default:
+		return Errorf("unknown grantee type %s", g.Type)
+	}
+	return nil
+}
+
+// Grant - a permission granted to a grantee.
+type Grant struct {
+	Grantee    Grantee    `xml:"Grantee"`
+	Permission Permission `xml:"Permission"`
+}
+
+// AccessControlList - list of grants.
+type AccessControlList struct {
+	Grants []Grant `xml:"Grant"`
+}
+
+// AccessControlPolicy - the owner and the access control list of a
+// bucket or an object.
+type AccessControlPolicy struct {
+	XMLNS             string            `xml:"xmlns,attr,omitempty"`
+	XMLName           xml.Name          `xml:"AccessControlPolicy"`
+	Owner             Owner             `xml:"Owner"`
+	AccessControlList AccessControlList `xml:"AccessControlList"`
+}
+
+// Validate - validates the access control policy.
+func (p AccessControlPolicy) Validate() error {
+	if p.Owner.ID == "" {
+		return Errorf("Owner ID must be specified")
+	}
+	if len(p.AccessControlList.Grants) > maxGrants {
+		return Errorf("access control list can not have more than %d grants", maxGrants)
+	}
+	for _, grant := range p.AccessControlList.Grants {
+		if !grant.Permission.IsValid() {
+			return Errorf("unknown permission %s", grant.Permission)
+		}
+		if err := grant.Grantee.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsPrivate - returns true if no one but the owner is granted access.
+func (p AccessControlPolicy) IsPrivate() bool {
+	for _, grant := range p.AccessControlList.Grants {
+		if grant.Grantee.Type != GranteeCanonicalUser || grant.Grantee.ID != p.Owner.ID {
+			return false
+		}
+	}
+	return true
+}
+
+// isPublicGrant - returns true if the grant grants access to all or to
+// all authenticated users.
+func isPublicGrant(grant Grant) bool {
+	if grant.Grantee.Type != GranteeGroup {
+		return false
+	}
+	return grant.Grantee.URI == AllUsersGroup || grant.Grantee.URI == AuthenticatedUsersGroup
+}
+
+// IsPublic - returns true if access is granted to all or to all
+// authenticated users.
+func (p AccessControlPolicy) IsPublic() bool {
+	for _, grant := range p.AccessControlList.Grants {
+		if isPublicGrant(grant) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithoutPublicGrants - returns a copy of the policy without the grants
+// to all or to all authenticated users.
+func (p AccessControlPolicy) WithoutPublicGrants() AccessControlPolicy {
+	grants := make([]Grant, 0, len(p.AccessControlList.Grants))
+	for _, grant := range p.AccessControlList.Grants {
+		if !isPublicGrant(grant) {
+			grants = append(grants, grant)
+		}
+	}
+	p.AccessControlList.Grants = grants
+	return p
+}
+
+// IsAllowed - returns true if the permission is granted to the requester
+// identified by the canonical user id, an empty id identifies anonymous
+// requests. The owner is always allowed to read and write the policy.
+func (p AccessControlPolicy) IsAllowed(id string, permission Permission) bool {
+	if id != "" && id == p.Owner.ID {
+		switch permission {
+		case PermissionReadACP, PermissionWriteACP:
+			return true
+		}
+	}
+	for _, grant := range p.AccessControlList.Grants {
+		if grant.Permission != permission && grant.Permission != PermissionFullControl {
+			continue
+		}
+		switch grant.Grantee.Type {
+		case GranteeCanonicalUser:
+			if id != "" && grant.Grantee.ID == id {
+				return true
+			}
+		case GranteeGroup:
+			switch grant.Grantee.URI {
+			case AllUsersGroup:
+				return true
+			case AuthenticatedUsersGroup:
+				if id != "" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// ParseAccessControlPolicy - parses data in given reader to an access
+// control policy.
+func ParseAccessControlPolicy(reader io.Reader) (*AccessControlPolicy, error) {
+	var p AccessControlPolicy
+	if err := xml.NewDecoder(reader).Decode(&p); err != nil {
+		return nil, err
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
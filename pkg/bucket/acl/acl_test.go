@@ -0,0 +1,225 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package acl
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseAccessControlPolicy(t *testing.T) {
+	testCases := []struct {
+		inputXML   string
+		shouldPass bool
+		grants     int
+	}{
+		// 1. Canonical user and group grants.
+		{
+			inputXML: `<AccessControlPolicy xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Owner><ID>owner</ID></Owner><AccessControlList>` +
+				`<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CanonicalUser"><ID>owner</ID></Grantee><Permission>FULL_CONTROL</Permission></Grant>` +
+				`<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="Group"><URI>http://acs.amazonaws.com/groups/global/AllUsers</URI></Grantee><Permission>READ</Permission></Grant>` +
+				`</AccessControlList></AccessControlPolicy>`,
+			shouldPass: true,
+			grants:     2,
+		},
+		// 2. Missing owner.
+		{
+			inputXML:   `<AccessControlPolicy><AccessControlList></AccessControlList></AccessControlPolicy>`,
+			shouldPass: false,
+		},
+		// 3. Unknown permission.
+		{
+			inputXML: `<AccessControlPolicy><Owner><ID>owner</ID></Owner><AccessControlList>` +
+				`<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CanonicalUser"><ID>owner</ID></Grantee><Permission>DELETE</Permission></Grant>` +
+				`</AccessControlList></AccessControlPolicy>`,
+			shouldPass: false,
+		},
+		// 4. Unknown group.
+		{
+			inputXML: `<AccessControlPolicy><Owner><ID>owner</ID></Owner><AccessControlList>` +
+				`<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="Group"><URI>http://example.com/group</URI></Grantee><Permission>READ</Permission></Grant>` +
+				`</AccessControlList></AccessControlPolicy>`,
+			shouldPass: false,
+		},
+		// 5. Email grantees are not supported.
+		{
+			inputXML: `<AccessControlPolicy><Owner><ID>owner</ID></Owner><AccessControlList>` +
+				`<Grant><Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="AmazonCustomerByEmail"><EmailAddress>a@example.com</EmailAddress></Grantee><Permission>READ</Permission></Grant>` +
+				`</AccessControlList></AccessControlPolicy>`,
+			shouldPass: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		p, err := ParseAccessControlPolicy(strings.NewReader(tc.inputXML))
+		if tc.shouldPass && err != nil {
+			t.Errorf("Test %d: expected to pass, failed with %v", i+1, err)
+			continue
+		}
+		if !tc.shouldPass {
+			if err == nil {
+				t.Errorf("Test %d: expected to fail, but passed", i+1)
+			}
+			continue
+		}
+		if len(p.AccessControlList.Grants) != tc.grants {
+			t.Errorf("Test %d: expected %d grants, got %d", i+1, tc.grants, len(p.AccessControlList.Grants))
+		}
+	}
+}
+
+func TestAccessControlPolicyEncode(t *testing.T) {
+	p, err := Canned(CannedPublicRead, Owner{ID: "owner"}, Owner{ID: "owner"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := xml.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte(`xsi:type="Group"`)) {
+		t.Fatalf("expected xsi:type attribute, got %s", data)
+	}
+	decoded, err := ParseAccessControlPolicy(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.AccessControlList.Grants) != 2 || decoded.AccessControlList.Grants[1].Grantee.Type != GranteeGroup {
+		t.Fatalf("unexpected decoded policy %#v", decoded)
+	}
+}
+
+func TestIsAllowed(t *testing.T) {
+	owner := Owner{ID: "owner"}
+	bucketOwner := Owner{ID: "bucket-owner"}
+	testCases := []struct {
+		canned     string
+		id         string
+		permission Permission
+		allowed    bool
+	}{
+		{CannedPrivate, "owner", PermissionRead, true},
+		{CannedPrivate, "user", PermissionRead, false},
+		{CannedPrivate, "", PermissionRead, false},
+		{CannedPublicRead, "", PermissionRead, true},
+		{CannedPublicRead, "", PermissionWrite, false},
+		{CannedPublicReadWrite, "", PermissionWrite, true},
+		{CannedAuthenticatedRead, "", PermissionRead, false},
+		{CannedAuthenticatedRead, "user", PermissionRead, true},
+		{CannedBucketOwnerRead, "bucket-owner", PermissionRead, true},
+		{CannedBucketOwnerRead, "bucket-owner", PermissionReadACP, false},
+		{CannedBucketOwnerFullControl, "bucket-owner", PermissionWriteACP, true},
+	}
+
+	for i, tc := range testCases {
+		p, err := Canned(tc.canned, owner, bucketOwner)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if allowed := p.IsAllowed(tc.id, tc.permission); allowed != tc.allowed {
+			t.Errorf("Test %d: expected %v, got %v", i+1, tc.allowed, allowed)
+		}
+		if private := p.IsPrivate(); private != (tc.canned == CannedPrivate) {
+			t.Errorf("Test %d: expected private %v, got %v", i+1, tc.canned == CannedPrivate, private)
+		}
+	}
+}
+
+func TestIsPublic(t *testing.T) {
+	owner := Owner{ID: "owner"}
+	testCases := []struct {
+		canned string
+		public bool
+	}{
+		{CannedPrivate, false},
+		{CannedPublicRead, true},
+		{CannedPublicReadWrite, true},
+		{CannedAuthenticatedRead, true},
+		{CannedBucketOwnerFullControl, false},
+	}
+
+	for i, tc := range testCases {
+		p, err := Canned(tc.canned, owner, Owner{ID: "bucket-owner"})
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if public := p.IsPublic(); public != tc.public {
+			t.Errorf("Test %d: expected %v, got %v", i+1, tc.public, public)
+		}
+		withoutPublic := p.WithoutPublicGrants()
+		if withoutPublic.IsPublic() || withoutPublic.IsAllowed("", PermissionRead) {
+			t.Errorf("Test %d: expected public grants to be removed", i+1)
+		}
+		if !withoutPublic.IsAllowed("owner", PermissionFullControl) {
+			t.Errorf("Test %d: expected owner grant to be kept", i+1)
+		}
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	owner := Owner{ID: "owner"}
+	testCases := []struct {
+		header     http.Header
+		shouldPass bool
+		grants     int
+	}{
+		// 1. No ACL headers.
+		{http.Header{}, true, 0},
+		// 2. Canned ACL.
+		{http.Header{AmzACL: []string{CannedPublicRead}}, true, 2},
+		// 3. Unknown canned ACL.
+		{http.Header{AmzACL: []string{"public"}}, false, 0},
+		// 4. Explicit grants.
+		{http.Header{
+			AmzGrantRead:        []string{`uri="http://acs.amazonaws.com/groups/global/AllUsers", id="user"`},
+			AmzGrantFullControl: []string{`id="owner"`},
+		}, true, 3},
+		// 5. Canned ACL and explicit grants.
+		{http.Header{
+			AmzACL:       []string{CannedPrivate},
+			AmzGrantRead: []string{`id="user"`},
+		}, false, 0},
+		// 6. Malformed grantee.
+		{http.Header{AmzGrantRead: []string{`user`}}, false, 0},
+	}
+
+	for i, tc := range testCases {
+		p, err := ParseHeaders(tc.header, owner, owner)
+		if tc.shouldPass && err != nil {
+			t.Errorf("Test %d: expected to pass, failed with %v", i+1, err)
+			continue
+		}
+		if !tc.shouldPass {
+			if err == nil {
+				t.Errorf("Test %d: expected to fail, but passed", i+1)
+			}
+			continue
+		}
+		if tc.grants == 0 {
+			if p != nil {
+				t.Errorf("Test %d: expected no policy, got %#v", i+1, p)
+			}
+			continue
+		}
+		if len(p.AccessControlList.Grants) != tc.grants {
+			t.Errorf("Test %d: expected %d grants, got %d", i+1, tc.grants, len(p.AccessControlList.Grants))
+		}
+	}
+}
@@ -0,0 +1,188 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package acl
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Request headers setting the access control list.
+const (
+	AmzACL              = "X-Amz-Acl"
+	AmzGrantRead        = "X-Amz-Grant-Read"
+	AmzGrantWrite       = "X-Amz-Grant-Write"
+	AmzGrantReadACP     = "X-Amz-Grant-Read-Acp"
+	AmzGrantWriteACP    = "X-Amz-Grant-Write-Acp"
+	AmzGrantFullControl = "X-Amz-Grant-Full-Control"
+)
+
+// Canned access control lists.
+const (
+	CannedPrivate                = "private"
+	CannedPublicRead             = "public-read"
+	CannedPublicReadWrite        = "public-read-write"
+	CannedAuthenticatedRead      = "authenticated-read"
+	CannedBucketOwnerRead        = "bucket-owner-read"
+	CannedBucketOwnerFullControl = "bucket-owner-full-control"
+	CannedLogDeliveryWrite       = "log-delivery-write"
+)
+
+var grantHeaders = []struct {
+	header     string
+	permission Permission
+}{
+	{AmzGrantRead, PermissionRead},
+	{AmzGrantWrite, PermissionWrite},
+	{AmzGrantReadACP, PermissionReadACP},
+	{AmzGrantWriteACP, PermissionWriteACP},
+	{AmzGrantFullControl, PermissionFullControl},
+}
+
+// IsRequested - returns true if the access control list is set by
+// request headers.
+func IsRequested(h http.Header) bool {
+	if _, ok := h[AmzACL]; ok {
+		return true
+	}
+	for _, g := range grantHeaders {
+		if _, ok := h[g.header]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func ownerGrant(owner Owner, permission Permission) Grant {
+	return Grant{
+		Grantee: Grantee{
+			Type:        GranteeCanonicalUser,
+			ID:          owner.ID,
+			DisplayName: owner.DisplayName,
+		},
+		Permission: permission,
+	}
+}
+
+func groupGrant(uri string, permission Permission) Grant {
+	return Grant{
+		Grantee: Grantee{
+			Type: GranteeGroup,
+			URI:  uri,
+		},
+		Permission: permission,
+	}
+}
+
+// Canned - returns the access control policy of a canned access control
+// list, bucketOwner is the owner of the bucket the object is stored in
+// and is the same as owner for buckets.
+func Canned(name string, owner, bucketOwner Owner) (*AccessControlPolicy, error) {
+	p := &AccessControlPolicy{Owner: owner}
+	grants := []Grant{ownerGrant(owner, PermissionFullControl)}
+	switch name {
+	case CannedPrivate:
+	case CannedPublicRead:
+		grants = append(grants, groupGrant(AllUsersGroup, PermissionRead))
+	case CannedPublicReadWrite:
+		grants = append(grants, groupGrant(AllUsersGroup, PermissionRead),
+			groupGrant(AllUsersGroup, PermissionWrite))
+	case CannedAuthenticatedRead:
+		grants = append(grants, groupGrant(AuthenticatedUsersGroup, PermissionRead))
+	case CannedBucketOwnerRead:
+		if bucketOwner.ID != owner.ID {
+			grants = append(grants, ownerGrant(bucketOwner, PermissionRead))
+		}
+	case CannedBucketOwnerFullControl:
+		if bucketOwner.ID != owner.ID {
+			grants = append(grants, ownerGrant(bucketOwner, PermissionFullControl))
+		}
+	case CannedLogDeliveryWrite:
+		grants = append(grants, groupGrant(LogDeliveryGroup, PermissionWrite),
+			groupGrant(LogDeliveryGroup, PermissionReadACP))
+	default:
+		return nil, Errorf("unknown canned ACL %s", name)
+	}
+	p.AccessControlList.Grants = grants
+	return p, nil
+}
+
+// parseGrantHeader - parses the grantees of a grant header, grantees are
+// comma separated key value pairs such as id="...", uri="..." or
+// emailAddress="...".
+func parseGrantHeader(value string, permission Permission) ([]Grant, error) {
+	var grants []Grant
+	for _, grantee := range strings.Split(value, ",") {
+		grantee = strings.TrimSpace(grantee)
+		if grantee == "" {
+			continue
+		}
+		kv := strings.SplitN(grantee, "=", 2)
+		if len(kv) != 2 {
+			return nil, Errorf("invalid grantee %s", grantee)
+		}
+		key := strings.TrimSpace(kv[0])
+		val := strings.Trim(strings.TrimSpace(kv[1]), `"`)
+		grant := Grant{Permission: permission}
+		switch strings.ToLower(key) {
+		case "id":
+			grant.Grantee = Grantee{Type: GranteeCanonicalUser, ID: val}
+		case "uri":
+			grant.Grantee = Grantee{Type: GranteeGroup, URI: val}
+		case "emailaddress":
+			grant.Grantee = Grantee{Type: GranteeEmail, EmailAddress: val}
+		default:
+			return nil, Errorf("invalid grantee %s", grantee)
+		}
+		grants = append(grants, grant)
+	}
+	return grants, nil
+}
+
+// ParseHeaders - returns the access control policy set by the request
+// headers, either a canned access control list or explicit grants. nil
+// is returned if the request sets no access control list.
+func ParseHeaders(h http.Header, owner, bucketOwner Owner) (*AccessControlPolicy, error) {
+	if !IsRequested(h) {
+		return nil, nil
+	}
+
+	var grants []Grant
+	for _, g := range grantHeaders {
+		for _, value := range h[g.header] {
+			headerGrants, err := parseGrantHeader(value, g.permission)
+			if err != nil {
+				return nil, err
+			}
+			grants = append(grants, headerGrants...)
+		}
+	}
+
+	if _, ok := h[AmzACL]; ok {
+		if len(grants) > 0 {
+			return nil, Errorf("specifying both canned ACLs and header grants is not allowed")
+		}
+		return Canned(h.Get(AmzACL), owner, bucketOwner)
+	}
+
+	p := &AccessControlPolicy{Owner: owner}
+	p.AccessControlList.Grants = grants
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,186 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/minio/minio/pkg/wildcard"
+)
+
+const (
+	// Maximum number of CORS rules allowed per bucket.
+	maxRules = 100
+
+	// Maximum length of a rule ID.
+	maxRuleIDLength = 255
+)
+
+// Supported methods in a CORS rule, as per S3 spec.
+var supportedMethods = map[string]struct{}{
+	http.MethodGet:    {},
+	http.MethodPut:    {},
+	http.MethodHead:   {},
+	http.MethodPost:   {},
+	http.MethodDelete: {},
+}
+
+// Rule - a single CORS rule of a bucket CORS configuration.
+type Rule struct {
+	ID            string   `xml:"ID,omitempty"`
+	AllowedHeader []string `xml:"AllowedHeader,omitempty"`
+	AllowedMethod []string `xml:"AllowedMethod"`
+	AllowedOrigin []string `xml:"AllowedOrigin"`
+	ExposeHeader  []string `xml:"ExposeHeader,omitempty"`
+	MaxAgeSeconds int      `xml:"MaxAgeSeconds,omitempty"`
+}
+
+// Validate - validates the CORS rule.
+func (r Rule) Validate() error {
+	if len(r.ID) > maxRuleIDLength {
+		return Errorf("ID must be less than %d characters", maxRuleIDLength)
+	}
+	if len(r.AllowedMethod) == 0 {
+		return Errorf("at least one AllowedMethod must be specified")
+	}
+	for _, method := range r.AllowedMethod {
+		if _, ok := supportedMethods[method]; !ok {
+			return Errorf("unsupported AllowedMethod %s", method)
+		}
+	}
+	if len(r.AllowedOrigin) == 0 {
+		return Errorf("at least one AllowedOrigin must be specified")
+	}
+	for _, origin := range r.AllowedOrigin {
+		if strings.Count(origin, "*") > 1 {
+			return Errorf("AllowedOrigin %s can not have more than one wildcard", origin)
+		}
+	}
+	for _, header := range r.AllowedHeader {
+		if strings.Count(header, "*") > 1 {
+			return Errorf("AllowedHeader %s can not have more than one wildcard", header)
+		}
+	}
+	if r.MaxAgeSeconds < 0 {
+		return Errorf("MaxAgeSeconds must be a positive integer")
+	}
+	return nil
+}
+
+// matchOrigin - returns true if origin is allowed by this rule.
+func (r Rule) matchOrigin(origin string) bool {
+	for _, allowedOrigin := range r.AllowedOrigin {
+		if wildcard.MatchSimple(allowedOrigin, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchMethod - returns true if method is allowed by this rule.
+func (r Rule) matchMethod(method string) bool {
+	for _, allowedMethod := range r.AllowedMethod {
+		if allowedMethod == method {
+			return true
+		}
+	}
+	return false
+}
+
+// matchHeaders - returns true if all headers are allowed by this rule,
+// header names are compared case-insensitively.
+func (r Rule) matchHeaders(headers []string) bool {
+	for _, header := range headers {
+		header = strings.ToLower(strings.TrimSpace(header))
+		if header == "" {
+			continue
+		}
+		var found bool
+		for _, allowedHeader := range r.AllowedHeader {
+			if wildcard.MatchSimple(strings.ToLower(allowedHeader), header) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// AllowsAnyOrigin - returns true if the rule allows all origins,
+// in which case S3 responds with a wildcard instead of echoing
+// the request origin.
+func (r Rule) AllowsAnyOrigin() bool {
+	for _, allowedOrigin := range r.AllowedOrigin {
+		if allowedOrigin == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Config - bucket CORS configuration.
+type Config struct {
+	XMLNS     string   `xml:"xmlns,attr,omitempty"`
+	XMLName   xml.Name `xml:"CORSConfiguration"`
+	CORSRules []Rule   `xml:"CORSRule"`
+}
+
+// Validate - validates the CORS configuration.
+func (c Config) Validate() error {
+	if len(c.CORSRules) == 0 {
+		return Errorf("at least one CORSRule must be specified")
+	}
+	if len(c.CORSRules) > maxRules {
+		return Errorf("CORS configuration allows a maximum of %d rules", maxRules)
+	}
+	for _, rule := range c.CORSRules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Match - returns the first rule which allows the given origin,
+// method and request headers. As per S3 spec the first matching
+// rule in the configuration wins.
+func (c Config) Match(origin, method string, headers []string) (Rule, bool) {
+	for _, rule := range c.CORSRules {
+		if rule.matchOrigin(origin) && rule.matchMethod(method) && rule.matchHeaders(headers) {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// ParseConfig - parses data in given reader to CORS configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
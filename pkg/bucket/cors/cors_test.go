@@ -0,0 +1,111 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		inputXML   string
+		shouldPass bool
+	}{
+		// 1. Valid configuration with a single rule.
+		{
+			inputXML:   `<CORSConfiguration><CORSRule><AllowedOrigin>http://www.example.com</AllowedOrigin><AllowedMethod>PUT</AllowedMethod><AllowedHeader>*</AllowedHeader><MaxAgeSeconds>3000</MaxAgeSeconds></CORSRule></CORSConfiguration>`,
+			shouldPass: true,
+		},
+		// 2. No rules.
+		{
+			inputXML:   `<CORSConfiguration></CORSConfiguration>`,
+			shouldPass: false,
+		},
+		// 3. Missing AllowedOrigin.
+		{
+			inputXML:   `<CORSConfiguration><CORSRule><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+			shouldPass: false,
+		},
+		// 4. Missing AllowedMethod.
+		{
+			inputXML:   `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin></CORSRule></CORSConfiguration>`,
+			shouldPass: false,
+		},
+		// 5. Unsupported method.
+		{
+			inputXML:   `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin><AllowedMethod>PATCH</AllowedMethod></CORSRule></CORSConfiguration>`,
+			shouldPass: false,
+		},
+		// 6. Origin with more than one wildcard.
+		{
+			inputXML:   `<CORSConfiguration><CORSRule><AllowedOrigin>http://*.*.com</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+			shouldPass: false,
+		},
+		// 7. Invalid XML.
+		{
+			inputXML:   `<CORSConfiguration><CORSRule>`,
+			shouldPass: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		_, err := ParseConfig(strings.NewReader(tc.inputXML))
+		if tc.shouldPass && err != nil {
+			t.Errorf("Test %d: expected to pass, failed with %v", i+1, err)
+		}
+		if !tc.shouldPass && err == nil {
+			t.Errorf("Test %d: expected to fail, but passed", i+1)
+		}
+	}
+}
+
+func TestConfigMatch(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader(`<CORSConfiguration>
+<CORSRule><ID>first</ID><AllowedOrigin>http://*.example.com</AllowedOrigin><AllowedMethod>PUT</AllowedMethod><AllowedMethod>POST</AllowedMethod><AllowedHeader>x-amz-*</AllowedHeader></CORSRule>
+<CORSRule><ID>second</ID><AllowedOrigin>*</AllowedOrigin><AllowedMethod>GET</AllowedMethod></CORSRule>
+</CORSConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		origin     string
+		method     string
+		headers    []string
+		expectedID string
+		expectedOk bool
+	}{
+		{"http://www.example.com", "PUT", []string{"X-Amz-Date"}, "first", true},
+		{"http://www.example.com", "PUT", []string{"Content-Type"}, "", false},
+		{"http://www.example.com", "DELETE", nil, "", false},
+		{"http://www.example.org", "PUT", nil, "", false},
+		{"http://www.example.org", "GET", nil, "second", true},
+		{"http://www.example.com", "GET", []string{"X-Amz-Date"}, "", false},
+	}
+
+	for i, tc := range testCases {
+		rule, ok := config.Match(tc.origin, tc.method, tc.headers)
+		if ok != tc.expectedOk {
+			t.Errorf("Test %d: expected match %v, got %v", i+1, tc.expectedOk, ok)
+			continue
+		}
+		if rule.ID != tc.expectedID {
+			t.Errorf("Test %d: expected rule %s, got %s", i+1, tc.expectedID, rule.ID)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inventory
+
+import (
+	"encoding/xml"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+)
+
+const (
+	// Maximum number of inventory configurations of a bucket.
+	maxConfigs = 1000
+
+	// Maximum length of an inventory configuration id.
+	maxIDLength = 64
+
+	// Prefix of destination bucket ARNs.
+	bucketARNPrefix = "arn:aws:s3:::"
+)
+
+var validID = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// Format - format of inventory files.
+type Format string
+
+// Inventory file formats.
+const (
+	FormatCSV     Format = "CSV"
+	FormatParquet Format = "Parquet"
+	FormatORC     Format = "ORC"
+)
+
+// Frequency - how often inventory reports are generated.
+type Frequency string
+
+// Inventory schedules.
+const (
+	FrequencyDaily  Frequency = "Daily"
+	FrequencyWeekly Frequency = "Weekly"
+)
+
+// Interval - returns the time between two inventory reports.
+func (f Frequency) Interval() time.Duration {
+	if f == FrequencyWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// Object versions included in inventory reports.
+const (
+	IncludeAllVersions     = "All"
+	IncludeCurrentVersions = "Current"
+)
+
+// S3BucketDestination - bucket where inventory reports are published.
+type S3BucketDestination struct {
+	AccountID string `xml:"AccountId,omitempty"`
+	Bucket    string `xml:"Bucket"`
+	Format    Format `xml:"Format"`
+	Prefix    string `xml:"Prefix,omitempty"`
+}
+
+// Destination - describes where inventory reports are published.
+type Destination struct {
+	S3BucketDestination S3BucketDestination `xml:"S3BucketDestination"`
+}
+
+// Filter - limits the objects listed in inventory reports.
+type Filter struct {
+	Prefix string `xml:"Prefix"`
+}
+
+// Schedule - how often inventory reports are generated.
+type Schedule struct {
+	Frequency Frequency `xml:"Frequency"`
+}
+
+// OptionalFields - optional fields included in inventory reports.
+type OptionalFields struct {
+	Fields []string `xml:"Field"`
+}
+
+// Config - inventory configuration of a bucket.
+type Config struct {
+	XMLNS                  string          `xml:"xmlns,attr,omitempty"`
+	XMLName                xml.Name        `xml:"InventoryConfiguration"`
+	ID                     string          `xml:"Id"`
+	IsEnabled              bool            `xml:"IsEnabled"`
+	Destination            Destination     `xml:"Destination"`
+	Filter                 *Filter         `xml:"Filter,omitempty"`
+	IncludedObjectVersions string          `xml:"IncludedObjectVersions"`
+	OptionalFields         *OptionalFields `xml:"OptionalFields,omitempty"`
+	Schedule               Schedule        `xml:"Schedule"`
+}
+
+// DestinationBucket - returns the name of the destination bucket.
+func (c Config) DestinationBucket() string {
+	return strings.TrimPrefix(c.Destination.S3BucketDestination.Bucket, bucketARNPrefix)
+}
+
+// Prefix - returns the prefix of objects listed in inventory reports.
+func (c Config) Prefix() string {
+	if c.Filter == nil {
+		return ""
+	}
+	return c.Filter.Prefix
+}
+
+// AllVersions - returns true if all object versions are listed in
+// inventory reports.
+func (c Config) AllVersions() bool {
+	return c.IncludedObjectVersions == IncludeAllVersions
+}
+
+// Validate - validates the inventory configuration.
+func (c Config) Validate() error {
+	if c.ID == "" {
+		return Errorf("Id must be specified")
+	}
+	if len(c.ID) > maxIDLength || !validID.MatchString(c.ID) {
+		return Errorf("invalid Id %s", c.ID)
+	}
+
+	dst := c.Destination.S3BucketDestination
+	if !strings.HasPrefix(dst.Bucket, bucketARNPrefix) {
+		return Errorf("destination Bucket must be an ARN of the form %s<bucket>", bucketARNPrefix)
+	}
+	if err := s3utils.CheckValidBucketNameStrict(c.DestinationBucket()); err != nil {
+		return Errorf("invalid destination Bucket %s: %v", dst.Bucket, err)
+	}
+	switch dst.Format {
+	case FormatCSV, FormatParquet:
+	case FormatORC:
+		return Errorf("format %s is not supported", dst.Format)
+	default:
+		return Errorf("unknown format %s", dst.Format)
+	}
+
+	switch c.Schedule.Frequency {
+	case FrequencyDaily, FrequencyWeekly:
+	default:
+		return Errorf("unknown frequency %s", c.Schedule.Frequency)
+	}
+
+	switch c.IncludedObjectVersions {
+	case IncludeAllVersions, IncludeCurrentVersions:
+	default:
+		return Errorf("unknown IncludedObjectVersions %s", c.IncludedObjectVersions)
+	}
+
+	if c.OptionalFields != nil {
+		seen := make(map[string]bool)
+		for _, field := range c.OptionalFields.Fields {
+			if _, ok := optionalFields[field]; !ok {
+				return Errorf("unknown optional field %s", field)
+			}
+			if seen[field] {
+				return Errorf("duplicate optional field %s", field)
+			}
+			seen[field] = true
+		}
+	}
+	return nil
+}
+
+// ParseConfig - parses data in given reader to an inventory
+// configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Configs - all inventory configurations of a bucket, sorted by id.
+type Configs struct {
+	XMLName xml.Name `xml:"InventoryConfigurations"`
+	Configs []Config `xml:"InventoryConfiguration"`
+}
+
+// Get - returns the inventory configuration with given id.
+func (c *Configs) Get(id string) (Config, bool) {
+	for _, config := range c.Configs {
+		if config.ID == id {
+			return config, true
+		}
+	}
+	return Config{}, false
+}
+
+// Set - adds the inventory configuration or replaces the configuration
+// with the same id.
+func (c *Configs) Set(config Config) error {
+	config.XMLNS = ""
+	for i := range c.Configs {
+		if c.Configs[i].ID == config.ID {
+			c.Configs[i] = config
+			return nil
+		}
+	}
+	if len(c.Configs) >= maxConfigs {
+		return Errorf("bucket can not have more than %d inventory configurations", maxConfigs)
+	}
+	c.Configs = append(c.Configs, config)
+	sort.Slice(c.Configs, func(i, j int) bool {
+		return c.Configs[i].ID < c.Configs[j].ID
+	})
+	return nil
+}
+
+// Delete - removes the inventory configuration with given id, returns
+// false if there is no such configuration.
+func (c *Configs) Delete(id string) bool {
+	for i := range c.Configs {
+		if c.Configs[i].ID == id {
+			c.Configs = append(c.Configs[:i], c.Configs[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// ParseConfigs - parses data in given reader to the inventory
+// configurations of a bucket.
+func ParseConfigs(reader io.Reader) (*Configs, error) {
+	var c Configs
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,200 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inventory
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func configXML(id, bucket, format, frequency, versions, fields string) string {
+	return fmt.Sprintf(`<InventoryConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">`+
+		`<Id>%s</Id><IsEnabled>true</IsEnabled>`+
+		`<Destination><S3BucketDestination><Bucket>%s</Bucket><Format>%s</Format><Prefix>reports</Prefix></S3BucketDestination></Destination>`+
+		`<Filter><Prefix>photos/</Prefix></Filter>`+
+		`<IncludedObjectVersions>%s</IncludedObjectVersions>`+
+		`<OptionalFields>%s</OptionalFields>`+
+		`<Schedule><Frequency>%s</Frequency></Schedule>`+
+		`</InventoryConfiguration>`, id, bucket, format, versions, fields, frequency)
+}
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		inputXML   string
+		shouldPass bool
+	}{
+		// 1. Valid configuration.
+		{configXML("report1", "arn:aws:s3:::dst", "CSV", "Daily", "All", "<Field>Size</Field><Field>ETag</Field>"), true},
+		// 2. Parquet format, weekly schedule.
+		{configXML("report1", "arn:aws:s3:::dst", "Parquet", "Weekly", "Current", ""), true},
+		// 3. Missing id.
+		{configXML("", "arn:aws:s3:::dst", "CSV", "Daily", "All", ""), false},
+		// 4. Invalid id.
+		{configXML("report 1", "arn:aws:s3:::dst", "CSV", "Daily", "All", ""), false},
+		// 5. Destination bucket is not an ARN.
+		{configXML("report1", "dst", "CSV", "Daily", "All", ""), false},
+		// 6. ORC is not supported.
+		{configXML("report1", "arn:aws:s3:::dst", "ORC", "Daily", "All", ""), false},
+		// 7. Unknown frequency.
+		{configXML("report1", "arn:aws:s3:::dst", "CSV", "Hourly", "All", ""), false},
+		// 8. Unknown versions.
+		{configXML("report1", "arn:aws:s3:::dst", "CSV", "Daily", "Some", ""), false},
+		// 9. Unknown optional field.
+		{configXML("report1", "arn:aws:s3:::dst", "CSV", "Daily", "All", "<Field>Owner</Field>"), false},
+		// 10. Duplicate optional field.
+		{configXML("report1", "arn:aws:s3:::dst", "CSV", "Daily", "All", "<Field>Size</Field><Field>Size</Field>"), false},
+	}
+
+	for i, tc := range testCases {
+		_, err := ParseConfig(strings.NewReader(tc.inputXML))
+		if tc.shouldPass && err != nil {
+			t.Errorf("Test %d: expected to pass, failed with %v", i+1, err)
+		}
+		if !tc.shouldPass && err == nil {
+			t.Errorf("Test %d: expected to fail, but passed", i+1)
+		}
+	}
+}
+
+func TestConfigs(t *testing.T) {
+	var configs Configs
+	for _, id := range []string{"b", "a", "c"} {
+		c, err := ParseConfig(strings.NewReader(configXML(id, "arn:aws:s3:::dst", "CSV", "Daily", "All", "")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = configs.Set(*c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if configs.Configs[0].ID != "a" || configs.Configs[2].ID != "c" {
+		t.Fatalf("expected configurations sorted by id, got %v", configs.Configs)
+	}
+
+	c, ok := configs.Get("b")
+	if !ok || c.DestinationBucket() != "dst" || c.Prefix() != "photos/" {
+		t.Fatalf("unexpected configuration %#v", c)
+	}
+	c.IsEnabled = false
+	if err := configs.Set(c); err != nil {
+		t.Fatal(err)
+	}
+	if c, _ = configs.Get("b"); c.IsEnabled || len(configs.Configs) != 3 {
+		t.Fatal("expected configuration to be replaced")
+	}
+
+	if !configs.Delete("b") || configs.Delete("b") {
+		t.Fatal("expected configuration to be deleted once")
+	}
+
+	data, err := xml.Marshal(configs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := ParseConfigs(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Configs) != 2 {
+		t.Fatalf("expected 2 configurations, got %d", len(decoded.Configs))
+	}
+}
+
+func TestFields(t *testing.T) {
+	c, err := ParseConfig(strings.NewReader(configXML("report1", "arn:aws:s3:::dst", "CSV", "Daily", "All",
+		"<Field>ETag</Field><Field>Size</Field>")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"Bucket", "Key", "VersionId", "IsLatest", "IsDeleteMarker", "Size", "ETag"}
+	if fields := c.Fields(); !reflect.DeepEqual(fields, expected) {
+		t.Fatalf("expected %v, got %v", expected, fields)
+	}
+
+	c.IncludedObjectVersions = IncludeCurrentVersions
+	c.Destination.S3BucketDestination.Format = FormatParquet
+	expectedSchema := "message s3.inventory { required binary bucket (UTF8); required binary key (UTF8); required int64 size; required binary e_tag (UTF8); }"
+	if schema := c.FileSchema(); schema != expectedSchema {
+		t.Fatalf("expected %s, got %s", expectedSchema, schema)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+func TestCSVWriter(t *testing.T) {
+	c, err := ParseConfig(strings.NewReader(configXML("report1", "arn:aws:s3:::dst", "CSV", "Daily", "Current",
+		"<Field>Size</Field><Field>LastModifiedDate</Field><Field>ObjectLockRetainUntilDate</Field>")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	w, err := NewWriter(nopWriteCloser{&buf}, *c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Write(Record{
+		Bucket:           "src",
+		Key:              "photos/a b.jpg",
+		Size:             10,
+		LastModifiedDate: time.Date(2020, 11, 6, 21, 32, 0, 0, time.UTC),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "src,photos%2Fa+b.jpg,10,2020-11-06T21:32:00.000Z,\n"
+	if string(data) != expected {
+		t.Fatalf("expected %q, got %q", expected, data)
+	}
+}
+
+func TestObjectNames(t *testing.T) {
+	c, err := ParseConfig(strings.NewReader(configXML("report1", "arn:aws:s3:::dst", "Parquet", "Daily", "All", "")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := DataObjectName(*c, "src", "uuid"); name != "reports/src/report1/data/uuid.parquet" {
+		t.Fatalf("unexpected data object name %s", name)
+	}
+	created := time.Date(2020, 11, 6, 21, 32, 0, 0, time.UTC)
+	if name := ManifestObjectName(*c, "src", created, ManifestName); name != "reports/src/report1/2020-11-06T21-32Z/manifest.json" {
+		t.Fatalf("unexpected manifest object name %s", name)
+	}
+}
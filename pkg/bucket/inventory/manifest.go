@@ -0,0 +1,81 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inventory
+
+import (
+	"path"
+	"strconv"
+	"time"
+)
+
+const (
+	// Version of the manifest format.
+	manifestVersion = "2016-11-30"
+
+	// Names of the manifest objects.
+	ManifestName         = "manifest.json"
+	ManifestChecksumName = "manifest.checksum"
+
+	// Time format of the folders of inventory reports.
+	reportTimeFormat = "2006-01-02T15-04Z"
+)
+
+// ManifestFile - an inventory file listed in the manifest.
+type ManifestFile struct {
+	Key         string `json:"key"`
+	Size        int64  `json:"size"`
+	MD5Checksum string `json:"MD5checksum"`
+}
+
+// Manifest - lists the inventory files of an inventory report.
+type Manifest struct {
+	SourceBucket      string         `json:"sourceBucket"`
+	DestinationBucket string         `json:"destinationBucket"`
+	Version           string         `json:"version"`
+	CreationTimestamp string         `json:"creationTimestamp"`
+	FileFormat        Format         `json:"fileFormat"`
+	FileSchema        string         `json:"fileSchema"`
+	Files             []ManifestFile `json:"files"`
+}
+
+// NewManifest - returns the manifest of an inventory report of the
+// source bucket created at given time.
+func NewManifest(c Config, sourceBucket string, created time.Time) Manifest {
+	return Manifest{
+		SourceBucket:      sourceBucket,
+		DestinationBucket: c.Destination.S3BucketDestination.Bucket,
+		Version:           manifestVersion,
+		CreationTimestamp: strconv.FormatInt(created.UnixNano()/int64(time.Millisecond), 10),
+		FileFormat:        c.Destination.S3BucketDestination.Format,
+		FileSchema:        c.FileSchema(),
+		Files:             []ManifestFile{},
+	}
+}
+
+// DataObjectName - returns the name of an inventory file in the
+// destination bucket.
+func DataObjectName(c Config, sourceBucket, name string) string {
+	return path.Join(c.Destination.S3BucketDestination.Prefix, sourceBucket, c.ID, "data",
+		name+FileExtension(c.Destination.S3BucketDestination.Format))
+}
+
+// ManifestObjectName - returns the name of a manifest object of an
+// inventory report created at given time.
+func ManifestObjectName(c Config, sourceBucket string, created time.Time, name string) string {
+	return path.Join(c.Destination.S3BucketDestination.Prefix, sourceBucket, c.ID,
+		created.UTC().Format(reportTimeFormat), name)
+}
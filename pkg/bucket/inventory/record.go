@@ -0,0 +1,324 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package inventory
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/pkg/s3select/parquet"
+)
+
+// Fields of inventory records.
+const (
+	FieldBucket                    = "Bucket"
+	FieldKey                       = "Key"
+	FieldVersionID                 = "VersionId"
+	FieldIsLatest                  = "IsLatest"
+	FieldIsDeleteMarker            = "IsDeleteMarker"
+	FieldSize                      = "Size"
+	FieldLastModifiedDate          = "LastModifiedDate"
+	FieldETag                      = "ETag"
+	FieldStorageClass              = "StorageClass"
+	FieldIsMultipartUploaded       = "IsMultipartUploaded"
+	FieldReplicationStatus         = "ReplicationStatus"
+	FieldEncryptionStatus          = "EncryptionStatus"
+	FieldObjectLockRetainUntilDate = "ObjectLockRetainUntilDate"
+	FieldObjectLockMode            = "ObjectLockMode"
+	FieldObjectLockLegalHoldStatus = "ObjectLockLegalHoldStatus"
+)
+
+// Time format of dates in CSV inventory files.
+const csvTimeFormat = "2006-01-02T15:04:05.000Z"
+
+type fieldInfo struct {
+	column     string
+	columnType parquet.ColumnType
+}
+
+// Fields which are always listed.
+var requiredFields = []string{FieldBucket, FieldKey}
+
+// Fields which are listed when all object versions are included.
+var versionFields = []string{FieldVersionID, FieldIsLatest, FieldIsDeleteMarker}
+
+// Optional fields in the order they appear in inventory files.
+var optionalFieldsOrder = []string{
+	FieldSize,
+	FieldLastModifiedDate,
+	FieldETag,
+	FieldStorageClass,
+	FieldIsMultipartUploaded,
+	FieldReplicationStatus,
+	FieldEncryptionStatus,
+	FieldObjectLockRetainUntilDate,
+	FieldObjectLockMode,
+	FieldObjectLockLegalHoldStatus,
+}
+
+var optionalFields = map[string]fieldInfo{
+	FieldSize:                      {"size", parquet.ColumnInt64},
+	FieldLastModifiedDate:          {"last_modified_date", parquet.ColumnInt64},
+	FieldETag:                      {"e_tag", parquet.ColumnString},
+	FieldStorageClass:              {"storage_class", parquet.ColumnString},
+	FieldIsMultipartUploaded:       {"is_multipart_uploaded", parquet.ColumnBoolean},
+	FieldReplicationStatus:         {"replication_status", parquet.ColumnString},
+	FieldEncryptionStatus:          {"encryption_status", parquet.ColumnString},
+	FieldObjectLockRetainUntilDate: {"object_lock_retain_until_date", parquet.ColumnInt64},
+	FieldObjectLockMode:            {"object_lock_mode", parquet.ColumnString},
+	FieldObjectLockLegalHoldStatus: {"object_lock_legal_hold_status", parquet.ColumnString},
+}
+
+var fields = map[string]fieldInfo{
+	FieldBucket:         {"bucket", parquet.ColumnString},
+	FieldKey:            {"key", parquet.ColumnString},
+	FieldVersionID:      {"version_id", parquet.ColumnString},
+	FieldIsLatest:       {"is_latest", parquet.ColumnBoolean},
+	FieldIsDeleteMarker: {"is_delete_marker", parquet.ColumnBoolean},
+}
+
+func init() {
+	for name, info := range optionalFields {
+		fields[name] = info
+	}
+}
+
+// Fields - returns the fields of the inventory records, in the order
+// they appear in inventory files.
+func (c Config) Fields() []string {
+	names := append([]string{}, requiredFields...)
+	if c.AllVersions() {
+		names = append(names, versionFields...)
+	}
+	if c.OptionalFields == nil {
+		return names
+	}
+	for _, name := range optionalFieldsOrder {
+		for _, field := range c.OptionalFields.Fields {
+			if field == name {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// FileSchema - returns the schema of the inventory files as listed in
+// the manifest.
+func (c Config) FileSchema() string {
+	names := c.Fields()
+	if c.Destination.S3BucketDestination.Format != FormatParquet {
+		return strings.Join(names, ", ")
+	}
+
+	var b strings.Builder
+	b.WriteString("message s3.inventory { ")
+	for _, name := range names {
+		info := fields[name]
+		switch info.columnType {
+		case parquet.ColumnString:
+			b.WriteString("required binary " + info.column + " (UTF8); ")
+		case parquet.ColumnInt64:
+			b.WriteString("required int64 " + info.column + "; ")
+		case parquet.ColumnBoolean:
+			b.WriteString("required boolean " + info.column + "; ")
+		}
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// Record - inventory record of an object version.
+type Record struct {
+	Bucket                    string
+	Key                       string
+	VersionID                 string
+	IsLatest                  bool
+	IsDeleteMarker            bool
+	Size                      int64
+	LastModifiedDate          time.Time
+	ETag                      string
+	StorageClass              string
+	IsMultipartUploaded       bool
+	ReplicationStatus         string
+	EncryptionStatus          string
+	ObjectLockRetainUntilDate time.Time
+	ObjectLockMode            string
+	ObjectLockLegalHoldStatus string
+}
+
+// value - returns the value of a field.
+func (r Record) value(name string) interface{} {
+	switch name {
+	case FieldBucket:
+		return r.Bucket
+	case FieldKey:
+		return r.Key
+	case FieldVersionID:
+		return r.VersionID
+	case FieldIsLatest:
+		return r.IsLatest
+	case FieldIsDeleteMarker:
+		return r.IsDeleteMarker
+	case FieldSize:
+		return r.Size
+	case FieldLastModifiedDate:
+		return r.LastModifiedDate
+	case FieldETag:
+		return r.ETag
+	case FieldStorageClass:
+		return r.StorageClass
+	case FieldIsMultipartUploaded:
+		return r.IsMultipartUploaded
+	case FieldReplicationStatus:
+		return r.ReplicationStatus
+	case FieldEncryptionStatus:
+		return r.EncryptionStatus
+	case FieldObjectLockRetainUntilDate:
+		return r.ObjectLockRetainUntilDate
+	case FieldObjectLockMode:
+		return r.ObjectLockMode
+	case FieldObjectLockLegalHoldStatus:
+		return r.ObjectLockLegalHoldStatus
+	}
+	return nil
+}
+
+// Writer - writes inventory records to an inventory file.
+type Writer interface {
+	Write(r Record) error
+	Close() error
+}
+
+// NewWriter - returns a writer of inventory files in the format of the
+// inventory configuration, the file is written to writeCloser.
+func NewWriter(writeCloser io.WriteCloser, c Config) (Writer, error) {
+	switch c.Destination.S3BucketDestination.Format {
+	case FormatCSV:
+		return newCSVWriter(writeCloser, c.Fields()), nil
+	case FormatParquet:
+		return newParquetWriter(writeCloser, c.Fields())
+	}
+	return nil, Errorf("format %s is not supported", c.Destination.S3BucketDestination.Format)
+}
+
+// FileExtension - returns the extension of inventory files.
+func FileExtension(format Format) string {
+	if format == FormatParquet {
+		return ".parquet"
+	}
+	return ".csv.gz"
+}
+
+// csvWriter - writes gzip compressed CSV inventory files without a
+// header, keys are URL encoded.
+type csvWriter struct {
+	writeCloser io.WriteCloser
+	gzipWriter  *gzip.Writer
+	csvWriter   *csv.Writer
+	fields      []string
+	row         []string
+}
+
+func newCSVWriter(writeCloser io.WriteCloser, fields []string) *csvWriter {
+	gzipWriter := gzip.NewWriter(writeCloser)
+	return &csvWriter{
+		writeCloser: writeCloser,
+		gzipWriter:  gzipWriter,
+		csvWriter:   csv.NewWriter(gzipWriter),
+		fields:      fields,
+		row:         make([]string, len(fields)),
+	}
+}
+
+func (w *csvWriter) Write(r Record) error {
+	for i, name := range w.fields {
+		switch v := r.value(name).(type) {
+		case string:
+			if name == FieldKey {
+				v = url.QueryEscape(v)
+			}
+			w.row[i] = v
+		case int64:
+			w.row[i] = strconv.FormatInt(v, 10)
+		case bool:
+			w.row[i] = strconv.FormatBool(v)
+		case time.Time:
+			w.row[i] = ""
+			if !v.IsZero() {
+				w.row[i] = v.UTC().Format(csvTimeFormat)
+			}
+		}
+	}
+	return w.csvWriter.Write(w.row)
+}
+
+func (w *csvWriter) Close() error {
+	w.csvWriter.Flush()
+	if err := w.csvWriter.Error(); err != nil {
+		return err
+	}
+	if err := w.gzipWriter.Close(); err != nil {
+		return err
+	}
+	return w.writeCloser.Close()
+}
+
+// parquetWriter - writes Parquet inventory files, dates are stored as
+// milliseconds since the Unix epoch and unset dates as zero.
+type parquetWriter struct {
+	writer *parquet.Writer
+	fields []string
+}
+
+func newParquetWriter(writeCloser io.WriteCloser, names []string) (*parquetWriter, error) {
+	columns := make([]parquet.Column, 0, len(names))
+	for _, name := range names {
+		info := fields[name]
+		columns = append(columns, parquet.Column{Name: info.column, Type: info.columnType})
+	}
+	writer, err := parquet.NewWriter(writeCloser, columns)
+	if err != nil {
+		return nil, err
+	}
+	return &parquetWriter{writer: writer, fields: names}, nil
+}
+
+func (w *parquetWriter) Write(r Record) error {
+	row := make(map[string]interface{}, len(w.fields))
+	for _, name := range w.fields {
+		v := r.value(name)
+		if t, ok := v.(time.Time); ok {
+			v = int64(0)
+			if !t.IsZero() {
+				v = t.UnixNano() / int64(time.Millisecond)
+			}
+		}
+		row[fields[name].column] = v
+	}
+	return w.writer.Write(row)
+}
+
+func (w *parquetWriter) Close() error {
+	return w.writer.Close()
+}
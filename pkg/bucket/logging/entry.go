@@ -0,0 +1,125 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logging
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Time layouts used by S3 server access logs.
+const (
+	entryTimeFormat      = "02/Jan/2006:15:04:05 -0700"
+	objectNameTimeFormat = "2006-01-02-15-04-05"
+)
+
+// Entry - a single server access log record.
+type Entry struct {
+	BucketOwner      string
+	Bucket           string
+	Time             time.Time
+	RemoteIP         string
+	Requester        string
+	RequestID        string
+	Operation        string
+	Key              string
+	RequestURI       string
+	HTTPStatus       int
+	ErrorCode        string
+	BytesSent        int64
+	ObjectSize       int64
+	TotalTime        time.Duration
+	TurnAroundTime   time.Duration
+	Referer          string
+	UserAgent        string
+	VersionID        string
+	HostID           string
+	SignatureVersion string
+	CipherSuite      string
+	AuthType         string
+	HostHeader       string
+	TLSVersion       string
+}
+
+// field - returns the value or '-' when there is no value.
+func field(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// quotedField - returns the quoted value or '-' when there is no value.
+func quotedField(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return strconv.Quote(value)
+}
+
+func sizeField(size int64) string {
+	if size <= 0 {
+		return "-"
+	}
+	return strconv.FormatInt(size, 10)
+}
+
+func durationField(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	return strconv.FormatInt(d.Milliseconds(), 10)
+}
+
+// String - returns the record in the S3 server access log format,
+// fields are space delimited and terminated by a newline.
+func (e Entry) String() string {
+	fields := []string{
+		field(e.BucketOwner),
+		field(e.Bucket),
+		"[" + e.Time.UTC().Format(entryTimeFormat) + "]",
+		field(e.RemoteIP),
+		field(e.Requester),
+		field(e.RequestID),
+		field(e.Operation),
+		field(e.Key),
+		quotedField(e.RequestURI),
+		strconv.Itoa(e.HTTPStatus),
+		field(e.ErrorCode),
+		sizeField(e.BytesSent),
+		sizeField(e.ObjectSize),
+		durationField(e.TotalTime),
+		durationField(e.TurnAroundTime),
+		quotedField(e.Referer),
+		quotedField(e.UserAgent),
+		field(e.VersionID),
+		field(e.HostID),
+		field(e.SignatureVersion),
+		field(e.CipherSuite),
+		field(e.AuthType),
+		field(e.HostHeader),
+		field(e.TLSVersion),
+	}
+	return strings.Join(fields, " ") + "\n"
+}
+
+// ObjectName - returns the name of an access log object delivered at
+// time t, uniqueID distinguishes objects delivered at the same second.
+func ObjectName(prefix string, t time.Time, uniqueID string) string {
+	return prefix + t.UTC().Format(objectNameTimeFormat) + "-" + uniqueID
+}
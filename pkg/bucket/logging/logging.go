@@ -0,0 +1,75 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logging
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+)
+
+// Maximum length of the target prefix.
+const maxTargetPrefixLength = 512
+
+// LoggingEnabled - describes where access logs are stored.
+type LoggingEnabled struct {
+	TargetBucket string `xml:"TargetBucket"`
+	TargetPrefix string `xml:"TargetPrefix"`
+}
+
+// Config - bucket logging status, logging is disabled when
+// LoggingEnabled is not set.
+type Config struct {
+	XMLNS          string          `xml:"xmlns,attr,omitempty"`
+	XMLName        xml.Name        `xml:"BucketLoggingStatus"`
+	LoggingEnabled *LoggingEnabled `xml:"LoggingEnabled,omitempty"`
+}
+
+// Enabled - returns true if access logging is enabled.
+func (c Config) Enabled() bool {
+	return c.LoggingEnabled != nil
+}
+
+// Validate - validates the bucket logging status.
+func (c Config) Validate() error {
+	if c.LoggingEnabled == nil {
+		return nil
+	}
+	if c.LoggingEnabled.TargetBucket == "" {
+		return Errorf("TargetBucket must be specified")
+	}
+	if err := s3utils.CheckValidBucketNameStrict(c.LoggingEnabled.TargetBucket); err != nil {
+		return Errorf("invalid TargetBucket %s: %v", c.LoggingEnabled.TargetBucket, err)
+	}
+	if len(c.LoggingEnabled.TargetPrefix) > maxTargetPrefixLength {
+		return Errorf("TargetPrefix can not be longer than %d characters", maxTargetPrefixLength)
+	}
+	return nil
+}
+
+// ParseConfig - parses data in given reader to bucket logging status.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
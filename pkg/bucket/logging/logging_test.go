@@ -0,0 +1,108 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logging
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		inputXML       string
+		shouldPass     bool
+		expectedEnable bool
+	}{
+		// 1. Logging disabled.
+		{
+			inputXML:       `<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></BucketLoggingStatus>`,
+			shouldPass:     true,
+			expectedEnable: false,
+		},
+		// 2. Logging enabled.
+		{
+			inputXML:       `<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>access/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`,
+			shouldPass:     true,
+			expectedEnable: true,
+		},
+		// 3. Missing target bucket.
+		{
+			inputXML:   `<BucketLoggingStatus><LoggingEnabled><TargetPrefix>access/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`,
+			shouldPass: false,
+		},
+		// 4. Invalid target bucket.
+		{
+			inputXML:   `<BucketLoggingStatus><LoggingEnabled><TargetBucket>Logs_Bucket</TargetBucket></LoggingEnabled></BucketLoggingStatus>`,
+			shouldPass: false,
+		},
+		// 5. Invalid root element.
+		{
+			inputXML:   `<LoggingStatus></LoggingStatus>`,
+			shouldPass: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		config, err := ParseConfig(strings.NewReader(tc.inputXML))
+		if tc.shouldPass && err != nil {
+			t.Errorf("Test %d: expected to pass, failed with %v", i+1, err)
+			continue
+		}
+		if !tc.shouldPass {
+			if err == nil {
+				t.Errorf("Test %d: expected to fail, but passed", i+1)
+			}
+			continue
+		}
+		if config.Enabled() != tc.expectedEnable {
+			t.Errorf("Test %d: expected enabled %v, got %v", i+1, tc.expectedEnable, config.Enabled())
+		}
+	}
+}
+
+func TestEntryString(t *testing.T) {
+	entry := Entry{
+		BucketOwner:      "owner",
+		Bucket:           "photos",
+		Time:             time.Date(2020, time.November, 12, 10, 4, 5, 0, time.UTC),
+		RemoteIP:         "192.168.1.10",
+		Requester:        "minio",
+		RequestID:        "16474E5D5AB7A1C8",
+		Operation:        "REST.GET.OBJECT",
+		Key:              "2020/a.jpg",
+		RequestURI:       "GET /photos/2020/a.jpg HTTP/1.1",
+		HTTPStatus:       200,
+		BytesSent:        1024,
+		ObjectSize:       1024,
+		TotalTime:        12 * time.Millisecond,
+		TurnAroundTime:   3 * time.Millisecond,
+		UserAgent:        "MinIO (linux; amd64) minio-go/v7.0.6",
+		SignatureVersion: "SigV4",
+		AuthType:         "AuthHeader",
+		HostHeader:       "localhost:9000",
+	}
+
+	expected := `owner photos [12/Nov/2020:10:04:05 +0000] 192.168.1.10 minio 16474E5D5AB7A1C8 REST.GET.OBJECT 2020/a.jpg "GET /photos/2020/a.jpg HTTP/1.1" 200 - 1024 1024 12 3 - "MinIO (linux; amd64) minio-go/v7.0.6" - - SigV4 - AuthHeader localhost:9000 -` + "\n"
+	if got := entry.String(); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	if name := ObjectName("logs/", entry.Time, "ABCDEF"); name != "logs/2020-11-12-10-04-05-ABCDEF" {
+		t.Errorf("unexpected object name %s", name)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ownership
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// ObjectOwnership - who owns the objects uploaded to a bucket.
+type ObjectOwnership string
+
+const (
+	// BucketOwnerEnforced - the bucket owner owns all objects, ACLs are
+	// disabled and no longer affect access permissions.
+	BucketOwnerEnforced ObjectOwnership = "BucketOwnerEnforced"
+
+	// BucketOwnerPreferred - the bucket owner owns objects uploaded with
+	// the bucket-owner-full-control canned ACL, the writer owns all
+	// other objects.
+	BucketOwnerPreferred ObjectOwnership = "BucketOwnerPreferred"
+
+	// ObjectWriter - the writer owns the objects it uploads, this is the
+	// default for buckets without ownership controls.
+	ObjectWriter ObjectOwnership = "ObjectWriter"
+)
+
+// IsValid - returns true if the object ownership is known.
+func (o ObjectOwnership) IsValid() bool {
+	switch o {
+	case BucketOwnerEnforced, BucketOwnerPreferred, ObjectWriter:
+		return true
+	}
+	return false
+}
+
+// Rule - an ownership controls rule.
+type Rule struct {
+	ObjectOwnership ObjectOwnership `xml:"ObjectOwnership"`
+}
+
+// Config - ownership controls configuration of a bucket.
+type Config struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"OwnershipControls"`
+	Rules   []Rule   `xml:"Rule"`
+}
+
+// Validate - validates the ownership controls, exactly one rule is
+// required.
+func (c Config) Validate() error {
+	if len(c.Rules) != 1 {
+		return Errorf("exactly one ownership controls rule must be specified")
+	}
+	if !c.Rules[0].ObjectOwnership.IsValid() {
+		return Errorf("unknown object ownership %s", c.Rules[0].ObjectOwnership)
+	}
+	return nil
+}
+
+// ObjectOwnership - returns the object ownership of the bucket.
+func (c Config) ObjectOwnership() ObjectOwnership {
+	if len(c.Rules) == 0 {
+		return ObjectWriter
+	}
+	return c.Rules[0].ObjectOwnership
+}
+
+// ParseConfig - parses data in given reader to ownership controls
+// configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, Errorf("invalid ownership controls configuration: %v", err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,65 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ownership
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		inputXML          string
+		expectedOwnership ObjectOwnership
+		shouldPass        bool
+	}{
+		// 1. Bucket owner enforced.
+		{`<OwnershipControls xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><ObjectOwnership>BucketOwnerEnforced</ObjectOwnership></Rule></OwnershipControls>`,
+			BucketOwnerEnforced, true},
+		// 2. Bucket owner preferred.
+		{`<OwnershipControls><Rule><ObjectOwnership>BucketOwnerPreferred</ObjectOwnership></Rule></OwnershipControls>`,
+			BucketOwnerPreferred, true},
+		// 3. Object writer.
+		{`<OwnershipControls><Rule><ObjectOwnership>ObjectWriter</ObjectOwnership></Rule></OwnershipControls>`,
+			ObjectWriter, true},
+		// 4. Unknown object ownership.
+		{`<OwnershipControls><Rule><ObjectOwnership>Anyone</ObjectOwnership></Rule></OwnershipControls>`, "", false},
+		// 5. No rule.
+		{`<OwnershipControls></OwnershipControls>`, "", false},
+		// 6. More than one rule.
+		{`<OwnershipControls><Rule><ObjectOwnership>ObjectWriter</ObjectOwnership></Rule><Rule><ObjectOwnership>ObjectWriter</ObjectOwnership></Rule></OwnershipControls>`, "", false},
+		// 7. Unexpected root element.
+		{`<Ownership><Rule><ObjectOwnership>ObjectWriter</ObjectOwnership></Rule></Ownership>`, "", false},
+	}
+
+	for i, tc := range testCases {
+		c, err := ParseConfig(strings.NewReader(tc.inputXML))
+		if tc.shouldPass && err != nil {
+			t.Errorf("Test %d: expected to pass, failed with %v", i+1, err)
+			continue
+		}
+		if !tc.shouldPass {
+			if err == nil {
+				t.Errorf("Test %d: expected to fail, but passed", i+1)
+			}
+			continue
+		}
+		if c.ObjectOwnership() != tc.expectedOwnership {
+			t.Errorf("Test %d: expected %s, got %s", i+1, tc.expectedOwnership, c.ObjectOwnership())
+		}
+	}
+}
@@ -172,6 +172,59 @@ const (
 
 	// RestoreObjectAction - RestoreObject REST API action
 	RestoreObjectAction = "s3:RestoreObject"
+
+	// PutBucketCorsAction - PutBucketCors REST API action
+	PutBucketCorsAction = "s3:PutBucketCORS"
+	// GetBucketCorsAction - GetBucketCors REST API action
+	GetBucketCorsAction = "s3:GetBucketCORS"
+
+	// PutBucketWebsiteAction - PutBucketWebsite REST API action
+	PutBucketWebsiteAction = "s3:PutBucketWebsite"
+
+	// GetBucketWebsiteAction - GetBucketWebsite REST API action
+	GetBucketWebsiteAction = "s3:GetBucketWebsite"
+
+	// DeleteBucketWebsiteAction - DeleteBucketWebsite REST API action
+	DeleteBucketWebsiteAction = "s3:DeleteBucketWebsite"
+
+	// PutBucketLoggingAction - PutBucketLogging REST API action
+	PutBucketLoggingAction = "s3:PutBucketLogging"
+
+	// GetBucketLoggingAction - GetBucketLogging REST API action
+	GetBucketLoggingAction = "s3:GetBucketLogging"
+
+	// GetBucketAclAction - GetBucketAcl REST API action
+	GetBucketAclAction = "s3:GetBucketAcl"
+
+	// PutBucketAclAction - PutBucketAcl REST API action
+	PutBucketAclAction = "s3:PutBucketAcl"
+
+	// GetObjectAclAction - GetObjectAcl REST API action
+	GetObjectAclAction = "s3:GetObjectAcl"
+
+	// PutObjectAclAction - PutObjectAcl REST API action
+	PutObjectAclAction = "s3:PutObjectAcl"
+
+	// PutInventoryConfigurationAction - PutInventoryConfiguration REST API action
+	PutInventoryConfigurationAction = "s3:PutInventoryConfiguration"
+
+	// GetInventoryConfigurationAction - GetInventoryConfiguration REST API action
+	GetInventoryConfigurationAction = "s3:GetInventoryConfiguration"
+
+	// PutBucketPublicAccessBlockAction - PutBucketPublicAccessBlock REST API action
+	PutBucketPublicAccessBlockAction = "s3:PutBucketPublicAccessBlock"
+
+	// GetBucketPublicAccessBlockAction - GetBucketPublicAccessBlock REST API action
+	GetBucketPublicAccessBlockAction = "s3:GetBucketPublicAccessBlock"
+
+	// GetObjectAttributesAction - GetObjectAttributes REST API action
+	GetObjectAttributesAction = "s3:GetObjectAttributes"
+
+	// PutBucketOwnershipControlsAction - PutBucketOwnershipControls REST API action
+	PutBucketOwnershipControlsAction = "s3:PutBucketOwnershipControls"
+
+	// GetBucketOwnershipControlsAction - GetBucketOwnershipControls REST API action
+	GetBucketOwnershipControlsAction = "s3:GetBucketOwnershipControls"
 )
 
 // List of all supported object actions.
@@ -199,6 +252,9 @@ var supportedObjectActions = map[Action]struct{}{
 	ReplicateTagsAction:                  {},
 	GetObjectVersionForReplicationAction: {},
 	RestoreObjectAction:                  {},
+	GetObjectAclAction:                   {},
+	PutObjectAclAction:                   {},
+	GetObjectAttributesAction:            {},
 }
 
 // isObjectAction - returns whether action is object type or not.
@@ -260,6 +316,24 @@ var supportedActions = map[Action]struct{}{
 	ReplicateTagsAction:                    {},
 	GetObjectVersionForReplicationAction:   {},
 	RestoreObjectAction:                    {},
+	PutBucketCorsAction:                    {},
+	GetBucketCorsAction:                    {},
+	PutBucketWebsiteAction:                 {},
+	GetBucketWebsiteAction:                 {},
+	DeleteBucketWebsiteAction:              {},
+	PutBucketLoggingAction:                 {},
+	GetBucketLoggingAction:                 {},
+	GetBucketAclAction:                     {},
+	PutBucketAclAction:                     {},
+	GetObjectAclAction:                     {},
+	PutObjectAclAction:                     {},
+	PutInventoryConfigurationAction:        {},
+	GetInventoryConfigurationAction:        {},
+	PutBucketPublicAccessBlockAction:       {},
+	GetBucketPublicAccessBlockAction:       {},
+	GetObjectAttributesAction:              {},
+	PutBucketOwnershipControlsAction:       {},
+	GetBucketOwnershipControlsAction:       {},
 }
 
 // IsValid - checks if action is valid or not.
@@ -416,4 +490,22 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 	ReplicateTagsAction:                  condition.NewKeySet(condition.CommonKeys...),
 	GetObjectVersionForReplicationAction: condition.NewKeySet(condition.CommonKeys...),
 	RestoreObjectAction:                  condition.NewKeySet(condition.CommonKeys...),
+	PutBucketCorsAction:                  condition.NewKeySet(condition.CommonKeys...),
+	GetBucketCorsAction:                  condition.NewKeySet(condition.CommonKeys...),
+	PutBucketWebsiteAction:               condition.NewKeySet(condition.CommonKeys...),
+	GetBucketWebsiteAction:               condition.NewKeySet(condition.CommonKeys...),
+	DeleteBucketWebsiteAction:            condition.NewKeySet(condition.CommonKeys...),
+	PutBucketLoggingAction:               condition.NewKeySet(condition.CommonKeys...),
+	GetBucketLoggingAction:               condition.NewKeySet(condition.CommonKeys...),
+	GetBucketAclAction:                   condition.NewKeySet(condition.CommonKeys...),
+	PutBucketAclAction:                   condition.NewKeySet(condition.CommonKeys...),
+	GetObjectAclAction:                   condition.NewKeySet(condition.CommonKeys...),
+	PutObjectAclAction:                   condition.NewKeySet(condition.CommonKeys...),
+	PutInventoryConfigurationAction:      condition.NewKeySet(condition.CommonKeys...),
+	GetInventoryConfigurationAction:      condition.NewKeySet(condition.CommonKeys...),
+	PutBucketPublicAccessBlockAction:     condition.NewKeySet(condition.CommonKeys...),
+	GetBucketPublicAccessBlockAction:     condition.NewKeySet(condition.CommonKeys...),
+	GetObjectAttributesAction:            condition.NewKeySet(condition.CommonKeys...),
+	PutBucketOwnershipControlsAction:     condition.NewKeySet(condition.CommonKeys...),
+	GetBucketOwnershipControlsAction:     condition.NewKeySet(condition.CommonKeys...),
 }
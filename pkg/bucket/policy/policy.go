@@ -41,32 +41,52 @@ type Policy struct {
 	Statements []Statement `json:"Statement"`
 }
 
-// IsAllowed - checks given policy args is allowed to continue the Rest API.
-func (policy Policy) IsAllowed(args Args) bool {
-	// Check all deny statements. If any one statement denies, return false.
+// Decision - outcome of the evaluation of a policy.
+type Decision int
+
+const (
+	// NoMatch - no statement of the policy applies.
+	NoMatch Decision = iota
+
+	// Allowed - an allow statement applies and no deny statement applies.
+	Allowed
+
+	// Denied - a deny statement applies.
+	Denied
+)
+
+// Evaluate - returns whether given policy args are explicitly denied,
+// allowed or not matched by any statement of the policy.
+func (policy Policy) Evaluate(args Args) Decision {
+	// Check all deny statements. If any one statement denies, return denied.
 	for _, statement := range policy.Statements {
 		if statement.Effect == Deny {
 			if !statement.IsAllowed(args) {
-				return false
+				return Denied
 			}
 		}
 	}
 
 	// For owner, its allowed by default.
 	if args.IsOwner {
-		return true
+		return Allowed
 	}
 
-	// Check all allow statements. If any one statement allows, return true.
+	// Check all allow statements. If any one statement allows, return allowed.
 	for _, statement := range policy.Statements {
 		if statement.Effect == Allow {
 			if statement.IsAllowed(args) {
-				return true
+				return Allowed
 			}
 		}
 	}
 
-	return false
+	return NoMatch
+}
+
+// IsAllowed - checks given policy args is allowed to continue the Rest API.
+func (policy Policy) IsAllowed(args Args) bool {
+	return policy.Evaluate(args) == Allowed
 }
 
 // IsEmpty - returns whether policy is empty or not.
@@ -74,6 +94,17 @@ func (policy Policy) IsEmpty() bool {
 	return len(policy.Statements) == 0
 }
 
+// IsPublic - returns true if any statement allows access to everyone,
+// i.e. its principal is "*".
+func (policy Policy) IsPublic() bool {
+	for _, statement := range policy.Statements {
+		if statement.Effect == Allow && statement.Principal.AWS.Contains("*") {
+			return true
+		}
+	}
+	return false
+}
+
 // isValid - checks if Policy is valid or not.
 func (policy Policy) isValid() error {
 	if policy.Version != DefaultVersion && policy.Version != "" {
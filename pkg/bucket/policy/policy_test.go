@@ -184,6 +184,48 @@ func TestPolicyIsAllowed(t *testing.T) {
 	}
 }
 
+func TestPolicyEvaluate(t *testing.T) {
+	p := Policy{
+		Version: DefaultVersion,
+		Statements: []Statement{
+			NewStatement(
+				Allow,
+				NewPrincipal("*"),
+				NewActionSet(GetObjectAction, PutObjectAction),
+				NewResourceSet(NewResource("mybucket", "*")),
+				condition.NewFunctions(),
+			),
+			NewStatement(
+				Deny,
+				NewPrincipal("*"),
+				NewActionSet(GetObjectAction),
+				NewResourceSet(NewResource("mybucket", "/private*")),
+				condition.NewFunctions(),
+			),
+		},
+	}
+
+	testCases := []struct {
+		args             Args
+		expectedDecision Decision
+	}{
+		{Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, Allowed},
+		{Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "private/myobject"}, Denied},
+		{Args{Action: GetObjectAction, BucketName: "mybucket", ObjectName: "private/myobject", IsOwner: true}, Denied},
+		{Args{Action: DeleteObjectAction, BucketName: "mybucket", ObjectName: "myobject"}, NoMatch},
+		{Args{Action: DeleteObjectAction, BucketName: "mybucket", ObjectName: "myobject", IsOwner: true}, Allowed},
+		{Args{Action: GetObjectAction, BucketName: "yourbucket", ObjectName: "myobject"}, NoMatch},
+	}
+
+	for i, testCase := range testCases {
+		decision := p.Evaluate(testCase.args)
+
+		if decision != testCase.expectedDecision {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedDecision, decision)
+		}
+	}
+}
+
 func TestPolicyIsEmpty(t *testing.T) {
 	case1Policy := Policy{
 		Version: DefaultVersion,
@@ -220,6 +262,65 @@ func TestPolicyIsEmpty(t *testing.T) {
 	}
 }
 
+func TestPolicyIsPublic(t *testing.T) {
+	case1Policy := Policy{
+		Version: DefaultVersion,
+		Statements: []Statement{
+			NewStatement(
+				Allow,
+				NewPrincipal("*"),
+				NewActionSet(GetObjectAction),
+				NewResourceSet(NewResource("mybucket", "/myobject*")),
+				condition.NewFunctions(),
+			),
+		},
+	}
+
+	case2Policy := Policy{
+		Version: DefaultVersion,
+		Statements: []Statement{
+			NewStatement(
+				Deny,
+				NewPrincipal("*"),
+				NewActionSet(GetObjectAction),
+				NewResourceSet(NewResource("mybucket", "/myobject*")),
+				condition.NewFunctions(),
+			),
+		},
+	}
+
+	case3Policy := Policy{
+		Version: DefaultVersion,
+		Statements: []Statement{
+			NewStatement(
+				Allow,
+				NewPrincipal("arn:aws:iam::AccountNumber:root"),
+				NewActionSet(GetObjectAction),
+				NewResourceSet(NewResource("mybucket", "/myobject*")),
+				condition.NewFunctions(),
+			),
+		},
+	}
+
+	testCases := []struct {
+		policy         Policy
+		expectedResult bool
+	}{
+		{case1Policy, true},
+		{case2Policy, false},
+		{case3Policy, false},
+		{Policy{Version: DefaultVersion}, false},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.policy.IsPublic()
+
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
 func TestPolicyIsValid(t *testing.T) {
 	case1Policy := Policy{
 		Version: DefaultVersion,
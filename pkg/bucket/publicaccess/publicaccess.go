@@ -0,0 +1,67 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publicaccess
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// Config - public access block configuration of a bucket, settings
+// which are not specified are disabled.
+type Config struct {
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	XMLName xml.Name `xml:"PublicAccessBlockConfiguration"`
+
+	// Reject requests setting ACLs which grant public access.
+	BlockPublicAcls bool `xml:"BlockPublicAcls"`
+
+	// Ignore grants to public groups when ACLs are evaluated.
+	IgnorePublicAcls bool `xml:"IgnorePublicAcls"`
+
+	// Reject bucket policies which grant public access.
+	BlockPublicPolicy bool `xml:"BlockPublicPolicy"`
+
+	// Deny anonymous requests even if the bucket policy allows them.
+	RestrictPublicBuckets bool `xml:"RestrictPublicBuckets"`
+}
+
+// IsEmpty - returns true if no setting is enabled.
+func (c Config) IsEmpty() bool {
+	return !c.BlockPublicAcls && !c.IgnorePublicAcls && !c.BlockPublicPolicy && !c.RestrictPublicBuckets
+}
+
+// Merge - returns the most restrictive combination of both
+// configurations, a setting is enabled if it is enabled in either.
+func (c Config) Merge(other Config) Config {
+	return Config{
+		BlockPublicAcls:       c.BlockPublicAcls || other.BlockPublicAcls,
+		IgnorePublicAcls:      c.IgnorePublicAcls || other.IgnorePublicAcls,
+		BlockPublicPolicy:     c.BlockPublicPolicy || other.BlockPublicPolicy,
+		RestrictPublicBuckets: c.RestrictPublicBuckets || other.RestrictPublicBuckets,
+	}
+}
+
+// ParseConfig - parses data in given reader to public access block
+// configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, Errorf("invalid public access block configuration: %v", err)
+	}
+	return &c, nil
+}
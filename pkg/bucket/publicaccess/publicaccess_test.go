@@ -0,0 +1,72 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package publicaccess
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		inputXML       string
+		expectedConfig Config
+		shouldPass     bool
+	}{
+		// 1. All settings enabled.
+		{`<PublicAccessBlockConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><BlockPublicAcls>true</BlockPublicAcls><IgnorePublicAcls>true</IgnorePublicAcls><BlockPublicPolicy>true</BlockPublicPolicy><RestrictPublicBuckets>true</RestrictPublicBuckets></PublicAccessBlockConfiguration>`,
+			Config{BlockPublicAcls: true, IgnorePublicAcls: true, BlockPublicPolicy: true, RestrictPublicBuckets: true}, true},
+		// 2. Settings which are not specified are disabled.
+		{`<PublicAccessBlockConfiguration><BlockPublicPolicy>true</BlockPublicPolicy></PublicAccessBlockConfiguration>`,
+			Config{BlockPublicPolicy: true}, true},
+		// 3. Empty configuration.
+		{`<PublicAccessBlockConfiguration></PublicAccessBlockConfiguration>`, Config{}, true},
+		// 4. Invalid boolean.
+		{`<PublicAccessBlockConfiguration><BlockPublicAcls>yes</BlockPublicAcls></PublicAccessBlockConfiguration>`, Config{}, false},
+		// 5. Unexpected root element.
+		{`<PublicAccessBlock><BlockPublicAcls>true</BlockPublicAcls></PublicAccessBlock>`, Config{}, false},
+	}
+
+	for i, tc := range testCases {
+		c, err := ParseConfig(strings.NewReader(tc.inputXML))
+		if tc.shouldPass && err != nil {
+			t.Errorf("Test %d: expected to pass, failed with %v", i+1, err)
+			continue
+		}
+		if !tc.shouldPass {
+			if err == nil {
+				t.Errorf("Test %d: expected to fail, but passed", i+1)
+			}
+			continue
+		}
+		if c.Merge(Config{}) != tc.expectedConfig {
+			t.Errorf("Test %d: expected %+v, got %+v", i+1, tc.expectedConfig, *c)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	bucket := Config{BlockPublicAcls: true}
+	server := Config{RestrictPublicBuckets: true}
+	expected := Config{BlockPublicAcls: true, RestrictPublicBuckets: true}
+	if c := bucket.Merge(server); c != expected {
+		t.Fatalf("expected %+v, got %+v", expected, c)
+	}
+	if !(Config{}).IsEmpty() || expected.IsEmpty() {
+		t.Fatal("unexpected IsEmpty result")
+	}
+}
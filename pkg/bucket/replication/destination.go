@@ -27,11 +27,18 @@ import (
 // DestinationARNPrefix - destination ARN prefix as per AWS S3 specification.
 const DestinationARNPrefix = "arn:aws:s3:::"
 
+// DestinationTargetARNPrefix - prefix of the ARNs of MinIO remote targets,
+// a destination may be a remote target ARN to replicate to several targets.
+const DestinationTargetARNPrefix = "arn:minio:replication:"
+
 // Destination - destination in ReplicationConfiguration.
 type Destination struct {
 	XMLName      xml.Name `xml:"Destination" json:"Destination"`
 	Bucket       string   `xml:"Bucket" json:"Bucket"`
 	StorageClass string   `xml:"StorageClass" json:"StorageClass"`
+	// ARN of the remote target of the destination bucket, set when the
+	// destination is specified as a remote target ARN - MinIO extension.
+	ARN string `xml:"-" json:"ARN,omitempty"`
 	//EncryptionConfiguration TODO: not needed for MinIO
 }
 
@@ -48,6 +55,9 @@ func (d Destination) IsValid() bool {
 }
 
 func (d Destination) String() string {
+	if d.ARN != "" {
+		return d.ARN
+	}
 	return DestinationARNPrefix + d.Bucket
 }
 
@@ -106,6 +116,18 @@ func (d Destination) Validate(bucketName string) error {
 
 // parseDestination - parses string to Destination.
 func parseDestination(s string) (Destination, error) {
+	if strings.HasPrefix(s, DestinationTargetARNPrefix) {
+		// arn:minio:replication:<region>:<id>:<remote-bucket>
+		tokens := strings.Split(s, ":")
+		if len(tokens) != 6 || tokens[4] == "" || tokens[5] == "" {
+			return Destination{}, Errorf("invalid destination '%v'", s)
+		}
+		return Destination{
+			Bucket: tokens[5],
+			ARN:    s,
+		}, nil
+	}
+
 	if !strings.HasPrefix(s, DestinationARNPrefix) {
 		return Destination{}, Errorf("invalid destination '%v'", s)
 	}
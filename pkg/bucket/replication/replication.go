@@ -57,6 +57,7 @@ var (
 	errReplicationUniquePriority      = Errorf("Replication configuration has duplicate priority")
 	errReplicationDestinationMismatch = Errorf("The destination bucket must be same for all rules")
 	errRoleArnMissing                 = Errorf("Missing required parameter `Role` in ReplicationConfiguration")
+	errRoleArnPresentForTargetArns    = Errorf("`Role` must be empty when the destinations of the rules are remote target ARNs")
 )
 
 // Config - replication configuration specified in
@@ -90,18 +91,25 @@ func (c Config) Validate(bucket string, sameTarget bool) error {
 	if len(c.Rules) == 0 {
 		return errReplicationNoRule
 	}
-	if c.RoleArn == "" {
-		return errRoleArnMissing
-	}
 	// Validate all the rules in the replication config
 	targetMap := make(map[string]struct{})
 	priorityMap := make(map[string]struct{})
 	for _, r := range c.Rules {
-		if len(targetMap) == 0 {
-			targetMap[r.Destination.Bucket] = struct{}{}
+		// Either all rules replicate to the target of the configuration
+		// or each rule names its own target.
+		if c.RoleArn == "" && r.Destination.ARN == "" {
+			return errRoleArnMissing
+		}
+		if c.RoleArn != "" && r.Destination.ARN != "" {
+			return errRoleArnPresentForTargetArns
 		}
-		if _, ok := targetMap[r.Destination.Bucket]; !ok {
-			return errReplicationDestinationMismatch
+		if c.RoleArn != "" {
+			if len(targetMap) == 0 {
+				targetMap[r.Destination.Bucket] = struct{}{}
+			}
+			if _, ok := targetMap[r.Destination.Bucket]; !ok {
+				return errReplicationDestinationMismatch
+			}
 		}
 		if err := r.Validate(bucket, sameTarget); err != nil {
 			return err
@@ -123,6 +131,11 @@ type ObjectOpts struct {
 	IsLatest     bool
 	DeleteMarker bool
 	SSEC         bool
+	// TargetArn limits the rules to those replicating to this target.
+	TargetArn string
+	// ExistingObject is set for objects which were not
+	// considered for replication when they were written.
+	ExistingObject bool
 }
 
 // FilterActionableRules returns the rules actions that need to be executed
@@ -136,6 +149,9 @@ func (c Config) FilterActionableRules(obj ObjectOpts) []Rule {
 		if rule.Status == Disabled {
 			continue
 		}
+		if obj.TargetArn != "" && c.RuleTargetArn(rule) != obj.TargetArn {
+			continue
+		}
 		if !strings.HasPrefix(obj.Name, rule.Prefix()) {
 			continue
 		}
@@ -157,6 +173,43 @@ func (c Config) GetDestination() Destination {
 	return Destination{}
 }
 
+// RuleTargetArn returns the ARN of the remote target the rule replicates to.
+func (c Config) RuleTargetArn(rule Rule) string {
+	if rule.Destination.ARN != "" {
+		return rule.Destination.ARN
+	}
+	return c.RoleArn
+}
+
+// TargetArns returns the ARNs of all remote targets of the configuration.
+func (c Config) TargetArns() []string {
+	var arns []string
+	seen := make(map[string]struct{})
+	for _, rule := range c.Rules {
+		arn := c.RuleTargetArn(rule)
+		if _, ok := seen[arn]; ok {
+			continue
+		}
+		seen[arn] = struct{}{}
+		arns = append(arns, arn)
+	}
+	return arns
+}
+
+// FilterTargetArns returns the ARNs of the remote targets the object
+// should be replicated to.
+func (c Config) FilterTargetArns(obj ObjectOpts) []string {
+	var arns []string
+	for _, arn := range c.TargetArns() {
+		opts := obj
+		opts.TargetArn = arn
+		if c.Replicate(opts) {
+			arns = append(arns, arn)
+		}
+	}
+	return arns
+}
+
 // Replicate returns true if the object should be replicated.
 func (c Config) Replicate(obj ObjectOpts) bool {
 
@@ -173,6 +226,9 @@ func (c Config) Replicate(obj ObjectOpts) bool {
 		if obj.SSEC {
 			return false
 		}
+		if obj.ExistingObject && rule.ExistingObjectReplication.Status != Enabled {
+			return false
+		}
 		if rule.Status == Disabled {
 			continue
 		}
@@ -0,0 +1,151 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"bytes"
+	"encoding/xml"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const (
+	testArn1 = "arn:minio:replication::id1:dest1"
+	testArn2 = "arn:minio:replication::id2:dest2"
+)
+
+func testRule(priority int, prefix, dest string) string {
+	return `<Rule><Status>Enabled</Status><Priority>` + strconv.Itoa(priority) + `</Priority>` +
+		`<DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication>` +
+		`<DeleteReplication><Status>Disabled</Status></DeleteReplication>` +
+		`<Filter><Prefix>` + prefix + `</Prefix></Filter>` +
+		`<Destination><Bucket>` + dest + `</Bucket></Destination></Rule>`
+}
+
+func TestParseAndValidateTargetArns(t *testing.T) {
+	testCases := []struct {
+		role  string
+		rules []string
+		valid bool
+	}{
+		// Single target named by the configuration.
+		{testArn1, []string{testRule(1, "", "arn:aws:s3:::dest1")}, true},
+		{testArn1, []string{testRule(1, "", "arn:aws:s3:::dest1"), testRule(2, "", "arn:aws:s3:::dest2")}, false},
+		// Each rule names its own target.
+		{"", []string{testRule(1, "a/", testArn1), testRule(2, "", testArn2)}, true},
+		{"", []string{testRule(1, "", testArn1), testRule(2, "", "arn:aws:s3:::dest2")}, false},
+		{testArn1, []string{testRule(1, "", testArn2)}, false},
+		{"", []string{testRule(1, "", "arn:minio:replication::id1")}, false},
+	}
+	for i, tc := range testCases {
+		config := `<ReplicationConfiguration><Role>` + tc.role + `</Role>` + strings.Join(tc.rules, "") + `</ReplicationConfiguration>`
+		cfg, err := ParseConfig(bytes.NewReader([]byte(config)))
+		if err == nil {
+			err = cfg.Validate("bucket", false)
+		}
+		if (err == nil) != tc.valid {
+			t.Errorf("test %d: expected valid %v, got %v", i+1, tc.valid, err)
+		}
+	}
+}
+
+func TestFilterTargetArns(t *testing.T) {
+	config := `<ReplicationConfiguration>` + testRule(1, "a/", testArn1) + testRule(2, "", testArn2) + `</ReplicationConfiguration>`
+	cfg, err := ParseConfig(bytes.NewReader([]byte(config)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dest := cfg.Rules[0].Destination; dest.Bucket != "dest1" || dest.ARN != testArn1 {
+		t.Fatalf("unexpected destination %#v", dest)
+	}
+
+	// Destination ARNs are preserved.
+	data, err := xml.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg2, err := ParseConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cfg2.TargetArns(), []string{testArn1, testArn2}) {
+		t.Fatalf("unexpected targets %v", cfg2.TargetArns())
+	}
+
+	testCases := []struct {
+		object string
+		arns   []string
+	}{
+		{"a/object", []string{testArn1, testArn2}},
+		{"b/object", []string{testArn2}},
+	}
+	for i, tc := range testCases {
+		if arns := cfg.FilterTargetArns(ObjectOpts{Name: tc.object}); !reflect.DeepEqual(arns, tc.arns) {
+			t.Errorf("test %d: expected %v, got %v", i+1, tc.arns, arns)
+		}
+	}
+}
+
+func TestExistingObjectReplication(t *testing.T) {
+	existing := strings.Replace(testRule(2, "a/", testArn1), `</Rule>`,
+		`<ExistingObjectReplication><Status>Enabled</Status></ExistingObjectReplication></Rule>`, 1)
+	config := `<ReplicationConfiguration>` + existing + testRule(1, "", testArn2) + `</ReplicationConfiguration>`
+	cfg, err := ParseConfig(bytes.NewReader([]byte(config)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cfg.Validate("bucket", false); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name     string
+		existing bool
+		expected []string
+	}{
+		{"a/obj", false, []string{testArn1, testArn2}},
+		{"a/obj", true, []string{testArn1}},
+		{"b/obj", false, []string{testArn2}},
+		{"b/obj", true, nil},
+	}
+	for i, tc := range testCases {
+		arns := cfg.FilterTargetArns(ObjectOpts{Name: tc.name, ExistingObject: tc.existing})
+		if !reflect.DeepEqual(arns, tc.expected) {
+			t.Errorf("test %d: expected %v, got %v", i+1, tc.expected, arns)
+		}
+	}
+
+	// The element is only encoded when set.
+	data, err := xml.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(data), "<ExistingObjectReplication>"); n != 1 {
+		t.Fatalf("expected 1 ExistingObjectReplication element, got %d: %s", n, data)
+	}
+
+	invalid := strings.Replace(config, `<Status>Enabled</Status></ExistingObjectReplication>`,
+		`<Status>On</Status></ExistingObjectReplication>`, 1)
+	if cfg, err = ParseConfig(bytes.NewReader([]byte(invalid))); err != nil {
+		t.Fatal(err)
+	}
+	if err = cfg.Validate("bucket", false); err != errInvalidExistingObjectReplicationStatus {
+		t.Fatalf("expected %v, got %v", errInvalidExistingObjectReplicationStatus, err)
+	}
+}
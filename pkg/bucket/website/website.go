@@ -0,0 +1,243 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package website
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Maximum number of routing rules allowed per bucket.
+const maxRoutingRules = 50
+
+// Supported redirect protocols.
+const (
+	ProtocolHTTP  = "http"
+	ProtocolHTTPS = "https"
+)
+
+// IndexDocument - the document returned for requests on a "directory".
+type IndexDocument struct {
+	Suffix string `xml:"Suffix"`
+}
+
+// ErrorDocument - the object returned when an error occurs.
+type ErrorDocument struct {
+	Key string `xml:"Key"`
+}
+
+// RedirectAllRequestsTo - redirects all requests on the bucket to
+// another host.
+type RedirectAllRequestsTo struct {
+	HostName string `xml:"HostName"`
+	Protocol string `xml:"Protocol,omitempty"`
+}
+
+// Condition - condition which must be met for a redirect to apply.
+type Condition struct {
+	HTTPErrorCodeReturnedEquals string `xml:"HttpErrorCodeReturnedEquals,omitempty"`
+	KeyPrefixEquals             string `xml:"KeyPrefixEquals,omitempty"`
+}
+
+// Redirect - redirect information of a routing rule.
+type Redirect struct {
+	HostName             string `xml:"HostName,omitempty"`
+	HTTPRedirectCode     string `xml:"HttpRedirectCode,omitempty"`
+	Protocol             string `xml:"Protocol,omitempty"`
+	ReplaceKeyPrefixWith string `xml:"ReplaceKeyPrefixWith,omitempty"`
+	ReplaceKeyWith       string `xml:"ReplaceKeyWith,omitempty"`
+}
+
+// RoutingRule - redirect rule applied when its condition is met.
+type RoutingRule struct {
+	Condition *Condition `xml:"Condition,omitempty"`
+	Redirect  Redirect   `xml:"Redirect"`
+}
+
+// Config - bucket website configuration.
+type Config struct {
+	XMLNS                 string                 `xml:"xmlns,attr,omitempty"`
+	XMLName               xml.Name               `xml:"WebsiteConfiguration"`
+	RedirectAllRequestsTo *RedirectAllRequestsTo `xml:"RedirectAllRequestsTo,omitempty"`
+	IndexDocument         *IndexDocument         `xml:"IndexDocument,omitempty"`
+	ErrorDocument         *ErrorDocument         `xml:"ErrorDocument,omitempty"`
+	RoutingRules          []RoutingRule          `xml:"RoutingRules>RoutingRule,omitempty"`
+}
+
+func validateProtocol(protocol string) error {
+	switch protocol {
+	case "", ProtocolHTTP, ProtocolHTTPS:
+		return nil
+	}
+	return Errorf("invalid protocol %s, valid values are http and https", protocol)
+}
+
+// Validate - validates the routing rule.
+func (r RoutingRule) Validate() error {
+	if r.Condition != nil {
+		if r.Condition.KeyPrefixEquals == "" && r.Condition.HTTPErrorCodeReturnedEquals == "" {
+			return Errorf("Condition must specify KeyPrefixEquals or HttpErrorCodeReturnedEquals")
+		}
+		if code := r.Condition.HTTPErrorCodeReturnedEquals; code != "" {
+			n, err := strconv.Atoi(code)
+			if err != nil || n < 400 || n > 599 {
+				return Errorf("invalid HttpErrorCodeReturnedEquals %s", code)
+			}
+		}
+	}
+	redirect := r.Redirect
+	if redirect == (Redirect{}) {
+		return Errorf("Redirect must specify at least one element")
+	}
+	if redirect.ReplaceKeyPrefixWith != "" && redirect.ReplaceKeyWith != "" {
+		return Errorf("ReplaceKeyPrefixWith and ReplaceKeyWith can not be specified together")
+	}
+	if code := redirect.HTTPRedirectCode; code != "" {
+		n, err := strconv.Atoi(code)
+		if err != nil || n < 300 || n > 399 {
+			return Errorf("invalid HttpRedirectCode %s", code)
+		}
+	}
+	return validateProtocol(redirect.Protocol)
+}
+
+// Validate - validates the website configuration.
+func (c Config) Validate() error {
+	if c.RedirectAllRequestsTo != nil {
+		if c.IndexDocument != nil || c.ErrorDocument != nil || len(c.RoutingRules) > 0 {
+			return Errorf("RedirectAllRequestsTo can not be specified with any other element")
+		}
+		if c.RedirectAllRequestsTo.HostName == "" {
+			return Errorf("RedirectAllRequestsTo must specify HostName")
+		}
+		return validateProtocol(c.RedirectAllRequestsTo.Protocol)
+	}
+	if c.IndexDocument == nil || c.IndexDocument.Suffix == "" {
+		return Errorf("IndexDocument Suffix must be specified")
+	}
+	if strings.Contains(c.IndexDocument.Suffix, "/") {
+		return Errorf("IndexDocument Suffix can not contain '/'")
+	}
+	if c.ErrorDocument != nil && c.ErrorDocument.Key == "" {
+		return Errorf("ErrorDocument Key must be specified")
+	}
+	if len(c.RoutingRules) > maxRoutingRules {
+		return Errorf("website configuration allows a maximum of %d routing rules", maxRoutingRules)
+	}
+	for _, rule := range c.RoutingRules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IndexKey - returns the key to be served for the requested key,
+// requests on the root or on a "directory" are served the index
+// document of that directory.
+func (c Config) IndexKey(key string) string {
+	if c.IndexDocument == nil {
+		return key
+	}
+	if key == "" || strings.HasSuffix(key, "/") {
+		return key + c.IndexDocument.Suffix
+	}
+	return key
+}
+
+// MatchRoutingRule - returns the first routing rule which applies to the
+// key, statusCode is the HTTP error code returned while serving the key,
+// zero when the key has not been served yet.
+func (c Config) MatchRoutingRule(key string, statusCode int) (RoutingRule, bool) {
+	for _, rule := range c.RoutingRules {
+		if rule.Condition == nil {
+			if statusCode == 0 {
+				return rule, true
+			}
+			continue
+		}
+		if !strings.HasPrefix(key, rule.Condition.KeyPrefixEquals) {
+			continue
+		}
+		switch rule.Condition.HTTPErrorCodeReturnedEquals {
+		case "":
+			if statusCode == 0 {
+				return rule, true
+			}
+		case strconv.Itoa(statusCode):
+			return rule, true
+		}
+	}
+	return RoutingRule{}, false
+}
+
+// Location - returns the redirect location for the key, host and
+// protocol are used when the rule does not replace them.
+func (r RoutingRule) Location(key, host, protocol string) string {
+	redirect := r.Redirect
+	if redirect.HostName != "" {
+		host = redirect.HostName
+	}
+	if redirect.Protocol != "" {
+		protocol = redirect.Protocol
+	}
+	switch {
+	case redirect.ReplaceKeyWith != "":
+		key = redirect.ReplaceKeyWith
+	case redirect.ReplaceKeyPrefixWith != "":
+		var prefix string
+		if r.Condition != nil {
+			prefix = r.Condition.KeyPrefixEquals
+		}
+		key = redirect.ReplaceKeyPrefixWith + strings.TrimPrefix(key, prefix)
+	}
+	return protocol + "://" + host + "/" + key
+}
+
+// StatusCode - returns the HTTP status code of the redirect.
+func (r RoutingRule) StatusCode() int {
+	if r.Redirect.HTTPRedirectCode != "" {
+		if n, err := strconv.Atoi(r.Redirect.HTTPRedirectCode); err == nil {
+			return n
+		}
+	}
+	return http.StatusMovedPermanently
+}
+
+// Location - returns the redirect location for the key, protocol is used
+// when no protocol is configured.
+func (r RedirectAllRequestsTo) Location(key, protocol string) string {
+	if r.Protocol != "" {
+		protocol = r.Protocol
+	}
+	return protocol + "://" + r.HostName + "/" + key
+}
+
+// ParseConfig - parses data in given reader to website configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var c Config
+	if err := xml.NewDecoder(reader).Decode(&c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,131 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package website
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		inputXML   string
+		shouldPass bool
+	}{
+		// 1. Index and error documents.
+		{
+			inputXML:   `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><ErrorDocument><Key>error.html</Key></ErrorDocument></WebsiteConfiguration>`,
+			shouldPass: true,
+		},
+		// 2. Redirect all requests.
+		{
+			inputXML:   `<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName><Protocol>https</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`,
+			shouldPass: true,
+		},
+		// 3. Redirect all requests along with an index document.
+		{
+			inputXML:   `<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName></RedirectAllRequestsTo><IndexDocument><Suffix>index.html</Suffix></IndexDocument></WebsiteConfiguration>`,
+			shouldPass: false,
+		},
+		// 4. Missing index document.
+		{
+			inputXML:   `<WebsiteConfiguration><ErrorDocument><Key>error.html</Key></ErrorDocument></WebsiteConfiguration>`,
+			shouldPass: false,
+		},
+		// 5. Index document suffix with a slash.
+		{
+			inputXML:   `<WebsiteConfiguration><IndexDocument><Suffix>a/index.html</Suffix></IndexDocument></WebsiteConfiguration>`,
+			shouldPass: false,
+		},
+		// 6. Valid routing rule.
+		{
+			inputXML:   `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition><Redirect><ReplaceKeyPrefixWith>documents/</ReplaceKeyPrefixWith></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			shouldPass: true,
+		},
+		// 7. Routing rule replacing both key and key prefix.
+		{
+			inputXML:   `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Redirect><ReplaceKeyPrefixWith>a/</ReplaceKeyPrefixWith><ReplaceKeyWith>b</ReplaceKeyWith></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			shouldPass: false,
+		},
+		// 8. Routing rule with invalid redirect code.
+		{
+			inputXML:   `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Redirect><HttpRedirectCode>200</HttpRedirectCode></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			shouldPass: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		_, err := ParseConfig(strings.NewReader(tc.inputXML))
+		if tc.shouldPass && err != nil {
+			t.Errorf("Test %d: expected to pass, failed with %v", i+1, err)
+		}
+		if !tc.shouldPass && err == nil {
+			t.Errorf("Test %d: expected to fail, but passed", i+1)
+		}
+	}
+}
+
+func TestRoutingRules(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader(`<WebsiteConfiguration>
+<IndexDocument><Suffix>index.html</Suffix></IndexDocument>
+<RoutingRules>
+<RoutingRule><Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition><Redirect><ReplaceKeyPrefixWith>documents/</ReplaceKeyPrefixWith></Redirect></RoutingRule>
+<RoutingRule><Condition><HttpErrorCodeReturnedEquals>404</HttpErrorCodeReturnedEquals></Condition><Redirect><HostName>example.com</HostName><HttpRedirectCode>302</HttpRedirectCode><ReplaceKeyWith>404.html</ReplaceKeyWith></Redirect></RoutingRule>
+</RoutingRules>
+</WebsiteConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if key := config.IndexKey(""); key != "index.html" {
+		t.Errorf("expected index.html, got %s", key)
+	}
+	if key := config.IndexKey("photos/"); key != "photos/index.html" {
+		t.Errorf("expected photos/index.html, got %s", key)
+	}
+
+	testCases := []struct {
+		key          string
+		statusCode   int
+		expectedOk   bool
+		expectedLoc  string
+		expectedCode int
+	}{
+		{"docs/a.html", 0, true, "http://minio/documents/a.html", http.StatusMovedPermanently},
+		{"images/a.png", 0, false, "", 0},
+		{"images/a.png", http.StatusNotFound, true, "http://example.com/404.html", http.StatusFound},
+		{"images/a.png", http.StatusForbidden, false, "", 0},
+	}
+
+	for i, tc := range testCases {
+		rule, ok := config.MatchRoutingRule(tc.key, tc.statusCode)
+		if ok != tc.expectedOk {
+			t.Errorf("Test %d: expected match %v, got %v", i+1, tc.expectedOk, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if loc := rule.Location(tc.key, "minio", "http"); loc != tc.expectedLoc {
+			t.Errorf("Test %d: expected location %s, got %s", i+1, tc.expectedLoc, loc)
+		}
+		if code := rule.StatusCode(); code != tc.expectedCode {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, tc.expectedCode, code)
+		}
+	}
+}
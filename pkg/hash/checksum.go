@@ -0,0 +1,206 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hash
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"hash"
+	"hash/crc32"
+	"net/http"
+	"strconv"
+	"strings"
+
+	sha256 "github.com/minio/sha256-simd"
+)
+
+// ChecksumType - algorithm of a content checksum.
+type ChecksumType string
+
+// Supported checksum algorithms.
+const (
+	ChecksumCRC32  ChecksumType = "CRC32"
+	ChecksumCRC32C ChecksumType = "CRC32C"
+	ChecksumSHA1   ChecksumType = "SHA1"
+	ChecksumSHA256 ChecksumType = "SHA256"
+)
+
+// ChecksumTypes - all supported checksum algorithms.
+var ChecksumTypes = []ChecksumType{ChecksumCRC32, ChecksumCRC32C, ChecksumSHA1, ChecksumSHA256}
+
+const (
+	// Prefix of the headers carrying content checksums.
+	checksumHeaderPrefix = "x-amz-checksum-"
+
+	// Header naming the checksum sent in the trailer of aws-chunked uploads.
+	trailerHeader = "x-amz-trailer"
+)
+
+// NewChecksumType - returns the checksum algorithm of given name, the
+// name is case insensitive.
+func NewChecksumType(name string) (ChecksumType, error) {
+	for _, t := range ChecksumTypes {
+		if strings.EqualFold(string(t), name) {
+			return t, nil
+		}
+	}
+	return "", ErrInvalidChecksum
+}
+
+// Header - returns the header carrying checksums of this type.
+func (t ChecksumType) Header() string {
+	return checksumHeaderPrefix + strings.ToLower(string(t))
+}
+
+// Hasher - returns a new hash computing checksums of this type.
+func (t ChecksumType) Hasher() hash.Hash {
+	switch t {
+	case ChecksumCRC32:
+		return crc32.NewIEEE()
+	case ChecksumCRC32C:
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	case ChecksumSHA1:
+		return sha1.New()
+	case ChecksumSHA256:
+		return sha256.New()
+	}
+	return nil
+}
+
+// Checksum - base64 encoded content checksum. Checksums of multipart
+// objects are checksums of the checksums of their parts, suffixed by
+// the number of parts, i.e. <base64>-N.
+type Checksum struct {
+	Type    ChecksumType
+	Encoded string
+}
+
+// NewChecksum - returns the checksum of given type and base64 encoded
+// value, the value must be a valid checksum of that type.
+func NewChecksum(t ChecksumType, encoded string) (Checksum, error) {
+	h := t.Hasher()
+	if h == nil {
+		return Checksum{}, ErrInvalidChecksum
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(raw) != h.Size() {
+		return Checksum{}, ErrInvalidChecksum
+	}
+	return Checksum{Type: t, Encoded: encoded}, nil
+}
+
+// ParseChecksum - parses a checksum formatted by Checksum.String.
+func ParseChecksum(s string) (Checksum, error) {
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return Checksum{}, ErrInvalidChecksum
+	}
+	t, err := NewChecksumType(s[:i])
+	if err != nil {
+		return Checksum{}, err
+	}
+	encoded := s[i+1:]
+	if j := strings.IndexByte(encoded, '-'); j >= 0 {
+		if n, err := strconv.Atoi(encoded[j+1:]); err != nil || n <= 0 {
+			return Checksum{}, ErrInvalidChecksum
+		}
+		if _, err = NewChecksum(t, encoded[:j]); err != nil {
+			return Checksum{}, err
+		}
+		return Checksum{Type: t, Encoded: encoded}, nil
+	}
+	return NewChecksum(t, encoded)
+}
+
+// IsSet - returns true if the checksum is set.
+func (c Checksum) IsSet() bool {
+	return c.Type != "" && c.Encoded != ""
+}
+
+// Raw - returns the decoded checksum, the part count of multipart
+// checksums is not included.
+func (c Checksum) Raw() []byte {
+	encoded := c.Encoded
+	if i := strings.IndexByte(encoded, '-'); i >= 0 {
+		encoded = encoded[:i]
+	}
+	raw, _ := base64.StdEncoding.DecodeString(encoded)
+	return raw
+}
+
+// String - returns the checksum formatted as <type>:<value>.
+func (c Checksum) String() string {
+	return string(c.Type) + ":" + c.Encoded
+}
+
+// CompositeChecksum - returns the checksum of a multipart object, which
+// is the checksum of the concatenated checksums of its parts. All parts
+// must have checksums of the same type.
+func CompositeChecksum(parts []Checksum) (Checksum, error) {
+	if len(parts) == 0 {
+		return Checksum{}, ErrInvalidChecksum
+	}
+	h := parts[0].Type.Hasher()
+	if h == nil {
+		return Checksum{}, ErrInvalidChecksum
+	}
+	for _, part := range parts {
+		if part.Type != parts[0].Type || !part.IsSet() {
+			return Checksum{}, ErrInvalidChecksum
+		}
+		h.Write(part.Raw())
+	}
+	return Checksum{
+		Type:    parts[0].Type,
+		Encoded: base64.StdEncoding.EncodeToString(h.Sum(nil)) + "-" + strconv.Itoa(len(parts)),
+	}, nil
+}
+
+// GetContentChecksum - returns the checksum sent with the
+// x-amz-checksum-* request headers. When the checksum is sent in the
+// trailer of an aws-chunked upload, as announced by x-amz-trailer, only
+// its type is returned and trailing is true. A nil checksum is returned
+// if the request has no checksum.
+func GetContentChecksum(h http.Header) (checksum *Checksum, trailing bool, err error) {
+	if name := h.Get(trailerHeader); name != "" {
+		for _, t := range ChecksumTypes {
+			if strings.EqualFold(name, t.Header()) {
+				checksum = &Checksum{Type: t}
+				trailing = true
+			}
+		}
+		if checksum == nil {
+			return nil, false, ErrInvalidChecksum
+		}
+	}
+	for _, t := range ChecksumTypes {
+		value := h.Get(t.Header())
+		if value == "" {
+			continue
+		}
+		// Only one checksum can be sent.
+		if checksum != nil {
+			return nil, false, ErrInvalidChecksum
+		}
+		c, err := NewChecksum(t, value)
+		if err != nil {
+			return nil, false, err
+		}
+		checksum = &c
+	}
+	return checksum, trailing, nil
+}
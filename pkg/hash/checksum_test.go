@@ -0,0 +1,155 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hash
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestParseChecksum(t *testing.T) {
+	testCases := []struct {
+		value      string
+		shouldPass bool
+	}{
+		{"CRC32:7YLNEQ==", true},
+		{"CRC32C:ksgKMQ==", true},
+		{"SHA1:gf6L/odXbD7LIkJvjleEc4KRes8=", true},
+		{"SHA256:iNQmb9TmM40TuEX88olXnSCciXgjuSF9o+Fhk28DFYk=", true},
+		{"CRC32:QMh4oA==-2", true},
+		// Unknown type.
+		{"MD5:7YLNEQ==", false},
+		// Invalid size.
+		{"SHA1:7YLNEQ==", false},
+		// Invalid encoding.
+		{"CRC32:7YLNEQ", false},
+		// Invalid part count.
+		{"CRC32:QMh4oA==-0", false},
+		{"7YLNEQ==", false},
+	}
+	for i, tc := range testCases {
+		c, err := ParseChecksum(tc.value)
+		if tc.shouldPass && err != nil {
+			t.Errorf("Test %d: expected to pass, failed with %v", i+1, err)
+		}
+		if !tc.shouldPass && err == nil {
+			t.Errorf("Test %d: expected to fail, but passed", i+1)
+		}
+		if err == nil && c.String() != tc.value {
+			t.Errorf("Test %d: expected %s, got %s", i+1, tc.value, c.String())
+		}
+	}
+}
+
+func TestCompositeChecksum(t *testing.T) {
+	part := Checksum{Type: ChecksumCRC32, Encoded: "7YLNEQ=="}
+	c, err := CompositeChecksum([]Checksum{part, part})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Encoded != "QMh4oA==-2" {
+		t.Fatalf("expected QMh4oA==-2, got %s", c.Encoded)
+	}
+	if _, err = CompositeChecksum([]Checksum{part, {Type: ChecksumCRC32C, Encoded: "ksgKMQ=="}}); err == nil {
+		t.Fatal("expected checksums of different types to fail")
+	}
+}
+
+func TestHashReaderChecksum(t *testing.T) {
+	testCases := []struct {
+		header    http.Header
+		trailer   http.Header
+		algorithm ChecksumType
+		checksum  string
+		err       error
+	}{
+		// No checksum.
+		{header: http.Header{}},
+		// Valid checksum.
+		{
+			header:   http.Header{"X-Amz-Checksum-Crc32c": []string{"ksgKMQ=="}},
+			checksum: "CRC32C:ksgKMQ==",
+		},
+		// Checksum mismatch.
+		{
+			header: http.Header{"X-Amz-Checksum-Sha1": []string{"2jmj7l5rSw0yVb/vlWAYkK/YBwk="}},
+			err:    ChecksumMismatch{Want: "2jmj7l5rSw0yVb/vlWAYkK/YBwk=", Got: "gf6L/odXbD7LIkJvjleEc4KRes8="},
+		},
+		// Computed with the algorithm of the upload.
+		{
+			header:    http.Header{},
+			algorithm: ChecksumSHA256,
+			checksum:  "SHA256:iNQmb9TmM40TuEX88olXnSCciXgjuSF9o+Fhk28DFYk=",
+		},
+		// Valid trailing checksum.
+		{
+			header:   http.Header{"X-Amz-Trailer": []string{"x-amz-checksum-crc32"}},
+			trailer:  http.Header{"X-Amz-Checksum-Crc32": []string{"7YLNEQ=="}},
+			checksum: "CRC32:7YLNEQ==",
+		},
+		// Trailing checksum mismatch.
+		{
+			header:  http.Header{"X-Amz-Trailer": []string{"x-amz-checksum-crc32"}},
+			trailer: http.Header{"X-Amz-Checksum-Crc32": []string{"ksgKMQ=="}},
+			err:     ChecksumMismatch{Want: "ksgKMQ==", Got: "7YLNEQ=="},
+		},
+		// Missing trailing checksum.
+		{
+			header:  http.Header{"X-Amz-Trailer": []string{"x-amz-checksum-crc32"}},
+			trailer: http.Header{},
+			err:     ErrInvalidChecksum,
+		},
+	}
+	for i, tc := range testCases {
+		r, err := NewReader(bytes.NewReader([]byte("abcd")), 4, "", "", 4, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = r.AddChecksum(&http.Request{Header: tc.header, Trailer: tc.trailer}, tc.algorithm); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		_, err = io.Copy(ioutil.Discard, r)
+		if err != tc.err {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, tc.err, err)
+		}
+		if err != nil {
+			continue
+		}
+		var checksum string
+		if c := r.ContentChecksum(); c != nil {
+			checksum = c.String()
+		}
+		if checksum != tc.checksum {
+			t.Errorf("Test %d: expected checksum %q, got %q", i+1, tc.checksum, checksum)
+		}
+	}
+
+	// Checksums of several types or of another type than the
+	// algorithm of the upload are rejected.
+	r, _ := NewReader(bytes.NewReader([]byte("abcd")), 4, "", "", 4, false)
+	header := http.Header{"X-Amz-Checksum-Crc32": []string{"7YLNEQ=="}, "X-Amz-Checksum-Crc32c": []string{"ksgKMQ=="}}
+	if err := r.AddChecksum(&http.Request{Header: header}, ""); err != ErrInvalidChecksum {
+		t.Errorf("expected %v, got %v", ErrInvalidChecksum, err)
+	}
+	header = http.Header{"X-Amz-Checksum-Crc32": []string{"7YLNEQ=="}}
+	if err := r.AddChecksum(&http.Request{Header: header}, ChecksumSHA1); err != ErrInvalidChecksum {
+		t.Errorf("expected %v, got %v", ErrInvalidChecksum, err)
+	}
+}
@@ -16,7 +16,10 @@
 
 package hash
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 // SHA256Mismatch - when content sha256 does not match with what was sent from client.
 type SHA256Mismatch struct {
@@ -47,3 +50,16 @@ type ErrSizeMismatch struct {
 func (e ErrSizeMismatch) Error() string {
 	return fmt.Sprintf("Size mismatch: got %d, want %d", e.Got, e.Want)
 }
+
+// ErrInvalidChecksum - invalid or multiple checksums were sent by the client.
+var ErrInvalidChecksum = errors.New("invalid checksum provided")
+
+// ChecksumMismatch - when the content checksum does not match with what was sent from client.
+type ChecksumMismatch struct {
+	Want string
+	Got  string
+}
+
+func (e ChecksumMismatch) Error() string {
+	return "Bad checksum: Expected " + e.Want + " does not match calculated " + e.Got
+}
@@ -24,6 +24,8 @@ import (
 	"errors"
 	"hash"
 	"io"
+	"io/ioutil"
+	"net/http"
 
 	sha256 "github.com/minio/sha256-simd"
 )
@@ -38,6 +40,15 @@ type Reader struct {
 
 	md5sum, sha256sum   []byte // Byte values of md5sum, sha256sum of client sent values.
 	md5Hash, sha256Hash hash.Hash
+
+	// Source before it was limited to size, trailing checksums
+	// are only known once it is read until EOF.
+	rawSrc io.Reader
+
+	checksum        *Checksum   // Client sent checksum, its value is empty when trailing.
+	trailer         http.Header // Trailer of the request carrying a trailing checksum.
+	checksumHash    hash.Hash
+	contentChecksum *Checksum // Checksum computed at EOF.
 }
 
 // NewReader returns a new hash Reader which computes the MD5 sum and
@@ -49,7 +60,7 @@ func NewReader(src io.Reader, size int64, md5Hex, sha256Hex string, actualSize i
 	}
 
 	// Create empty reader and merge into that.
-	r := Reader{src: src, size: -1, actualSize: -1}
+	r := Reader{src: src, rawSrc: src, size: -1, actualSize: -1}
 	return r.merge(size, md5Hex, sha256Hex, actualSize, strictCompat)
 }
 
@@ -62,6 +73,9 @@ func (r *Reader) Read(p []byte) (n int, err error) {
 		if r.sha256Hash != nil {
 			r.sha256Hash.Write(p[:n])
 		}
+		if r.checksumHash != nil {
+			r.checksumHash.Write(p[:n])
+		}
 	}
 	r.bytesRead += int64(n)
 
@@ -120,9 +134,79 @@ func (r *Reader) SHA256HexString() string {
 	return hex.EncodeToString(r.sha256sum)
 }
 
+// AddChecksum - verifies the content against the x-amz-checksum-*
+// header or the trailing checksum of the request. When the request
+// carries no checksum but an algorithm is given, the checksum of that
+// type is computed without verification. A checksum of another type
+// than the algorithm is an error.
+func (r *Reader) AddChecksum(req *http.Request, algorithm ChecksumType) error {
+	if r.bytesRead > 0 {
+		return errors.New("internal error: Already read from hash reader")
+	}
+	checksum, trailing, err := GetContentChecksum(req.Header)
+	if err != nil {
+		return err
+	}
+	if checksum == nil {
+		if algorithm == "" {
+			return nil
+		}
+		checksum = &Checksum{Type: algorithm}
+	}
+	if algorithm != "" && checksum.Type != algorithm {
+		return ErrInvalidChecksum
+	}
+	if trailing {
+		if req.Trailer == nil {
+			req.Trailer = make(http.Header)
+		}
+		r.trailer = req.Trailer
+	}
+	r.checksum = checksum
+	r.checksumHash = checksum.Type.Hasher()
+	return nil
+}
+
+// ContentChecksum - returns the checksum of the content computed at
+// EOF, nil if no checksum was requested.
+func (r *Reader) ContentChecksum() *Checksum {
+	return r.contentChecksum
+}
+
+// verifyChecksum verifies the computed checksum against the one sent
+// by the client, either in the headers or in the trailer.
+func (r *Reader) verifyChecksum() error {
+	if r.trailer != nil && r.checksum.Encoded == "" {
+		// The trailer follows the content, read the source
+		// until EOF so that the trailer is parsed.
+		if _, err := io.Copy(ioutil.Discard, r.rawSrc); err != nil {
+			return err
+		}
+		want, err := NewChecksum(r.checksum.Type, r.trailer.Get(r.checksum.Type.Header()))
+		if err != nil {
+			return err
+		}
+		r.checksum = &want
+	}
+	got := Checksum{
+		Type:    r.checksum.Type,
+		Encoded: base64.StdEncoding.EncodeToString(r.checksumHash.Sum(nil)),
+	}
+	if r.checksum.Encoded != "" && r.checksum.Encoded != got.Encoded {
+		return ChecksumMismatch{Want: r.checksum.Encoded, Got: got.Encoded}
+	}
+	r.contentChecksum = &got
+	return nil
+}
+
 // verify verifies if the computed MD5 sum and SHA256 sum are
 // equal to the ones specified when creating the Reader.
 func (r *Reader) verify() error {
+	if r.checksumHash != nil {
+		if err := r.verifyChecksum(); err != nil {
+			return err
+		}
+	}
 	if r.sha256Hash != nil && len(r.sha256sum) > 0 {
 		if sum := r.sha256Hash.Sum(nil); !bytes.Equal(r.sha256sum, sum) {
 			return SHA256Mismatch{hex.EncodeToString(r.sha256sum), hex.EncodeToString(sum)}
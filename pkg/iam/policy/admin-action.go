@@ -51,6 +51,10 @@ const (
 	HealthInfoAdminAction = "admin:OBDInfo"
 	// BandwidthMonitorAction - allow monitoring bandwidth usage
 	BandwidthMonitorAction = "admin:BandwidthMonitor"
+	// ReplicationInfoAdminAction - allow listing the queued replication tasks
+	ReplicationInfoAdminAction = "admin:ReplicationInfo"
+	// ReplicationResyncAdminAction - allow replicating again all versions of a bucket to a target
+	ReplicationResyncAdminAction = "admin:ReplicationResync"
 
 	// ServerUpdateAdminAction - allow MinIO binary update
 	ServerUpdateAdminAction = "admin:ServerUpdate"
@@ -58,6 +62,13 @@ const (
 	ServiceRestartAdminAction = "admin:ServiceRestart"
 	// ServiceStopAdminAction - allow stopping MinIO service.
 	ServiceStopAdminAction = "admin:ServiceStop"
+	// DecommissionAdminAction - allow starting and canceling the decommission of a pool
+	DecommissionAdminAction = "admin:Decommission"
+	// RebalanceAdminAction - allow starting and stopping the rebalance of pools
+	RebalanceAdminAction = "admin:Rebalance"
+
+	// RestripeAdminAction - allow starting and stopping the re-stripe of existing objects
+	RestripeAdminAction = "admin:Restripe"
 
 	// ConfigUpdateAdminAction - allow MinIO config management
 	ConfigUpdateAdminAction = "admin:ConfigUpdate"
@@ -110,6 +121,24 @@ const (
 	// GetBucketQuotaAdminAction - allow getting bucket quota
 	GetBucketQuotaAdminAction = "admin:GetBucketQuota"
 
+	// IAM quota Actions
+
+	// SetUserQuotaAdminAction - allow setting user and service account quota
+	SetUserQuotaAdminAction = "admin:SetUserQuota"
+	// GetUserQuotaAdminAction - allow getting user and service account quota
+	GetUserQuotaAdminAction = "admin:GetUserQuota"
+	// SetGroupQuotaAdminAction - allow setting group quota
+	SetGroupQuotaAdminAction = "admin:SetGroupQuota"
+	// GetGroupQuotaAdminAction - allow getting group quota
+	GetGroupQuotaAdminAction = "admin:GetGroupQuota"
+
+	// Bucket storage class Actions
+
+	// SetBucketStorageClassAdminAction - allow setting bucket storage classes
+	SetBucketStorageClassAdminAction = "admin:SetBucketStorageClass"
+	// GetBucketStorageClassAdminAction - allow getting bucket storage classes
+	GetBucketStorageClassAdminAction = "admin:GetBucketStorageClass"
+
 	// Bucket Target admin Actions
 
 	// SetBucketTargetAction - allow setting bucket target
@@ -123,43 +152,54 @@ const (
 
 // List of all supported admin actions.
 var supportedAdminActions = map[AdminAction]struct{}{
-	HealAdminAction:                {},
-	StorageInfoAdminAction:         {},
-	DataUsageInfoAdminAction:       {},
-	TopLocksAdminAction:            {},
-	ProfilingAdminAction:           {},
-	TraceAdminAction:               {},
-	ConsoleLogAdminAction:          {},
-	KMSKeyStatusAdminAction:        {},
-	ServerInfoAdminAction:          {},
-	HealthInfoAdminAction:          {},
-	BandwidthMonitorAction:         {},
-	ServerUpdateAdminAction:        {},
-	ServiceRestartAdminAction:      {},
-	ServiceStopAdminAction:         {},
-	ConfigUpdateAdminAction:        {},
-	CreateUserAdminAction:          {},
-	DeleteUserAdminAction:          {},
-	ListUsersAdminAction:           {},
-	EnableUserAdminAction:          {},
-	DisableUserAdminAction:         {},
-	GetUserAdminAction:             {},
-	AddUserToGroupAdminAction:      {},
-	RemoveUserFromGroupAdminAction: {},
-	GetGroupAdminAction:            {},
-	ListGroupsAdminAction:          {},
-	EnableGroupAdminAction:         {},
-	DisableGroupAdminAction:        {},
-	CreatePolicyAdminAction:        {},
-	DeletePolicyAdminAction:        {},
-	GetPolicyAdminAction:           {},
-	AttachPolicyAdminAction:        {},
-	ListUserPoliciesAdminAction:    {},
-	SetBucketQuotaAdminAction:      {},
-	GetBucketQuotaAdminAction:      {},
-	SetBucketTargetAction:          {},
-	GetBucketTargetAction:          {},
-	AllAdminActions:                {},
+	HealAdminAction:                  {},
+	StorageInfoAdminAction:           {},
+	DataUsageInfoAdminAction:         {},
+	TopLocksAdminAction:              {},
+	ProfilingAdminAction:             {},
+	TraceAdminAction:                 {},
+	ConsoleLogAdminAction:            {},
+	KMSKeyStatusAdminAction:          {},
+	ServerInfoAdminAction:            {},
+	HealthInfoAdminAction:            {},
+	BandwidthMonitorAction:           {},
+	ReplicationInfoAdminAction:       {},
+	ReplicationResyncAdminAction:     {},
+	ServerUpdateAdminAction:          {},
+	DecommissionAdminAction:          {},
+	RebalanceAdminAction:             {},
+	RestripeAdminAction:              {},
+	ServiceRestartAdminAction:        {},
+	ServiceStopAdminAction:           {},
+	ConfigUpdateAdminAction:          {},
+	CreateUserAdminAction:            {},
+	DeleteUserAdminAction:            {},
+	ListUsersAdminAction:             {},
+	EnableUserAdminAction:            {},
+	DisableUserAdminAction:           {},
+	GetUserAdminAction:               {},
+	AddUserToGroupAdminAction:        {},
+	RemoveUserFromGroupAdminAction:   {},
+	GetGroupAdminAction:              {},
+	ListGroupsAdminAction:            {},
+	EnableGroupAdminAction:           {},
+	DisableGroupAdminAction:          {},
+	CreatePolicyAdminAction:          {},
+	DeletePolicyAdminAction:          {},
+	GetPolicyAdminAction:             {},
+	AttachPolicyAdminAction:          {},
+	ListUserPoliciesAdminAction:      {},
+	SetBucketQuotaAdminAction:        {},
+	GetBucketQuotaAdminAction:        {},
+	SetUserQuotaAdminAction:          {},
+	GetUserQuotaAdminAction:          {},
+	SetGroupQuotaAdminAction:         {},
+	GetGroupQuotaAdminAction:         {},
+	SetBucketStorageClassAdminAction: {},
+	GetBucketStorageClassAdminAction: {},
+	SetBucketTargetAction:            {},
+	GetBucketTargetAction:            {},
+	AllAdminActions:                  {},
 }
 
 // IsValid - checks if action is valid or not.
@@ -170,40 +210,51 @@ func (action AdminAction) IsValid() bool {
 
 // adminActionConditionKeyMap - holds mapping of supported condition key for an action.
 var adminActionConditionKeyMap = map[Action]condition.KeySet{
-	AllAdminActions:                condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	HealAdminAction:                condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	StorageInfoAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ServerInfoAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DataUsageInfoAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	HealthInfoAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	BandwidthMonitorAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	TopLocksAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ProfilingAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	TraceAdminAction:               condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ConsoleLogAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	KMSKeyStatusAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ServerUpdateAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ServiceRestartAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ServiceStopAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ConfigUpdateAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	CreateUserAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DeleteUserAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ListUsersAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	EnableUserAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DisableUserAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	GetUserAdminAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	AddUserToGroupAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	RemoveUserFromGroupAdminAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ListGroupsAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	EnableGroupAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DisableGroupAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	CreatePolicyAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DeletePolicyAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	GetPolicyAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	AttachPolicyAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ListUserPoliciesAdminAction:    condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	SetBucketQuotaAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	GetBucketQuotaAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	SetBucketTargetAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	GetBucketTargetAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	AllAdminActions:                  condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	HealAdminAction:                  condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	StorageInfoAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ServerInfoAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DataUsageInfoAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	HealthInfoAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	BandwidthMonitorAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ReplicationInfoAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ReplicationResyncAdminAction:     condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	TopLocksAdminAction:              condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ProfilingAdminAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	TraceAdminAction:                 condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ConsoleLogAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	KMSKeyStatusAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ServerUpdateAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DecommissionAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	RebalanceAdminAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	RestripeAdminAction:              condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ServiceRestartAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ServiceStopAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ConfigUpdateAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	CreateUserAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DeleteUserAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListUsersAdminAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	EnableUserAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DisableUserAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetUserAdminAction:               condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	AddUserToGroupAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	RemoveUserFromGroupAdminAction:   condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListGroupsAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	EnableGroupAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DisableGroupAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	CreatePolicyAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DeletePolicyAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetPolicyAdminAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	AttachPolicyAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListUserPoliciesAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketQuotaAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketQuotaAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetUserQuotaAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetUserQuotaAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetGroupQuotaAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetGroupQuotaAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketStorageClassAdminAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketStorageClassAdminAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketTargetAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketTargetAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
 }
@@ -94,32 +94,38 @@ type Policy struct {
 	Statements []Statement `json:"Statement"`
 }
 
-// IsAllowed - checks given policy args is allowed to continue the Rest API.
-func (iamp Policy) IsAllowed(args Args) bool {
-	// Check all deny statements. If any one statement denies, return false.
+// Evaluate - returns whether given policy args are explicitly denied,
+// allowed or not matched by any statement of the policy.
+func (iamp Policy) Evaluate(args Args) policy.Decision {
+	// Check all deny statements. If any one statement denies, return denied.
 	for _, statement := range iamp.Statements {
 		if statement.Effect == policy.Deny {
 			if !statement.IsAllowed(args) {
-				return false
+				return policy.Denied
 			}
 		}
 	}
 
 	// For owner, its allowed by default.
 	if args.IsOwner {
-		return true
+		return policy.Allowed
 	}
 
-	// Check all allow statements. If any one statement allows, return true.
+	// Check all allow statements. If any one statement allows, return allowed.
 	for _, statement := range iamp.Statements {
 		if statement.Effect == policy.Allow {
 			if statement.IsAllowed(args) {
-				return true
+				return policy.Allowed
 			}
 		}
 	}
 
-	return false
+	return policy.NoMatch
+}
+
+// IsAllowed - checks given policy args is allowed to continue the Rest API.
+func (iamp Policy) IsAllowed(args Args) bool {
+	return iamp.Evaluate(args) == policy.Allowed
 }
 
 // IsEmpty - returns whether policy is empty or not.
@@ -0,0 +1,94 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// PoolCapacityForecast - capacity growth of a server pool.
+type PoolCapacityForecast struct {
+	ID      int    `json:"id"`
+	CmdLine string `json:"cmdline"`
+	// Raw capacity of the drives of the pool.
+	Used     uint64 `json:"used"`
+	Capacity uint64 `json:"capacity"`
+	// Growth of the used capacity per day.
+	GrowthRate float64 `json:"growthRate"`
+	// Projected date when the pool is full, zero if
+	// the used capacity is not growing.
+	Full time.Time `json:"full,omitempty"`
+}
+
+// BucketCapacityForecast - usage growth of a bucket.
+type BucketCapacityForecast struct {
+	Bucket  string `json:"bucket"`
+	Size    uint64 `json:"size"`
+	Objects uint64 `json:"objects"`
+	// Growth of the size and of the object count per day.
+	GrowthRate        float64 `json:"growthRate"`
+	ObjectsGrowthRate float64 `json:"objectsGrowthRate"`
+	// Projected date when the quota of the bucket is reached, zero
+	// if the bucket has no quota or its size is not growing.
+	Quota     uint64    `json:"quota,omitempty"`
+	QuotaFull time.Time `json:"quotaFull,omitempty"`
+}
+
+// CapacityForecast - capacity growth of all pools and buckets, estimated
+// from the usage history recorded by the data crawler.
+type CapacityForecast struct {
+	// Time of the last usage snapshot.
+	LastUpdate time.Time `json:"lastUpdate"`
+	// Time of the oldest usage snapshot the growth is estimated from.
+	Since time.Time `json:"since"`
+
+	Pools   []PoolCapacityForecast   `json:"pools"`
+	Buckets []BucketCapacityForecast `json:"buckets"`
+}
+
+// CapacityForecast - returns the growth rates of all pools and buckets,
+// along with the projected dates when the pools are full and when the
+// bucket quotas are reached.
+func (adm *AdminClient) CapacityForecast(ctx context.Context) (CapacityForecast, error) {
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		// GET <endpoint>/<admin-API>/capacity/forecast
+		relPath: adminAPIPrefix + "/capacity/forecast",
+	})
+	if err != nil {
+		return CapacityForecast{}, err
+	}
+	defer closeResponse(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return CapacityForecast{}, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return CapacityForecast{}, err
+	}
+	var forecast CapacityForecast
+	if err = json.Unmarshal(b, &forecast); err != nil {
+		return CapacityForecast{}, err
+	}
+	return forecast, nil
+}
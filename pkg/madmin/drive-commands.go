@@ -0,0 +1,143 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DriveLifecycle - lifecycle state of a drive.
+type DriveLifecycle string
+
+// Lifecycle states of a drive.
+const (
+	// DriveActive - the drive is online and serves requests.
+	DriveActive DriveLifecycle = "active"
+	// DriveOffline - the drive cannot be reached.
+	DriveOffline DriveLifecycle = "offline"
+	// DriveReplacing - the drive was marked to be swapped, it
+	// does not serve reads until the new drive is healed.
+	DriveReplacing DriveLifecycle = "replacing"
+	// DriveHealing - the drive is a new drive being healed.
+	DriveHealing DriveLifecycle = "healing"
+	// DriveDecommissioned - the pool of the drive is decommissioned.
+	DriveDecommissioned DriveLifecycle = "decommissioned"
+)
+
+// DriveHealProgress - progress of the heal of a new drive.
+type DriveHealProgress struct {
+	Started    time.Time `json:"started"`
+	LastUpdate time.Time `json:"lastUpdate"`
+	Paused     bool      `json:"paused"`
+	// Bucket currently being healed.
+	Bucket string `json:"bucket,omitempty"`
+
+	ObjectsHealed uint64 `json:"objectsHealed"`
+	ObjectsFailed uint64 `json:"objectsFailed"`
+	BytesHealed   uint64 `json:"bytesHealed"`
+
+	// Estimated from the data usage of the erasure set
+	// when the heal started.
+	ObjectsTotal uint64    `json:"objectsTotal"`
+	BytesTotal   uint64    `json:"bytesTotal"`
+	ETA          time.Time `json:"eta,omitempty"`
+}
+
+// DriveStatus - lifecycle state of a drive.
+type DriveStatus struct {
+	Endpoint  string             `json:"endpoint"`
+	PoolIndex int                `json:"pool"`
+	SetIndex  int                `json:"set"`
+	DiskIndex int                `json:"disk"`
+	State     DriveLifecycle     `json:"state"`
+	Heal      *DriveHealProgress `json:"heal,omitempty"`
+}
+
+// DrivesStatus - returns the lifecycle state of all drives, along with
+// the heal progress of the drives being healed.
+func (adm *AdminClient) DrivesStatus(ctx context.Context) ([]DriveStatus, error) {
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		// GET <endpoint>/<admin-API>/drives/status
+		relPath: adminAPIPrefix + "/drives/status",
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponse(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []DriveStatus
+	if err = json.Unmarshal(b, &statuses); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+func (adm *AdminClient) driveAction(ctx context.Context, action, drive string) error {
+	values := url.Values{}
+	values.Set("drive", drive)
+	resp, err := adm.executeMethod(ctx, http.MethodPost, requestData{
+		relPath:     adminAPIPrefix + "/drives/" + action,
+		queryValues: values,
+	})
+	if err != nil {
+		return err
+	}
+	defer closeResponse(resp)
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
+
+// ReplaceDrive - marks an online drive as being replaced, it does not
+// serve reads anymore and is healed once it is swapped for a new drive.
+func (adm *AdminClient) ReplaceDrive(ctx context.Context, drive string) error {
+	// POST <endpoint>/<admin-API>/drives/replace?drive=http://server1/disk1
+	return adm.driveAction(ctx, "replace", drive)
+}
+
+// CancelReplaceDrive - makes a drive marked as being replaced active again.
+func (adm *AdminClient) CancelReplaceDrive(ctx context.Context, drive string) error {
+	// POST <endpoint>/<admin-API>/drives/cancel-replace?drive=http://server1/disk1
+	return adm.driveAction(ctx, "cancel-replace", drive)
+}
+
+// PauseDriveHeal - pauses the heal of a new drive.
+func (adm *AdminClient) PauseDriveHeal(ctx context.Context, drive string) error {
+	// POST <endpoint>/<admin-API>/drives/pause-heal?drive=http://server1/disk1
+	return adm.driveAction(ctx, "pause-heal", drive)
+}
+
+// ResumeDriveHeal - resumes a paused heal of a new drive.
+func (adm *AdminClient) ResumeDriveHeal(ctx context.Context, drive string) error {
+	// POST <endpoint>/<admin-API>/drives/resume-heal?drive=http://server1/disk1
+	return adm.driveAction(ctx, "resume-heal", drive)
+}
@@ -0,0 +1,125 @@
+/*
+ * MinIO Cloud Storage, (C) 2018 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// IAMQuota holds the hard limits of the objects uploaded by an IAM
+// user, service account or the members of a group, zero means no limit.
+type IAMQuota struct {
+	Quota        uint64 `json:"quota,omitempty"`
+	ObjectsQuota uint64 `json:"objectsQuota,omitempty"`
+}
+
+// IsEmpty returns true if no limit is set.
+func (q IAMQuota) IsEmpty() bool {
+	return q.Quota == 0 && q.ObjectsQuota == 0
+}
+
+// IAMQuotaInfo holds the quota of an IAM user, service account or group
+// along with its usage as last computed by the data crawler.
+type IAMQuotaInfo struct {
+	IAMQuota
+	Size         uint64 `json:"size"`
+	ObjectsCount uint64 `json:"objectsCount"`
+}
+
+// SetUserQuota - sets the quota of a user or service account, an empty
+// quota removes it.
+func (adm *AdminClient) SetUserQuota(ctx context.Context, accessKey string, quota IAMQuota) error {
+	queryValues := url.Values{}
+	queryValues.Set("accessKey", accessKey)
+	return adm.setIAMQuota(ctx, "/set-user-quota", queryValues, quota)
+}
+
+// GetUserQuota - returns the quota and usage of a user or service account.
+func (adm *AdminClient) GetUserQuota(ctx context.Context, accessKey string) (IAMQuotaInfo, error) {
+	queryValues := url.Values{}
+	queryValues.Set("accessKey", accessKey)
+	return adm.getIAMQuota(ctx, "/get-user-quota", queryValues)
+}
+
+// SetGroupQuota - sets the quota shared by the members of a group, an
+// empty quota removes it.
+func (adm *AdminClient) SetGroupQuota(ctx context.Context, group string, quota IAMQuota) error {
+	queryValues := url.Values{}
+	queryValues.Set("group", group)
+	return adm.setIAMQuota(ctx, "/set-group-quota", queryValues, quota)
+}
+
+// GetGroupQuota - returns the quota of a group and the usage of its members.
+func (adm *AdminClient) GetGroupQuota(ctx context.Context, group string) (IAMQuotaInfo, error) {
+	queryValues := url.Values{}
+	queryValues.Set("group", group)
+	return adm.getIAMQuota(ctx, "/get-group-quota", queryValues)
+}
+
+func (adm *AdminClient) setIAMQuota(ctx context.Context, path string, queryValues url.Values, quota IAMQuota) error {
+	data, err := json.Marshal(quota)
+	if err != nil {
+		return err
+	}
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + path,
+		queryValues: queryValues,
+		content:     data,
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+func (adm *AdminClient) getIAMQuota(ctx context.Context, path string, queryValues url.Values) (info IAMQuotaInfo, err error) {
+	reqData := requestData{
+		relPath:     adminAPIPrefix + path,
+		queryValues: queryValues,
+	}
+
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return info, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return info, httpRespToErrorResponse(resp)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return info, err
+	}
+
+	err = json.Unmarshal(data, &info)
+	return info, err
+}
@@ -23,6 +23,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 // QuotaType represents bucket quota type
@@ -44,13 +45,40 @@ func (t QuotaType) IsValid() bool {
 type BucketQuota struct {
 	Quota uint64    `json:"quota"`
 	Type  QuotaType `json:"quotatype,omitempty"`
+
+	// ObjectsQuota is a hard limit of the number of
+	// objects in the bucket, zero means no limit.
+	ObjectsQuota uint64 `json:"objectsQuota,omitempty"`
+	// PrefixQuotas are hard limits of prefixes within the bucket.
+	PrefixQuotas []PrefixQuota `json:"prefixQuotas,omitempty"`
+}
+
+// PrefixQuota holds the hard limits of the objects under a prefix,
+// the prefix is a folder and must end with a '/'.
+type PrefixQuota struct {
+	Prefix       string `json:"prefix"`
+	Quota        uint64 `json:"quota,omitempty"`
+	ObjectsQuota uint64 `json:"objectsQuota,omitempty"`
 }
 
 // IsValid returns false if quota is invalid
 // empty quota when Quota == 0 is always true.
 func (q BucketQuota) IsValid() bool {
-	if q.Quota > 0 {
-		return q.Type.IsValid()
+	if q.Quota > 0 && !q.Type.IsValid() {
+		return false
+	}
+	prefixes := make(map[string]struct{}, len(q.PrefixQuotas))
+	for _, pq := range q.PrefixQuotas {
+		if !strings.HasSuffix(pq.Prefix, "/") || strings.HasPrefix(pq.Prefix, "/") {
+			return false
+		}
+		if pq.Quota == 0 && pq.ObjectsQuota == 0 {
+			return false
+		}
+		if _, ok := prefixes[pq.Prefix]; ok {
+			return false
+		}
+		prefixes[pq.Prefix] = struct{}{}
 	}
 	// Empty configs are valid.
 	return true
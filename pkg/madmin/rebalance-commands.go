@@ -0,0 +1,130 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// RebalancePoolStatus - rebalance progress of a server pool.
+type RebalancePoolStatus struct {
+	ID      int    `json:"id"`
+	CmdLine string `json:"cmdline"`
+	// Fraction of the pool capacity in use.
+	Used float64 `json:"used"`
+	// Objects are moved off the pool until it is
+	// within the utilization band of the goal.
+	Participating bool `json:"participating"`
+	Done          bool `json:"done"`
+
+	ObjectsMoved  int64 `json:"objectsMoved"`
+	BytesMoved    int64 `json:"bytesMoved"`
+	ObjectsFailed int64 `json:"objectsFailed"`
+}
+
+// RebalanceStatus - status of a rebalance of all server pools.
+type RebalanceStatus struct {
+	ID        string    `json:"id"`
+	StartTime time.Time `json:"startTime"`
+	StopTime  time.Time `json:"stopTime,omitempty"`
+	// Fraction of the total capacity in use when the rebalance
+	// started, which all pools are moved towards.
+	Goal     float64 `json:"goal"`
+	Complete bool    `json:"complete"`
+	Stopped  bool    `json:"stopped"`
+	Failed   bool    `json:"failed"`
+
+	Pools []RebalancePoolStatus `json:"pools"`
+}
+
+// Running - returns true if the rebalance is in progress.
+func (r *RebalanceStatus) Running() bool {
+	return r != nil && !r.StartTime.IsZero() && !r.Complete && !r.Stopped && !r.Failed
+}
+
+// RebalanceStart - starts moving objects from the pools which are fuller
+// than the rest, returns the id of the rebalance.
+func (adm *AdminClient) RebalanceStart(ctx context.Context) (string, error) {
+	resp, err := adm.executeMethod(ctx, http.MethodPost, requestData{
+		// POST <endpoint>/<admin-API>/rebalance/start
+		relPath: adminAPIPrefix + "/rebalance/start",
+	})
+	if err != nil {
+		return "", err
+	}
+	defer closeResponse(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return "", httpRespToErrorResponse(resp)
+	}
+
+	var result struct {
+		ID string `json:"id"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	return result.ID, nil
+}
+
+// RebalanceStop - stops an ongoing rebalance, objects which are already
+// moved stay on their new pools.
+func (adm *AdminClient) RebalanceStop(ctx context.Context) error {
+	resp, err := adm.executeMethod(ctx, http.MethodPost, requestData{
+		// POST <endpoint>/<admin-API>/rebalance/stop
+		relPath: adminAPIPrefix + "/rebalance/stop",
+	})
+	if err != nil {
+		return err
+	}
+	defer closeResponse(resp)
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
+
+// RebalanceStatus - returns the status of the last rebalance.
+func (adm *AdminClient) RebalanceStatus(ctx context.Context) (RebalanceStatus, error) {
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		// GET <endpoint>/<admin-API>/rebalance/status
+		relPath: adminAPIPrefix + "/rebalance/status",
+	})
+	if err != nil {
+		return RebalanceStatus{}, err
+	}
+	defer closeResponse(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return RebalanceStatus{}, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return RebalanceStatus{}, err
+	}
+	var status RebalanceStatus
+	if err = json.Unmarshal(b, &status); err != nil {
+		return RebalanceStatus{}, err
+	}
+	return status, nil
+}
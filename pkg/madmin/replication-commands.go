@@ -0,0 +1,228 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ReplicationQueueInfo - replication tasks queued on a server and not
+// attempted yet, the tasks are journaled on the drives of the server
+// and are queued again when it restarts.
+type ReplicationQueueInfo struct {
+	Node           string `json:"node"`
+	PendingObjects int    `json:"pendingObjects"`
+	PendingDeletes int    `json:"pendingDeletes"`
+	// Pending tasks waiting for room in the in-memory queue.
+	Waiting int `json:"waiting"`
+	// Time the oldest pending task was queued, zero if none is pending.
+	OldestQueued time.Time `json:"oldestQueued,omitempty"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// ReplicationQueueInfo - returns the replication tasks queued on all servers.
+func (adm *AdminClient) ReplicationQueueInfo(ctx context.Context) ([]ReplicationQueueInfo, error) {
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		// GET <endpoint>/<admin-API>/replication/queue
+		relPath: adminAPIPrefix + "/replication/queue",
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponse(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var queues []ReplicationQueueInfo
+	if err = json.Unmarshal(b, &queues); err != nil {
+		return nil, err
+	}
+	return queues, nil
+}
+
+// ReplicationResyncStatus - status of the resync of a replication target.
+type ReplicationResyncStatus string
+
+// Supported resync statuses.
+const (
+	ReplicationResyncStarted   ReplicationResyncStatus = "Started"
+	ReplicationResyncCompleted ReplicationResyncStatus = "Completed"
+	ReplicationResyncFailed    ReplicationResyncStatus = "Failed"
+)
+
+// ReplicationResyncInfo - progress of the resync of a replication target,
+// all versions of the bucket are queued again for replication to the target.
+type ReplicationResyncInfo struct {
+	Bucket    string `json:"bucket"`
+	TargetArn string `json:"targetArn"`
+	ID        string `json:"id"`
+	// Server walking the bucket, the resync resumes there after a restart.
+	Node       string                  `json:"node"`
+	Status     ReplicationResyncStatus `json:"status"`
+	StartTime  time.Time               `json:"startTime"`
+	LastUpdate time.Time               `json:"lastUpdate"`
+	// Versions scanned and queued for replication to the target so far.
+	Scanned uint64 `json:"scanned"`
+	Queued  uint64 `json:"queued"`
+	// Last object whose versions were all scanned.
+	LastObject string `json:"lastObject,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ResyncReplicationTarget - starts replicating again all versions of the bucket
+// to the remote target, a resync of the target in progress is restarted.
+func (adm *AdminClient) ResyncReplicationTarget(ctx context.Context, bucket, arn string) (ReplicationResyncInfo, error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("arn", arn)
+
+	resp, err := adm.executeMethod(ctx, http.MethodPost, requestData{
+		// POST <endpoint>/<admin-API>/replication/resync?bucket=<bucket>&arn=<arn>
+		relPath:     adminAPIPrefix + "/replication/resync",
+		queryValues: queryValues,
+	})
+	if err != nil {
+		return ReplicationResyncInfo{}, err
+	}
+	defer closeResponse(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return ReplicationResyncInfo{}, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return ReplicationResyncInfo{}, err
+	}
+	var info ReplicationResyncInfo
+	if err = json.Unmarshal(b, &info); err != nil {
+		return ReplicationResyncInfo{}, err
+	}
+	return info, nil
+}
+
+// ReplicationResyncStatus - returns the progress of the last resync
+// of each replication target of the bucket.
+func (adm *AdminClient) ReplicationResyncStatus(ctx context.Context, bucket string) ([]ReplicationResyncInfo, error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		// GET <endpoint>/<admin-API>/replication/resync?bucket=<bucket>
+		relPath:     adminAPIPrefix + "/replication/resync",
+		queryValues: queryValues,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponse(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var resyncs []ReplicationResyncInfo
+	if err = json.Unmarshal(b, &resyncs); err != nil {
+		return nil, err
+	}
+	return resyncs, nil
+}
+
+// ReplicationLatency - latency of the replications to a target in the last minute.
+type ReplicationLatency struct {
+	Avg   time.Duration `json:"avg"`
+	Max   time.Duration `json:"max"`
+	Count uint64        `json:"count"`
+}
+
+// ReplicationTargetStats - replication stats of a remote target of a bucket.
+type ReplicationTargetStats struct {
+	Arn string `json:"arn"`
+
+	// Replication backlog and replicated size found by the last crawl.
+	PendingSize    uint64 `json:"pendingSize"`
+	PendingCount   uint64 `json:"pendingCount"`
+	FailedSize     uint64 `json:"failedSize"`
+	FailedCount    uint64 `json:"failedCount"`
+	ReplicatedSize uint64 `json:"replicatedSize"`
+
+	// Replications in progress, and replications performed
+	// by the servers since they started.
+	ActiveCount    uint64             `json:"activeCount"`
+	ActiveSize     uint64             `json:"activeSize"`
+	CompletedCount uint64             `json:"completedCount"`
+	CompletedSize  uint64             `json:"completedSize"`
+	FailuresCount  uint64             `json:"failuresCount"`
+	FailuresSize   uint64             `json:"failuresSize"`
+	Latency        ReplicationLatency `json:"latency"`
+}
+
+// BucketReplicationStats - replication stats of the remote targets of a bucket.
+type BucketReplicationStats struct {
+	Bucket string `json:"bucket"`
+	// Time of the last crawl the backlog was found by.
+	LastCrawl time.Time `json:"lastCrawl"`
+	// Size of the replicas received from other sites found by the last crawl.
+	ReplicaSize uint64                   `json:"replicaSize"`
+	Targets     []ReplicationTargetStats `json:"targets"`
+}
+
+// BucketReplicationStats - returns the replication backlog of each remote
+// target of the bucket along with the replications in progress.
+func (adm *AdminClient) BucketReplicationStats(ctx context.Context, bucket string) (BucketReplicationStats, error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		// GET <endpoint>/<admin-API>/replication/stats?bucket=<bucket>
+		relPath:     adminAPIPrefix + "/replication/stats",
+		queryValues: queryValues,
+	})
+	if err != nil {
+		return BucketReplicationStats{}, err
+	}
+	defer closeResponse(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return BucketReplicationStats{}, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return BucketReplicationStats{}, err
+	}
+	var stats BucketReplicationStats
+	if err = json.Unmarshal(b, &stats); err != nil {
+		return BucketReplicationStats{}, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,127 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RestripeStatus - status of a re-stripe, which rewrites existing object
+// versions with the current parity and block size.
+type RestripeStatus struct {
+	ID string `json:"id"`
+	// Only objects of the bucket under the prefix are re-striped,
+	// all buckets if the bucket is empty.
+	Bucket    string    `json:"bucket,omitempty"`
+	Prefix    string    `json:"prefix,omitempty"`
+	StartTime time.Time `json:"startTime"`
+	StopTime  time.Time `json:"stopTime,omitempty"`
+	Complete  bool      `json:"complete"`
+	Stopped   bool      `json:"stopped"`
+	Failed    bool      `json:"failed"`
+
+	ObjectsRestriped int64 `json:"objectsRestriped"`
+	BytesRestriped   int64 `json:"bytesRestriped"`
+	// Versions which already have the current parity and block size.
+	ObjectsSkipped int64 `json:"objectsSkipped"`
+	ObjectsFailed  int64 `json:"objectsFailed"`
+}
+
+// Running - returns true if the re-stripe is in progress.
+func (r *RestripeStatus) Running() bool {
+	return r != nil && !r.StartTime.IsZero() && !r.Complete && !r.Stopped && !r.Failed
+}
+
+// RestripeStart - starts rewriting the object versions of the bucket under
+// the prefix, or of all buckets if bucket is empty, whose parity or block
+// size differ from the current ones. Version ids, modification times and
+// metadata are preserved. Returns the id of the re-stripe.
+func (adm *AdminClient) RestripeStart(ctx context.Context, bucket, prefix string) (string, error) {
+	values := url.Values{}
+	values.Set("bucket", bucket)
+	values.Set("prefix", prefix)
+	resp, err := adm.executeMethod(ctx, http.MethodPost, requestData{
+		// POST <endpoint>/<admin-API>/restripe/start?bucket=mybucket&prefix=photos/
+		relPath:     adminAPIPrefix + "/restripe/start",
+		queryValues: values,
+	})
+	if err != nil {
+		return "", err
+	}
+	defer closeResponse(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return "", httpRespToErrorResponse(resp)
+	}
+
+	var result struct {
+		ID string `json:"id"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	return result.ID, nil
+}
+
+// RestripeStop - stops an ongoing re-stripe, versions which are already
+// rewritten keep their new layout.
+func (adm *AdminClient) RestripeStop(ctx context.Context) error {
+	resp, err := adm.executeMethod(ctx, http.MethodPost, requestData{
+		// POST <endpoint>/<admin-API>/restripe/stop
+		relPath: adminAPIPrefix + "/restripe/stop",
+	})
+	if err != nil {
+		return err
+	}
+	defer closeResponse(resp)
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
+
+// RestripeStatus - returns the status of the last re-stripe.
+func (adm *AdminClient) RestripeStatus(ctx context.Context) (RestripeStatus, error) {
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		// GET <endpoint>/<admin-API>/restripe/status
+		relPath: adminAPIPrefix + "/restripe/status",
+	})
+	if err != nil {
+		return RestripeStatus{}, err
+	}
+	defer closeResponse(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return RestripeStatus{}, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return RestripeStatus{}, err
+	}
+	var status RestripeStatus
+	if err = json.Unmarshal(b, &status); err != nil {
+		return RestripeStatus{}, err
+	}
+	return status, nil
+}
@@ -0,0 +1,85 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// ScrubCorruption - an object version whose shard on the drive failed
+// bitrot verification or is missing.
+type ScrubCorruption struct {
+	Bucket    string    `json:"bucket"`
+	Object    string    `json:"object"`
+	VersionID string    `json:"versionId,omitempty"`
+	Time      time.Time `json:"time"`
+	Error     string    `json:"error"`
+}
+
+// ScrubDriveReport - scrub report of a drive.
+type ScrubDriveReport struct {
+	Endpoint  string `json:"endpoint"`
+	PoolIndex int    `json:"pool"`
+	SetIndex  int    `json:"set"`
+	DiskIndex int    `json:"disk"`
+	// The report is reset when the drive is replaced.
+	DiskID string `json:"diskId,omitempty"`
+
+	// Current or last scrub of the drive, Finished
+	// is zero while the scrub is running.
+	Started         time.Time `json:"started,omitempty"`
+	Finished        time.Time `json:"finished,omitempty"`
+	ObjectsScanned  uint64    `json:"objectsScanned"`
+	BytesScanned    uint64    `json:"bytesScanned"`
+	CorruptedShards uint64    `json:"corruptedShards"`
+
+	// Corrupted shards found since the drive was added.
+	TotalCorruptedShards uint64 `json:"totalCorruptedShards"`
+	// Most recent corruptions, oldest first.
+	Corruptions []ScrubCorruption `json:"corruptions,omitempty"`
+}
+
+// ScrubStatus - returns the scrub report of all drives.
+func (adm *AdminClient) ScrubStatus(ctx context.Context) ([]ScrubDriveReport, error) {
+	resp, err := adm.executeMethod(ctx, http.MethodGet, requestData{
+		// GET <endpoint>/<admin-API>/scrub/status
+		relPath: adminAPIPrefix + "/scrub/status",
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponse(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var reports []ScrubDriveReport
+	if err = json.Unmarshal(b, &reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
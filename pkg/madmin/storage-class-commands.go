@@ -0,0 +1,139 @@
+/*
+ * MinIO Cloud Storage, (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// Storage classes which are always available.
+const (
+	StandardStorageClass = "STANDARD"
+	RRSStorageClass      = "REDUCED_REDUNDANCY"
+)
+
+// StorageClassRule - selects the storage class of the objects whose
+// name starts with Prefix and which carry all of Tags.
+type StorageClassRule struct {
+	Prefix       string            `json:"prefix,omitempty"`
+	Tags         map[string]string `json:"tags,omitempty"`
+	StorageClass string            `json:"storageClass"`
+}
+
+// BucketStorageClass - storage class configuration of a bucket, applied
+// to the objects uploaded without a storage class.
+type BucketStorageClass struct {
+	// Custom storage classes by name, along with their
+	// parity in the form "EC:Number of parity disks".
+	Classes map[string]string `json:"classes,omitempty"`
+	// Rules are evaluated in order, the first matching rule applies.
+	Rules []StorageClassRule `json:"rules,omitempty"`
+}
+
+// Validate - returns an error if a custom storage class shadows a
+// standard one or a rule refers to an unknown storage class.
+func (c BucketStorageClass) Validate() error {
+	for name := range c.Classes {
+		if name == "" {
+			return fmt.Errorf("storage class name cannot be empty")
+		}
+		if name == StandardStorageClass || name == RRSStorageClass {
+			return fmt.Errorf("storage class %s cannot be redefined", name)
+		}
+	}
+	for _, rule := range c.Rules {
+		switch rule.StorageClass {
+		case StandardStorageClass, RRSStorageClass:
+		default:
+			if _, ok := c.Classes[rule.StorageClass]; !ok {
+				return fmt.Errorf("unknown storage class %q", rule.StorageClass)
+			}
+		}
+	}
+	return nil
+}
+
+// GetBucketStorageClass - returns the storage class configuration of a bucket.
+func (adm *AdminClient) GetBucketStorageClass(ctx context.Context, bucket string) (c BucketStorageClass, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/get-bucket-storage-class",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/get-bucket-storage-class
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return c, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return c, httpRespToErrorResponse(resp)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return c, err
+	}
+	if err = json.Unmarshal(b, &c); err != nil {
+		return c, err
+	}
+
+	return c, nil
+}
+
+// SetBucketStorageClass - sets the storage class configuration of a bucket,
+// an empty configuration removes all custom classes and rules.
+func (adm *AdminClient) SetBucketStorageClass(ctx context.Context, bucket string, c *BucketStorageClass) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/set-bucket-storage-class",
+		queryValues: queryValues,
+		content:     data,
+	}
+
+	// Execute PUT on /minio/admin/v3/set-bucket-storage-class
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
@@ -474,17 +474,23 @@ func (column *Column) toDataPageV2(element *schema.Element, parquetEncoding parq
 		panic(err)
 	}
 
-	DLData := encoding.RLEBitPackedHybridEncode(
-		column.definitionLevels,
-		common.BitWidth(uint64(element.MaxDefinitionLevel)),
-		parquet.Type_INT64,
-	)
-
-	RLData := encoding.RLEBitPackedHybridEncode(
-		column.repetitionLevels,
-		common.BitWidth(uint64(element.MaxRepetitionLevel)),
-		parquet.Type_INT64,
-	)
+	// Levels are omitted when their maximum level is zero.
+	var DLData, RLData []byte
+	if element.MaxDefinitionLevel > 0 {
+		DLData = encoding.RLEBitPackedHybridEncode(
+			column.definitionLevels,
+			common.BitWidth(uint64(element.MaxDefinitionLevel)),
+			parquet.Type_INT64,
+		)
+	}
+
+	if element.MaxRepetitionLevel > 0 {
+		RLData = encoding.RLEBitPackedHybridEncode(
+			column.repetitionLevels,
+			common.BitWidth(uint64(element.MaxRepetitionLevel)),
+			parquet.Type_INT64,
+		)
+	}
 
 	pageHeader := parquet.NewPageHeader()
 	pageHeader.Type = parquet.PageType_DATA_PAGE_V2
@@ -58,6 +58,7 @@ func bitPackedEncode(values interface{}, bitWidth uint64, withHeader bool, parqu
 			panic(fmt.Errorf("expected slice of int32"))
 		}
 
+		i64s = make([]int64, len(i32s))
 		for i := range i32s {
 			i64s[i] = int64(i32s[i])
 		}
@@ -17,7 +17,12 @@
 package parquet
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/minio/minio/pkg/s3select/internal/parquet-go/data"
@@ -150,3 +155,54 @@ func TestWriterWriteJSON(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+type bufferWriteCloser struct {
+	bytes.Buffer
+}
+
+func (*bufferWriteCloser) Close() error { return nil }
+
+func TestWriterRowGroups(t *testing.T) {
+	schemaTree := schema.NewTree()
+	one, err := schema.NewElement("one", parquet.FieldRepetitionType_REQUIRED,
+		parquet.TypePtr(parquet.Type_INT64), nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = schemaTree.Set("one", one); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bufferWriteCloser
+	writer, err := NewWriter(&buf, schemaTree, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 250; i++ {
+		if err = writer.WriteJSON([]byte(fmt.Sprintf(`{"one": %d}`, i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rows are batched into row groups of rowGroupCount rows.
+	data := buf.Bytes()
+	fileMeta, err := fileMetadata(func(offset, length int64) (io.ReadCloser, error) {
+		if offset < 0 {
+			offset = int64(len(data)) + offset
+		}
+		return ioutil.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows []int64
+	for _, rowGroup := range fileMeta.GetRowGroups() {
+		rows = append(rows, rowGroup.NumRows)
+	}
+	if !reflect.DeepEqual(rows, []int64{100, 100, 50}) || fileMeta.NumRows != 250 {
+		t.Fatalf("unexpected rows per row group %v, total %d", rows, fileMeta.NumRows)
+	}
+}
@@ -0,0 +1,112 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	parquetgo "github.com/minio/minio/pkg/s3select/internal/parquet-go"
+	parquetgen "github.com/minio/minio/pkg/s3select/internal/parquet-go/gen-go/parquet"
+	"github.com/minio/minio/pkg/s3select/internal/parquet-go/schema"
+)
+
+// Number of records written per row group.
+const writerRowGroupCount = 10000
+
+// ColumnType - type of the values of a column.
+type ColumnType int
+
+// Supported column types.
+const (
+	// UTF8 encoded string.
+	ColumnString ColumnType = iota
+	// 64-bit signed integer.
+	ColumnInt64
+	// Boolean.
+	ColumnBoolean
+)
+
+// Column - describes a column of the Parquet file.
+type Column struct {
+	Name string
+	Type ColumnType
+}
+
+// Writer - writes records as rows of a Parquet file.
+type Writer struct {
+	writer *parquetgo.Writer
+}
+
+func newElement(column Column) (*schema.Element, error) {
+	var elementType parquetgen.Type
+	var convertedType *parquetgen.ConvertedType
+	switch column.Type {
+	case ColumnString:
+		elementType = parquetgen.Type_BYTE_ARRAY
+		convertedType = parquetgen.ConvertedTypePtr(parquetgen.ConvertedType_UTF8)
+	case ColumnInt64:
+		elementType = parquetgen.Type_INT64
+	case ColumnBoolean:
+		elementType = parquetgen.Type_BOOLEAN
+	default:
+		return nil, fmt.Errorf("unknown type of column %s", column.Name)
+	}
+
+	// Values are always plain encoded, which every Parquet reader supports.
+	return schema.NewElement(column.Name, parquetgen.FieldRepetitionType_REQUIRED,
+		parquetgen.TypePtr(elementType), convertedType,
+		parquetgen.EncodingPtr(parquetgen.Encoding_PLAIN), nil, nil)
+}
+
+// NewWriter - creates a new Parquet writer with given columns, the
+// Parquet file is written to writeCloser.
+func NewWriter(writeCloser io.WriteCloser, columns []Column) (*Writer, error) {
+	schemaTree := schema.NewTree()
+	for _, column := range columns {
+		element, err := newElement(column)
+		if err != nil {
+			return nil, err
+		}
+		if err = schemaTree.Set(column.Name, element); err != nil {
+			return nil, err
+		}
+	}
+
+	writer, err := parquetgo.NewWriter(writeCloser, schemaTree, writerRowGroupCount)
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{writer: writer}, nil
+}
+
+// Write - writes a record, which maps column names to values. All
+// columns are required.
+func (w *Writer) Write(record map[string]interface{}) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return w.writer.WriteJSON(data)
+}
+
+// Close - writes pending records and the file footer and closes the
+// underlying writer.
+func (w *Writer) Close() error {
+	return w.writer.Close()
+}
@@ -0,0 +1,96 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(nopWriteCloser{&buf}, []Column{
+		{Name: "key", Type: ColumnString},
+		{Name: "size", Type: ColumnInt64},
+		{Name: "is_latest", Type: ColumnBoolean},
+		{Name: "last_modified_date", Type: ColumnInt64},
+		{Name: "object_lock_mode", Type: ColumnString},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records := []map[string]interface{}{
+		{"key": "a", "size": 1, "is_latest": true, "last_modified_date": 1000, "object_lock_mode": "GOVERNANCE"},
+		{"key": "b", "size": 2, "is_latest": false, "last_modified_date": 2000, "object_lock_mode": ""},
+	}
+	for _, record := range records {
+		if err = w.Write(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data := buf.Bytes()
+	r, err := NewReader(func(offset, length int64) (io.ReadCloser, error) {
+		if offset < 0 {
+			offset = int64(len(data)) + offset
+		}
+		if length < 0 {
+			length = int64(len(data)) - offset
+		}
+		return ioutil.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+	}, &ReaderArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	var keys []string
+	for {
+		rec, err := r.Read(nil)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out bytes.Buffer
+		if err = rec.WriteJSON(&out); err != nil {
+			t.Fatal(err)
+		}
+		var row map[string]interface{}
+		if err = json.Unmarshal(out.Bytes(), &row); err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, row["key"].(string))
+	}
+	if len(keys) != 2 || keys[0] != "a" || keys[1] != "b" {
+		t.Fatalf("unexpected keys %v", keys)
+	}
+}